@@ -1,11 +1,21 @@
 package main
 
 import (
+	"flag"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/vmware/terraform-provider-vcd/v4/vcd"
 )
 
 func main() {
+	var debug bool
+
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: vcd.Provider})
+		ProviderFunc: vcd.Provider,
+		ProviderAddr: "registry.terraform.io/vmware/vcd",
+		Debug:        debug,
+	})
 }
@@ -0,0 +1,172 @@
+package vcd
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiLimiter caps the concurrency of the provider against VCD:
+// * 'requests' limits the number of API calls in flight at the same time, for all orgs. It is
+// enforced by limiterTransport, set in the HTTP client used by govcd
+// * 'orgOperations' limits, for each org, the number of create, update and delete operations
+// running at the same time. These are the operations that start VCD tasks. It is enforced by
+// wrapping resource functions with withOrgOperationLimit
+//
+// A nil channel means that there is no limit.
+type apiLimiter struct {
+	requests chan struct{}
+
+	orgOperationLimit int
+	orgOperationsLock sync.Mutex
+	orgOperations     map[string]chan struct{}
+}
+
+// newApiLimiter creates a limiter. Zero values mean no limit
+func newApiLimiter(maxRequests, maxOrgOperations int) *apiLimiter {
+	limiter := &apiLimiter{
+		orgOperationLimit: maxOrgOperations,
+		orgOperations:     make(map[string]chan struct{}),
+	}
+	if maxRequests > 0 {
+		limiter.requests = make(chan struct{}, maxRequests)
+	}
+	return limiter
+}
+
+// acquireSlot takes a slot from the given semaphore, waiting until one is available or the context is
+// done. It returns a function that releases the slot
+func acquireSlot(ctx context.Context, semaphore chan struct{}, description string) (func(), error) {
+	if semaphore == nil {
+		return func() {}, nil
+	}
+	select {
+	case semaphore <- struct{}{}:
+	default:
+		tflog.Debug(ctx, "[limiter] waiting for a free slot", map[string]interface{}{
+			"description": description,
+			"cap":         cap(semaphore),
+		})
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-semaphore })
+	}, nil
+}
+
+// orgOperationSemaphore returns the semaphore that limits the operations of the given org, or nil
+// when there is no limit
+func (l *apiLimiter) orgOperationSemaphore(orgName string) chan struct{} {
+	if l == nil || l.orgOperationLimit <= 0 || orgName == "" {
+		return nil
+	}
+	l.orgOperationsLock.Lock()
+	defer l.orgOperationsLock.Unlock()
+	semaphore, ok := l.orgOperations[orgName]
+	if !ok {
+		semaphore = make(chan struct{}, l.orgOperationLimit)
+		l.orgOperations[orgName] = semaphore
+	}
+	return semaphore
+}
+
+// limiterTransport is an http.RoundTripper that limits the number of requests in flight. A slot is
+// held until the response headers are received, which covers the upload of request bodies
+type limiterTransport struct {
+	transport http.RoundTripper
+	limiter   *apiLimiter
+}
+
+// newLimiterTransport wraps the given transport with the limiter
+func newLimiterTransport(transport http.RoundTripper, limiter *apiLimiter) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &limiterTransport{transport: transport, limiter: limiter}
+}
+
+func (t *limiterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := acquireSlot(req.Context(), t.limiter.requests, "API request "+req.Method+" "+req.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return t.transport.RoundTrip(req)
+}
+
+// withOrgOperationLimits returns a copy of the given resource map, where create, update and delete
+// operations of each resource wait for a free slot of the per-org operation limit (see
+// apiLimiter). The org is taken from the `org` argument of the resource, or from the provider
+// configuration. Resources without an `org` argument are not limited.
+func withOrgOperationLimits(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		if _, hasOrg := resource.Schema["org"]; !hasOrg {
+			result[name] = resource
+			continue
+		}
+		limited := *resource
+		if resource.CreateContext != nil {
+			limited.CreateContext = withOrgOperationLimit(name, resource.CreateContext)
+		}
+		if resource.UpdateContext != nil {
+			limited.UpdateContext = withOrgOperationLimit(name, resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			limited.DeleteContext = withOrgOperationLimit(name, resource.DeleteContext)
+		}
+		if resource.Create != nil {
+			limited.Create = withOrgOperationLimitNoContext(name, resource.Create)
+		}
+		if resource.Update != nil {
+			limited.Update = withOrgOperationLimitNoContext(name, resource.Update)
+		}
+		if resource.Delete != nil {
+			limited.Delete = withOrgOperationLimitNoContext(name, resource.Delete)
+		}
+		result[name] = &limited
+	}
+	return result
+}
+
+// withOrgOperationLimit wraps a resource function with the per-org operation limit
+func withOrgOperationLimit(resourceType string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		release, err := acquireOrgOperationSlot(ctx, resourceType, d, meta)
+		if err != nil {
+			return diag.Errorf("error waiting for a free operation slot for %s: %s", resourceType, err)
+		}
+		defer release()
+		return f(ctx, d, meta)
+	}
+}
+
+// withOrgOperationLimitNoContext wraps a legacy resource function with the per-org operation limit
+func withOrgOperationLimitNoContext(resourceType string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		release, err := acquireOrgOperationSlot(providerLogContext(), resourceType, d, meta)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return f(d, meta)
+	}
+}
+
+func acquireOrgOperationSlot(ctx context.Context, resourceType string, d *schema.ResourceData, meta interface{}) (func(), error) {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil {
+		return func() {}, nil
+	}
+	orgName := vcdClient.getOrgName(d)
+	return acquireSlot(ctx, vcdClient.limiter.orgOperationSemaphore(orgName), resourceType+" operation in Org "+orgName)
+}
@@ -0,0 +1,168 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_limiterTransport(t *testing.T) {
+	const maxRequests = 2
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			previous := atomic.LoadInt32(&maxInFlight)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxInFlight, previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := newApiLimiter(maxRequests, 0)
+	client := http.Client{Transport: newLimiterTransport(http.DefaultTransport, limiter)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > maxRequests {
+		t.Errorf("expected at most %d requests in flight, got %d", maxRequests, maxInFlight)
+	}
+	if len(limiter.requests) != 0 {
+		t.Errorf("expected all slots to be released, %d still taken", len(limiter.requests))
+	}
+}
+
+func Test_apiLimiterOrgOperations(t *testing.T) {
+	limiter := newApiLimiter(0, 1)
+	if limiter.requests != nil {
+		t.Errorf("expected no request limit")
+	}
+	if limiter.orgOperationSemaphore("") != nil {
+		t.Errorf("expected no limit for an empty Org name")
+	}
+
+	release, err := acquireSlot(context.Background(), limiter.orgOperationSemaphore("org1"), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Another Org has its own slots
+	releaseOther, err := acquireSlot(context.Background(), limiter.orgOperationSemaphore("org2"), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	releaseOther()
+
+	// The same Org must wait until the slot is released
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = acquireSlot(ctx, limiter.orgOperationSemaphore("org1"), "test")
+	if err == nil {
+		t.Fatalf("expected timeout waiting for a slot")
+	}
+
+	release()
+	// Releasing twice must not free more slots than were taken
+	release()
+	release, err = acquireSlot(context.Background(), limiter.orgOperationSemaphore("org1"), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	release()
+
+	var nilLimiter *apiLimiter
+	if nilLimiter.orgOperationSemaphore("org1") != nil {
+		t.Errorf("expected no limit for a nil limiter")
+	}
+}
+
+func Test_withOrgOperationLimits(t *testing.T) {
+	client := &VCDClient{Org: "org1", limiter: newApiLimiter(0, 1)}
+	semaphore := client.limiter.orgOperationSemaphore("org1")
+	var calls int
+	checkSlotTaken := func(operation string) {
+		calls++
+		if len(semaphore) != 1 {
+			t.Errorf("%s: expected the Org operation slot to be taken, %d taken", operation, len(semaphore))
+		}
+	}
+
+	resources := withOrgOperationLimits(map[string]*schema.Resource{
+		"vcd_context": {
+			Schema: map[string]*schema.Schema{"org": {Type: schema.TypeString, Optional: true}},
+			CreateContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+				checkSlotTaken("CreateContext")
+				return nil
+			},
+			DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+				checkSlotTaken("DeleteContext")
+				return nil
+			},
+		},
+		"vcd_legacy": {
+			Schema: map[string]*schema.Schema{"org": {Type: schema.TypeString, Optional: true}},
+			Create: func(_ *schema.ResourceData, _ interface{}) error {
+				checkSlotTaken("Create")
+				return nil
+			},
+			Update: func(_ *schema.ResourceData, _ interface{}) error {
+				checkSlotTaken("Update")
+				return nil
+			},
+			Delete: func(_ *schema.ResourceData, _ interface{}) error {
+				checkSlotTaken("Delete")
+				return nil
+			},
+		},
+	})
+
+	contextResource := resources["vcd_context"]
+	d := contextResource.TestResourceData()
+	if diags := contextResource.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := contextResource.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	legacyResource := resources["vcd_legacy"]
+	d = legacyResource.TestResourceData()
+	for _, f := range []func(*schema.ResourceData, interface{}) error{legacyResource.Create, legacyResource.Update, legacyResource.Delete} {
+		if err := f(d, client); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if calls != 5 {
+		t.Errorf("expected 5 operations, got %d", calls)
+	}
+	if len(semaphore) != 0 {
+		t.Errorf("expected all slots to be released, %d still taken", len(semaphore))
+	}
+}
@@ -0,0 +1,364 @@
+package vcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// With the provider `audit_log_file` argument, each create, update, delete and import of a resource appends a JSON
+// line to the given file, with the entity, the user and the VCD tasks that were run. The operations are wrapped by
+// withAuditLog, so that all the resources are covered, whether they use the generic CRUD functions or not.
+//
+// The tasks are collected by a transport set in a copy of the client that is used only by the audited operation
+// (see taskRecorderTransport)
+
+const (
+	auditOperationCreate = "create"
+	auditOperationUpdate = "update"
+	auditOperationDelete = "delete"
+	auditOperationImport = "import"
+
+	auditOutcomeSuccess = "success"
+	auditOutcomeFailure = "failure"
+)
+
+// auditRecord is a line of the audit log. SDKv2 doesn't pass the address of the resource in the configuration
+// (e.g. vcd_org.org1) to the provider, so the resource is identified by type, name and ID
+type auditRecord struct {
+	Timestamp    string   `json:"timestamp"`
+	Operation    string   `json:"operation"`
+	ResourceType string   `json:"resource_type"`
+	Name         string   `json:"name,omitempty"`
+	Id           string   `json:"id,omitempty"`
+	Org          string   `json:"org,omitempty"`
+	Vdc          string   `json:"vdc,omitempty"`
+	User         string   `json:"user,omitempty"`
+	TaskIds      []string `json:"task_ids,omitempty"`
+	Outcome      string   `json:"outcome"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// auditLogger writes the audit records to a file. The file is opened for each record in append mode, so that more
+// provider processes can write to the same file
+type auditLogger struct {
+	fileName string
+	lock     sync.Mutex
+
+	// user is the name of the user running the operations, which is retrieved from the session on first use
+	userOnce   sync.Once
+	user       string
+	configUser string
+}
+
+// newAuditLogger returns a logger writing to the given file, checking that the file can be written
+func newAuditLogger(fileName, configUser string) (*auditLogger, error) {
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log file '%s': %s", fileName, err)
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error closing audit log file '%s': %s", fileName, err)
+	}
+	return &auditLogger{fileName: fileName, configUser: configUser}, nil
+}
+
+// write appends the given record to the audit log
+func (l *auditLogger) write(record auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error encoding audit record: %s", err)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	file, err := os.OpenFile(l.fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening audit log file '%s': %s", l.fileName, err)
+	}
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error writing audit log file '%s': %s", l.fileName, err)
+	}
+	return file.Close()
+}
+
+// getUser returns the name of the user of the client session. When the session can't be retrieved, the user of the
+// provider configuration is used
+func (l *auditLogger) getUser(ctx context.Context, vcdClient *VCDClient) string {
+	l.userOnce.Do(func() {
+		l.user = l.configUser
+		sessionInfo, err := vcdClient.Client.GetSessionInfo()
+		if err != nil {
+			tflog.Warn(ctx, "could not retrieve the session user for the audit log", map[string]interface{}{"error": err})
+			return
+		}
+		if sessionInfo.User.Name != "" {
+			l.user = sessionInfo.User.Name
+		}
+	})
+	return l.user
+}
+
+// auditTaskHrefRegex matches the HREF of a task, capturing its UUID
+var auditTaskHrefRegex = regexp.MustCompile(`/api/task/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})`)
+
+// taskRecorderTransport is an http.RoundTripper that collects the IDs of the tasks returned by VCD, either in the
+// Location header (OpenAPI) or in a Task body (legacy API)
+type taskRecorderTransport struct {
+	transport http.RoundTripper
+
+	lock    sync.Mutex
+	taskIds []string
+}
+
+func (t *taskRecorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if match := auditTaskHrefRegex.FindStringSubmatch(resp.Header.Get("Location")); match != nil {
+		t.addTask(match[1])
+	}
+	if strings.Contains(resp.Header.Get("Content-Type"), "vcloud.task") && resp.Body != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(strings.NewReader(string(body)))
+		if readErr != nil {
+			return resp, readErr
+		}
+		if match := auditTaskHrefRegex.FindStringSubmatch(string(body)); match != nil {
+			t.addTask(match[1])
+		}
+	}
+	return resp, nil
+}
+
+// addTask stores the ID of a task, once
+func (t *taskRecorderTransport) addTask(uuid string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	taskId := "urn:vcloud:task:" + strings.ToLower(uuid)
+	for _, existing := range t.taskIds {
+		if existing == taskId {
+			return
+		}
+	}
+	t.taskIds = append(t.taskIds, taskId)
+}
+
+// getTaskIds returns the IDs of the tasks collected so far
+func (t *taskRecorderTransport) getTaskIds() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]string(nil), t.taskIds...)
+}
+
+// auditOperation is a resource operation that is written to the audit log when it finishes
+type auditOperation struct {
+	logger       *auditLogger
+	client       *VCDClient
+	recorder     *taskRecorderTransport
+	resourceType string
+	resource     *schema.Resource
+	operation    string
+	// id is the ID of the resource when the operation starts, which is lost when deleting
+	id string
+}
+
+// newAuditOperation starts the audit of an operation. It returns nil when the audit log is not enabled, and otherwise
+// the client to use for the operation, which collects the tasks
+func newAuditOperation(meta interface{}, resourceType string, resource *schema.Resource, operation, id string) (*auditOperation, interface{}) {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient.auditLog == nil {
+		return nil, meta
+	}
+	recorder := &taskRecorderTransport{transport: vcdClient.Client.Http.Transport}
+	if recorder.transport == nil {
+		recorder.transport = http.DefaultTransport
+	}
+	client := vcdClient.copyWithTransport(recorder)
+	return &auditOperation{
+		logger:       vcdClient.auditLog,
+		client:       client,
+		recorder:     recorder,
+		resourceType: resourceType,
+		resource:     resource,
+		operation:    operation,
+		id:           id,
+	}, client
+}
+
+// finish writes the audit record of the operation, using the resource data after the operation and its error
+func (op *auditOperation) finish(ctx context.Context, d *schema.ResourceData, operationErr error) error {
+	record := auditRecord{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Operation:    op.operation,
+		ResourceType: op.resourceType,
+		Id:           op.id,
+		User:         op.logger.getUser(ctx, op.client),
+		TaskIds:      op.recorder.getTaskIds(),
+		Outcome:      auditOutcomeSuccess,
+	}
+	if d != nil {
+		if d.Id() != "" {
+			record.Id = d.Id()
+		}
+		record.Name = op.getString(d, "name", "")
+		record.Org = op.getString(d, "org", op.client.Org)
+		record.Vdc = op.getString(d, "vdc", op.client.Vdc)
+	}
+	if operationErr != nil {
+		record.Outcome = auditOutcomeFailure
+		record.Error = apiLogRedactor.redact(operationErr.Error())
+	}
+	return op.logger.write(record)
+}
+
+// getString returns the value of a string argument of the resource, or the given default when it is empty
+func (op *auditOperation) getString(d *schema.ResourceData, attribute, defaultValue string) string {
+	if _, found := op.resource.Schema[attribute]; !found {
+		return ""
+	}
+	value, _ := d.Get(attribute).(string)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// withAuditLog returns a copy of the given resource map, where the create, update, delete and import operations
+// are written to the audit log, when it is enabled
+func withAuditLog(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		audited := *resource
+		if resource.CreateContext != nil {
+			audited.CreateContext = withAuditRecord(name, resource, auditOperationCreate, resource.CreateContext)
+		}
+		if resource.UpdateContext != nil {
+			audited.UpdateContext = withAuditRecord(name, resource, auditOperationUpdate, resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			audited.DeleteContext = withAuditRecord(name, resource, auditOperationDelete, resource.DeleteContext)
+		}
+		if resource.Create != nil {
+			audited.Create = withAuditRecordNoContext(name, resource, auditOperationCreate, resource.Create)
+		}
+		if resource.Update != nil {
+			audited.Update = withAuditRecordNoContext(name, resource, auditOperationUpdate, resource.Update)
+		}
+		if resource.Delete != nil {
+			audited.Delete = withAuditRecordNoContext(name, resource, auditOperationDelete, resource.Delete)
+		}
+		if resource.Importer != nil {
+			importer := *resource.Importer
+			if resource.Importer.StateContext != nil {
+				stateContext := resource.Importer.StateContext
+				importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+					return auditImport(ctx, name, resource, d, meta, func(meta interface{}) ([]*schema.ResourceData, error) {
+						return stateContext(ctx, d, meta)
+					})
+				}
+			}
+			if resource.Importer.State != nil {
+				state := resource.Importer.State
+				importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+					return auditImport(providerLogContext(), name, resource, d, meta, func(meta interface{}) ([]*schema.ResourceData, error) {
+						return state(d, meta)
+					})
+				}
+			}
+			audited.Importer = &importer
+		}
+		result[name] = &audited
+	}
+	return result
+}
+
+// withAuditRecord wraps a resource function so that its outcome is written to the audit log. A failure to write the
+// record is reported as a warning
+func withAuditRecord(resourceType string, resource *schema.Resource, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		audit, meta := newAuditOperation(meta, resourceType, resource, operation, d.Id())
+		diags := f(ctx, d, meta)
+		if audit == nil {
+			return diags
+		}
+		if err := audit.finish(ctx, d, diagnosticsError(diags)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("could not write the %s of %s to the audit log", operation, resourceType),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+}
+
+// withAuditRecordNoContext is the same as withAuditRecord, for functions without context. A failure to write the
+// record is only logged
+func withAuditRecordNoContext(resourceType string, resource *schema.Resource, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		audit, meta := newAuditOperation(meta, resourceType, resource, operation, d.Id())
+		err := f(d, meta)
+		if audit == nil {
+			return err
+		}
+		if auditErr := audit.finish(providerLogContext(), d, err); auditErr != nil {
+			tflog.Error(providerLogContext(), "could not write the operation to the audit log", map[string]interface{}{
+				"operation":     operation,
+				"resource_type": resourceType,
+				"error":         auditErr,
+			})
+		}
+		return err
+	}
+}
+
+// auditImport runs an import function, writing its outcome to the audit log
+func auditImport(ctx context.Context, resourceType string, resource *schema.Resource, d *schema.ResourceData, meta interface{}, f func(interface{}) ([]*schema.ResourceData, error)) ([]*schema.ResourceData, error) {
+	audit, meta := newAuditOperation(meta, resourceType, resource, auditOperationImport, d.Id())
+	results, err := f(meta)
+	if audit == nil {
+		return results, err
+	}
+	imported := d
+	if len(results) > 0 {
+		imported = results[0]
+	}
+	if auditErr := audit.finish(ctx, imported, err); auditErr != nil {
+		tflog.Error(ctx, "could not write the import to the audit log", map[string]interface{}{
+			"resource_type": resourceType,
+			"error":         auditErr,
+		})
+	}
+	return results, err
+}
+
+// diagnosticsError returns the error diagnostics as a single error, or nil when there are none
+func diagnosticsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, diagnostic := range diags {
+		if diagnostic.Severity == diag.Error {
+			messages = append(messages, diagnostic.Summary)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "; "))
+}
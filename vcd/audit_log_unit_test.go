@@ -0,0 +1,140 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+// roundTripperFunc turns a function into an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_taskRecorderTransport(t *testing.T) {
+	taskBody := `<Task xmlns="http://www.vmware.com/vcloud/v1.5" href="https://vcd.example.com/api/task/AAAAAAAA-1111-2222-3333-444444444444" status="running"/>`
+	recorder := &taskRecorderTransport{transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+		switch req.URL.Path {
+		case "/cloudapi/1.0.0/edgeGateways":
+			resp.Header.Set("Location", "https://vcd.example.com/api/task/bbbbbbbb-1111-2222-3333-444444444444")
+		case "/api/vApp/vapp-1/action/deploy":
+			resp.Header.Set("Content-Type", "application/vnd.vmware.vcloud.task+xml")
+			resp.Body = io.NopCloser(strings.NewReader(taskBody))
+		}
+		return resp, nil
+	})}
+
+	for _, path := range []string{"/cloudapi/1.0.0/edgeGateways", "/api/vApp/vapp-1/action/deploy", "/api/vApp/vapp-1/action/deploy", "/api/org"} {
+		req, _ := http.NewRequest(http.MethodPost, "https://vcd.example.com"+path, nil)
+		resp, err := recorder.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if path == "/api/vApp/vapp-1/action/deploy" && string(body) != taskBody {
+			t.Errorf("the response body was not preserved: %s", body)
+		}
+	}
+	expected := "urn:vcloud:task:bbbbbbbb-1111-2222-3333-444444444444,urn:vcloud:task:aaaaaaaa-1111-2222-3333-444444444444"
+	if got := strings.Join(recorder.getTaskIds(), ","); got != expected {
+		t.Errorf("expected tasks %s, got %s", expected, got)
+	}
+}
+
+func Test_withAuditLog(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := newAuditLogger(fileName, "config-user")
+	if err != nil {
+		t.Fatalf("error creating audit logger: %s", err)
+	}
+	logger.userOnce.Do(func() { logger.user = "session-user" })
+	client := &VCDClient{VCDClient: &govcd.VCDClient{}, Org: "default-org", auditLog: logger}
+
+	resources := withAuditLog(map[string]*schema.Resource{
+		"vcd_test": {
+			Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+				"org":  {Type: schema.TypeString, Optional: true},
+			},
+			CreateContext: func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				if meta == client {
+					return diag.Errorf("the operation must receive a copy of the client")
+				}
+				d.SetId("urn:vcloud:test:1")
+				return nil
+			},
+			Update: func(d *schema.ResourceData, meta interface{}) error {
+				if meta == client {
+					return fmt.Errorf("the operation must receive a copy of the client")
+				}
+				return nil
+			},
+			Delete: func(d *schema.ResourceData, meta interface{}) error {
+				return fmt.Errorf("deletion failed")
+			},
+		},
+	})
+	resource := resources["vcd_test"]
+
+	d := resource.TestResourceData()
+	_ = d.Set("name", "test-name")
+	if diags := resource.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if err := resource.Update(d, client); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := resource.Delete(d, client); err == nil {
+		t.Fatalf("expected the delete error to be returned")
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("error reading audit log: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 audit records, got %d: %s", len(lines), content)
+	}
+	var records []auditRecord
+	for _, line := range lines {
+		var record auditRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid audit record %s: %s", line, err)
+		}
+		records = append(records, record)
+	}
+
+	expected := []auditRecord{
+		{Operation: auditOperationCreate, ResourceType: "vcd_test", Name: "test-name", Id: "urn:vcloud:test:1",
+			Org: "default-org", User: "session-user", Outcome: auditOutcomeSuccess},
+		{Operation: auditOperationUpdate, ResourceType: "vcd_test", Name: "test-name", Id: "urn:vcloud:test:1",
+			Org: "default-org", User: "session-user", Outcome: auditOutcomeSuccess},
+		{Operation: auditOperationDelete, ResourceType: "vcd_test", Name: "test-name", Id: "urn:vcloud:test:1",
+			Org: "default-org", User: "session-user", Outcome: auditOutcomeFailure, Error: "deletion failed"},
+	}
+	for i, record := range records {
+		if record.Timestamp == "" {
+			t.Errorf("record %d has no timestamp", i)
+		}
+		record.Timestamp = ""
+		if fmt.Sprintf("%+v", record) != fmt.Sprintf("%+v", expected[i]) {
+			t.Errorf("record %d: expected %+v, got %+v", i, expected[i], record)
+		}
+	}
+}
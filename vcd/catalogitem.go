@@ -1,18 +1,20 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 )
 
 // Deletes catalog item which can be vApp template OVA or media ISO file
-func deleteCatalogItem(d *schema.ResourceData, vcdClient *VCDClient) diag.Diagnostics {
-	log.Printf("[TRACE] Catalog item delete started")
+func deleteCatalogItem(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient) diag.Diagnostics {
+	tflog.Trace(ctx, "Catalog item delete started")
 
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
 	if err != nil {
@@ -21,20 +23,20 @@ func deleteCatalogItem(d *schema.ResourceData, vcdClient *VCDClient) diag.Diagno
 
 	catalog, err := adminOrg.GetCatalogByName(d.Get("catalog").(string), false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog. Removing from tfstate")
+		tflog.Debug(ctx, "Unable to find catalog. Removing from tfstate")
 		return diag.Errorf("unable to find catalog")
 	}
 
 	catalogItemName := d.Get("name").(string)
 	catalogItem, err := catalog.GetCatalogItemByName(catalogItemName, false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog item. Removing from tfstate")
+		tflog.Debug(ctx, "Unable to find catalog item. Removing from tfstate")
 		return diag.Errorf("unable to find catalog item %s", catalogItemName)
 	}
 
 	err = catalogItem.Delete()
 	if err != nil {
-		log.Printf("[DEBUG] Error removing catalog item %s", err)
+		tflog.Debug(ctx, "Error removing catalog item", map[string]interface{}{"error": err})
 		return diag.Errorf("[deleteCatalogItem] error removing catalog item %s: %s", catalogItem.CatalogItem.Name, err)
 	}
 
@@ -42,14 +44,14 @@ func deleteCatalogItem(d *schema.ResourceData, vcdClient *VCDClient) diag.Diagno
 	if err == nil {
 		return diag.Errorf("catalog item %s still found after deletion", catalogItemName)
 	}
-	log.Printf("[TRACE] Catalog item delete completed: %s", catalogItemName)
+	tflog.Trace(ctx, "Catalog item delete completed", map[string]interface{}{"catalog_item_name": catalogItemName})
 
 	return nil
 }
 
 // Finds catalog item which can be vApp template OVA or media ISO file
-func findCatalogItem(d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd.CatalogItem, error) {
-	log.Printf("[TRACE] Catalog item read initiated")
+func findCatalogItem(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd.CatalogItem, error) {
+	tflog.Trace(ctx, "Catalog item read initiated")
 
 	orgName, err := vcdClient.GetOrgNameFromResource(d)
 	if err != nil {
@@ -57,7 +59,7 @@ func findCatalogItem(d *schema.ResourceData, vcdClient *VCDClient, origin string
 	}
 	catalog, err := vcdClient.Client.GetCatalogByName(orgName, d.Get("catalog").(string))
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog.")
+		tflog.Debug(ctx, "Unable to find catalog")
 		return nil, fmt.Errorf("unable to find catalog: %s", err)
 	}
 
@@ -90,7 +92,7 @@ func findCatalogItem(d *schema.ResourceData, vcdClient *VCDClient, origin string
 
 	catalogItem, err = catalog.GetCatalogItemByNameOrId(identifier, false)
 	if govcd.IsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] Unable to find catalog item %s. Removing from tfstate", identifier)
+		tflog.Info(ctx, "Unable to find catalog item. Removing from tfstate", map[string]interface{}{"identifier": identifier})
 		d.SetId("")
 		return nil, nil
 	}
@@ -100,15 +102,15 @@ func findCatalogItem(d *schema.ResourceData, vcdClient *VCDClient, origin string
 	}
 
 	d.SetId(catalogItem.CatalogItem.ID)
-	log.Printf("[TRACE] Catalog item read completed: %#v", catalogItem.CatalogItem)
+	tflog.Trace(ctx, "Catalog item read completed", map[string]interface{}{"catalog_item_name": catalogItem.CatalogItem.Name})
 	return catalogItem, nil
 }
 
-func getError(task govcd.UploadTask) error {
+func getError(ctx context.Context, task govcd.UploadTask) error {
 	if task.GetUploadError() != nil {
 		err := task.CancelTask()
 		if err != nil {
-			log.Printf("[DEBUG] error cancelling media upload task: %#v", err)
+			tflog.Debug(ctx, "error cancelling media upload task", map[string]interface{}{"error": err})
 		}
 		return fmt.Errorf("error uploading media: %#v", task.GetUploadError())
 	}
@@ -3,13 +3,15 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
 )
 
 // updateEdgeGatewayTier0Dedication updates default NSX-T Edge Gateway used for tests to use Tier-0
@@ -60,7 +62,7 @@ func checkNetworkPoolExists(networkPoolName string, wantExisting bool) resource.
 func testAccCheckOrgDestroy(orgName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := testAccProvider.Meta().(*VCDClient)
-		adminOrg, err := runWithRetry(
+		adminOrg, err := runWithRetry(context.Background(),
 			"[testAccCheckOrgDestroy] organization retrieval",
 			"[testAccCheckOrgDestroy] error retrieving org",
 			10*time.Second,
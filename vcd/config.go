@@ -1,8 +1,11 @@
 package vcd
 
 import (
+	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -12,8 +15,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
 	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
@@ -33,6 +38,8 @@ type Config struct {
 	AllowApiTokenFile       bool   // Setting to suppress API Token File security warnings
 	ServiceAccountTokenFile string // File containing the Service Account API token
 	AllowSATokenFile        bool   // Setting to suppress Service Account Token File security warnings
+	Jwt                     string // JWT issued by an external identity provider, exchanged for a VCD token
+	JwtFile                 string // File containing a JWT issued by an external identity provider
 	SysOrg                  string // Org used for authentication
 	Org                     string // Default Org used for API operations
 	Vdc                     string // Default (optional) VDC for API operations
@@ -57,6 +64,46 @@ type Config struct {
 	// IgnoredMetadata allows to configure a set of metadata entries that should be ignored by all the
 	// API operations related to metadata.
 	IgnoredMetadata []govcd.IgnoredMetadata
+
+	// SessionCacheFile is the file where authenticated sessions are stored to be reused across
+	// Terraform runs. The session cache is disabled when empty
+	SessionCacheFile string
+	// SessionCacheKey is the secret used to identify and encrypt the session cache entries. When empty,
+	// a secret is generated in the user configuration directory
+	SessionCacheKey string
+	// SessionCacheMaxAge is the maximum age of a cached session before a new login is performed
+	SessionCacheMaxAge time.Duration
+
+	// Retry is the policy for retrying API requests that fail with transient errors. No retries are
+	// performed when nil
+	Retry *retryPolicy
+
+	// MaxConcurrentRequests is the maximum number of API requests in flight at the same time. 0 means no limit
+	MaxConcurrentRequests int
+	// MaxConcurrentOrgOperations is the maximum number of create, update and delete operations running at
+	// the same time in each Org. 0 means no limit
+	MaxConcurrentOrgOperations int
+
+	// CaFile and CaPem contain additional certificate authorities trusted to verify the VCD certificates
+	CaFile string
+	CaPem  string
+	// ProxyUrl is the proxy used for all connections to VCD. When empty, the proxy environment variables are used
+	ProxyUrl string
+	// NoProxy is a comma-separated list of hosts and domains that are reached without proxy
+	NoProxy string
+
+	// ReadOnly prevents any change in VCD. Only read requests and logins are sent (see readOnlyTransport)
+	ReadOnly bool
+
+	// TenantContext is the name or ID of the Org whose context is sent with all requests. Empty for no context
+	TenantContext string
+
+	// AuditLogFile is the file where the create, update, delete and import operations are recorded. Empty to disable
+	AuditLogFile string
+
+	// DefaultMetadata holds the metadata entries configured in the provider 'default_metadata' blocks, which are added
+	// to every resource that supports 'metadata_entry'. Entries with the same key in the resource take precedence.
+	DefaultMetadata map[string]types.MetadataValue
 }
 
 type VCDClient struct {
@@ -66,6 +113,25 @@ type VCDClient struct {
 	Vdc             string // name of default VDC
 	MaxRetryTimeout int
 	InsecureFlag    bool
+
+	// limiter caps the concurrent API requests and operations (see apiLimiter)
+	limiter *apiLimiter
+	// retry is the retry policy of the provider, also applied to tasks failing on busy entities (see retryOnBusyEntity)
+	retry *retryPolicy
+	// readOnly is set when the provider must not change anything in VCD
+	readOnly bool
+	// tenantContexts contains the copies of this client for each tenant context (see withTenantContext)
+	tenantContexts *tenantContextClients
+	// connectedVersion is the API version of the connected VCD, used to check the version requirements
+	connectedVersion *connectedVcdVersion
+	// auditLog records the operations that change VCD, when enabled (see withAuditLog)
+	auditLog *auditLogger
+	// defaultMetadata contains the metadata entries added to every resource that supports 'metadata_entry'
+	defaultMetadata map[string]types.MetadataValue
+	// generatedResources contains the resources generated by the "config" list mode of vcd_resource_list
+	generatedResources *generatedResources
+	// inventory, when set, collects the resources found by vcd_resource_list instead of listing them (see orgInventoryList)
+	inventory *resourceInventory
 }
 
 // StringMap type is used to simplify reading resource definitions
@@ -683,8 +749,21 @@ func (cli *VCDClient) GetOrgName(orgName string) (string, error) {
 }
 
 // TODO Look into refactoring this into a method of *Config
-func ProviderAuthenticate(client *govcd.VCDClient, user, password, token, org, apiToken, apiTokenFile, saTokenFile string) error {
+func ProviderAuthenticate(client *govcd.VCDClient, user, password, token, org, apiToken, apiTokenFile, saTokenFile, jwt, jwtFile string) error {
 	var err error
+	if jwtFile != "" {
+		jwt, err = readJwtFile(jwtFile)
+		if err != nil {
+			return err
+		}
+	}
+	if jwt != "" {
+		err = authenticateWithJwt(client, org, jwt)
+		if err != nil {
+			return fmt.Errorf("error during JWT authentication: %s", err)
+		}
+		return nil
+	}
 	if saTokenFile != "" {
 		return client.SetServiceAccountApiToken(org, saTokenFile)
 	}
@@ -713,17 +792,67 @@ func ProviderAuthenticate(client *govcd.VCDClient, user, password, token, org, a
 	return client.Authenticate(user, password, org)
 }
 
-func (c *Config) Client() (*VCDClient, error) {
+// readJwtFile reads a JWT from a file. The file is read at each authentication, as workload identity tokens are
+// usually rotated by the platform that issues them
+func readJwtFile(jwtFile string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(jwtFile))
+	if err != nil {
+		return "", fmt.Errorf("error reading JWT file %s: %s", jwtFile, err)
+	}
+	jwt := strings.TrimSpace(string(content))
+	if jwt == "" {
+		return "", fmt.Errorf("JWT file %s is empty", jwtFile)
+	}
+	return jwt, nil
+}
+
+// authenticateWithJwt exchanges a JWT issued by an external identity provider for a VCD bearer token, using the
+// OAuth token endpoint of the Org. The Org must trust the identity provider (see vcd_org_oidc)
+func authenticateWithJwt(client *govcd.VCDClient, org, jwt string) error {
+	apiLogRedactor.addValue(jwt)
+
+	userDef := "tenant/" + org
+	if strings.EqualFold(org, "system") {
+		userDef = "provider"
+	}
+	rootHref := strings.TrimSuffix(client.Client.VCDHREF.String(), "/api")
+	tokenUrl, err := url.ParseRequestURI(fmt.Sprintf("%s/oauth/%s/token", rootHref, userDef))
+	if err != nil {
+		return fmt.Errorf("error building token URL: %s", err)
+	}
+
+	payload := map[string]string{
+		"grant_type": "urn:ietf:params:oauth:grant-type:jwt-bearer",
+		"assertion":  jwt,
+	}
+	accessToken := &types.ApiTokenRefresh{}
+	err = client.Client.OpenApiPostUrlEncoded(client.Client.APIVersion, tokenUrl, nil, payload, accessToken, nil)
+	if err != nil {
+		return fmt.Errorf("error exchanging JWT at %s: %s", tokenUrl.String(), err)
+	}
+	if accessToken.AccessToken == "" {
+		return fmt.Errorf("no access token returned by %s", tokenUrl.String())
+	}
+	apiLogRedactor.addValue(accessToken.AccessToken)
+
+	return client.SetToken(org, govcd.BearerTokenHeader, accessToken.AccessToken)
+}
+
+func (c *Config) Client(ctx context.Context) (*VCDClient, error) {
 	rawData := c.User + "#" +
 		c.Password + "#" +
 		c.Token + "#" +
 		c.ApiToken + "#" +
 		c.ApiTokenFile + "#" +
 		c.ServiceAccountTokenFile + "#" +
+		c.Jwt + "#" +
+		c.JwtFile + "#" +
 		c.SysOrg + "#" +
 		c.Vdc + "#" +
 		c.Href
-	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(rawData)))
+	// The on-disk session cache depends only on the connection data (see sessionCacheKeys), while the connection
+	// cache also depends on the settings of the client, so that clients with different settings are never shared
+	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(rawData+"#"+c.clientSettings())))
 
 	// The cached connection is served only if the variable VCD_CACHE is set
 	cachedVCDClients.Lock()
@@ -736,7 +865,9 @@ func (c *Config) Client() (*VCDClient, error) {
 		// debugPrintf("[%s] cached connection served %d times (size:%d)\n",
 		elapsed := time.Since(client.initTime)
 		if elapsed > maxConnectionValidity {
-			debugPrintf("cached connection invalidated after %2.0f minutes \n", maxConnectionValidity.Minutes())
+			tflog.Debug(ctx, "cached connection invalidated", map[string]interface{}{
+				"max_validity_minutes": maxConnectionValidity.Minutes(),
+			})
 			cachedVCDClients.Lock()
 			delete(cachedVCDClients.conMap, checksum)
 			cachedVCDClients.Unlock()
@@ -759,15 +890,73 @@ func (c *Config) Client() (*VCDClient, error) {
 			govcd.WithHttpUserAgent(userAgent),
 			govcd.WithIgnoredMetadata(c.IgnoredMetadata),
 		),
-		SysOrg:          c.SysOrg,
-		Org:             c.Org,
-		Vdc:             c.Vdc,
-		MaxRetryTimeout: c.MaxRetryTimeout,
-		InsecureFlag:    c.InsecureFlag}
-
-	err = ProviderAuthenticate(vcdClient.VCDClient, c.User, c.Password, c.Token, c.SysOrg, c.ApiToken, c.ApiTokenFile, c.ServiceAccountTokenFile)
+		SysOrg:             c.SysOrg,
+		Org:                c.Org,
+		Vdc:                c.Vdc,
+		MaxRetryTimeout:    c.MaxRetryTimeout,
+		InsecureFlag:       c.InsecureFlag,
+		limiter:            newApiLimiter(c.MaxConcurrentRequests, c.MaxConcurrentOrgOperations),
+		retry:              c.Retry,
+		readOnly:           c.ReadOnly,
+		defaultMetadata:    c.DefaultMetadata,
+		connectedVersion:   &connectedVcdVersion{},
+		generatedResources: newGeneratedResources(),
+	}
+
+	httpTransport, ok := vcdClient.Client.Http.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected HTTP transport type %T", vcdClient.Client.Http.Transport)
+	}
+	err = c.configureHttpTransport(ctx, httpTransport)
 	if err != nil {
-		return nil, fmt.Errorf("something went wrong during authentication: %s", err)
+		return nil, err
+	}
+
+	// The limiter is set before the retry transport, so that waiting between retries does not hold a slot
+	if c.MaxConcurrentRequests > 0 {
+		vcdClient.Client.Http.Transport = newLimiterTransport(vcdClient.Client.Http.Transport, vcdClient.limiter)
+	}
+	if c.Retry != nil {
+		vcdClient.Client.Http.Transport = newRetryTransport(vcdClient.Client.Http.Transport, *c.Retry)
+	}
+	// The on-disk session cache, when enabled, allows to skip the login performed by previous runs
+	var sessionCache *sessionCacheTransport
+	if c.sessionCacheEnabled() {
+		sessionChecksum, sessionCacheKey, err := c.sessionCacheKeys(rawData)
+		if err != nil {
+			tflog.Warn(ctx, "[session cache] disabled", map[string]interface{}{"error": err})
+		} else {
+			sessionCache = newSessionCacheTransport(vcdClient.Client.Http.Transport, c, vcdClient, sessionChecksum, sessionCacheKey)
+			vcdClient.Client.Http.Transport = sessionCache
+		}
+	}
+	// The read-only check is the outermost layer, so that refused requests are neither retried nor limited
+	if c.ReadOnly {
+		vcdClient.Client.Http.Transport = newReadOnlyTransport(vcdClient.Client.Http.Transport)
+	}
+	vcdClient.tenantContexts = newTenantContextClients(vcdClient.Client.Http.Transport)
+	if c.AuditLogFile != "" {
+		vcdClient.auditLog, err = newAuditLogger(c.AuditLogFile, c.User)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sessionRestored := sessionCache != nil && sessionCache.restore(ctx)
+	if !sessionRestored {
+		err = ProviderAuthenticate(vcdClient.VCDClient, c.User, c.Password, c.Token, c.SysOrg, c.ApiToken, c.ApiTokenFile, c.ServiceAccountTokenFile, c.Jwt, c.JwtFile)
+		if err != nil {
+			return nil, fmt.Errorf("something went wrong during authentication: %s", err)
+		}
+		if sessionCache != nil {
+			sessionCache.store(ctx)
+		}
+	}
+	if c.TenantContext != "" {
+		err = vcdClient.setTenantContext(c.TenantContext)
+		if err != nil {
+			return nil, err
+		}
 	}
 	cachedVCDClients.Lock()
 	cachedVCDClients.conMap[checksum] = cachedConnection{initTime: time.Now(), connection: vcdClient}
@@ -776,6 +965,41 @@ func (c *Config) Client() (*VCDClient, error) {
 	return vcdClient, nil
 }
 
+// clientSettings returns the settings that change the behavior of the client created by Client(), other than the
+// connection data. They are serialized with JSON, which sorts map keys and follows pointers
+func (c *Config) clientSettings() string {
+	settings, err := json.Marshal(struct {
+		ReadOnly                   bool
+		TenantContext              string
+		Retry                      *retryPolicy
+		MaxConcurrentRequests      int
+		MaxConcurrentOrgOperations int
+		CaFile                     string
+		CaPem                      string
+		ProxyUrl                   string
+		NoProxy                    string
+		AuditLogFile               string
+		DefaultMetadata            map[string]types.MetadataValue
+	}{
+		ReadOnly:                   c.ReadOnly,
+		TenantContext:              c.TenantContext,
+		Retry:                      c.Retry,
+		MaxConcurrentRequests:      c.MaxConcurrentRequests,
+		MaxConcurrentOrgOperations: c.MaxConcurrentOrgOperations,
+		CaFile:                     c.CaFile,
+		CaPem:                      c.CaPem,
+		ProxyUrl:                   c.ProxyUrl,
+		NoProxy:                    c.NoProxy,
+		AuditLogFile:               c.AuditLogFile,
+		DefaultMetadata:            c.DefaultMetadata,
+	})
+	if err != nil {
+		// Not expected with these types. The settings are still different from any valid serialization
+		return fmt.Sprintf("%+v", err)
+	}
+	return string(settings)
+}
+
 // callFuncName returns the name of the function that called the current function. It is used for
 // tracing
 func callFuncName() string {
@@ -799,7 +1023,7 @@ func buildUserAgent(version, sysOrg string) string {
 }
 
 // logForScreen writes to go-vcloud-director log with a tag that can be used to
-// filter messages directed at the user.
+// filter messages directed at the user. The message is also written to the provider log.
 // * origin is the name of the resource that originates the message
 // * msg is the text that will end up in the logs
 //
@@ -809,6 +1033,7 @@ func buildUserAgent(version, sysOrg string) string {
 //	tail -f go-vcloud-director.log | grep '\[SCREEN\]'
 func logForScreen(origin, msg string) {
 	util.Logger.Printf("[SCREEN] {%s} %s\n", origin, msg)
+	tflog.Info(providerLogContext(), msg, map[string]interface{}{"origin": origin})
 }
 
 // dSet sets the value of a schema property, discarding the error
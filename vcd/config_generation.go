@@ -0,0 +1,341 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The "config" list mode of vcd_resource_list generates the full HCL configuration of the listed objects. Each
+// object is imported and read with the functions of its resource, as `terraform import` would do, and the resulting
+// state is rendered as a resource block, next to the import block that brings it under Terraform management.
+//
+// The string values matching the ID of another generated resource are rendered as a reference to that resource.
+// The IDs are collected in the client (see generatedResources), so that references can cross the boundaries of
+// a single vcd_resource_list, as long as the referenced list is read first (e.g. using `depends_on`).
+
+// generatedResources contains the addresses of the resources generated by the "config" list mode, indexed by ID
+type generatedResources struct {
+	lock      sync.Mutex
+	addresses map[string]string
+}
+
+func newGeneratedResources() *generatedResources {
+	return &generatedResources{addresses: make(map[string]string)}
+}
+
+// add records the address of a generated resource
+func (g *generatedResources) add(id, address string) {
+	if id == "" {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.addresses[strings.ToLower(id)] = address
+}
+
+// get returns the address of the generated resource with the given ID
+func (g *generatedResources) get(id string) (string, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	address, found := g.addresses[strings.ToLower(id)]
+	return address, found
+}
+
+// generatedResource is a resource to be rendered by the "config" list mode
+type generatedResource struct {
+	resourceType string
+	name         string
+	importId     string
+	resource     *schema.Resource
+	data         *schema.ResourceData
+}
+
+func (r generatedResource) address() string {
+	return r.resourceType + "." + r.name
+}
+
+// readResourceForConfig imports and reads a resource, in the same way as `terraform import`
+func readResourceForConfig(ctx context.Context, meta interface{}, resourceType string, resource *schema.Resource, importId string) (*schema.ResourceData, error) {
+	if resource.Importer == nil {
+		return nil, fmt.Errorf("resource %s does not support import", resourceType)
+	}
+
+	d := resource.Data(nil)
+	d.SetId(importId)
+	var imported []*schema.ResourceData
+	var err error
+	switch {
+	case resource.Importer.StateContext != nil:
+		imported, err = resource.Importer.StateContext(ctx, d, meta)
+	case resource.Importer.State != nil:
+		imported, err = resource.Importer.State(d, meta)
+	default:
+		imported = []*schema.ResourceData{d}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error importing %s '%s': %s", resourceType, importId, err)
+	}
+	if len(imported) == 0 {
+		return nil, fmt.Errorf("importing %s '%s' returned no resource", resourceType, importId)
+	}
+	d = imported[0]
+
+	switch {
+	case resource.ReadContext != nil:
+		diags := resource.ReadContext(ctx, d, meta)
+		err = diagnosticsError(diags)
+	case resource.ReadWithoutTimeout != nil:
+		diags := resource.ReadWithoutTimeout(ctx, d, meta)
+		err = diagnosticsError(diags)
+	case resource.Read != nil:
+		err = resource.Read(d, meta)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s '%s': %s", resourceType, importId, err)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("%s '%s' was not found after import", resourceType, importId)
+	}
+	return d, nil
+}
+
+// configRenderer renders the state of resources as HCL
+type configRenderer struct {
+	references *generatedResources
+	self       string
+}
+
+// renderResource returns the HCL resource block of a generated resource
+func (cr configRenderer) renderResource(r generatedResource) string {
+	cr.self = r.address()
+	values := make(map[string]interface{}, len(r.resource.Schema))
+	for key := range r.resource.Schema {
+		values[key] = r.data.Get(key)
+	}
+
+	var hcl strings.Builder
+	hcl.WriteString(fmt.Sprintf("resource %q %q {\n", r.resourceType, r.name))
+	cr.renderBody(&hcl, r.resource.Schema, values, "  ")
+	hcl.WriteString("}\n")
+	return hcl.String()
+}
+
+// renderBody writes the attributes of a schema, followed by its nested blocks
+func (cr configRenderer) renderBody(hcl *strings.Builder, schemas map[string]*schema.Schema, values map[string]interface{}, indent string) {
+	var attributes, blocks []string
+	for _, key := range sortedSchemaKeys(schemas) {
+		if !isConfigurable(schemas[key], values[key]) || conflictsWithRendered(schemas[key], attributes, blocks) {
+			continue
+		}
+		if _, isBlock := schemas[key].Elem.(*schema.Resource); isBlock {
+			blocks = append(blocks, key)
+		} else {
+			attributes = append(attributes, key)
+		}
+	}
+
+	width := 0
+	for _, key := range attributes {
+		width = max(width, len(key))
+	}
+	for _, key := range attributes {
+		if schemas[key].Sensitive {
+			hcl.WriteString(fmt.Sprintf("%s# %s is sensitive and is not retrieved from VCD\n", indent, key))
+			hcl.WriteString(fmt.Sprintf("%s%-*s = \"\"\n", indent, width, key))
+			continue
+		}
+		hcl.WriteString(fmt.Sprintf("%s%-*s = %s\n", indent, width, key, cr.renderValue(schemas[key], values[key])))
+	}
+
+	for _, key := range blocks {
+		nested := schemas[key].Elem.(*schema.Resource)
+		for _, item := range collectionItems(values[key]) {
+			itemValues, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hcl.WriteString(fmt.Sprintf("\n%s%s {\n", indent, key))
+			cr.renderBody(hcl, nested.Schema, itemValues, indent+"  ")
+			hcl.WriteString(fmt.Sprintf("%s}\n", indent))
+		}
+	}
+}
+
+// renderValue returns the HCL expression of an attribute value
+func (cr configRenderer) renderValue(s *schema.Schema, value interface{}) string {
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		elemSchema, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elemSchema = &schema.Schema{Type: schema.TypeString}
+		}
+		var items []string
+		for _, item := range collectionItems(value) {
+			items = append(items, cr.renderValue(elemSchema, item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case schema.TypeMap:
+		m, _ := value.(map[string]interface{})
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var items []string
+		for _, key := range keys {
+			items = append(items, fmt.Sprintf("%s = %s", quoteHcl(key), cr.renderScalar(m[key])))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return cr.renderScalar(value)
+	}
+}
+
+// renderScalar returns the HCL expression of a primitive value. IDs of generated resources become references
+func (cr configRenderer) renderScalar(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		if cr.references != nil {
+			if address, found := cr.references.get(v); found && address != cr.self {
+				return address + ".id"
+			}
+		}
+		return quoteHcl(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return "null"
+	default:
+		return quoteHcl(fmt.Sprintf("%v", v))
+	}
+}
+
+// quoteHcl returns a quoted HCL string, escaping the template sequences
+func quoteHcl(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+// isConfigurable tells whether an attribute must be part of the configuration: required attributes are always
+// included, optional ones only when they have a value different from the default. Without a default, zero values
+// are omitted, while with a default a zero value such as `false` is included when the default is not
+func isConfigurable(s *schema.Schema, value interface{}) bool {
+	if s.Deprecated != "" {
+		return false
+	}
+	if s.Required {
+		return true
+	}
+	if !s.Optional {
+		return false
+	}
+	if s.Sensitive {
+		return false
+	}
+	if s.Default != nil {
+		return !reflect.DeepEqual(s.Default, value)
+	}
+	return !isZeroValue(value)
+}
+
+// conflictsWithRendered tells whether an attribute conflicts with one that was already included
+func conflictsWithRendered(s *schema.Schema, rendered ...[]string) bool {
+	for _, conflict := range s.ConflictsWith {
+		for _, keys := range rendered {
+			for _, key := range keys {
+				if key == conflict {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isZeroValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if set, ok := value.(*schema.Set); ok {
+		return set.Len() == 0
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// collectionItems returns the items of a list or set value
+func collectionItems(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+func sortedSchemaKeys(schemas map[string]*schema.Schema) []string {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// generateResourceConfig imports and reads the given resources, and writes their import and resource blocks to
+// importData. It returns the resource blocks
+func generateResourceConfig(ctx context.Context, meta interface{}, resources []generatedResource, importData *strings.Builder) ([]string, error) {
+	client := meta.(*VCDClient)
+	references := client.generatedResources
+	if references == nil {
+		references = newGeneratedResources()
+	}
+
+	// All the resources are read before rendering any of them, so that they can refer to each other
+	for i, r := range resources {
+		resource, found := globalResourceMap[r.resourceType]
+		if !found {
+			return nil, fmt.Errorf("error generating configuration for %s: unknown resource type", r.address())
+		}
+		d, err := readResourceForConfig(ctx, meta, r.resourceType, resource, r.importId)
+		if err != nil {
+			return nil, fmt.Errorf("error generating configuration for %s: %s", r.address(), err)
+		}
+		resources[i].resource = resource
+		resources[i].data = d
+		references.add(d.Id(), r.address())
+	}
+
+	renderer := configRenderer{references: references}
+	var list []string
+	for _, r := range resources {
+		resourceHcl := renderer.renderResource(r)
+		list = append(list, resourceHcl)
+		importData.WriteString(fmt.Sprintf("# Configuration of %s %s\n", r.resourceType, r.importId))
+		importData.WriteString("import {\n")
+		importData.WriteString(fmt.Sprintf("  to = %s\n", r.address()))
+		importData.WriteString(fmt.Sprintf("  id = %s\n", quoteHcl(r.importId)))
+		importData.WriteString("}\n\n")
+		importData.WriteString(resourceHcl)
+		importData.WriteString("\n")
+	}
+	return list, nil
+}
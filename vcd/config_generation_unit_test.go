@@ -0,0 +1,102 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_generateConfig(t *testing.T) {
+	testResource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":         {Type: schema.TypeString, Required: true},
+			"description":  {Type: schema.TypeString, Optional: true},
+			"org":          {Type: schema.TypeString, Optional: true},
+			"network_id":   {Type: schema.TypeString, Optional: true},
+			"enabled":      {Type: schema.TypeBool, Optional: true, Default: true},
+			"power_on":     {Type: schema.TypeBool, Optional: true, Default: true},
+			"retries":      {Type: schema.TypeInt, Optional: true, Default: 3},
+			"cpus":         {Type: schema.TypeInt, Optional: true, Computed: true},
+			"status":       {Type: schema.TypeString, Computed: true},
+			"old_name":     {Type: schema.TypeString, Optional: true, Deprecated: "use name"},
+			"password":     {Type: schema.TypeString, Required: true, Sensitive: true},
+			"tags":         {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"labels":       {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"empty_labels": {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":   {Type: schema.TypeString, Required: true},
+						"action": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				parts := strings.Split(d.Id(), ImportSeparator)
+				dSet(d, "org", parts[0])
+				d.SetId("urn:vcloud:test:" + parts[1])
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			dSet(d, "name", "my-${name}")
+			dSet(d, "description", "")
+			dSet(d, "network_id", "urn:vcloud:network:1")
+			dSet(d, "enabled", true)
+			dSet(d, "power_on", false)
+			dSet(d, "retries", 0)
+			dSet(d, "cpus", 2)
+			dSet(d, "status", "READY")
+			dSet(d, "old_name", "my-name")
+			err := d.Set("tags", []string{"b"})
+			if err == nil {
+				err = d.Set("labels", map[string]string{"env": "test"})
+			}
+			if err == nil {
+				err = d.Set("rule", []interface{}{map[string]interface{}{"name": "rule1", "action": "ALLOW"}})
+			}
+			return diag.FromErr(err)
+		},
+	}
+
+	d, err := readResourceForConfig(context.Background(), nil, "vcd_test", testResource, "my-org.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	references := newGeneratedResources()
+	references.add("URN:VCLOUD:NETWORK:1", "vcd_network.net-1")
+	references.add(d.Id(), "vcd_test.test-1")
+
+	renderer := configRenderer{references: references}
+	hcl := renderer.renderResource(generatedResource{resourceType: "vcd_test", name: "test-1", resource: testResource, data: d})
+	expected := `resource "vcd_test" "test-1" {
+  cpus       = 2
+  labels     = {"env" = "test"}
+  name       = "my-$${name}"
+  network_id = vcd_network.net-1.id
+  org        = "my-org"
+  # password is sensitive and is not retrieved from VCD
+  password   = ""
+  power_on   = false
+  retries    = 0
+  tags       = ["b"]
+
+  rule {
+    action = "ALLOW"
+    name   = "rule1"
+  }
+}
+`
+	if hcl != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, hcl)
+	}
+}
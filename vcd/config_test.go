@@ -78,6 +78,8 @@ type TestConfig struct {
 		ApiToken                string `json:"api_token,omitempty"`
 		ApiTokenFile            string `json:"api_token_file,omitempty"`
 		ServiceAccountTokenFile string `json:"service_account_token_file,omitempty"`
+		Jwt                     string `json:"jwt,omitempty"`
+		JwtFile                 string `json:"jwt_file,omitempty"`
 
 		// Versions are filled by the VCD provisioning task, and allow tests to
 		// check for compatibility without using an extra connection
@@ -875,7 +877,7 @@ func getVcdVersion(config TestConfig) (string, error) {
 	if vcdClient == nil || err != nil {
 		return "", err
 	}
-	err = ProviderAuthenticate(vcdClient, config.Provider.User, config.Provider.Password, config.Provider.Token, config.Provider.SysOrg, config.Provider.ApiToken, config.Provider.ApiTokenFile, config.Provider.ServiceAccountTokenFile)
+	err = ProviderAuthenticate(vcdClient, config.Provider.User, config.Provider.Password, config.Provider.Token, config.Provider.SysOrg, config.Provider.ApiToken, config.Provider.ApiTokenFile, config.Provider.ServiceAccountTokenFile, config.Provider.Jwt, config.Provider.JwtFile)
 	if err != nil {
 		return "", err
 	}
@@ -1001,7 +1003,7 @@ func TestMain(m *testing.M) {
 		fmt.Printf("error getting a govcd client: %s\n", err)
 		exitCode = 1
 	} else {
-		err = ProviderAuthenticate(govcdClient, testConfig.Provider.User, testConfig.Provider.Password, testConfig.Provider.Token, testConfig.Provider.SysOrg, testConfig.Provider.ApiToken, testConfig.Provider.ApiTokenFile, testConfig.Provider.ServiceAccountTokenFile)
+		err = ProviderAuthenticate(govcdClient, testConfig.Provider.User, testConfig.Provider.Password, testConfig.Provider.Token, testConfig.Provider.SysOrg, testConfig.Provider.ApiToken, testConfig.Provider.ApiTokenFile, testConfig.Provider.ServiceAccountTokenFile, testConfig.Provider.Jwt, testConfig.Provider.JwtFile)
 		if err != nil {
 			fmt.Printf("error authenticating provider: %s\n", err)
 			exitCode = 1
@@ -1303,6 +1305,20 @@ func importStateIdViaResource(resource string) resource.ImportStateIdFunc {
 	}
 }
 
+// importStateIdUrnViaResource runs the import of a resource using the URN of its entity
+func importStateIdUrnViaResource(resourceDef string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceDef]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceDef)
+		}
+		if rs.Primary.ID == "" {
+			return "", fmt.Errorf("no ID is set for %s resource", resourceDef)
+		}
+		return rs.Primary.ID, nil
+	}
+}
+
 func importStateCatalogIdViaResource(resourceDef string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
 		rs, ok := s.RootModule().Resources[resourceDef]
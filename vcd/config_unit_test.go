@@ -5,6 +5,8 @@ package vcd
 import (
 	"testing"
 	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 func Test_isScalar(t *testing.T) {
@@ -128,3 +130,44 @@ func Test_isScalar(t *testing.T) {
 		})
 	}
 }
+
+func Test_clientSettings(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Retry: &retryPolicy{MaxAttempts: 3, MinBackoff: time.Second, MaxBackoff: time.Minute,
+				RetryableErrors: map[string]bool{retryErrorBusyEntity: true, retryErrorThrottled: true}},
+			DefaultMetadata: map[string]types.MetadataValue{
+				"owner": {TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "team-a"}},
+			},
+		}
+	}
+	base := newConfig().clientSettings()
+	if newConfig().clientSettings() != base {
+		t.Errorf("equal configurations must have the same settings")
+	}
+
+	changes := map[string]func(c *Config){
+		"read_only":           func(c *Config) { c.ReadOnly = true },
+		"tenant_context":      func(c *Config) { c.TenantContext = "org1" },
+		"retry":               func(c *Config) { c.Retry.RetryableErrors[retryErrorBusyEntity] = false },
+		"no_retry":            func(c *Config) { c.Retry = nil },
+		"request_limit":       func(c *Config) { c.MaxConcurrentRequests = 10 },
+		"operation_limit":     func(c *Config) { c.MaxConcurrentOrgOperations = 10 },
+		"ca_file":             func(c *Config) { c.CaFile = "/tmp/ca.pem" },
+		"ca_pem":              func(c *Config) { c.CaPem = "-----BEGIN CERTIFICATE-----" },
+		"proxy":               func(c *Config) { c.ProxyUrl = "http://proxy:3128" },
+		"no_proxy":            func(c *Config) { c.NoProxy = "vcd.example.com" },
+		"audit_log_file":      func(c *Config) { c.AuditLogFile = "/tmp/audit.log" },
+		"default_metadata":    func(c *Config) { c.DefaultMetadata["owner"].TypedValue.Value = "team-b" },
+		"no_default_metadata": func(c *Config) { c.DefaultMetadata = nil },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			config := newConfig()
+			change(config)
+			if config.clientSettings() == base {
+				t.Errorf("changing %s must change the client settings", name)
+			}
+		})
+	}
+}
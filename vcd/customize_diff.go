@@ -0,0 +1,136 @@
+package vcd
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// This file contains helpers for plan-time validations performed in CustomizeDiff functions.
+//
+// Validations inspect the raw configuration (d.GetRawConfig()) instead of d.Get(), because the
+// latter mixes configuration with computed state values and does not tell whether a value is
+// unknown at plan time (e.g. it depends on a resource that is not created yet). Any value that is
+// unknown at plan time must be skipped - it will be checked again during apply.
+
+// rawConfigAttribute returns attribute 'name' of a raw configuration object. The second return
+// value is false when the object or the attribute are null or unknown at plan time, meaning that
+// validations involving the attribute must be skipped.
+func rawConfigAttribute(object cty.Value, name string) (cty.Value, bool) {
+	if !object.IsKnown() {
+		return cty.DynamicVal, false
+	}
+	if object.IsNull() || !object.Type().IsObjectType() || !object.Type().HasAttribute(name) {
+		return cty.NullVal(cty.DynamicPseudoType), false
+	}
+	value := object.GetAttr(name)
+	if value.IsNull() || !value.IsKnown() {
+		return value, false
+	}
+	return value, true
+}
+
+// rawConfigString returns the value of string attribute 'name' of a raw configuration object.
+// Empty strings are reported as not set.
+func rawConfigString(object cty.Value, name string) (string, bool) {
+	value, ok := rawConfigAttribute(object, name)
+	if !ok || value.Type() != cty.String || value.AsString() == "" {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+// rawConfigInt returns the value of numeric attribute 'name' of a raw configuration object
+func rawConfigInt(object cty.Value, name string) (int, bool) {
+	value, ok := rawConfigAttribute(object, name)
+	if !ok || value.Type() != cty.Number {
+		return 0, false
+	}
+	intValue, _ := value.AsBigFloat().Int64()
+	return int(intValue), true
+}
+
+// rawConfigBool returns the value of boolean attribute 'name' of a raw configuration object
+func rawConfigBool(object cty.Value, name string) (bool, bool) {
+	value, ok := rawConfigAttribute(object, name)
+	if !ok || value.Type() != cty.Bool {
+		return false, false
+	}
+	return value.True(), true
+}
+
+// rawConfigElements returns the elements of list or set attribute 'name' of a raw configuration
+// object. The second return value is false when the attribute is null or unknown, including the
+// case when the number of elements is unknown (e.g. 'dynamic' blocks based on unknown values).
+// Single elements can still contain unknown values.
+func rawConfigElements(object cty.Value, name string) ([]cty.Value, bool) {
+	value, ok := rawConfigAttribute(object, name)
+	if !ok || !value.CanIterateElements() {
+		return nil, false
+	}
+	return value.AsValueSlice(), true
+}
+
+// rawConfigStringSet returns the known string values of set or list attribute 'name' of a raw
+// configuration object. The second return value is false when the attribute or any of its elements
+// are unknown, as the full set of values cannot be evaluated at plan time.
+func rawConfigStringSet(object cty.Value, name string) (map[string]bool, bool) {
+	value, ok := rawConfigAttribute(object, name)
+	if !ok || !value.CanIterateElements() || !value.IsWhollyKnown() {
+		return nil, false
+	}
+	result := make(map[string]bool)
+	for _, element := range value.AsValueSlice() {
+		if !element.IsNull() && element.Type() == cty.String {
+			result[element.AsString()] = true
+		}
+	}
+	return result, true
+}
+
+// validateIpRangeInSubnet checks that the IP range 'startAddress'-'endAddress' is a valid range
+// contained in the subnet defined by 'gateway' and 'prefixLength'
+func validateIpRangeInSubnet(gateway string, prefixLength int, startAddress, endAddress string) error {
+	gatewayIp, err := netip.ParseAddr(gateway)
+	if err != nil {
+		return fmt.Errorf("invalid gateway '%s': %s", gateway, err)
+	}
+	subnet, err := gatewayIp.Prefix(prefixLength)
+	if err != nil {
+		return fmt.Errorf("invalid prefix length %d for gateway '%s': %s", prefixLength, gateway, err)
+	}
+	startIp, err := netip.ParseAddr(startAddress)
+	if err != nil {
+		return fmt.Errorf("invalid start address '%s': %s", startAddress, err)
+	}
+	endIp, err := netip.ParseAddr(endAddress)
+	if err != nil {
+		return fmt.Errorf("invalid end address '%s': %s", endAddress, err)
+	}
+	if !subnet.Contains(startIp) || !subnet.Contains(endIp) {
+		return fmt.Errorf("range %s-%s is not within subnet %s", startAddress, endAddress, subnet)
+	}
+	if endIp.Less(startIp) {
+		return fmt.Errorf("start address %s is greater than end address %s", startAddress, endAddress)
+	}
+	return nil
+}
+
+// getOrgAndVdcNamesFromDiff returns the Org and VDC names of a resource diff, falling back to the
+// provider defaults. The last return value is false when any of the names is unknown at plan time
+func getOrgAndVdcNamesFromDiff(d *schema.ResourceDiff, vcdClient *VCDClient) (string, string, bool) {
+	if !d.NewValueKnown("org") || !d.NewValueKnown("vdc") {
+		return "", "", false
+	}
+	orgName := d.Get("org").(string)
+	if orgName == "" {
+		orgName = vcdClient.Org
+	}
+	vdcName := d.Get("vdc").(string)
+	if vdcName == "" {
+		vdcName = vcdClient.Vdc
+	}
+	return orgName, vdcName, orgName != "" && vdcName != ""
+}
@@ -0,0 +1,90 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func Test_validateIpRangeInSubnet(t *testing.T) {
+	tests := []struct {
+		name         string
+		gateway      string
+		prefixLength int
+		start        string
+		end          string
+		wantErr      bool
+	}{
+		{name: "ipv4-valid", gateway: "10.10.10.1", prefixLength: 24, start: "10.10.10.10", end: "10.10.10.20"},
+		{name: "ipv4-single-address", gateway: "10.10.10.1", prefixLength: 24, start: "10.10.10.10", end: "10.10.10.10"},
+		{name: "ipv4-outside", gateway: "10.10.10.1", prefixLength: 24, start: "10.10.11.10", end: "10.10.11.20", wantErr: true},
+		{name: "ipv4-end-outside", gateway: "10.10.10.1", prefixLength: 24, start: "10.10.10.10", end: "10.10.11.20", wantErr: true},
+		{name: "ipv4-reversed", gateway: "10.10.10.1", prefixLength: 24, start: "10.10.10.20", end: "10.10.10.10", wantErr: true},
+		{name: "ipv4-invalid-prefix", gateway: "10.10.10.1", prefixLength: 33, start: "10.10.10.10", end: "10.10.10.20", wantErr: true},
+		{name: "ipv4-invalid-address", gateway: "10.10.10.1", prefixLength: 24, start: "10.10.10.300", end: "10.10.10.20", wantErr: true},
+		{name: "ipv6-valid", gateway: "2002:0:0:1234:abcd:ffff:c0a6:121", prefixLength: 124, start: "2002:0:0:1234:abcd:ffff:c0a6:122", end: "2002:0:0:1234:abcd:ffff:c0a6:123"},
+		{name: "ipv6-outside", gateway: "2002:0:0:1234:abcd:ffff:c0a6:121", prefixLength: 124, start: "2002:0:0:1234:abcd:ffff:c0a6:222", end: "2002:0:0:1234:abcd:ffff:c0a6:223", wantErr: true},
+		{name: "mixed-families", gateway: "10.10.10.1", prefixLength: 24, start: "2002::1", end: "2002::2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIpRangeInSubnet(tt.gateway, tt.prefixLength, tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateIpRangeInSubnet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_rawConfigHelpers(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"name":    cty.StringVal("test"),
+		"empty":   cty.StringVal(""),
+		"unknown": cty.UnknownVal(cty.String),
+		"null":    cty.NullVal(cty.String),
+		"number":  cty.NumberIntVal(1024),
+		"flag":    cty.False,
+		"ids":     cty.SetVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		"partial": cty.SetVal([]cty.Value{cty.StringVal("a"), cty.UnknownVal(cty.String)}),
+		"blocks": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"ip": cty.StringVal("10.0.0.1")}),
+			cty.ObjectVal(map[string]cty.Value{"ip": cty.UnknownVal(cty.String)}),
+		}),
+	})
+
+	if value, ok := rawConfigString(config, "name"); !ok || value != "test" {
+		t.Errorf("expected known string 'test', got '%s' (%t)", value, ok)
+	}
+	for _, name := range []string{"empty", "unknown", "null", "missing", "number"} {
+		if _, ok := rawConfigString(config, name); ok {
+			t.Errorf("expected attribute '%s' to be reported as not set", name)
+		}
+	}
+	if value, ok := rawConfigInt(config, "number"); !ok || value != 1024 {
+		t.Errorf("expected known number 1024, got %d (%t)", value, ok)
+	}
+	if value, ok := rawConfigBool(config, "flag"); !ok || value {
+		t.Errorf("expected known boolean false, got %t (%t)", value, ok)
+	}
+	if ids, ok := rawConfigStringSet(config, "ids"); !ok || len(ids) != 2 || !ids["a"] || !ids["b"] {
+		t.Errorf("expected known set {a, b}, got %v (%t)", ids, ok)
+	}
+	if _, ok := rawConfigStringSet(config, "partial"); ok {
+		t.Errorf("expected set with unknown elements to be reported as not known")
+	}
+	blocks, ok := rawConfigElements(config, "blocks")
+	if !ok || len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d (%t)", len(blocks), ok)
+	}
+	if _, ok := rawConfigString(blocks[1], "ip"); ok {
+		t.Errorf("expected unknown attribute in block to be reported as not set")
+	}
+	if _, ok := rawConfigElements(cty.UnknownVal(config.Type()), "blocks"); ok {
+		t.Errorf("expected elements of unknown object to be reported as not known")
+	}
+	if _, ok := rawConfigString(cty.NilVal, "name"); ok {
+		t.Errorf("expected attribute of empty configuration to be reported as not set")
+	}
+}
@@ -529,6 +529,8 @@ func addMandatoryParams(dataSourceName string, mandatoryFields []string, t *test
 			templateFields = templateFields + `external_network_id = "urn:vcloud:network:74804d82-a58f-4714-be84-75c178751ab0"` + "\n"
 		case "api_filter_id":
 			templateFields = templateFields + `api_filter_id = "urn:vcloud:apiFilter:74804d82-a58f-4714-be84-75c178751ab0"` + "\n"
+		case "vm_id":
+			templateFields = templateFields + `vm_id = "urn:vcloud:vm:74804d82-a58f-4714-be84-75c178751ab0"` + "\n"
 		}
 	}
 
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -188,7 +188,7 @@ func getCatalogFromResource(catalogName string, d *schema.ResourceData, meta int
 	return vcdClient.Client.GetAdminCatalogByHref(catalogRecord.HREF)
 }
 
-func datasourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdCatalogRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var (
 		diags                 diag.Diagnostics
 		vcdClient             = meta.(*VCDClient)
@@ -209,11 +209,14 @@ func datasourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta in
 	adminOrg, orgErr := vcdClient.GetAdminOrgFromResource(d)
 	orgFound := ""
 	if orgErr != nil {
-		util.Logger.Printf("[TRACE] error retrieving org: %s", orgErr)
+		tflog.Trace(ctx, "error retrieving org", map[string]interface{}{"error": orgErr})
 		catalogOrgIsAvailable = false
 		orgFound = "NOT"
 	}
-	util.Logger.Printf("[TRACE] Org '%s' %s found", orgName, orgFound)
+	tflog.Trace(ctx, "Org found", map[string]interface{}{
+		"org_name":  orgName,
+		"org_found": orgFound,
+	})
 
 	identifier := d.Get("name").(string)
 
@@ -253,12 +256,12 @@ func datasourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta in
 	if adminOrg != nil {
 		orgId = adminOrg.AdminOrg.ID
 	}
-	err = setCatalogData(d, vcdClient, orgName, orgId, catalog)
+	err = setCatalogData(ctx, d, vcdClient, orgName, orgId, catalog)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_catalog", catalog)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_catalog", catalog)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -114,6 +114,6 @@ func datasourceVcdCatalogMedia() *schema.Resource {
 	}
 }
 
-func dataSourceVcdMediaRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdMediaRead(d, meta, "datasource")
+func dataSourceVcdMediaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdMediaRead(ctx, d, meta, "datasource")
 }
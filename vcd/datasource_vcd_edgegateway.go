@@ -1,10 +1,15 @@
 package vcd
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func datasourceVcdEdgeGateway() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceVcdEdgeGatewayRead,
+		ReadContext: datasourceVcdEdgeGatewayRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -189,6 +194,6 @@ func datasourceVcdEdgeGateway() *schema.Resource {
 	}
 }
 
-func datasourceVcdEdgeGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdEdgeGatewayRead(d, meta, "datasource")
+func datasourceVcdEdgeGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdEdgeGatewayRead(ctx, d, meta, "datasource"))
 }
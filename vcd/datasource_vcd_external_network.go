@@ -1,12 +1,15 @@
 package vcd
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceVcdExternalNetwork() *schema.Resource {
 	return &schema.Resource{
-		Read:               datasourceVcdExternalNetworkRead,
+		ReadContext:        datasourceVcdExternalNetworkRead,
 		DeprecationMessage: "Please use data source vcd_external_network_v2 instead",
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -103,6 +106,6 @@ func datasourceVcdExternalNetwork() *schema.Resource {
 	}
 }
 
-func datasourceVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdExternalNetworkRead(d, meta, "datasource")
+func datasourceVcdExternalNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdExternalNetworkRead(ctx, d, meta, "datasource"))
 }
@@ -2,8 +2,8 @@ package vcd
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -96,9 +96,9 @@ func datasourceVcdExternalNetworkV2() *schema.Resource {
 	}
 }
 
-func datasourceVcdExternalNetworkV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdExternalNetworkV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] external network V2 data source read initiated")
+	tflog.Trace(ctx, "external network V2 data source read initiated")
 
 	name := d.Get("name").(string)
 
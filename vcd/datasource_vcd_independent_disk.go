@@ -2,8 +2,8 @@ package vcd
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -110,7 +110,7 @@ func datasourceVcIndependentDisk() *schema.Resource {
 	}
 }
 
-func dataSourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func dataSourceVcdIndependentDiskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -130,7 +130,10 @@ func dataSourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData,
 	if identifier != "" {
 		disk, err = vdc.GetDiskById(identifier, true)
 		if govcd.IsNotFound(err) {
-			log.Printf("unable to find disk with ID %s: %s. Removing from state", identifier, err)
+			tflog.Debug(ctx, "unable to find disk. Removing from state", map[string]interface{}{
+				"identifier": identifier,
+				"error":      err,
+			})
 			return nil
 		}
 		if err != nil {
@@ -168,12 +171,12 @@ func dataSourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData,
 		return diag.Errorf("unable to find queried disk with name %s: and href: %s, %s", identifier, disk.Disk.HREF, err)
 	}
 
-	diags := setMainData(d, vcdClient, disk, diskRecord)
+	diags := setMainData(ctx, d, vcdClient, disk, diskRecord)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
 
-	log.Printf("[TRACE] Disk read completed.")
+	tflog.Trace(ctx, "Disk read completed")
 
 	// This must be checked at the end as setMainData can throw Warning diagnostics
 	if len(diags) > 0 {
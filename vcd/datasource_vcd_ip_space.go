@@ -2,8 +2,8 @@ package vcd
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -145,7 +145,7 @@ func datasourceVcdIpSpace() *schema.Resource {
 
 func datasourceVcdIpSpaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space datasource read initiated")
+	tflog.Trace(ctx, "IP Space datasource read initiated")
 
 	orgId := d.Get("org_id").(string)
 	ipSpaceName := d.Get("name").(string)
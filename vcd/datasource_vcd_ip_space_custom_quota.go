@@ -2,8 +2,8 @@ package vcd
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -52,7 +52,7 @@ func datasourceVcdIpSpaceCustomQuota() *schema.Resource {
 }
 
 func datasourceVcdIpSpaceCustomQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space Custom Quota DS read initiated")
+	tflog.Trace(ctx, "IP Space Custom Quota DS read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -2,9 +2,9 @@ package vcd
 
 import (
 	"context"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -74,7 +74,7 @@ func datasourceVcdIpAllocation() *schema.Resource {
 }
 
 func datasourceVcdIpAllocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space IP Allocation DS read initiated")
+	tflog.Trace(ctx, "IP Space IP Allocation DS read initiated")
 
 	vcdClient := meta.(*VCDClient)
 	orgId := d.Get("org_id").(string)
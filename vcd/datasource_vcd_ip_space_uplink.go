@@ -2,8 +2,8 @@ package vcd
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -58,7 +58,7 @@ func datasourceVcdIpSpaceUplink() *schema.Resource {
 
 func datasourceVcdIpSpaceUplinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space Uplink datasource read initiated")
+	tflog.Trace(ctx, "IP Space Uplink datasource read initiated")
 
 	externalNetworkId := d.Get("external_network_id").(string)
 	name := d.Get("name").(string)
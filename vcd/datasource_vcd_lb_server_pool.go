@@ -1,14 +1,15 @@
 package vcd
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceVcdLbServerPool() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceVcdLbServerPoolRead,
+		ReadContext: datasourceVcdLbServerPoolRead,
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -117,19 +118,19 @@ func datasourceVcdLbServerPool() *schema.Resource {
 	}
 }
 
-func datasourceVcdLbServerPoolRead(d *schema.ResourceData, meta interface{}) error {
+func datasourceVcdLbServerPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBPool, err := edgeGateway.GetLbServerPoolByName(d.Get("name").(string))
 	if err != nil {
-		return fmt.Errorf("unable to find load balancer server pool with Name %s: %s",
+		return diag.Errorf("unable to find load balancer server pool with Name %s: %s",
 			d.Get("name").(string), err)
 	}
 
 	d.SetId(readLBPool.ID)
-	return setLBPoolData(d, readLBPool)
+	return diag.FromErr(setLBPoolData(d, readLBPool))
 }
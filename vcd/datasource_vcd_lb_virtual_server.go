@@ -1,14 +1,15 @@
 package vcd
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceVcdLbVirtualServer() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceVcdLbVirtualServerRead,
+		ReadContext: datasourceVcdLbVirtualServerRead,
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -97,19 +98,19 @@ func datasourceVcdLbVirtualServer() *schema.Resource {
 	}
 }
 
-func datasourceVcdLbVirtualServerRead(d *schema.ResourceData, meta interface{}) error {
+func datasourceVcdLbVirtualServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBVirtualServer, err := edgeGateway.GetLbVirtualServerByName(d.Get("name").(string))
 	if err != nil {
-		return fmt.Errorf("unable to find load balancer virtual server with Name %s: %s",
+		return diag.Errorf("unable to find load balancer virtual server with Name %s: %s",
 			d.Get("name").(string), err)
 	}
 
 	d.SetId(readLBVirtualServer.ID)
-	return setlBVirtualServerData(d, readLBVirtualServer)
+	return diag.FromErr(setlBVirtualServerData(d, readLBVirtualServer))
 }
@@ -133,7 +133,7 @@ func datasourceVcdNetworkIsolatedV2() *schema.Resource {
 	}
 }
 
-func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdNetworkIsolatedV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
 
@@ -208,7 +208,7 @@ func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceDat
 
 	// Metadata is not supported when the network is in a VDC Group
 	if !govcd.OwnerIsVdcGroup(network.OpenApiOrgVdcNetwork.OwnerRef.ID) {
-		diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_network_isolated_v2", network)...)
+		diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_network_isolated_v2", network)...)
 		if diags != nil && diags.HasError() {
 			return diags
 		}
@@ -158,7 +158,7 @@ var networkV2IpRangeComputed = &schema.Resource{
 	},
 }
 
-func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdNetworkRoutedV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
 
@@ -265,7 +265,7 @@ func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData,
 
 	// Metadata is not supported when the network is in a VDC Group
 	if !govcd.OwnerIsVdcGroup(network.OpenApiOrgVdcNetwork.OwnerRef.ID) {
-		diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_network_routed_v2", network)...)
+		diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_network_routed_v2", network)...)
 		if diags != nil && diags.HasError() {
 			return diags
 		}
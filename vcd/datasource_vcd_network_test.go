@@ -42,7 +42,7 @@ func getAvailableNetworks() error {
 	if err != nil {
 		return fmt.Errorf("error getting client configuration: %s", err)
 	}
-	err = ProviderAuthenticate(vcdClient, testConfig.Provider.User, testConfig.Provider.Password, testConfig.Provider.Token, testConfig.Provider.SysOrg, testConfig.Provider.ApiToken, testConfig.Provider.ApiTokenFile, testConfig.Provider.ServiceAccountTokenFile)
+	err = ProviderAuthenticate(vcdClient, testConfig.Provider.User, testConfig.Provider.Password, testConfig.Provider.Token, testConfig.Provider.SysOrg, testConfig.Provider.ApiToken, testConfig.Provider.ApiTokenFile, testConfig.Provider.ServiceAccountTokenFile, testConfig.Provider.Jwt, testConfig.Provider.JwtFile)
 	if err != nil {
 		return fmt.Errorf("authentication error: %s", err)
 	}
@@ -3,10 +3,10 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -242,8 +242,8 @@ var nsxtEdgeExternalNetworksDS = &schema.Resource{
 	},
 }
 
-func datasourceVcdNsxtEdgeGatewayRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] NSX-T edge gateway datasource read initiated")
+func datasourceVcdNsxtEdgeGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "NSX-T edge gateway datasource read initiated")
 
 	vcdClient := meta.(*VCDClient)
 	org, err := vcdClient.GetOrgFromResource(d)
@@ -1,12 +1,15 @@
 package vcd
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceVcdNsxvDhcpRelay() *schema.Resource {
 	return &schema.Resource{
-		Read: datasourceVcdNsxvDhcpRelayRead,
+		ReadContext: datasourceVcdNsxvDhcpRelayRead,
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -73,6 +76,6 @@ func datasourceVcdNsxvDhcpRelay() *schema.Resource {
 	}
 }
 
-func datasourceVcdNsxvDhcpRelayRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdNsxvDhcpRelayRead(d, meta, "datasource")
+func datasourceVcdNsxvDhcpRelayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdNsxvDhcpRelayRead(d, meta, "datasource"))
 }
@@ -2,9 +2,10 @@ package vcd
 
 import (
 	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 )
 
 func datasourceVcdOrg() *schema.Resource {
@@ -165,22 +166,22 @@ func datasourceVcdOrg() *schema.Resource {
 	}
 }
 
-func datasourceVcdOrgRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdOrgRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	identifier := d.Get("name").(string)
-	log.Printf("Reading Org with id %s", identifier)
+	tflog.Debug(ctx, "Reading Org", map[string]interface{}{"identifier": identifier})
 	adminOrg, err := vcdClient.VCDClient.GetAdminOrgByNameOrId(identifier)
 
 	if err != nil {
-		log.Printf("Org with ID %s not found. Setting ID to nothing", identifier)
+		tflog.Debug(ctx, "Org with ID not found. Setting ID to nothing", map[string]interface{}{"identifier": identifier})
 		d.SetId("")
 		return diag.Errorf("org %s not found: %s", identifier, err)
 	}
-	log.Printf("Org with id %s found", identifier)
+	tflog.Debug(ctx, "Org with id found", map[string]interface{}{"identifier": identifier})
 	d.SetId(adminOrg.AdminOrg.ID)
 
-	diags := setOrgData(d, vcdClient, adminOrg)
+	diags := setOrgData(ctx, d, vcdClient, adminOrg)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -2,9 +2,10 @@ package vcd
 
 import (
 	"context"
+
 	"github.com/vmware/go-vcloud-director/v3/govcd"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -242,7 +243,7 @@ func datasourceVcdOrgVdc() *schema.Resource {
 	}
 }
 
-func datasourceVcdOrgVdcRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdOrgVdcRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
@@ -253,13 +254,13 @@ func datasourceVcdOrgVdcRead(_ context.Context, d *schema.ResourceData, meta int
 	vdcName := d.Get("name").(string)
 	adminVdc, err := adminOrg.GetAdminVDCByName(vdcName, false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VDC")
+		tflog.Debug(ctx, "Unable to find VDC")
 		return diag.Errorf("unable to find VDC %s", err)
 	}
 
 	d.SetId(adminVdc.AdminVdc.ID)
 
-	diags := setOrgVdcData(d, vcdClient, adminVdc)
+	diags := setOrgVdcData(ctx, d, vcdClient, adminVdc)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
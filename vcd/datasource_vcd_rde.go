@@ -57,9 +57,9 @@ func datasourceVcdRde() *schema.Resource {
 	}
 }
 
-func datasourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdRdeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	rde, err := getRde(d, vcdClient, "datasource")
+	rde, err := getRde(ctx, d, vcdClient, "datasource")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -84,7 +84,7 @@ func datasourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interf
 		dSet(d, "owner_user_id", rde.DefinedEntity.Owner.ID)
 	}
 
-	diags := updateOpenApiMetadataInState(d, vcdClient, "vcd_rde", rde)
+	diags := updateOpenApiMetadataInState(ctx, d, vcdClient, "vcd_rde", rde)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
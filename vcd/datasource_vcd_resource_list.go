@@ -25,6 +25,13 @@ type resourceRef struct {
 	importId     bool
 }
 
+// resourceListGroup contains resources of the same type that share the same ancestors
+type resourceListGroup struct {
+	resourceType string
+	ancestors    []string
+	refs         []resourceRef
+}
+
 type vappNetworkType int
 
 const (
@@ -54,6 +61,8 @@ func datasourceVcdResourceList() *schema.Resource {
 			// * mediaItem (catalog)
 			// * NSX-T edge gateway (when belonging to a VDC group)
 			// * all edge gateway objects (NAT, firewall, lb)
+			// * TM content library item (content library)
+			// * TM IP space and provider gateway (region)
 			// When the parent is org or vdc, they are taken from the regular fields above
 			"parent": {
 				Type:        schema.TypeString,
@@ -88,6 +97,7 @@ func datasourceVcdResourceList() *schema.Resource {
 					"id",        // The list will contain only the entity ID
 					"href",      // The list will contain only the entity HREF
 					"import",    // The list will contain the terraform import command
+					"config",    // The list will contain the HCL configuration of each resource
 					"name_id",   // The list will contain name + ID for each item
 					"hierarchy", // The list will contain parent names + resource name for each item
 				}, true),
@@ -95,7 +105,7 @@ func datasourceVcdResourceList() *schema.Resource {
 			"import_file_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "File where to store the import info - Only used with 'import' list mode ",
+				Description: "File where to store the import info - Only used with 'import' and 'config' list modes",
 			},
 			"name_regex": {
 				Type:         schema.TypeString,
@@ -113,7 +123,7 @@ func datasourceVcdResourceList() *schema.Resource {
 	}
 }
 
-func getSiteAssociationList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func getSiteAssociationList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	siteAssociationList, err := client.VCDClient.Client.QueryAllSiteAssociations(nil, nil)
@@ -133,10 +143,10 @@ func getSiteAssociationList(d *schema.ResourceData, meta interface{}, resType st
 			importId: false,
 		})
 	}
-	return genericResourceList(d, resType, nil, items)
+	return genericResourceList(ctx, d, meta, resType, nil, items)
 }
 
-func getOrgAssociationList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func getOrgAssociationList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	orgAssociationList, err := client.VCDClient.Client.QueryAllOrgAssociations(nil, nil)
@@ -156,10 +166,10 @@ func getOrgAssociationList(d *schema.ResourceData, meta interface{}, resType str
 			importId: false,
 		})
 	}
-	return genericResourceList(d, resType, nil, items)
+	return genericResourceList(ctx, d, meta, resType, nil, items)
 }
 
-func getOrgList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func getOrgList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	orgList, err := client.VCDClient.GetOrgList()
@@ -176,10 +186,10 @@ func getOrgList(d *schema.ResourceData, meta interface{}, resType string) (list
 			importId: false,
 		})
 	}
-	return genericResourceList(d, resType, nil, items)
+	return genericResourceList(ctx, d, meta, resType, nil, items)
 }
 
-func getPvdcList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func getPvdcList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	pvdcList, err := client.QueryProviderVdcs()
@@ -197,10 +207,10 @@ func getPvdcList(d *schema.ResourceData, meta interface{}) (list []string, err e
 			resourceType: "vcd_provider_vdc",
 		})
 	}
-	return genericResourceList(d, "vcd_provider_vdc", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_provider_vdc", nil, items)
 }
 
-func getVdcGroups(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func getVdcGroups(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
@@ -222,10 +232,10 @@ func getVdcGroups(d *schema.ResourceData, meta interface{}) (list []string, err
 			resourceType: "vcd_vdc_group",
 		})
 	}
-	return genericResourceList(d, "vcd_vdc_group", []string{org.AdminOrg.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_vdc_group", []string{org.AdminOrg.Name}, items)
 }
 
-func externalNetworkList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func externalNetworkList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	if !client.VCDClient.Client.IsSysAdmin {
@@ -253,10 +263,10 @@ func externalNetworkList(d *schema.ResourceData, meta interface{}) (list []strin
 			importId: false,
 		})
 	}
-	return genericResourceList(d, "vcd_external_network", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_external_network", nil, items)
 }
 
-func rightsList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func rightsList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
@@ -279,10 +289,10 @@ func rightsList(d *schema.ResourceData, meta interface{}) (list []string, err er
 			importId: false,
 		})
 	}
-	return genericResourceList(d, "vcd_right", []string{org.AdminOrg.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_right", []string{org.AdminOrg.Name}, items)
 }
 
-func rolesList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func rolesList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
@@ -305,11 +315,11 @@ func rolesList(d *schema.ResourceData, meta interface{}) (list []string, err err
 			importId: false,
 		})
 	}
-	return genericResourceList(d, "vcd_role", []string{org.AdminOrg.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_role", []string{org.AdminOrg.Name}, items)
 
 }
 
-func globalRolesList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func globalRolesList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 	globalRoles, err := client.Client.GetAllGlobalRoles(nil)
 	if err != nil {
@@ -326,10 +336,10 @@ func globalRolesList(d *schema.ResourceData, meta interface{}) (list []string, e
 			importId: false,
 		})
 	}
-	return genericResourceList(d, "vcd_global_role", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_global_role", nil, items)
 }
 
-func libraryCertificateList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func libraryCertificateList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	adminOrg, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
@@ -362,10 +372,10 @@ func libraryCertificateList(d *schema.ResourceData, meta interface{}) (list []st
 		})
 
 	}
-	return genericResourceList(d, "vcd_certificate_library", ancestors, items)
+	return genericResourceList(ctx, d, meta, "vcd_certificate_library", ancestors, items)
 }
 
-func rightsBundlesList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func rightsBundlesList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	rightsBundles, err := client.Client.GetAllRightsBundles(nil)
@@ -383,10 +393,10 @@ func rightsBundlesList(d *schema.ResourceData, meta interface{}) (list []string,
 			importId: false,
 		})
 	}
-	return genericResourceList(d, "vcd_rights_bundle", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_rights_bundle", nil, items)
 }
 
-func catalogList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func catalogList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 	org, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
 	if err != nil {
@@ -406,11 +416,11 @@ func catalogList(d *schema.ResourceData, meta interface{}, resType string) (list
 			href: catalog.Catalog.HREF,
 		})
 	}
-	return genericResourceList(d, resType, []string{org.AdminOrg.Name}, items)
+	return genericResourceList(ctx, d, meta, resType, []string{org.AdminOrg.Name}, items)
 }
 
 // catalogItemList finds either catalogItem or mediaItem
-func catalogItemList(d *schema.ResourceData, meta interface{}, wantResource string) (list []string, err error) {
+func catalogItemList(ctx context.Context, d *schema.ResourceData, meta interface{}, wantResource string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, err := client.GetAdminOrg(d.Get("org").(string))
@@ -462,11 +472,11 @@ func catalogItemList(d *schema.ResourceData, meta interface{}, wantResource stri
 			}
 		}
 	}
-	return genericResourceList(d, "vcd_catalog_item", []string{org.AdminOrg.Name, catalogName}, items)
+	return genericResourceList(ctx, d, meta, "vcd_catalog_item", []string{org.AdminOrg.Name, catalogName}, items)
 }
 
 // vappTemplateList finds all vApp Templates
-func vappTemplateList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func vappTemplateList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 	org, err := client.GetOrg(d.Get("org").(string))
 	if err != nil {
@@ -498,10 +508,10 @@ func vappTemplateList(d *schema.ResourceData, meta interface{}) (list []string,
 		})
 	}
 
-	return genericResourceList(d, "vcd_catalog_vapp_template", []string{org.Org.Name, catalogName}, items)
+	return genericResourceList(ctx, d, meta, "vcd_catalog_vapp_template", []string{org.Org.Name, catalogName}, items)
 }
 
-func vdcList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func vdcList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
@@ -518,10 +528,10 @@ func vdcList(d *schema.ResourceData, meta interface{}, resType string) (list []s
 			parent: org.AdminOrg.Name,
 		})
 	}
-	return genericResourceList(d, resType, []string{org.AdminOrg.Name}, items)
+	return genericResourceList(ctx, d, meta, resType, []string{org.AdminOrg.Name}, items)
 }
 
-func orgUserList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func orgUserList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, err := client.GetAdminOrg(firstNonEmpty(d.Get("org").(string), d.Get("parent").(string)))
@@ -538,10 +548,10 @@ func orgUserList(d *schema.ResourceData, meta interface{}) (list []string, err e
 			parent: org.AdminOrg.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_org_user", []string{org.AdminOrg.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_org_user", []string{org.AdminOrg.Name}, items)
 }
 
-func networkList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func networkList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	vdcName, err := getVdcName(client, d)
@@ -594,11 +604,11 @@ func networkList(d *schema.ResourceData, meta interface{}) (list []string, err e
 		})
 	}
 
-	return genericResourceList(d, resourceType, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, resourceType, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
 // orgNetworkListV2 uses OpenAPI endpoint to query Org VDC networks and return their list
-func orgNetworkListV2(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func orgNetworkListV2(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 	vdcName, err := getVdcName(client, d)
 	if err != nil {
@@ -647,7 +657,7 @@ func orgNetworkListV2(d *schema.ResourceData, meta interface{}) (list []string,
 		})
 	}
 
-	return genericResourceList(d, resourceType, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, resourceType, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
 func getVdcName(client *VCDClient, d *schema.ResourceData) (string, error) {
@@ -664,7 +674,7 @@ func getVdcName(client *VCDClient, d *schema.ResourceData) (string, error) {
 	return vdcName, nil
 }
 
-func getEdgeGatewayList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func getEdgeGatewayList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	vdcName, err := getVdcName(client, d)
@@ -694,10 +704,10 @@ func getEdgeGatewayList(d *schema.ResourceData, meta interface{}, resType string
 			parent: vdc.Vdc.Name,
 		})
 	}
-	return genericResourceList(d, resType, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, resType, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func distributedSwitchList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func distributedSwitchList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	vCenterName := d.Get("parent").(string)
@@ -723,10 +733,10 @@ func distributedSwitchList(d *schema.ResourceData, meta interface{}) (list []str
 			id:   dsw.BackingRef.ID,
 		})
 	}
-	return genericResourceList(d, "vcd_distributed_switch", []string{vCenter.VSphereVCenter.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_distributed_switch", []string{vCenter.VSphereVCenter.Name}, items)
 }
 
-func transportZoneList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func transportZoneList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	nsxtManagerName := d.Get("parent").(string)
@@ -762,10 +772,10 @@ func transportZoneList(d *schema.ResourceData, meta interface{}) (list []string,
 			id:   tz.Id,
 		})
 	}
-	return genericResourceList(d, "vcd_nsxt_transport_zone", []string{manager.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_transport_zone", []string{manager.Name}, items)
 }
 
-func importablePortGroupList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func importablePortGroupList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	vCenterName := d.Get("parent").(string)
@@ -791,10 +801,10 @@ func importablePortGroupList(d *schema.ResourceData, meta interface{}) (list []s
 			id:   pg.VcenterImportableDvpg.BackingRef.ID,
 		})
 	}
-	return genericResourceList(d, "vcd_importable_port_group", []string{vCenter.VSphereVCenter.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_importable_port_group", []string{vCenter.VSphereVCenter.Name}, items)
 }
 
-func networkPoolList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func networkPoolList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	networkPools, err := client.QueryNetworkPools()
@@ -811,10 +821,10 @@ func networkPoolList(d *schema.ResourceData, meta interface{}) (list []string, e
 			href: np.HREF,
 		})
 	}
-	return genericResourceList(d, "vcd_network_pool", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_network_pool", nil, items)
 }
 
-func nsxtManagerList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func nsxtManagerList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	managers, err := client.QueryNsxtManagers()
@@ -832,10 +842,10 @@ func nsxtManagerList(d *schema.ResourceData, meta interface{}) (list []string, e
 		})
 
 	}
-	return genericResourceList(d, "vcd_nsxt_manager", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_manager", nil, items)
 }
 
-func vcenterList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func vcenterList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	vcenters, err := client.GetAllVCenters(nil)
@@ -857,10 +867,10 @@ func vcenterList(d *schema.ResourceData, meta interface{}) (list []string, err e
 		})
 
 	}
-	return genericResourceList(d, "vcd_vcenter", nil, items)
+	return genericResourceList(ctx, d, meta, resType, nil, items)
 }
 
-func getNsxtEdgeGatewayList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func getNsxtEdgeGatewayList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	// A NSX-T edge gateway could belong to either a VDC or a VDC group
@@ -888,6 +898,7 @@ func getNsxtEdgeGatewayList(d *schema.ResourceData, meta interface{}) (list []st
 				if err != nil {
 					return nil, fmt.Errorf("neither a VDC or a VDC group found with name '%s'", parentName)
 				}
+				vdcName = parentName
 			} else {
 				return nil, fmt.Errorf("error retrieving VDC group '%s': %s", parentName, err)
 			}
@@ -925,10 +936,10 @@ func getNsxtEdgeGatewayList(d *schema.ResourceData, meta interface{}) (list []st
 			parent: parentName,
 		})
 	}
-	return genericResourceList(d, "vcd_nsxt_edgegateway", ancestors, items)
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_edgegateway", ancestors, items)
 }
 
-func diskList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func diskList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 	vdcName, err := getVdcName(client, d)
 	if err != nil {
@@ -953,10 +964,10 @@ func diskList(d *schema.ResourceData, meta interface{}) (list []string, err erro
 			parent: vdc.Vdc.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_independent_disk", []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_independent_disk", []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func vappList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+func vappList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 	vdcName, err := getVdcName(client, d)
 	if err != nil {
@@ -981,10 +992,10 @@ func vappList(d *schema.ResourceData, meta interface{}, resType string) (list []
 			}
 		}
 	}
-	return genericResourceList(d, resType, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, resType, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func vmList(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (list []string, err error) {
+func vmList(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
@@ -1017,12 +1028,12 @@ func vmList(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (list []s
 		})
 	}
 	if vmType == vappVmType {
-		return genericResourceList(d, "vcd_vapp_vm", []string{org.Org.Name, vdc.Vdc.Name, vappName}, items)
+		return genericResourceList(ctx, d, meta, "vcd_vapp_vm", []string{org.Org.Name, vdc.Vdc.Name, vappName}, items)
 	}
-	return genericResourceList(d, "vcd_vm", []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_vm", []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func vappNetworkList(d *schema.ResourceData, vnt vappNetworkType, meta interface{}) (list []string, err error) {
+func vappNetworkList(ctx context.Context, d *schema.ResourceData, vnt vappNetworkType, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
@@ -1051,6 +1062,10 @@ func vappNetworkList(d *schema.ResourceData, vnt vappNetworkType, meta interface
 		return nil, err
 	}
 
+	resourceType := "vcd_vapp_network"
+	if vnt == vntVappOrgNetwork {
+		resourceType = "vcd_vapp_org_network"
+	}
 	var items []resourceRef
 	for _, net := range networks {
 		items = append(items, resourceRef{
@@ -1058,13 +1073,13 @@ func vappNetworkList(d *schema.ResourceData, vnt vappNetworkType, meta interface
 			id:       extractUuid(net.HREF),
 			href:     net.HREF,
 			parent:   vappName,
-			importId: true,
+			importId: false, // vApp networks are imported by name
 		})
 	}
-	return genericResourceList(d, "vcd_vapp_network", []string{org.Org.Name, vdc.Vdc.Name, vappName}, items)
+	return genericResourceList(ctx, d, meta, resourceType, []string{org.Org.Name, vdc.Vdc.Name, vappName}, items)
 }
 
-func vdcTemplateList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func vdcTemplateList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	parentOrg := d.Get("parent").(string)
@@ -1107,10 +1122,10 @@ func vdcTemplateList(d *schema.ResourceData, meta interface{}) (list []string, e
 		}
 		ancestors = []string{org.Org.Name}
 	}
-	return genericResourceList(d, "vcd_org_vdc_template", ancestors, items)
+	return genericResourceList(ctx, d, meta, "vcd_org_vdc_template", ancestors, items)
 }
 
-func nsxtAlbServiceEngineGroup(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func nsxtAlbServiceEngineGroup(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 	allSegs, err := client.GetAllAlbServiceEngineGroups("", nil)
 	if err != nil {
@@ -1125,10 +1140,10 @@ func nsxtAlbServiceEngineGroup(d *schema.ResourceData, meta interface{}) (list [
 			parent: "System",
 		}
 	}
-	return genericResourceList(d, "vcd_nsxt_alb_service_engine_group", nil, items)
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_alb_service_engine_group", nil, items)
 }
 
-func nsxtAlbServiceEngineGroupAssignment(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func nsxtAlbServiceEngineGroupAssignment(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	client := meta.(*VCDClient)
 	edgeGatewayName := d.Get("parent").(string)
 	if edgeGatewayName == "" {
@@ -1168,15 +1183,28 @@ func nsxtAlbServiceEngineGroupAssignment(d *schema.ResourceData, meta interface{
 		}
 	}
 
-	return genericResourceList(d, "vcd_nsxt_alb_edgegateway_service_engine_group", []string{org.Org.Name, vdc.Vdc.Name, nsxtEdgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_alb_edgegateway_service_engine_group", []string{org.Org.Name, vdc.Vdc.Name, nsxtEdgeGateway.EdgeGateway.Name}, items)
 }
 
-func genericResourceList(d *schema.ResourceData, resType string, ancestors []string, refs []resourceRef) (list []string, err error) {
+// genericResourceList builds the list of the given resources, according to the list mode. When the client is
+// collecting an inventory (see orgInventoryList), the resources are added to the inventory instead
+func genericResourceList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string, ancestors []string, refs []resourceRef) (list []string, err error) {
+	group := resourceListGroup{resourceType: resType, ancestors: ancestors, refs: refs}
+	if client, ok := meta.(*VCDClient); ok && client.inventory != nil {
+		client.inventory.add(group)
+		return nil, nil
+	}
+	return renderResourceList(ctx, d, meta, []resourceListGroup{group})
+}
+
+// renderResourceList builds the list of the resources of several groups, according to the list mode
+func renderResourceList(ctx context.Context, d *schema.ResourceData, meta interface{}, groups []resourceListGroup) (list []string, err error) {
 	listMode := d.Get("list_mode").(string)
 	nameIdSeparator := d.Get("name_id_separator").(string)
 	importFile := d.Get("import_file_name").(string)
 	nameRegex := d.Get("name_regex").(string)
 	var importData strings.Builder
+	var generated []generatedResource
 	importData.WriteString(fmt.Sprintf("# Generated by vcd_resource_list - %s\n", time.Now().Format(time.RFC3339)))
 	var reName *regexp.Regexp
 	if nameRegex != "" {
@@ -1186,77 +1214,93 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 				d.Get("name").(string), nameRegex, err)
 		}
 	}
-	for _, ref := range refs {
-		resourceType := resType
-		if ref.resourceType != "" {
-			resourceType = ref.resourceType
-		}
-		if reName != nil {
-			// If the regular expression doesn't match, the resource is skipped from the list
-			if reName.FindString(ref.name) == "" {
-				continue
+	for _, group := range groups {
+		for _, ref := range group.refs {
+			resourceType := group.resourceType
+			if ref.resourceType != "" {
+				resourceType = ref.resourceType
 			}
-		}
-		switch listMode {
-		case "name":
-			list = append(list, ref.name)
-		case "id":
-			list = append(list, ref.id)
-		case "name_id":
-			list = append(list, ref.name+nameIdSeparator+ref.id)
-		case "hierarchy":
-			// If the parent is already present in the ancestors slice, don't repeat it
-			addParent := true
-			for _, ancestor := range ancestors {
-				if ancestor == ref.parent {
-					addParent = false
+			if reName != nil {
+				// If the regular expression doesn't match, the resource is skipped from the list
+				if reName.FindString(ref.name) == "" {
+					continue
 				}
 			}
-			if ref.parent != "" && addParent {
-				list = append(list, strings.Join(ancestors, nameIdSeparator)+
-					nameIdSeparator+ref.parent+
-					nameIdSeparator+ref.name)
-			} else {
-				list = append(list, strings.Join(ancestors, nameIdSeparator)+nameIdSeparator+ref.name)
-			}
-		case "href":
-			list = append(list, ref.href)
-		case "import":
-			identifier := ref.name
-			if ref.importId {
-				identifier = ref.id
-			}
-			sanitizedName := ref.name
-			illegalHclNameCharsRegex := regexp.MustCompile(`[^a-zA-Z0-9_\-]+`)
-			if illegalHclNameCharsRegex.MatchString(ref.name) {
-				// Names can have special characters in VCD, but must not have in HCL resource names
-				sanitizedName = illegalHclNameCharsRegex.ReplaceAllString(ref.name, "_")
-			}
-			list = append(list, fmt.Sprintf("terraform import %s.%s '%s%s%s'",
-				resourceType,
-				sanitizedName,
-				strings.Join(ancestors, ImportSeparator),
-				ImportSeparator,
-				identifier))
-
-			ancestorsText := ""
-			if len(ancestors) > 0 {
-				ancestorsText = strings.Join(ancestors, ImportSeparator) + ImportSeparator
-			}
-			importData.WriteString(fmt.Sprintf("# Import directive for %s %s%s \n", resourceType, ancestorsText, sanitizedName))
-			importData.WriteString("import {\n")
-			importData.WriteString(fmt.Sprintf("  to = %s.%s-%s\n", resourceType, sanitizedName, idTail(ref.id)))
-			if len(ancestors) > 0 {
-				importData.WriteString(fmt.Sprintf("  id = \"%s%s%s\"\n",
-					strings.Join(ancestors, ImportSeparator), ImportSeparator, identifier))
-			} else {
-				importData.WriteString(fmt.Sprintf("  id = \"%s\"\n", identifier))
+			switch listMode {
+			case "name":
+				list = append(list, ref.name)
+			case "id":
+				list = append(list, ref.id)
+			case "name_id":
+				list = append(list, ref.name+nameIdSeparator+ref.id)
+			case "hierarchy":
+				// If the parent is already present in the ancestors slice, don't repeat it
+				addParent := true
+				for _, ancestor := range group.ancestors {
+					if ancestor == ref.parent {
+						addParent = false
+					}
+				}
+				if ref.parent != "" && addParent {
+					list = append(list, strings.Join(group.ancestors, nameIdSeparator)+
+						nameIdSeparator+ref.parent+
+						nameIdSeparator+ref.name)
+				} else {
+					list = append(list, strings.Join(group.ancestors, nameIdSeparator)+nameIdSeparator+ref.name)
+				}
+			case "href":
+				list = append(list, ref.href)
+			case "import", "config":
+				identifier := ref.name
+				if ref.importId {
+					identifier = ref.id
+				}
+				sanitizedName := ref.name
+				illegalHclNameCharsRegex := regexp.MustCompile(`[^a-zA-Z0-9_\-]+`)
+				if illegalHclNameCharsRegex.MatchString(ref.name) {
+					// Names can have special characters in VCD, but must not have in HCL resource names
+					sanitizedName = illegalHclNameCharsRegex.ReplaceAllString(ref.name, "_")
+				}
+				importId := identifier
+				if len(group.ancestors) > 0 {
+					importId = strings.Join(group.ancestors, ImportSeparator) + ImportSeparator + identifier
+				}
+				if listMode == "config" {
+					generated = append(generated, generatedResource{
+						resourceType: resourceType,
+						name:         sanitizedName + "-" + idTail(ref.id),
+						importId:     importId,
+					})
+					continue
+				}
+				list = append(list, fmt.Sprintf("terraform import %s.%s '%s%s%s'",
+					resourceType,
+					sanitizedName,
+					strings.Join(group.ancestors, ImportSeparator),
+					ImportSeparator,
+					identifier))
+
+				ancestorsText := ""
+				if len(group.ancestors) > 0 {
+					ancestorsText = strings.Join(group.ancestors, ImportSeparator) + ImportSeparator
+				}
+				importData.WriteString(fmt.Sprintf("# Import directive for %s %s%s \n", resourceType, ancestorsText, sanitizedName))
+				importData.WriteString("import {\n")
+				importData.WriteString(fmt.Sprintf("  to = %s.%s-%s\n", resourceType, sanitizedName, idTail(ref.id)))
+				importData.WriteString(fmt.Sprintf("  id = \"%s\"\n", importId))
+				importData.WriteString("}\n\n")
 			}
-			importData.WriteString("}\n\n")
 		}
 	}
 
-	if importFile != "" && listMode == "import" {
+	if listMode == "config" {
+		list, err = generateResourceConfig(ctx, meta, generated, &importData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if importFile != "" && (listMode == "import" || listMode == "config") {
 		err = os.WriteFile(importFile, []byte(importData.String()), 0600)
 		if err != nil {
 			return nil, err
@@ -1293,7 +1337,7 @@ func getEdgeGatewayDetails(d *schema.ResourceData, meta interface{}) (orgName st
 	return org.Org.Name, vdc.Vdc.Name, listMode, separator, edgeGateway, nil
 }
 
-func lbServerPoolList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func lbServerPoolList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
 		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", d.Get("parent").(string), err)
@@ -1312,10 +1356,10 @@ func lbServerPoolList(d *schema.ResourceData, meta interface{}) (list []string,
 		})
 	}
 
-	return genericResourceList(d, "vcd_lb_server_pool", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_lb_server_pool", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func lbServiceMonitorList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func lbServiceMonitorList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
 		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", d.Get("parent").(string), err)
@@ -1334,10 +1378,10 @@ func lbServiceMonitorList(d *schema.ResourceData, meta interface{}) (list []stri
 			parent: edgeGateway.EdgeGateway.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_lb_service_monitor", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_lb_service_monitor", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func lbVirtualServerList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func lbVirtualServerList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
@@ -1356,10 +1400,10 @@ func lbVirtualServerList(d *schema.ResourceData, meta interface{}) (list []strin
 			parent: edgeGateway.EdgeGateway.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_lb_virtual_server", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_lb_virtual_server", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func nsxvFirewallList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func nsxvFirewallList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
 		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", d.Get("parent").(string), err)
@@ -1378,10 +1422,10 @@ func nsxvFirewallList(d *schema.ResourceData, meta interface{}) (list []string,
 			parent: edgeGateway.EdgeGateway.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_nsxv_firewall_rule", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_nsxv_firewall_rule", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func lbAppRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func lbAppRuleList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
 		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", d.Get("parent").(string), err)
@@ -1400,10 +1444,10 @@ func lbAppRuleList(d *schema.ResourceData, meta interface{}) (list []string, err
 			parent: edgeGateway.EdgeGateway.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_lb_app_rule", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_lb_app_rule", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func lbAppProfileList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func lbAppProfileList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
 		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", d.Get("parent").(string), err)
@@ -1422,10 +1466,10 @@ func lbAppProfileList(d *schema.ResourceData, meta interface{}) (list []string,
 			parent: edgeGateway.EdgeGateway.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_lb_app_profile", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_lb_app_profile", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
-func ipsetList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func ipsetList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 
 	client := meta.(*VCDClient)
 
@@ -1450,10 +1494,10 @@ func ipsetList(d *schema.ResourceData, meta interface{}) (list []string, err err
 			parent: vdc.Vdc.Name,
 		})
 	}
-	return genericResourceList(d, "vcd_ipset", []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_ipset", []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func nsxvNatRuleList(ctx context.Context, natType string, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, _, _, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
 		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", d.Get("parent").(string), err)
@@ -1475,7 +1519,493 @@ func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (
 			})
 		}
 	}
-	return genericResourceList(d, "vcd_lb_app_profile", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList(ctx, d, meta, "vcd_lb_app_profile", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+// getNsxtEdgeGatewayForList retrieves the NSX-T edge gateway named in "parent", which belongs to the VDC or VDC group
+// named in "vdc" (or to the VDC of the provider). It returns the ancestors used to import the edge gateway children:
+// Org name, VDC or VDC group name, edge gateway name
+func getNsxtEdgeGatewayForList(d *schema.ResourceData, meta interface{}) ([]string, *govcd.NsxtEdgeGateway, error) {
+	client := meta.(*VCDClient)
+	edgeGatewayName := d.Get("parent").(string)
+	if edgeGatewayName == "" {
+		return nil, nil, fmt.Errorf(`NSX-T edge gateway name (as "parent") is required for this task`)
+	}
+	// "parent" is the edge gateway, so the VDC or VDC group can only be given in "vdc"
+	vdcOrVdcGroupName := d.Get("vdc").(string)
+	if vdcOrVdcGroupName == "" {
+		vdcOrVdcGroupName = client.Vdc
+	}
+	if vdcOrVdcGroupName == "" {
+		return nil, nil, fmt.Errorf("VDC or VDC group name not given as 'vdc' field")
+	}
+	orgName, err := client.GetOrgNameFromResource(d)
+	if err != nil {
+		return nil, nil, err
+	}
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(client, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !vdcOrVdcGroup.IsNsxt() {
+		return nil, nil, fmt.Errorf("VDC or VDC group '%s' is not NSX-T", vdcOrVdcGroupName)
+	}
+	edgeGateway, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeGatewayName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving NSX-T edge gateway '%s': %s", edgeGatewayName, err)
+	}
+	return []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, edgeGateway, nil
+}
+
+// nsxtEdgeGatewaySingletonList lists the resources that exist once for each NSX-T edge gateway, such as the firewall,
+// which are identified by the edge gateway itself
+func nsxtEdgeGatewaySingletonList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	items := []resourceRef{
+		{
+			name:   edgeGateway.EdgeGateway.Name,
+			id:     edgeGateway.EdgeGateway.ID,
+			href:   "",
+			parent: ancestors[1],
+		},
+	}
+	return genericResourceList(ctx, d, meta, resType, ancestors[:2], items)
+}
+
+func nsxtNatRuleList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	rules, err := edgeGateway.GetAllNatRules(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T NAT rules: %s", err)
+	}
+	var items []resourceRef
+	for _, rule := range rules {
+		// NAT rule names are not unique, so they are imported by ID
+		items = append(items, resourceRef{
+			name:     rule.NsxtNatRule.Name,
+			id:       rule.NsxtNatRule.ID,
+			href:     "",
+			parent:   edgeGateway.EdgeGateway.Name,
+			importId: true,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_nat_rule", ancestors, items)
+}
+
+func nsxtFirewallGroupList(ctx context.Context, d *schema.ResourceData, meta interface{}, resType, firewallGroupType string) (list []string, err error) {
+	client := meta.(*VCDClient)
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+
+	// Firewall groups of edge gateways in a VDC group belong to the VDC group
+	var firewallGroups []*govcd.NsxtFirewallGroup
+	ownerId := edgeGateway.EdgeGateway.OwnerRef.ID
+	if govcd.OwnerIsVdcGroup(ownerId) {
+		org, err := client.GetOrgFromResource(d)
+		if err != nil {
+			return list, err
+		}
+		queryParams := url.Values{}
+		queryParams.Add("filter", fmt.Sprintf("_context==%s", ownerId))
+		firewallGroups, err = org.GetAllNsxtFirewallGroups(queryParams, firewallGroupType)
+		if err != nil {
+			return list, fmt.Errorf("error retrieving %s list: %s", resType, err)
+		}
+	} else {
+		firewallGroups, err = edgeGateway.GetAllNsxtFirewallGroups(nil, firewallGroupType)
+		if err != nil {
+			return list, fmt.Errorf("error retrieving %s list: %s", resType, err)
+		}
+	}
+
+	var items []resourceRef
+	for _, firewallGroup := range firewallGroups {
+		items = append(items, resourceRef{
+			name:   firewallGroup.NsxtFirewallGroup.Name,
+			id:     firewallGroup.NsxtFirewallGroup.ID,
+			href:   "",
+			parent: edgeGateway.EdgeGateway.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, resType, ancestors, items)
+}
+
+func nsxtAppPortProfileList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	// Tenant Application Port Profiles belong to a VDC or VDC group, which is given as "parent" or "vdc"
+	vdcOrVdcGroupName, err := getVdcName(client, d)
+	if err != nil {
+		return list, err
+	}
+	org, err := client.GetOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(client, org.Org.Name, vdcOrVdcGroupName)
+	if err != nil {
+		return list, err
+	}
+	var contextId string
+	switch owner := vdcOrVdcGroup.(type) {
+	case *govcd.Vdc:
+		contextId = owner.Vdc.ID
+	case *govcd.VdcGroup:
+		contextId = owner.VdcGroup.Id
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("filter", fmt.Sprintf("_context==%s", contextId))
+	profiles, err := org.GetAllNsxtAppPortProfiles(queryParams, types.ApplicationPortProfileScopeTenant)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T Application Port Profiles: %s", err)
+	}
+	var items []resourceRef
+	for _, profile := range profiles {
+		items = append(items, resourceRef{
+			name:   profile.NsxtAppPortProfile.Name,
+			id:     profile.NsxtAppPortProfile.ID,
+			href:   "",
+			parent: vdcOrVdcGroupName,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_app_port_profile", []string{org.Org.Name, vdcOrVdcGroupName}, items)
+}
+
+func nsxtIpSecVpnTunnelList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	tunnels, err := edgeGateway.GetAllIpSecVpnTunnels(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T IPsec VPN tunnels: %s", err)
+	}
+	var items []resourceRef
+	for _, tunnel := range tunnels {
+		// IPsec VPN tunnel names are not unique, so they are imported by ID
+		items = append(items, resourceRef{
+			name:     tunnel.NsxtIpSecVpn.Name,
+			id:       tunnel.NsxtIpSecVpn.ID,
+			href:     "",
+			parent:   edgeGateway.EdgeGateway.Name,
+			importId: true,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_ipsec_vpn_tunnel", ancestors, items)
+}
+
+func nsxtAlbPoolList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	pools, err := client.GetAllAlbPoolSummaries(edgeGateway.EdgeGateway.ID, nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T ALB Pools: %s", err)
+	}
+	var items []resourceRef
+	for _, pool := range pools {
+		items = append(items, resourceRef{
+			name:   pool.NsxtAlbPool.Name,
+			id:     pool.NsxtAlbPool.ID,
+			href:   "",
+			parent: edgeGateway.EdgeGateway.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_alb_pool", ancestors, items)
+}
+
+func nsxtAlbVirtualServiceList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	virtualServices, err := client.GetAllAlbVirtualServiceSummaries(edgeGateway.EdgeGateway.ID, nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T ALB Virtual Services: %s", err)
+	}
+	var items []resourceRef
+	for _, virtualService := range virtualServices {
+		items = append(items, resourceRef{
+			name:   virtualService.NsxtAlbVirtualService.Name,
+			id:     virtualService.NsxtAlbVirtualService.ID,
+			href:   "",
+			parent: edgeGateway.EdgeGateway.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_alb_virtual_service", ancestors, items)
+}
+
+func nsxtBgpNeighborList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	neighbors, err := edgeGateway.GetAllBgpNeighbors(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T BGP neighbors: %s", err)
+	}
+	var items []resourceRef
+	for _, neighbor := range neighbors {
+		// BGP neighbors have no name, and are identified by their IP address
+		items = append(items, resourceRef{
+			name:   neighbor.EdgeBgpNeighbor.NeighborAddress,
+			id:     neighbor.EdgeBgpNeighbor.ID,
+			href:   "",
+			parent: edgeGateway.EdgeGateway.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_edgegateway_bgp_neighbor", ancestors, items)
+}
+
+func nsxtBgpIpPrefixList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	prefixLists, err := edgeGateway.GetAllBgpIpPrefixLists(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T BGP IP prefix lists: %s", err)
+	}
+	var items []resourceRef
+	for _, prefixList := range prefixLists {
+		items = append(items, resourceRef{
+			name:   prefixList.EdgeBgpIpPrefixList.Name,
+			id:     prefixList.EdgeBgpIpPrefixList.ID,
+			href:   "",
+			parent: edgeGateway.EdgeGateway.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_edgegateway_bgp_ip_prefix_list", ancestors, items)
+}
+
+func nsxtStaticRouteList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	ancestors, edgeGateway, err := getNsxtEdgeGatewayForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	staticRoutes, err := edgeGateway.GetAllStaticRoutes(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T static routes: %s", err)
+	}
+	var items []resourceRef
+	for _, staticRoute := range staticRoutes {
+		items = append(items, resourceRef{
+			name:   staticRoute.NsxtEdgeGatewayStaticRoute.Name,
+			id:     staticRoute.NsxtEdgeGatewayStaticRoute.ID,
+			href:   "",
+			parent: edgeGateway.EdgeGateway.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_nsxt_edgegateway_static_route", ancestors, items)
+}
+
+// openApiHref builds the HREF of an OpenAPI entity, for the entities that don't expose it
+func openApiHref(client *VCDClient, endpoint, id string) string {
+	return fmt.Sprintf("%s/cloudapi/%s%s", client.Client.VCDHREF.String(), endpoint, id)
+}
+
+func tmOrgList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	orgs, err := client.GetAllTmOrgs(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelTmOrg, err)
+	}
+	var items []resourceRef
+	for _, org := range orgs {
+		items = append(items, resourceRef{
+			name: org.TmOrg.Name,
+			id:   org.TmOrg.ID,
+			href: openApiHref(client, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointOrgs, org.TmOrg.ID),
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_org", nil, items)
+}
+
+func tmRegionList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	regions, err := client.GetAllRegions(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelTmRegion, err)
+	}
+	var items []resourceRef
+	for _, region := range regions {
+		items = append(items, resourceRef{
+			name: region.Region.Name,
+			id:   region.Region.ID,
+			href: openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointRegions, region.Region.ID),
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_region", nil, items)
+}
+
+func tmOrgVdcList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	vdcs, err := client.GetAllTmVdcs(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelTmOrgVdc, err)
+	}
+	var items []resourceRef
+	for _, vdc := range vdcs {
+		parent := ""
+		if vdc.TmVdc.Org != nil {
+			parent = vdc.TmVdc.Org.Name
+		}
+		items = append(items, resourceRef{
+			name:   vdc.TmVdc.Name,
+			id:     vdc.TmVdc.ID,
+			href:   openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointTmVdcs, vdc.TmVdc.ID),
+			parent: parent,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_org_vdc", nil, items)
+}
+
+func tmContentLibraryList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	contentLibraries, err := client.GetAllContentLibraries(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving Content Library list: %s", err)
+	}
+	var items []resourceRef
+	for _, contentLibrary := range contentLibraries {
+		parent := ""
+		if contentLibrary.ContentLibrary.Org != nil {
+			parent = contentLibrary.ContentLibrary.Org.Name
+		}
+		items = append(items, resourceRef{
+			name:   contentLibrary.ContentLibrary.Name,
+			id:     contentLibrary.ContentLibrary.ID,
+			href:   openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointContentLibraries, contentLibrary.ContentLibrary.ID),
+			parent: parent,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_content_library", nil, items)
+}
+
+func tmContentLibraryItemList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	contentLibraryName := d.Get("parent").(string)
+	if contentLibraryName == "" {
+		return list, fmt.Errorf(`no Content Library name (as "parent") given`)
+	}
+	contentLibrary, err := client.GetContentLibraryByName(contentLibraryName)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving Content Library '%s': %s", contentLibraryName, err)
+	}
+	contentLibraryItems, err := contentLibrary.GetAllContentLibraryItems(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelTmContentLibraryItem, err)
+	}
+	var items []resourceRef
+	for _, item := range contentLibraryItems {
+		items = append(items, resourceRef{
+			name:   item.ContentLibraryItem.Name,
+			id:     item.ContentLibraryItem.ID,
+			href:   openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointContentLibraryItems, item.ContentLibraryItem.ID),
+			parent: contentLibraryName,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_content_library_item", []string{contentLibraryName}, items)
+}
+
+// getTmRegionForList retrieves the Region given as "parent", used by the Region children
+func getTmRegionForList(d *schema.ResourceData, meta interface{}) (*govcd.Region, error) {
+	client := meta.(*VCDClient)
+
+	regionName := d.Get("parent").(string)
+	if regionName == "" {
+		return nil, fmt.Errorf(`no %s name (as "parent") given`, labelTmRegion)
+	}
+	region, err := client.GetRegionByName(regionName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s '%s': %s", labelTmRegion, regionName, err)
+	}
+	return region, nil
+}
+
+func tmIpSpaceList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	region, err := getTmRegionForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", "regionRef.id=="+region.Region.ID)
+	ipSpaces, err := client.GetAllTmIpSpaces(queryParams)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelTmIpSpace, err)
+	}
+	var items []resourceRef
+	for _, ipSpace := range ipSpaces {
+		items = append(items, resourceRef{
+			name:   ipSpace.TmIpSpace.Name,
+			id:     ipSpace.TmIpSpace.ID,
+			href:   openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointTmIpSpaces, ipSpace.TmIpSpace.ID),
+			parent: region.Region.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_ip_space", []string{region.Region.Name}, items)
+}
+
+func tmProviderGatewayList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	region, err := getTmRegionForList(d, meta)
+	if err != nil {
+		return list, err
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", "regionRef.id=="+region.Region.ID)
+	providerGateways, err := client.GetAllTmProviderGateways(queryParams)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelTmProviderGateway, err)
+	}
+	var items []resourceRef
+	for _, providerGateway := range providerGateways {
+		items = append(items, resourceRef{
+			name:   providerGateway.TmProviderGateway.Name,
+			id:     providerGateway.TmProviderGateway.ID,
+			href:   openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointTmProviderGateways, providerGateway.TmProviderGateway.ID),
+			parent: region.Region.Name,
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_provider_gateway", []string{region.Region.Name}, items)
+}
+
+func tmNsxtManagerList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	managers, err := client.GetAllNsxtManagersOpenApi(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s list: %s", labelNsxtManager, err)
+	}
+	var items []resourceRef
+	for _, manager := range managers {
+		items = append(items, resourceRef{
+			name: manager.NsxtManagerOpenApi.Name,
+			id:   manager.NsxtManagerOpenApi.ID,
+			href: openApiHref(client, types.OpenApiPathVcf+types.OpenApiEndpointNsxManagers, manager.NsxtManagerOpenApi.ID),
+		})
+	}
+	return genericResourceList(ctx, d, meta, "vcd_tm_nsxt_manager", nil, items)
 }
 
 func getResourcesList() ([]string, error) {
@@ -1489,120 +2019,191 @@ func getResourcesList() ([]string, error) {
 	return list, nil
 }
 
-func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdResourceListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	requested := d.Get("resource_type").(string)
-	var err error
 	var list []string
+	var warnings []error
+	var err error
+	// The Org inventory skips the resource types that can't be listed, reporting them as warnings
+	if isOrgInventoryResourceType(d.Get("resource_type").(string)) {
+		list, warnings, err = orgInventoryList(ctx, d, meta)
+	} else {
+		list, err = getResourceList(ctx, d, meta, d.Get("resource_type").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("list", list)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("name").(string))
+
+	diags := diag.Diagnostics{}
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  warning.Error(),
+		})
+	}
+	return diags
+}
+
+// getResourceList returns the list of the requested resource type
+func getResourceList(ctx context.Context, d *schema.ResourceData, meta interface{}, requested string) (list []string, err error) {
 	switch requested {
 	// Note: do not try to get the data sources list, as it would result in a circular reference
 	case "resource", "resources":
 		list, err = getResourcesList()
+	case "org_inventory", "inventory":
+		list, _, err = orgInventoryList(ctx, d, meta)
 	case "vcd_multisite_site_association":
-		list, err = getSiteAssociationList(d, meta, "vcd_multisite_site_association")
+		list, err = getSiteAssociationList(ctx, d, meta, "vcd_multisite_site_association")
 	case "vcd_multisite_org_association":
-		list, err = getOrgAssociationList(d, meta, "vcd_multisite_org_association")
+		list, err = getOrgAssociationList(ctx, d, meta, "vcd_multisite_org_association")
 	case "vcd_org", "org", "orgs":
-		list, err = getOrgList(d, meta, "vcd_org")
+		list, err = getOrgList(ctx, d, meta, "vcd_org")
 	case "vcd_org_ldap", "vcd_org_saml":
-		list, err = getOrgList(d, meta, requested)
+		list, err = getOrgList(ctx, d, meta, requested)
 	case "vcd_provider_vdc", "provider_vdc":
-		list, err = getPvdcList(d, meta)
+		list, err = getPvdcList(ctx, d, meta)
 	case "vcd_distributed_switch":
-		list, err = distributedSwitchList(d, meta)
+		list, err = distributedSwitchList(ctx, d, meta)
 	case "vcd_nsxt_transport_zone":
-		list, err = transportZoneList(d, meta)
+		list, err = transportZoneList(ctx, d, meta)
 	case "vcd_importable_port_group":
-		list, err = importablePortGroupList(d, meta)
+		list, err = importablePortGroupList(ctx, d, meta)
 	case "vcd_network_pool":
-		list, err = networkPoolList(d, meta)
+		list, err = networkPoolList(ctx, d, meta)
 	case "vcd_vcenter":
-		list, err = vcenterList(d, meta)
+		list, err = vcenterList(ctx, d, meta, "vcd_vcenter")
 	case "vcd_nsxt_manager":
-		list, err = nsxtManagerList(d, meta)
+		list, err = nsxtManagerList(ctx, d, meta)
 	case "vcd_external_network", "external_network", "external_networks":
-		list, err = externalNetworkList(d, meta)
+		list, err = externalNetworkList(ctx, d, meta)
 	case "vcd_org_vdc", "vdc", "vdcs":
-		list, err = vdcList(d, meta, "vcd_org_vdc")
+		list, err = vdcList(ctx, d, meta, "vcd_org_vdc")
 	case "vcd_vdc_group":
-		list, err = getVdcGroups(d, meta)
+		list, err = getVdcGroups(ctx, d, meta)
 	case "vcd_org_vdc_access_control":
-		list, err = vdcList(d, meta, "vcd_org_vdc_access_control")
+		list, err = vdcList(ctx, d, meta, "vcd_org_vdc_access_control")
 	case "vcd_catalog", "catalog", "catalogs", "vcd_subscribed_catalog":
-		list, err = catalogList(d, meta, "vcd_catalog")
+		list, err = catalogList(ctx, d, meta, "vcd_catalog")
 	case "vcd_catalog_access_control":
-		list, err = catalogList(d, meta, "vcd_catalog_access_control")
+		list, err = catalogList(ctx, d, meta, "vcd_catalog_access_control")
 	case "vcd_catalog_item", "catalog_item", "catalog_items", "catalogitem", "catalogitems":
-		list, err = catalogItemList(d, meta, "vcd_catalog_item")
+		list, err = catalogItemList(ctx, d, meta, "vcd_catalog_item")
 	case "vcd_catalog_vapp_template", "vapp_template":
-		list, err = vappTemplateList(d, meta)
+		list, err = vappTemplateList(ctx, d, meta)
 	case "vcd_catalog_media", "catalog_media", "media_items", "mediaitems", "mediaitem":
-		list, err = catalogItemList(d, meta, "vcd_catalog_media")
+		list, err = catalogItemList(ctx, d, meta, "vcd_catalog_media")
 	case "vcd_independent_disk", "disk", "disks":
-		list, err = diskList(d, meta)
+		list, err = diskList(ctx, d, meta)
 	case "vcd_vapp", "vapp", "vapps", "vcd_cloned_vapp":
-		list, err = vappList(d, meta, "vcd_vapp")
+		list, err = vappList(ctx, d, meta, "vcd_vapp")
 	case "vcd_vapp_access_control":
-		list, err = vappList(d, meta, "vcd_vapp_access_control")
+		list, err = vappList(ctx, d, meta, "vcd_vapp_access_control")
 	case "vcd_vapp_vm", "vapp_vm", "vapp_vms":
-		list, err = vmList(d, meta, vappVmType)
+		list, err = vmList(ctx, d, meta, vappVmType)
 	case "vcd_vapp_network", "vapp_network", "vapp_networks":
-		list, err = vappNetworkList(d, vntVappNetwork, meta)
+		list, err = vappNetworkList(ctx, d, vntVappNetwork, meta)
 	case "vcd_vapp_org_network", "vapp_org_network", "vapp_org_networks":
-		list, err = vappNetworkList(d, vntVappOrgNetwork, meta)
+		list, err = vappNetworkList(ctx, d, vntVappOrgNetwork, meta)
 	case "vcd_vapp_all_network", "vapp_all_network", "vapp_all_networks":
-		list, err = vappNetworkList(d, vntVappAllNetworks, meta)
+		list, err = vappNetworkList(ctx, d, vntVappAllNetworks, meta)
 	case "vcd_vm", "standalone_vm":
-		list, err = vmList(d, meta, standaloneVmType)
+		list, err = vmList(ctx, d, meta, standaloneVmType)
 	case "vcd_all_vm", "vm", "vms":
-		list, err = vmList(d, meta, "all")
+		list, err = vmList(ctx, d, meta, "all")
 	case "vcd_org_user", "org_user", "user", "users":
-		list, err = orgUserList(d, meta)
+		list, err = orgUserList(ctx, d, meta)
 	case "vcd_edgegateway", "edge_gateway", "edge", "edgegateway":
-		list, err = getEdgeGatewayList(d, meta, "vcd_edgegateway")
+		list, err = getEdgeGatewayList(ctx, d, meta, "vcd_edgegateway")
 	case "vcd_edgegateway_settings":
-		list, err = getEdgeGatewayList(d, meta, "vcd_edgegateway_settings")
+		list, err = getEdgeGatewayList(ctx, d, meta, "vcd_edgegateway_settings")
 	case "vcd_nsxt_edgegateway", "nsxt_edge_gateway", "nsxt_edge", "nsxt_edgegateway":
-		list, err = getNsxtEdgeGatewayList(d, meta)
+		list, err = getNsxtEdgeGatewayList(ctx, d, meta)
 	case "vcd_lb_server_pool", "lb_server_pool":
-		list, err = lbServerPoolList(d, meta)
+		list, err = lbServerPoolList(ctx, d, meta)
 	case "vcd_lb_service_monitor", "lb_service_monitor":
-		list, err = lbServiceMonitorList(d, meta)
+		list, err = lbServiceMonitorList(ctx, d, meta)
 	case "vcd_lb_virtual_server", "lb_virtual_server":
-		list, err = lbVirtualServerList(d, meta)
+		list, err = lbVirtualServerList(ctx, d, meta)
 	case "vcd_lb_app_rule", "lb_app_rule":
-		list, err = lbAppRuleList(d, meta)
+		list, err = lbAppRuleList(ctx, d, meta)
 	case "vcd_lb_app_profile", "lb_app_profile":
-		list, err = lbAppProfileList(d, meta)
+		list, err = lbAppProfileList(ctx, d, meta)
 	case "vcd_nsxv_firewall_rule", "nsxv_firewall_rule":
-		list, err = nsxvFirewallList(d, meta)
+		list, err = nsxvFirewallList(ctx, d, meta)
 	case "vcd_ipset", "ipset":
-		list, err = ipsetList(d, meta)
+		list, err = ipsetList(ctx, d, meta)
 	case "vcd_nsxv_dnat", "nsxv_dnat":
-		list, err = nsxvNatRuleList("dnat", d, meta)
+		list, err = nsxvNatRuleList(ctx, "dnat", d, meta)
 	case "vcd_nsxv_snat", "nsxv_snat":
-		list, err = nsxvNatRuleList("snat", d, meta)
+		list, err = nsxvNatRuleList(ctx, "snat", d, meta)
 	case "vcd_network_isolated", "vcd_network_direct", "vcd_network_routed",
 		"network", "networks", "network_direct", "network_routed", "network_isolated":
-		list, err = networkList(d, meta)
+		list, err = networkList(ctx, d, meta)
 	case "vcd_network_routed_v2", "vcd_network_isolated_v2", "vcd_nsxt_network_imported":
-		list, err = orgNetworkListV2(d, meta)
+		list, err = orgNetworkListV2(ctx, d, meta)
 	case "vcd_right", "rights":
-		list, err = rightsList(d, meta)
+		list, err = rightsList(ctx, d, meta)
 	case "vcd_rights_bundle", "rights_bundle":
-		list, err = rightsBundlesList(d, meta)
+		list, err = rightsBundlesList(ctx, d, meta)
 	case "vcd_role", "roles":
-		list, err = rolesList(d, meta)
+		list, err = rolesList(ctx, d, meta)
 	case "vcd_global_role", "global_roles":
-		list, err = globalRolesList(d, meta)
+		list, err = globalRolesList(ctx, d, meta)
 	case "vcd_library_certificate":
-		list, err = libraryCertificateList(d, meta)
+		list, err = libraryCertificateList(ctx, d, meta)
 	case "vcd_org_vdc_template":
-		list, err = vdcTemplateList(d, meta)
+		list, err = vdcTemplateList(ctx, d, meta)
 	case "vcd_nsxt_alb_service_engine_group":
-		list, err = nsxtAlbServiceEngineGroup(d, meta)
+		list, err = nsxtAlbServiceEngineGroup(ctx, d, meta)
 	case "vcd_nsxt_alb_edgegateway_service_engine_group":
-		list, err = nsxtAlbServiceEngineGroupAssignment(d, meta)
+		list, err = nsxtAlbServiceEngineGroupAssignment(ctx, d, meta)
+	case "vcd_nsxt_nat_rule", "nsxt_nat_rule":
+		list, err = nsxtNatRuleList(ctx, d, meta)
+	case "vcd_nsxt_firewall", "nsxt_firewall":
+		list, err = nsxtEdgeGatewaySingletonList(ctx, d, meta, "vcd_nsxt_firewall")
+	case "vcd_nsxt_ip_set", "nsxt_ip_set":
+		list, err = nsxtFirewallGroupList(ctx, d, meta, "vcd_nsxt_ip_set", types.FirewallGroupTypeIpSet)
+	case "vcd_nsxt_security_group", "nsxt_security_group":
+		list, err = nsxtFirewallGroupList(ctx, d, meta, "vcd_nsxt_security_group", types.FirewallGroupTypeSecurityGroup)
+	case "vcd_nsxt_app_port_profile", "nsxt_app_port_profile":
+		list, err = nsxtAppPortProfileList(ctx, d, meta)
+	case "vcd_nsxt_ipsec_vpn_tunnel", "nsxt_ipsec_vpn_tunnel":
+		list, err = nsxtIpSecVpnTunnelList(ctx, d, meta)
+	case "vcd_nsxt_alb_pool", "nsxt_alb_pool":
+		list, err = nsxtAlbPoolList(ctx, d, meta)
+	case "vcd_nsxt_alb_virtual_service", "nsxt_alb_virtual_service":
+		list, err = nsxtAlbVirtualServiceList(ctx, d, meta)
+	case "vcd_nsxt_edgegateway_bgp_configuration":
+		list, err = nsxtEdgeGatewaySingletonList(ctx, d, meta, "vcd_nsxt_edgegateway_bgp_configuration")
+	case "vcd_nsxt_edgegateway_bgp_neighbor":
+		list, err = nsxtBgpNeighborList(ctx, d, meta)
+	case "vcd_nsxt_edgegateway_bgp_ip_prefix_list":
+		list, err = nsxtBgpIpPrefixList(ctx, d, meta)
+	case "vcd_nsxt_edgegateway_static_route":
+		list, err = nsxtStaticRouteList(ctx, d, meta)
+	case "vcd_tm_org", "tm_org":
+		list, err = tmOrgList(ctx, d, meta)
+	case "vcd_tm_region", "tm_region":
+		list, err = tmRegionList(ctx, d, meta)
+	case "vcd_tm_org_vdc", "tm_org_vdc":
+		list, err = tmOrgVdcList(ctx, d, meta)
+	case "vcd_tm_content_library", "tm_content_library":
+		list, err = tmContentLibraryList(ctx, d, meta)
+	case "vcd_tm_content_library_item", "tm_content_library_item":
+		list, err = tmContentLibraryItemList(ctx, d, meta)
+	case "vcd_tm_ip_space", "tm_ip_space":
+		list, err = tmIpSpaceList(ctx, d, meta)
+	case "vcd_tm_provider_gateway", "tm_provider_gateway":
+		list, err = tmProviderGatewayList(ctx, d, meta)
+	case "vcd_tm_vcenter", "tm_vcenter":
+		list, err = vcenterList(ctx, d, meta, "vcd_tm_vcenter")
+	case "vcd_tm_nsxt_manager", "tm_nsxt_manager":
+		list, err = tmNsxtManagerList(ctx, d, meta)
 
 		//// place holder to remind of what needs to be implemented
 		//	case "edgegateway_vpn",
@@ -1610,17 +2211,7 @@ func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, me
 		//		"inserted_media":
 		//		list, err = []string{"not implemented yet"}, nil
 	default:
-		return diag.FromErr(fmt.Errorf("unhandled resource type '%s'", requested))
+		return nil, fmt.Errorf("unhandled resource type '%s'", requested)
 	}
-
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	err = d.Set("list", list)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	d.SetId(d.Get("name").(string))
-
-	return diag.Diagnostics{}
+	return list, err
 }
@@ -0,0 +1,251 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// The "org_inventory" resource type of vcd_resource_list discovers all the resources of an Org, walking down from the
+// Org to its VDCs and VDC Groups, and from there to vApps, VMs, networks, edge gateways and their children.
+//
+// Each level is listed with the same functions used for single resource types: the walker calls them with a
+// client that collects the resources in an inventory, instead of building the list (see genericResourceList).
+// When the walk is complete, all the resources are rendered together according to the list mode, so that a
+// single import file, or a single generated configuration, contains the whole Org.
+
+// resourceInventory collects the resources found by the list functions
+type resourceInventory struct {
+	groups []resourceListGroup
+}
+
+func (inv *resourceInventory) add(group resourceListGroup) {
+	inv.groups = append(inv.groups, group)
+}
+
+// names returns the names of all the collected resources
+func (inv *resourceInventory) names() []string {
+	var names []string
+	for _, group := range inv.groups {
+		for _, ref := range group.refs {
+			names = append(names, ref.name)
+		}
+	}
+	return names
+}
+
+// Children of the edge gateways, listed with the edge gateway as parent
+var (
+	nsxvEdgeGatewayInventoryTypes = []string{
+		"vcd_nsxv_firewall_rule",
+		"vcd_nsxv_dnat",
+		"vcd_nsxv_snat",
+		"vcd_lb_service_monitor",
+		"vcd_lb_server_pool",
+		"vcd_lb_app_profile",
+		"vcd_lb_app_rule",
+		"vcd_lb_virtual_server",
+	}
+	nsxtEdgeGatewayInventoryTypes = []string{
+		"vcd_nsxt_ip_set",
+		"vcd_nsxt_security_group",
+		"vcd_nsxt_nat_rule",
+		"vcd_nsxt_firewall",
+		"vcd_nsxt_ipsec_vpn_tunnel",
+		"vcd_nsxt_alb_pool",
+		"vcd_nsxt_alb_virtual_service",
+		"vcd_nsxt_edgegateway_static_route",
+	}
+)
+
+// orgInventoryWalker discovers the resources of an Org
+type orgInventoryWalker struct {
+	client    *VCDClient
+	orgName   string
+	inventory *resourceInventory
+	// warnings contains the errors of the resource types that could not be listed, e.g. because of missing rights
+	// or of a type that is not available in the VCD. The walk continues with the other types
+	warnings []error
+}
+
+// warn records an error that doesn't stop the walk
+func (w *orgInventoryWalker) warn(ctx context.Context, err error) {
+	tflog.Warn(ctx, "[org inventory] skipping resources", map[string]interface{}{"org": w.orgName, "error": err})
+	w.warnings = append(w.warnings, err)
+}
+
+// list collects the resources of the given type, returning their names. The VDC and the parent have the same
+// meaning they have in vcd_resource_list. A listing error is recorded as a warning
+func (w *orgInventoryWalker) list(ctx context.Context, resourceType, vdcName, parent string) []string {
+	collector := *w.client
+	collector.inventory = &resourceInventory{}
+
+	d := datasourceVcdResourceList().Data(nil)
+	dSet(d, "name", "org_inventory")
+	dSet(d, "resource_type", resourceType)
+	dSet(d, "org", w.orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "parent", parent)
+	_, err := getResourceList(ctx, d, &collector, resourceType)
+	if err != nil {
+		location := w.orgName
+		if parent != "" {
+			location += "/" + parent
+		} else if vdcName != "" {
+			location += "/" + vdcName
+		}
+		w.warn(ctx, fmt.Errorf("error listing %s in '%s': %s", resourceType, location, err))
+		return nil
+	}
+	for _, group := range collector.inventory.groups {
+		w.inventory.add(group)
+	}
+	return collector.inventory.names()
+}
+
+// walk discovers all the resources of the Org. Only a failure to retrieve the Org stops the walk
+func (w *orgInventoryWalker) walk(ctx context.Context) error {
+	adminOrg, err := w.client.GetAdminOrgByName(w.orgName)
+	if err != nil {
+		return fmt.Errorf("error retrieving Org '%s': %s", w.orgName, err)
+	}
+	w.inventory.add(resourceListGroup{
+		resourceType: "vcd_org",
+		refs: []resourceRef{{
+			name: adminOrg.AdminOrg.Name,
+			id:   adminOrg.AdminOrg.ID,
+			href: adminOrg.AdminOrg.HREF,
+		}},
+	})
+
+	w.list(ctx, "vcd_org_user", "", "")
+	for _, catalog := range w.list(ctx, "vcd_catalog", "", "") {
+		for _, resourceType := range []string{"vcd_catalog_vapp_template", "vcd_catalog_media"} {
+			w.list(ctx, resourceType, "", catalog)
+		}
+	}
+	for _, vdcName := range w.list(ctx, "vcd_org_vdc", "", "") {
+		w.walkVdc(ctx, adminOrg, vdcName)
+	}
+	for _, vdcGroupName := range w.list(ctx, "vcd_vdc_group", "", "") {
+		w.walkVdcGroup(ctx, adminOrg, vdcGroupName)
+	}
+	return nil
+}
+
+// walkVdc discovers the resources of a VDC
+func (w *orgInventoryWalker) walkVdc(ctx context.Context, adminOrg *govcd.AdminOrg, vdcName string) {
+	vdc, err := adminOrg.GetVDCByName(vdcName, false)
+	if err != nil {
+		w.warn(ctx, fmt.Errorf("error retrieving VDC '%s': %s", vdcName, err))
+		return
+	}
+
+	for _, vappName := range w.list(ctx, "vcd_vapp", vdcName, "") {
+		for _, resourceType := range []string{"vcd_vapp_vm", "vcd_vapp_network", "vcd_vapp_org_network"} {
+			w.list(ctx, resourceType, vdcName, vappName)
+		}
+	}
+	for _, resourceType := range []string{"vcd_vm", "vcd_independent_disk"} {
+		w.list(ctx, resourceType, vdcName, "")
+	}
+
+	if !vdc.IsNsxt() {
+		for _, resourceType := range []string{"vcd_network_routed", "vcd_network_isolated", "vcd_network_direct"} {
+			w.list(ctx, resourceType, vdcName, "")
+		}
+		for _, edgeGatewayName := range w.list(ctx, "vcd_edgegateway", vdcName, "") {
+			for _, resourceType := range nsxvEdgeGatewayInventoryTypes {
+				w.list(ctx, resourceType, vdcName, edgeGatewayName)
+			}
+		}
+		return
+	}
+
+	for _, resourceType := range []string{"vcd_network_routed_v2", "vcd_network_isolated_v2", "vcd_nsxt_network_imported"} {
+		w.list(ctx, resourceType, vdcName, "")
+	}
+	w.list(ctx, "vcd_nsxt_app_port_profile", "", vdcName)
+	w.walkNsxtEdgeGateways(ctx, vdcName)
+}
+
+// walkVdcGroup discovers the resources of a VDC Group
+func (w *orgInventoryWalker) walkVdcGroup(ctx context.Context, adminOrg *govcd.AdminOrg, vdcGroupName string) {
+	vdcGroup, err := adminOrg.GetVdcGroupByName(vdcGroupName)
+	if err != nil {
+		w.warn(ctx, fmt.Errorf("error retrieving VDC Group '%s': %s", vdcGroupName, err))
+		return
+	}
+	if !vdcGroup.IsNsxt() {
+		return
+	}
+
+	// The network list functions only handle VDCs, so the networks of VDC Groups are retrieved here
+	networks, err := vdcGroup.GetAllOpenApiOrgVdcNetworks(nil)
+	if err != nil {
+		w.warn(ctx, fmt.Errorf("error retrieving networks of VDC Group '%s': %s", vdcGroupName, err))
+	}
+	group := resourceListGroup{ancestors: []string{adminOrg.AdminOrg.Name, vdcGroupName}}
+	for _, network := range networks {
+		var resourceType string
+		switch network.OpenApiOrgVdcNetwork.NetworkType {
+		case types.OrgVdcNetworkTypeRouted:
+			resourceType = "vcd_network_routed_v2"
+		case types.OrgVdcNetworkTypeIsolated:
+			resourceType = "vcd_network_isolated_v2"
+		case types.OrgVdcNetworkTypeOpaque: // Used for Imported
+			resourceType = "vcd_nsxt_network_imported"
+		default:
+			continue
+		}
+		group.refs = append(group.refs, resourceRef{
+			name:         network.OpenApiOrgVdcNetwork.Name,
+			id:           network.OpenApiOrgVdcNetwork.ID,
+			parent:       vdcGroupName,
+			resourceType: resourceType,
+		})
+	}
+	w.inventory.add(group)
+
+	w.list(ctx, "vcd_nsxt_app_port_profile", "", vdcGroupName)
+	w.walkNsxtEdgeGateways(ctx, vdcGroupName)
+}
+
+// walkNsxtEdgeGateways discovers the NSX-T edge gateways of a VDC or VDC Group, with their children
+func (w *orgInventoryWalker) walkNsxtEdgeGateways(ctx context.Context, vdcOrVdcGroupName string) {
+	for _, edgeGatewayName := range w.list(ctx, "vcd_nsxt_edgegateway", "", vdcOrVdcGroupName) {
+		for _, resourceType := range nsxtEdgeGatewayInventoryTypes {
+			w.list(ctx, resourceType, vdcOrVdcGroupName, edgeGatewayName)
+		}
+	}
+}
+
+// orgInventoryList lists all the resources of an Org, given in `org` or `parent`. The returned warnings contain the
+// resource types that could not be listed
+func orgInventoryList(ctx context.Context, d *schema.ResourceData, meta interface{}) (list []string, warnings []error, err error) {
+	client := meta.(*VCDClient)
+
+	orgName := firstNonEmpty(d.Get("org").(string), d.Get("parent").(string), client.Org)
+	if orgName == "" {
+		return nil, nil, fmt.Errorf("no Org name given either as 'org' or 'parent' field")
+	}
+	walker := &orgInventoryWalker{client: client, orgName: orgName, inventory: &resourceInventory{}}
+	err = walker.walk(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	list, err = renderResourceList(ctx, d, meta, walker.inventory.groups)
+	if err != nil {
+		return nil, nil, err
+	}
+	return list, walker.warnings, nil
+}
+
+// isOrgInventoryResourceType returns true for the resource types of vcd_resource_list that list the whole Org
+func isOrgInventoryResourceType(resourceType string) bool {
+	return resourceType == "org_inventory" || resourceType == "inventory"
+}
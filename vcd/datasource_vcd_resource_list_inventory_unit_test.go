@@ -0,0 +1,77 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+func Test_resourceInventory(t *testing.T) {
+	ctx := context.Background()
+	client := &VCDClient{VCDClient: &govcd.VCDClient{}, inventory: &resourceInventory{}}
+	d := datasourceVcdResourceList().Data(nil)
+
+	list, err := genericResourceList(ctx, d, client, "vcd_org_vdc", []string{"my-org"},
+		[]resourceRef{{name: "vdc1", id: "urn:vcloud:vdc:1", parent: "my-org"}})
+	if err != nil || list != nil {
+		t.Fatalf("expected the resources to be collected, got %v and error %v", list, err)
+	}
+	_, _ = genericResourceList(ctx, d, client, "vcd_vapp", []string{"my-org", "vdc1"},
+		[]resourceRef{{name: "vapp1", id: "urn:vcloud:vapp:1", parent: "vdc1"}, {name: "vapp 2", id: "urn:vcloud:vapp:2", parent: "vdc1"}})
+	if names := strings.Join(client.inventory.names(), ","); names != "vdc1,vapp1,vapp 2" {
+		t.Fatalf("unexpected inventory: %s", names)
+	}
+
+	dSet(d, "list_mode", "hierarchy")
+	dSet(d, "name_id_separator", "/")
+	list, err = renderResourceList(ctx, d, client, client.inventory.groups)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := strings.Join(list, ","); got != "my-org/vdc1,my-org/vdc1/vapp1,my-org/vdc1/vapp 2" {
+		t.Errorf("unexpected hierarchy: %s", got)
+	}
+
+	importFile := filepath.Join(t.TempDir(), "import.tf")
+	dSet(d, "list_mode", "import")
+	dSet(d, "import_file_name", importFile)
+	_, err = renderResourceList(ctx, d, client, client.inventory.groups)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	content, err := os.ReadFile(importFile)
+	if err != nil {
+		t.Fatalf("error reading import file: %s", err)
+	}
+	for _, expected := range []string{
+		"to = vcd_org_vdc.vdc1-1\n  id = \"my-org.vdc1\"",
+		"to = vcd_vapp.vapp1-1\n  id = \"my-org.vdc1.vapp1\"",
+		"to = vcd_vapp.vapp_2-2\n  id = \"my-org.vdc1.vapp 2\"",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("import file does not contain %q:\n%s", expected, content)
+		}
+	}
+}
+
+func Test_orgInventoryWalkerListWarning(t *testing.T) {
+	client := &VCDClient{VCDClient: &govcd.VCDClient{}}
+	walker := &orgInventoryWalker{client: client, orgName: "my-org", inventory: &resourceInventory{}}
+
+	names := walker.list(context.Background(), "vcd_unknown_type", "vdc1", "")
+	if names != nil {
+		t.Errorf("expected no names for a failing type, got %v", names)
+	}
+	if len(walker.warnings) != 1 || !strings.Contains(walker.warnings[0].Error(), "error listing vcd_unknown_type in 'my-org/vdc1'") {
+		t.Fatalf("expected a warning for the failing type, got %v", walker.warnings)
+	}
+	if len(walker.inventory.groups) != 0 {
+		t.Errorf("expected no resources in the inventory, got %v", walker.inventory.groups)
+	}
+}
@@ -68,6 +68,8 @@ func TestAccVcdDatasourceResourceList(t *testing.T) {
 		// For each resource, we test with and without and explicit parent
 		lists = append(lists, listDef{name: "user-parent", resourceType: "vcd_org_user", parent: testConfig.VCD.Org})
 		lists = append(lists, listDef{name: "role-parent", resourceType: "vcd_role", parent: testConfig.VCD.Org, knownItem: "vApp Author"})
+		// The configuration of each role is generated, reading it with the vcd_role resource
+		lists = append(lists, listDef{name: "role-config", resourceType: "vcd_role", parent: testConfig.VCD.Org, knownItem: "vApp Author", listMode: "config", importFile: true})
 		if testConfig.Networking.ExternalNetwork != "" {
 			lists = append(lists, listDef{name: "extent-parent", resourceType: "vcd_external_network", parent: testConfig.VCD.Org, knownItem: testConfig.Networking.ExternalNetwork})
 		} else {
@@ -84,6 +86,8 @@ func TestAccVcdDatasourceResourceList(t *testing.T) {
 			// entities belonging to a VDC don't require an explicit parent, as it is given from the VDC passed in the provider
 			// For each resource, we test with and without and explicit parent
 			lists = append(lists, listDef{name: "VDC-parent", resourceType: "vcd_org_vdc", parent: testConfig.VCD.Org, knownItem: testConfig.Nsxt.Vdc})
+			// The whole Org is discovered, and the import file contains all its resources
+			lists = append(lists, listDef{name: "org-inventory", resourceType: "org_inventory", parent: testConfig.VCD.Org, knownItem: testConfig.Nsxt.Vdc, listMode: "import", importFile: true})
 		} else {
 			fmt.Print("`Nsxt.Vdc` value isn't configured, datasource test using this will be skipped\n")
 		}
@@ -242,7 +246,37 @@ func TestAccVcdDatasourceResourceList(t *testing.T) {
 				vdc:          testConfig.Nsxt.Vdc,
 				parent:       testConfig.Nsxt.EdgeGateway,
 			})
+			// NSX-T edge gateway children, listed with the edge gateway as parent
+			for _, resourceType := range []string{"vcd_nsxt_nat_rule", "vcd_nsxt_ip_set", "vcd_nsxt_security_group",
+				"vcd_nsxt_ipsec_vpn_tunnel", "vcd_nsxt_alb_pool", "vcd_nsxt_alb_virtual_service",
+				"vcd_nsxt_edgegateway_bgp_neighbor", "vcd_nsxt_edgegateway_bgp_ip_prefix_list",
+				"vcd_nsxt_edgegateway_static_route"} {
+				lists = append(lists, listDef{
+					name:         strings.TrimPrefix(resourceType, "vcd_"),
+					resourceType: resourceType,
+					vdc:          testConfig.Nsxt.Vdc,
+					parent:       testConfig.Nsxt.EdgeGateway,
+				})
+			}
+			// Resources existing once for each edge gateway are listed with the edge gateway name
+			lists = append(lists, listDef{
+				name:         "nsxt_edgegateway_bgp_configuration",
+				resourceType: "vcd_nsxt_edgegateway_bgp_configuration",
+				vdc:          testConfig.Nsxt.Vdc,
+				parent:       testConfig.Nsxt.EdgeGateway,
+				knownItem:    testConfig.Nsxt.EdgeGateway,
+			})
+			lists = append(lists, listDef{
+				name:         "nsxt_firewall",
+				resourceType: "vcd_nsxt_firewall",
+				vdc:          testConfig.Nsxt.Vdc,
+				parent:       testConfig.Nsxt.EdgeGateway,
+				knownItem:    testConfig.Nsxt.EdgeGateway,
+				listMode:     "import",
+				importFile:   true,
+			})
 		}
+		lists = append(lists, listDef{name: "nsxt_app_port_profile", resourceType: "vcd_nsxt_app_port_profile", parent: testConfig.Nsxt.Vdc})
 	} else {
 		fmt.Print("`Nsxt.Vdc` value isn't configured, datasource test using this will be skipped\n")
 	}
@@ -414,6 +448,35 @@ func runResourceInfoTest(def listDef, t *testing.T) {
 	})
 }
 
+func TestAccVcdDatasourceResourceListTm(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+	skipIfNotTm(t)
+
+	var lists = []listDef{
+		{name: "tm_org", resourceType: "vcd_tm_org", knownItem: "*"},
+		{name: "tm_region", resourceType: "vcd_tm_region", knownItem: testConfig.Tm.Region},
+		{name: "tm_org_vdc", resourceType: "vcd_tm_org_vdc", listMode: "hierarchy"},
+		{name: "tm_content_library", resourceType: "vcd_tm_content_library", knownItem: testConfig.Tm.ContentLibrary},
+		{name: "tm_vcenter", resourceType: "vcd_tm_vcenter", listMode: "import", importFile: true, knownItem: "*"},
+		{name: "tm_nsxt_manager", resourceType: "vcd_tm_nsxt_manager", listMode: "import", importFile: true, knownItem: "*"},
+	}
+	if testConfig.Tm.Region != "" {
+		lists = append(lists,
+			listDef{name: "tm_ip_space", resourceType: "vcd_tm_ip_space", parent: testConfig.Tm.Region, listMode: "import", importFile: true},
+			listDef{name: "tm_provider_gateway", resourceType: "vcd_tm_provider_gateway", parent: testConfig.Tm.Region, listMode: "import", importFile: true},
+		)
+	}
+	if testConfig.Tm.ContentLibrary != "" {
+		lists = append(lists, listDef{name: "tm_content_library_item", resourceType: "vcd_tm_content_library_item", parent: testConfig.Tm.ContentLibrary})
+	}
+
+	for _, def := range lists {
+		t.Run(def.name+"-"+def.resourceType, func(t *testing.T) { runResourceInfoTest(def, t) })
+	}
+	postTestChecks(t)
+}
+
 // checkImportFile returns an error if an import filename is expected (importing==true) but was not found.
 func checkImportFile(fileName string, importing bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
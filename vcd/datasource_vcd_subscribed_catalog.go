@@ -2,12 +2,11 @@ package vcd
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 func datasourceVcdSubscribedCatalog() *schema.Resource {
@@ -138,7 +137,7 @@ func datasourceVcdSubscribedCatalog() *schema.Resource {
 }
 
 func datasourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Subscribed Catalog data source read initiated\n")
+	tflog.Trace(ctx, "Subscribed Catalog data source read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -185,18 +184,18 @@ func datasourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceD
 
 	dSet(d, "subscription_url", adminCatalog.AdminCatalog.ExternalCatalogSubscription.Location)
 	dSet(d, "make_local_copy", adminCatalog.AdminCatalog.ExternalCatalogSubscription.LocalCopy)
-	err = setCatalogData(d, vcdClient, adminOrg.AdminOrg.Name, adminOrg.AdminOrg.ID, adminCatalog)
+	err = setCatalogData(ctx, d, vcdClient, adminOrg.AdminOrg.Name, adminOrg.AdminOrg.ID, adminCatalog)
 	if err != nil {
 		return diag.Errorf("%v", err)
 	}
 
-	log.Printf("[TRACE] Catalog sync read initiated")
+	tflog.Trace(ctx, "Catalog sync read initiated")
 
-	taskIdCollection, err := readTaskIdCollection(vcdClient, adminCatalog.AdminCatalog.ID, d)
+	taskIdCollection, err := readTaskIdCollection(ctx, vcdClient, adminCatalog.AdminCatalog.ID, d)
 	if err != nil {
 		return diag.Errorf("error retrieving task list for catalog %s: %s", adminCatalog.AdminCatalog.ID, err)
 	}
-	newTaskIdCollection, err := skimTaskCollection(vcdClient, taskIdCollection)
+	newTaskIdCollection, err := skimTaskCollection(ctx, vcdClient, taskIdCollection)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -210,6 +209,6 @@ func datasourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceD
 	}
 	dSet(d, "href", adminCatalog.AdminCatalog.HREF)
 	d.SetId(adminCatalog.AdminCatalog.ID)
-	util.Logger.Printf("[TRACE] Subscribed Catalog data source read completed: %#v\n", adminCatalog.AdminCatalog)
+	tflog.Trace(ctx, "Subscribed Catalog data source read completed", map[string]interface{}{"catalog_name": adminCatalog.AdminCatalog.Name})
 	return nil
 }
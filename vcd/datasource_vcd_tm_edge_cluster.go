@@ -118,7 +118,7 @@ func setTmEdgeClusterData(_ *VCDClient, d *schema.ResourceData, t *govcd.TmEdgeC
 	return nil
 }
 
-func syncTmEdgeClustersBeforeReadHook(vcdClient *VCDClient, d *schema.ResourceData) error {
+func syncTmEdgeClustersBeforeReadHook(_ context.Context, vcdClient *VCDClient, d *schema.ResourceData) error {
 	if d.Get("sync_before_read").(bool) {
 		err := vcdClient.TmSyncEdgeClusters()
 		if err != nil {
@@ -2,6 +2,7 @@ package vcd
 
 import (
 	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -105,6 +106,6 @@ func datasourceVcdVApp() *schema.Resource {
 	}
 }
 
-func datasourceVcdVAppRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdVAppRead(d, meta, "datasource")
+func datasourceVcdVAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdVAppRead(ctx, d, meta, "datasource")
 }
@@ -144,5 +144,5 @@ func datasourceVcdVappNetwork() *schema.Resource {
 }
 
 func datasourceVappNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVappNetworkRead(d, meta, "datasource")
+	return genericVappNetworkRead(ctx, d, meta, "datasource")
 }
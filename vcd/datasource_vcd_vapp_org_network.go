@@ -2,6 +2,7 @@ package vcd
 
 import (
 	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -45,6 +46,6 @@ func datasourceVcdVappOrgNetwork() *schema.Resource {
 	}
 }
 
-func datasourceVappOrgNetworkRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVappOrgNetworkRead(d, meta, "datasource")
+func datasourceVappOrgNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVappOrgNetworkRead(ctx, d, meta, "datasource")
 }
@@ -322,6 +322,11 @@ func vcdVmDS(vmType typeOfVm) map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Expose hardware-assisted CPU virtualization to guest OS.",
 		},
+		"vtpm": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if the VM has a virtual TPM device",
+		},
 		"guest_properties": {
 			Type:        schema.TypeMap,
 			Computed:    true,
@@ -481,6 +486,16 @@ func vcdVmDS(vmType typeOfVm) map[string]*schema.Schema {
 			Computed:    true,
 			Description: "A map that contains metadata that is automatically added by VCD (10.5.1+) and provides details on the origin of the VM",
 		},
+		"has_snapshot": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "True if the VM has a snapshot",
+		},
+		"snapshot_created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The creation time of the VM snapshot, if any",
+		},
 	}
 }
 
@@ -491,6 +506,6 @@ func datasourceVcdVAppVm() *schema.Resource {
 	}
 }
 
-func datasourceVcdVAppVmRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdVmRead(d, meta, "datasource")
+func datasourceVcdVAppVmRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdVmRead(ctx, d, meta, "datasource")
 }
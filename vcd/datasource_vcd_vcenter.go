@@ -2,9 +2,9 @@ package vcd
 
 import (
 	"context"
-	"log"
 	"net/url"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,7 +51,7 @@ func datasourceVcdVcenter() *schema.Resource {
 	}
 }
 
-func datasourceVcenterRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcenterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	vCenterName := d.Get("name").(string)
@@ -78,18 +78,21 @@ func datasourceVcenterRead(_ context.Context, d *schema.ResourceData, meta inter
 	}
 
 	d.SetId(urn)
-	setVcenterData(d, vcs[0])
+	setVcenterData(ctx, d, vcs[0])
 
 	return nil
 }
 
-func setVcenterData(d *schema.ResourceData, vc *types.QueryResultVirtualCenterRecordType) {
+func setVcenterData(ctx context.Context, d *schema.ResourceData, vc *types.QueryResultVirtualCenterRecordType) {
 	dSet(d, "vcenter_version", vc.VcVersion)
 	// vc.Url is in format `https://XXXX.com/sdk` while UI shows hostname only so we extract it
 	// The error should not be a reason to fail datasource if it is invalid so it is just logged
 	host, err := url.Parse(vc.Url)
 	if err != nil {
-		log.Printf("[DEBUG] [vCenter read] - could not parse vCenter URL '%s': %s", vc.Url, err)
+		tflog.Debug(ctx, "[vCenter read] - could not parse vCenter URL", map[string]interface{}{
+			"url":   vc.Url,
+			"error": err,
+		})
 	}
 	dSet(d, "vcenter_host", host.Host)
 	dSet(d, "status", vc.Status)
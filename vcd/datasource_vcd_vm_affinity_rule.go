@@ -2,6 +2,7 @@ package vcd
 
 import (
 	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -63,6 +64,6 @@ func datasourceVcdVmAffinityRule() *schema.Resource {
 }
 
 // datasourceVcdVmAffinityRuleRead reads a data source VM affinity rule
-func datasourceVcdVmAffinityRuleRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdVmAffinityRuleRead(d, meta, "datasource")
+func datasourceVcdVmAffinityRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdVmAffinityRuleRead(ctx, d, meta, "datasource")
 }
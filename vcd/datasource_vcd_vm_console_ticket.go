@@ -0,0 +1,124 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// vmConsoleTicketValidity is how long VCD accepts an MKS ticket after it has been acquired. VCD does not return the
+// expiry of the ticket, so 'expires_at' is an estimate based on this value
+const vmConsoleTicketValidity = 30 * time.Second
+
+// mksTicket is the answer of the "acquireMksTicket" action of a VM, which go-vcloud-director does not handle
+type mksTicket struct {
+	XMLName xml.Name `xml:"MksTicket"`
+	Host    string   `xml:"Host"`
+	Vmx     string   `xml:"Vmx"`
+	Ticket  string   `xml:"Ticket"`
+	Port    int      `xml:"Port"`
+}
+
+func datasourceVcdVmConsoleTicket() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVmConsoleTicketRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vm_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the VM (from either 'vcd_vapp_vm' or 'vcd_vm') to acquire the console ticket for. The VM must be powered on",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The host that serves the console of the VM",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The port that serves the console of the VM",
+			},
+			"ticket": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The MKS ticket that grants access to the console of the VM. It is stored in clear text in the state",
+			},
+			"vmx": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the VMX file of the VM",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Estimated time (RFC3339) after which the ticket can no longer be used to open a console",
+			},
+		},
+	}
+}
+
+func datasourceVcdVmConsoleTicketRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vm, err := getVmFromVmId(d, vcdClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ticket, err := acquireVmConsoleTicket(vcdClient, vm)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	expiresAt := time.Now().Add(vmConsoleTicketValidity)
+
+	dSet(d, "host", ticket.Host)
+	dSet(d, "port", ticket.Port)
+	dSet(d, "ticket", ticket.Ticket)
+	dSet(d, "vmx", ticket.Vmx)
+	dSet(d, "expires_at", expiresAt.Format(time.RFC3339))
+	d.SetId(vm.VM.ID)
+
+	return nil
+}
+
+// acquireVmConsoleTicket acquires a WebMKS ticket for the console of a VM. VCD only offers the
+// action for powered on VMs
+func acquireVmConsoleTicket(vcdClient *VCDClient, vm *govcd.VM) (*mksTicket, error) {
+	var acquireLink *types.Link
+	for _, link := range vm.VM.Link {
+		if link.Rel == types.RelScreenAcquireMksTicket {
+			acquireLink = link
+			break
+		}
+	}
+	if acquireLink == nil {
+		return nil, fmt.Errorf("VM %s does not offer a console ticket: it must be powered on", vm.VM.Name)
+	}
+
+	ticket := &mksTicket{}
+	_, err := vcdClient.Client.ExecuteRequest(acquireLink.HREF, http.MethodPost, "",
+		"error acquiring console ticket: %s", nil, ticket)
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring console ticket of VM %s: %s", vm.VM.Name, err)
+	}
+	return ticket, nil
+}
@@ -0,0 +1,84 @@
+//go:build (standaloneVm || vm || ALL || functional) && !skipStandaloneVm
+
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdVmConsoleTicketDS(t *testing.T) {
+	preTestChecks(t)
+	var standaloneVmName = fmt.Sprintf("%s-%d", t.Name(), os.Getpid())
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VmName":      standaloneVmName,
+		"PowerOn":     "false",
+		"Tags":        "standaloneVm vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	binaryTestSkipText := "# skip-binary-test: the console ticket can't be acquired for a powered off VM\n"
+	configTextStep0 := templateFill(binaryTestSkipText+testAccCheckVcdVmConsoleTicket, params)
+
+	params["PowerOn"] = "true"
+	params["FuncName"] = t.Name() + "-step1"
+	configTextStep1 := templateFill(testAccCheckVcdVmConsoleTicket, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configTextStep1)
+	dataSourceName := "data.vcd_vm_console_ticket.ticket"
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdStandaloneVmDestroy(standaloneVmName, "", ""),
+		Steps: []resource.TestStep{
+			{
+				Config:      configTextStep0,
+				ExpectError: regexp.MustCompile("must be powered on"),
+			},
+			{
+				Config: configTextStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "vcd_vm."+standaloneVmName, "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "host"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "ticket"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "vmx"),
+					resource.TestMatchResourceAttr(dataSourceName, "port", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "expires_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccCheckVcdVmConsoleTicket = `
+resource "vcd_vm" "{{.VmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 384
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = {{.PowerOn}}
+}
+
+data "vcd_vm_console_ticket" "ticket" {
+  org   = "{{.Org}}"
+  vdc   = "{{.Vdc}}"
+  vm_id = vcd_vm.{{.VmName}}.id
+}
+`
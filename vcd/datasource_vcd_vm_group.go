@@ -2,9 +2,10 @@ package vcd
 
 import (
 	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"log"
 )
 
 // datasourceVcdVmGroup defines the data source for a VM Group, used to create VM Placement Policies.
@@ -46,7 +47,7 @@ func datasourceVcdVmGroup() *schema.Resource {
 	}
 }
 
-func datasourceVcdVmGroupRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdVmGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	name := d.Get("name").(string)
@@ -54,7 +55,11 @@ func datasourceVcdVmGroupRead(_ context.Context, d *schema.ResourceData, meta in
 
 	vmGroup, err := vcdClient.GetVmGroupByNameAndProviderVdcUrn(name, providerVdcId)
 	if err != nil {
-		log.Printf("[DEBUG] Could not find any VM Group with name %s and pVDC %s: %s", name, providerVdcId, err)
+		tflog.Debug(ctx, "Could not find any VM Group with name and pVDC", map[string]interface{}{
+			"name":            name,
+			"provider_vdc_id": providerVdcId,
+			"error":           err,
+		})
 		return diag.Errorf("could not find any VM Group with name %s and pVDC %s: %s", name, providerVdcId, err)
 	}
 
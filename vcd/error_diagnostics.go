@@ -0,0 +1,274 @@
+package vcd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// VCD reports errors with a major code (the HTTP status), a minor code (e.g. DUPLICATE_NAME) and sometimes a vendor
+// specific code. go-vcloud-director returns them as typed errors, but they are usually wrapped with "%s" along the
+// way, so the codes are also searched in the error text.
+//
+// errorDiagnostics turns such errors into diagnostics where the codes and the failing task are listed in the detail,
+// and the offending argument is highlighted when the error is one of the known cases in vcdErrorAttributeRules
+
+// taskError is returned when a VCD task does not complete successfully. It keeps the task, so that its
+// HREF and error codes can be reported in diagnostics
+type taskError struct {
+	task *types.Task
+}
+
+func (e *taskError) Error() string {
+	return fmt.Sprintf("task '%s' did not complete successfully: %s", e.task.ID, taskErrorMessage(e.task))
+}
+
+// vcdErrorDetails contains the information that VCD returns with an error
+type vcdErrorDetails struct {
+	majorErrorCode          int
+	minorErrorCode          string
+	vendorSpecificErrorCode string
+	taskHref                string
+}
+
+var (
+	// "API Error: 400: message", from types.Error
+	apiErrorCodeRegex = regexp.MustCompile(`API Error: (\d{3}): `)
+	// "[400:DUPLICATE_NAME] - message", from failed tasks
+	taskErrorCodeRegex = regexp.MustCompile(`\[(\d{3}):([A-Z][A-Z0-9_]*)\] - `)
+	// "DUPLICATE_NAME - message", from types.OpenApiError
+	openApiErrorCodeRegex = regexp.MustCompile(`(?:^|: )([A-Z][A-Z0-9]*(?:_[A-Z0-9]+)+) - `)
+	taskHrefRegex         = regexp.MustCompile(`https?://[^\s'"()]+/api/task/[0-9a-fA-F-]+`)
+)
+
+// getVcdErrorDetails extracts the VCD error codes and the task HREF from the given error
+func getVcdErrorDetails(err error) vcdErrorDetails {
+	var details vcdErrorDetails
+
+	var failedTask *taskError
+	var apiError *types.Error
+	var openApiError *types.OpenApiError
+	switch {
+	case errors.As(err, &failedTask):
+		details.taskHref = failedTask.task.HREF
+		if failedTask.task.Error != nil {
+			details.majorErrorCode = failedTask.task.Error.MajorErrorCode
+			details.minorErrorCode = failedTask.task.Error.MinorErrorCode
+			details.vendorSpecificErrorCode = failedTask.task.Error.VendorSpecificErrorCode
+		}
+	case errors.As(err, &apiError):
+		details.majorErrorCode = apiError.MajorErrorCode
+		details.minorErrorCode = apiError.MinorErrorCode
+		details.vendorSpecificErrorCode = apiError.VendorSpecificErrorCode
+	case errors.As(err, &openApiError):
+		details.minorErrorCode = openApiError.MinorErrorCode
+	}
+
+	text := err.Error()
+	if details.majorErrorCode == 0 && details.minorErrorCode == "" {
+		if match := taskErrorCodeRegex.FindStringSubmatch(text); match != nil {
+			details.majorErrorCode, _ = strconv.Atoi(match[1])
+			details.minorErrorCode = match[2]
+		} else if match := apiErrorCodeRegex.FindStringSubmatch(text); match != nil {
+			details.majorErrorCode, _ = strconv.Atoi(match[1])
+		} else if match := openApiErrorCodeRegex.FindStringSubmatch(text); match != nil {
+			details.minorErrorCode = match[1]
+		}
+	}
+	if details.taskHref == "" {
+		details.taskHref = taskHrefRegex.FindString(text)
+	}
+	return details
+}
+
+// vcdErrorAttributeRule maps an error recognized by its minor code or message to the arguments that caused it
+type vcdErrorAttributeRule struct {
+	label          string
+	minorCodes     []string
+	messageRegex   *regexp.Regexp
+	attributeNames func(message string) []string
+	// nestedAttributes are the attributes of list blocks that can cause the error, e.g. the IP of the 'network'
+	// blocks of a VM
+	nestedAttributes []vcdErrorNestedAttribute
+}
+
+// vcdErrorNestedAttribute is an attribute of the elements of a list block
+type vcdErrorNestedAttribute struct {
+	block     string
+	attribute string
+}
+
+// vcdErrorAttributeRules are evaluated in order, and the first matching rule is used. IP errors come before
+// duplicate names, as their messages can also say that something "already exists"
+var vcdErrorAttributeRules = []vcdErrorAttributeRule{
+	{
+		label:        "IP address already allocated",
+		minorCodes:   []string{"IP_ALREADY_ALLOCATED", "IP_ADDRESS_ALREADY_IN_USE", "DUPLICATE_IP_ADDRESS"},
+		messageRegex: regexp.MustCompile(`(?i)\bip(?: address)?\b.*\b(?:already (?:allocated|in use|used|exists)|is in use|not available)\b`),
+		attributeNames: func(string) []string {
+			return []string{"ip", "ip_address", "primary_ip", "external_address", "internal_address"}
+		},
+		nestedAttributes: []vcdErrorNestedAttribute{{block: "network", attribute: "ip"}},
+	},
+	{
+		label:        "duplicate name",
+		minorCodes:   []string{"DUPLICATE_NAME", "DUPLICATE_ENTITY", "ENTITY_ALREADY_EXISTS"},
+		messageRegex: regexp.MustCompile(`(?i)duplicate name|\bname\b.*\balready (?:exists|in use|used)\b`),
+		attributeNames: func(string) []string {
+			return []string{"name"}
+		},
+	},
+	{
+		label:        "quota exceeded",
+		minorCodes:   []string{"QUOTA_EXCEEDED", "VDC_QUOTA_EXCEEDED", "INSUFFICIENT_RESOURCES"},
+		messageRegex: regexp.MustCompile(`(?i)\bquota\b.*\b(?:exceeded|reached)\b|\bexceed(?:s|ed)?\b.*\bquota\b|\bexceeds? the (?:allocated|available|allowed)\b|\binsufficient (?:resources|capacity|storage|memory|cpu)\b|\bmaximum number of\b.*\b(?:exceeded|reached)\b`),
+		attributeNames: func(message string) []string {
+			switch {
+			case quotaStorageRegex.MatchString(message):
+				return []string{"storage_profile", "size_in_mb", "size", "storage_profile_name"}
+			case quotaMemoryRegex.MatchString(message):
+				return []string{"memory", "memory_limit", "memory_allocated"}
+			case quotaCpuRegex.MatchString(message):
+				return []string{"cpus", "cpu_cores", "cpu_limit", "cpu_allocated"}
+			case quotaVmRegex.MatchString(message):
+				return []string{"vm_quota"}
+			}
+			return nil
+		},
+	},
+}
+
+// The quota errors of VCD name the exhausted resource next to the quota, e.g. "exceeds the storage quota",
+// "maximum number of VMs" or "insufficient memory". The resource is only recognized in such sentences, as names
+// and HREFs in the message can contain the same words (e.g. "vm-1234" or "vmware")
+var (
+	quotaStorageRegex = quotaResourceRegex(`storage|disk`)
+	quotaMemoryRegex  = quotaResourceRegex(`memory`)
+	quotaCpuRegex     = quotaResourceRegex(`cpu`)
+	quotaVmRegex      = quotaResourceRegex(`vm|virtual machine`)
+)
+
+// quotaResourceRegex returns a regular expression that matches the quota errors about the given resources
+func quotaResourceRegex(resources string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b(?:` + resources + `)s? (?:quota|limit|allocation|capacity)\b|` +
+		`\b(?:quota|limit) (?:for|of|on) (?:the )?(?:` + resources + `)s?\b|` +
+		`\binsufficient (?:` + resources + `)s?\b|\bmaximum number of (?:` + resources + `)s?\b`)
+}
+
+// matches returns true if the rule applies to the given error
+func (rule vcdErrorAttributeRule) matches(details vcdErrorDetails, message string) bool {
+	for _, code := range rule.minorCodes {
+		if strings.EqualFold(details.minorErrorCode, code) {
+			return true
+		}
+	}
+	return rule.messageRegex.MatchString(message)
+}
+
+// getVcdErrorAttributePath returns the path of the argument that caused the error, or nil if the error is not
+// recognized or the resource has none of the expected arguments. When more arguments are candidates, the one whose
+// value is mentioned in the error message is preferred, including the attributes of list blocks such as network.N.ip
+func getVcdErrorAttributePath(d *schema.ResourceData, details vcdErrorDetails, message string) (cty.Path, string) {
+	if d == nil {
+		return nil, ""
+	}
+	rawConfig := d.GetRawConfig()
+
+	for _, rule := range vcdErrorAttributeRules {
+		if !rule.matches(details, message) {
+			continue
+		}
+		// Terraform doesn't send the configuration when a resource is read or deleted, so the raw configuration is
+		// null and the errors of these operations are recognized without pointing to an argument
+		if rawConfig.IsNull() || !rawConfig.Type().IsObjectType() {
+			return nil, rule.label
+		}
+		configType := rawConfig.Type()
+		var candidates []cty.Path
+		for _, name := range rule.attributeNames(message) {
+			if configType.HasAttribute(name) {
+				candidates = append(candidates, cty.GetAttrPath(name))
+			}
+		}
+		for _, nested := range rule.nestedAttributes {
+			if !configType.HasAttribute(nested.block) {
+				continue
+			}
+			elements, _ := d.Get(nested.block).([]interface{})
+			for index := range elements {
+				candidates = append(candidates, cty.GetAttrPath(nested.block).IndexInt(index).GetAttr(nested.attribute))
+			}
+		}
+		if len(candidates) == 0 {
+			return nil, rule.label
+		}
+		for _, path := range candidates {
+			value, ok := d.Get(attributePathKey(path)).(string)
+			if ok && value != "" && strings.Contains(message, value) {
+				return path, rule.label
+			}
+		}
+		return candidates[0], rule.label
+	}
+	return nil, ""
+}
+
+// attributePathKey returns the key used by schema.ResourceData for the given path, e.g. "network.1.ip"
+func attributePathKey(path cty.Path) string {
+	var parts []string
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			parts = append(parts, s.Name)
+		case cty.IndexStep:
+			index, _ := s.Key.AsBigFloat().Int64()
+			parts = append(parts, strconv.FormatInt(index, 10))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// errorDiagnostics works like diag.Errorf, but when the given error comes from VCD the diagnostic also contains the
+// VCD error codes, the HREF of the failing task and the path of the offending argument, when they are known. The
+// argument is never known on read and delete, as there is no configuration (see getVcdErrorAttributePath).
+// 'd' can be nil when there is no resource data to highlight.
+func errorDiagnostics(d *schema.ResourceData, err error, format string, a ...interface{}) diag.Diagnostics {
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf(format, a...),
+	}
+	if err == nil {
+		return diag.Diagnostics{diagnostic}
+	}
+
+	details := getVcdErrorDetails(err)
+	attributePath, label := getVcdErrorAttributePath(d, details, err.Error())
+	diagnostic.AttributePath = attributePath
+
+	var detailLines []string
+	if label != "" {
+		detailLines = append(detailLines, fmt.Sprintf("VCD reported: %s", label))
+	}
+	if details.majorErrorCode != 0 {
+		detailLines = append(detailLines, fmt.Sprintf("Major error code: %d", details.majorErrorCode))
+	}
+	if details.minorErrorCode != "" {
+		detailLines = append(detailLines, fmt.Sprintf("Minor error code: %s", details.minorErrorCode))
+	}
+	if details.vendorSpecificErrorCode != "" {
+		detailLines = append(detailLines, fmt.Sprintf("Vendor specific error code: %s", details.vendorSpecificErrorCode))
+	}
+	if details.taskHref != "" {
+		detailLines = append(detailLines, fmt.Sprintf("Task: %s", details.taskHref))
+	}
+	diagnostic.Detail = strings.Join(detailLines, "\n")
+
+	return diag.Diagnostics{diagnostic}
+}
@@ -0,0 +1,176 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_getVcdErrorDetails(t *testing.T) {
+	failedTask := &types.Task{
+		ID:     "urn:vcloud:task:8a1a2b3c-0000-4000-8000-000000000001",
+		HREF:   "https://vcd.example.com/api/task/8a1a2b3c-0000-4000-8000-000000000001",
+		Status: "error",
+		Error:  &types.Error{MajorErrorCode: 400, MinorErrorCode: "DUPLICATE_NAME", VendorSpecificErrorCode: "42", Message: "name exists"},
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected vcdErrorDetails
+	}{
+		{name: "task", err: fmt.Errorf("wrapped: %w", &taskError{task: failedTask}),
+			expected: vcdErrorDetails{majorErrorCode: 400, minorErrorCode: "DUPLICATE_NAME", vendorSpecificErrorCode: "42", taskHref: failedTask.HREF}},
+		{name: "api-error", err: &types.Error{MajorErrorCode: 403, MinorErrorCode: "ACCESS_TO_RESOURCE_IS_FORBIDDEN", Message: "forbidden"},
+			expected: vcdErrorDetails{majorErrorCode: 403, minorErrorCode: "ACCESS_TO_RESOURCE_IS_FORBIDDEN"}},
+		{name: "api-error-text", err: fmt.Errorf("error creating VDC: %s", types.Error{MajorErrorCode: 400, Message: "bad"}),
+			expected: vcdErrorDetails{majorErrorCode: 400}},
+		{name: "open-api-error-text", err: fmt.Errorf("error creating NSX-T segment: %s", types.OpenApiError{MinorErrorCode: "BAD_REQUEST", Message: "bad"}),
+			expected: vcdErrorDetails{minorErrorCode: "BAD_REQUEST"}},
+		{name: "task-text", err: fmt.Errorf("task did not complete successfully: Creating VM [500:INTERNAL_SERVER_ERROR] - failure, task https://vcd.example.com/api/task/1234-abcd"),
+			expected: vcdErrorDetails{majorErrorCode: 500, minorErrorCode: "INTERNAL_SERVER_ERROR", taskHref: "https://vcd.example.com/api/task/1234-abcd"}},
+		{name: "not-vcd", err: fmt.Errorf("something went wrong - really"),
+			expected: vcdErrorDetails{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getVcdErrorDetails(tt.err)
+			if got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func Test_errorDiagnostics(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name":            {Type: schema.TypeString, Required: true},
+		"ip":              {Type: schema.TypeString, Optional: true},
+		"primary_ip":      {Type: schema.TypeString, Optional: true},
+		"storage_profile": {Type: schema.TypeString, Optional: true},
+		"vm_quota":        {Type: schema.TypeInt, Optional: true},
+	}
+	d := testResourceDataWithConfig(t, resourceSchema, map[string]interface{}{
+		"name":       "vm1",
+		"ip":         "10.0.0.5",
+		"primary_ip": "10.0.0.10",
+	})
+	vmSchema := map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Required: true},
+		"network": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Optional: true},
+				"ip":   {Type: schema.TypeString, Optional: true},
+			}},
+		},
+	}
+	vmData := testResourceDataWithConfig(t, vmSchema, map[string]interface{}{
+		"name": "vm1",
+		"network": []interface{}{
+			map[string]interface{}{"name": "net1", "ip": "192.168.1.10"},
+			map[string]interface{}{"name": "net2", "ip": "192.168.2.10"},
+		},
+	})
+
+	tests := []struct {
+		name          string
+		d             *schema.ResourceData
+		err           error
+		expectedPath  cty.Path
+		expectedLabel string
+	}{
+		{name: "duplicate-name-code", err: &taskError{task: &types.Task{ID: "t1", HREF: "https://vcd.example.com/api/task/t1",
+			Error: &types.Error{MajorErrorCode: 400, MinorErrorCode: "DUPLICATE_NAME", Message: "The VM name is in use"}}},
+			expectedPath: cty.GetAttrPath("name"), expectedLabel: "duplicate name"},
+		{name: "duplicate-name-message", err: fmt.Errorf("API Error: 400: [ 1234 ] The VDC name \"vdc1\" already exists"),
+			expectedPath: cty.GetAttrPath("name"), expectedLabel: "duplicate name"},
+		{name: "ip-allocated", err: fmt.Errorf("API Error: 400: IP address 10.0.0.10 is already allocated"),
+			expectedPath: cty.GetAttrPath("primary_ip"), expectedLabel: "IP address already allocated"},
+		{name: "quota-storage", err: fmt.Errorf("API Error: 400: The operation would exceed the storage quota of the VDC"),
+			expectedPath: cty.GetAttrPath("storage_profile"), expectedLabel: "quota exceeded"},
+		{name: "quota-unknown-attribute", err: fmt.Errorf("API Error: 400: The operation would exceed the memory quota"),
+			expectedLabel: "quota exceeded"},
+		{name: "quota-vm", err: fmt.Errorf("API Error: 400: The number of VMs exceeds the VM quota of the VDC"),
+			expectedPath: cty.GetAttrPath("vm_quota"), expectedLabel: "quota exceeded"},
+		{name: "quota-vm-maximum", err: fmt.Errorf("API Error: 400: The maximum number of VMs for VDC vdc1 has been reached"),
+			expectedPath: cty.GetAttrPath("vm_quota"), expectedLabel: "quota exceeded"},
+		{name: "quota-vm-in-names", err: fmt.Errorf("API Error: 400: The operation would exceed the allocated resources of vmware-cluster-1 for vm-1234"),
+			expectedLabel: "quota exceeded"},
+		{name: "not-recognized", err: fmt.Errorf("API Error: 500: internal error")},
+		{name: "already-exists-without-name", err: fmt.Errorf("API Error: 400: The disk attachment already exists")},
+		{name: "quota-without-exceeding", err: fmt.Errorf("API Error: 404: quota policy not found")},
+		{name: "vm-network-ip", d: vmData, err: fmt.Errorf("error creating VM from template: task did not complete successfully: " +
+			"[400:BAD_REQUEST] - The IP address 192.168.2.10 is already in use"),
+			expectedPath: cty.GetAttrPath("network").IndexInt(1).GetAttr("ip"), expectedLabel: "IP address already allocated"},
+		{name: "vm-network-ip-code", d: vmData, err: &taskError{task: &types.Task{ID: "t2",
+			Error: &types.Error{MajorErrorCode: 400, MinorErrorCode: "IP_ALREADY_ALLOCATED", Message: "Address conflict"}}},
+			expectedPath: cty.GetAttrPath("network").IndexInt(0).GetAttr("ip"), expectedLabel: "IP address already allocated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceData := d
+			if tt.d != nil {
+				resourceData = tt.d
+			}
+			diags := errorDiagnostics(resourceData, tt.err, "error creating VM: %s", tt.err)
+			if len(diags) != 1 || !diags.HasError() {
+				t.Fatalf("expected one error diagnostic, got %v", diags)
+			}
+			if diags[0].Summary != "error creating VM: "+tt.err.Error() {
+				t.Errorf("unexpected summary: %s", diags[0].Summary)
+			}
+			if !diags[0].AttributePath.Equals(tt.expectedPath) {
+				t.Errorf("expected attribute path %#v, got %#v", tt.expectedPath, diags[0].AttributePath)
+			}
+			if tt.expectedLabel != "" && !strings.Contains(diags[0].Detail, tt.expectedLabel) {
+				t.Errorf("expected '%s' in detail, got: %s", tt.expectedLabel, diags[0].Detail)
+			}
+		})
+	}
+
+	// On delete there is no configuration: the error is still described, without highlighting an argument
+	deleteData, err := schema.InternalMap(resourceSchema).Data(&terraform.InstanceState{ID: "vm1", Attributes: map[string]string{
+		"id": "vm1", "name": "vm1",
+	}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := errorDiagnostics(deleteData, fmt.Errorf("API Error: 400: The VDC name \"vdc1\" already exists"), "error deleting VM")
+	if diags[0].AttributePath != nil || !strings.Contains(diags[0].Detail, "duplicate name") {
+		t.Errorf("expected no attribute path on delete, got %#v with detail %s", diags[0].AttributePath, diags[0].Detail)
+	}
+
+	diags = errorDiagnostics(nil, &taskError{task: &types.Task{ID: "t1", HREF: "https://vcd.example.com/api/task/t1", Status: "aborted"}}, "error")
+	if !strings.Contains(diags[0].Detail, "Task: https://vcd.example.com/api/task/t1") {
+		t.Errorf("expected task HREF in detail, got: %s", diags[0].Detail)
+	}
+}
+
+// testResourceDataWithConfig works like schema.TestResourceDataRaw, but also sets the raw configuration, as Terraform
+// does for create and update
+func testResourceDataWithConfig(t *testing.T, resourceSchema map[string]*schema.Schema, raw map[string]interface{}) *schema.ResourceData {
+	schemaMap := schema.InternalMap(resourceSchema)
+	diff, err := schemaMap.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff.RawConfig, err = schema.JSONMapToStateValue(raw, schemaMap.CoreConfigSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := schemaMap.Data(nil, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
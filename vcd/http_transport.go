@@ -0,0 +1,102 @@
+package vcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// configureHttpTransport applies the TLS and proxy settings of the provider configuration to the
+// HTTP transport created by govcd. All API calls, including OVA/ISO uploads and the SAML ADFS login,
+// use this transport.
+func (c *Config) configureHttpTransport(ctx context.Context, transport *http.Transport) error {
+	if c.CaFile != "" || c.CaPem != "" {
+		rootCAs, err := c.getRootCAs(ctx)
+		if err != nil {
+			return err
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{} // #nosec G402 -- MinVersion is the Go default
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+		if c.InsecureFlag {
+			tflog.Warn(ctx, "'allow_unverified_ssl' is set: the certificate authorities in 'ca_file' and 'ca_pem' are not used to verify VCD certificates")
+		}
+	}
+
+	if c.ProxyUrl != "" || c.NoProxy != "" {
+		proxyFunc, err := c.getProxyFunc()
+		if err != nil {
+			return err
+		}
+		transport.Proxy = proxyFunc
+	}
+	return nil
+}
+
+// getRootCAs returns the system certificate pool, extended with the certificates found in 'ca_file'
+// and 'ca_pem'
+func (c *Config) getRootCAs(ctx context.Context) (*x509.CertPool, error) {
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		tflog.Warn(ctx, "unable to load the system certificate pool, only the provided certificates will be trusted", map[string]interface{}{
+			"error": err,
+		})
+		rootCAs = x509.NewCertPool()
+	}
+
+	if c.CaFile != "" {
+		contents, err := os.ReadFile(filepath.Clean(c.CaFile))
+		if err != nil {
+			return nil, fmt.Errorf("error reading 'ca_file' %s: %s", c.CaFile, err)
+		}
+		if !rootCAs.AppendCertsFromPEM(contents) {
+			return nil, fmt.Errorf("no valid PEM certificates found in 'ca_file' %s", c.CaFile)
+		}
+	}
+	if c.CaPem != "" {
+		if !rootCAs.AppendCertsFromPEM([]byte(c.CaPem)) {
+			return nil, fmt.Errorf("no valid PEM certificates found in 'ca_pem'")
+		}
+	}
+	return rootCAs, nil
+}
+
+// getProxyFunc returns the proxy selection function for the HTTP transport. When 'proxy_url' is not
+// set, the proxies defined by the HTTPS_PROXY and HTTP_PROXY environment variables are used, with the
+// exclusions of 'no_proxy' taking the place of NO_PROXY
+func (c *Config) getProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if c.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(c.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'proxy_url': %s", err)
+		}
+		switch proxyUrl.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid 'proxy_url' %s: the scheme must be one of 'http', 'https' or 'socks5'", proxyUrl.Redacted())
+		}
+		if proxyUrl.Host == "" {
+			return nil, fmt.Errorf("invalid 'proxy_url' %s: missing host", proxyUrl.Redacted())
+		}
+		proxyConfig.HTTPProxy = c.ProxyUrl
+		proxyConfig.HTTPSProxy = c.ProxyUrl
+	}
+	if c.NoProxy != "" {
+		proxyConfig.NoProxy = c.NoProxy
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
@@ -0,0 +1,110 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_configureHttpTransportCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	caPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(caFile, []byte(caPem), 0600)
+	if err != nil {
+		t.Fatalf("error writing CA file: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{name: "ca-pem", config: Config{CaPem: caPem}},
+		{name: "ca-file", config: Config{CaFile: caFile}},
+		{name: "invalid-ca-pem", config: Config{CaPem: "not a certificate"}, wantErr: true},
+		{name: "missing-ca-file", config: Config{CaFile: caFile + ".missing"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &http.Transport{}
+			err := tt.config.configureHttpTransport(context.Background(), transport)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			client := http.Client{Transport: transport}
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("request with custom CA failed: %s", err)
+			}
+			_ = resp.Body.Close()
+		})
+	}
+
+	// Without the custom CA, the certificate is not trusted
+	client := http.Client{Transport: &http.Transport{}}
+	_, err = client.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("expected certificate error without custom CA, got %v", err)
+	}
+}
+
+func Test_getProxyFunc(t *testing.T) {
+	config := Config{ProxyUrl: "http://proxy.example.com:3128", NoProxy: "internal.example.com,10.0.0.0/8"}
+	proxyFunc, err := config.getProxyFunc()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		requestUrl string
+		wantProxy  string
+	}{
+		{requestUrl: "https://vcd.example.com/api", wantProxy: "http://proxy.example.com:3128"},
+		{requestUrl: "http://vcd.example.com/transfer/file.ova", wantProxy: "http://proxy.example.com:3128"},
+		{requestUrl: "https://vcd.internal.example.com/api", wantProxy: ""},
+		{requestUrl: "https://10.1.2.3/api", wantProxy: ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, tt.requestUrl, nil)
+		if err != nil {
+			t.Fatalf("error creating request: %s", err)
+		}
+		proxy, err := proxyFunc(req)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", tt.requestUrl, err)
+		}
+		gotProxy := ""
+		if proxy != nil {
+			gotProxy = proxy.String()
+		}
+		if gotProxy != tt.wantProxy {
+			t.Errorf("%s: expected proxy '%s', got '%s'", tt.requestUrl, tt.wantProxy, gotProxy)
+		}
+	}
+
+	for _, invalidUrl := range []string{"ftp://proxy.example.com", "http://", "://proxy"} {
+		_, err = (&Config{ProxyUrl: invalidUrl}).getProxyFunc()
+		if err == nil {
+			t.Errorf("expected error for proxy URL '%s'", invalidUrl)
+		}
+	}
+}
@@ -0,0 +1,883 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// Besides their import path (e.g. org-name.vdc-name.vapp-name), the importers accept the URN of the entity
+// (e.g. urn:vcloud:vapp:<uuid>) or its bare UUID. The parents of the entity are retrieved from VCD, and the import
+// path is built from their names, so that the importers themselves do not need to know about URNs.
+// Resources whose entities have no URN, such as rules and other children of an edge gateway, accept the URN of
+// their parent as the first element of the import path (e.g. urn:vcloud:gateway:<uuid>.nat-rule-name).
+//
+// The resources are wrapped by withUrnImport, using the entity type and the path function in urnImports. The
+// resolved path is passed to the importer in its context, and read with importIdElements, so that names containing
+// the import separator are not split.
+// Resources that are not in urnImports keep their importer unchanged: they are identified by RDE type
+// (vendor.nss.version), or accept the ID of the entity already (see the "Importing by ID" section of the
+// importing_resources guide).
+
+// urnPathFunc returns the elements of the import path of the entity with the given URN
+type urnPathFunc func(client *VCDClient, urn string) ([]string, error)
+
+// urnImport describes how to import a resource from the URN of its entity, or of the parent of its entity
+type urnImport struct {
+	// entityType is the type of the entity in its URN, e.g. "vm" in urn:vcloud:vm:<uuid>
+	entityType string
+	path       urnPathFunc
+}
+
+// urnImportPath is the import path resolved from a URN, passed to the importers in their context
+type urnImportPath struct {
+	// elements is the path of the entity identified by the URN
+	elements []string
+	// rest is the part of the import ID following the URN, if any
+	rest string
+}
+
+// urnImportPathKey is the context key of the urnImportPath
+type urnImportPathKey struct{}
+
+// urnImportRegex matches the URN of a VCD entity, capturing its type
+var urnImportRegex = regexp.MustCompile(`^urn:vcloud:([A-Za-z]+):[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}$`)
+
+// urnImports contains the resource types that can be imported using a URN or a UUID
+var urnImports = map[string]urnImport{
+	"vcd_org":                                       {"org", orgPath},
+	"vcd_org_ldap":                                  {"org", entityUrn},
+	"vcd_org_oidc":                                  {"org", entityUrn},
+	"vcd_org_saml":                                  {"org", entityUrn},
+	"vcd_service_account":                           {"org", orgPath},
+	"vcd_security_tag":                              {"org", orgPath},
+	"vcd_ip_space_ip_allocation":                    {"org", orgPath},
+	"vcd_org_vdc":                                   {"vdc", vdcPath},
+	"vcd_org_vdc_access_control":                    {"vdc", vdcPath},
+	"vcd_org_vdc_nsxt_network_profile":              {"vdc", vdcPath},
+	"vcd_org_vdc_template_instance":                 {"vdc", vdcPath},
+	"vcd_vm_affinity_rule":                          {"vdc", vdcPath},
+	"vcd_nsxv_ip_set":                               {"vdc", vdcPath},
+	"vcd_nsxv_distributed_firewall":                 {"vdc", vdcPath},
+	"vcd_vdc_group":                                 {"vdcGroup", vdcGroupPath},
+	"vcd_nsxt_distributed_firewall":                 {"vdcGroup", vdcGroupPath},
+	"vcd_nsxt_distributed_firewall_rule":            {"vdcGroup", vdcGroupPath},
+	"vcd_provider_vdc":                              {"providervdc", entityUrn},
+	"vcd_network_pool":                              {"networkpool", entityUrn},
+	"vcd_vm_sizing_policy":                          {"vdcComputePolicy", entityUrn},
+	"vcd_vm_placement_policy":                       {"vdcComputePolicy", entityUrn},
+	"vcd_vm_vgpu_policy":                            {"vdcComputePolicy", entityUrn},
+	"vcd_vapp":                                      {"vapp", vappPath},
+	"vcd_cloned_vapp":                               {"vapp", vappPath},
+	"vcd_vapp_access_control":                       {"vapp", vappPath},
+	"vcd_vapp_network":                              {"vapp", vappPath},
+	"vcd_vapp_org_network":                          {"vapp", vappPath},
+	"vcd_vapp_firewall_rules":                       {"vapp", vappPath},
+	"vcd_vapp_nat_rules":                            {"vapp", vappPath},
+	"vcd_vapp_static_routing":                       {"vapp", vappPath},
+	"vcd_vapp_vm":                                   {"vm", vmPath},
+	"vcd_vm":                                        {"vm", vmPath},
+	"vcd_vm_snapshot":                               {"vm", vmPath},
+	"vcd_vm_internal_disk":                          {"vm", vmInVappPath},
+	"vcd_inserted_media":                            {"vm", vmInVappPath},
+	"vcd_independent_disk":                          {"disk", diskPath},
+	"vcd_network_routed":                            {"network", networkPath},
+	"vcd_network_isolated":                          {"network", networkPath},
+	"vcd_network_direct":                            {"network", networkPath},
+	"vcd_network_routed_v2":                         {"network", networkPath},
+	"vcd_network_isolated_v2":                       {"network", networkPath},
+	"vcd_nsxt_network_imported":                     {"network", networkPath},
+	"vcd_nsxt_network_dhcp":                         {"network", networkPath},
+	"vcd_nsxt_network_dhcp_binding":                 {"network", networkPath},
+	"vcd_nsxt_network_segment_profile":              {"network", networkPath},
+	"vcd_external_network":                          {"network", externalNetworkPath},
+	"vcd_external_network_v2":                       {"network", externalNetworkV2Path},
+	"vcd_ip_space_uplink":                           {"ipSpaceUplink", ipSpaceUplinkPath},
+	"vcd_edgegateway":                               {"gateway", byId(edgeGatewayPath)},
+	"vcd_edgegateway_settings":                      {"gateway", byId(edgeGatewayPath)},
+	"vcd_edgegateway_vpn":                           {"gateway", edgeGatewayPath},
+	"vcd_nsxv_dhcp_relay":                           {"gateway", edgeGatewayPath},
+	"vcd_nsxv_dnat":                                 {"gateway", edgeGatewayPath},
+	"vcd_nsxv_snat":                                 {"gateway", edgeGatewayPath},
+	"vcd_nsxv_firewall_rule":                        {"gateway", edgeGatewayPath},
+	"vcd_lb_app_profile":                            {"gateway", edgeGatewayPath},
+	"vcd_lb_app_rule":                               {"gateway", edgeGatewayPath},
+	"vcd_lb_server_pool":                            {"gateway", edgeGatewayPath},
+	"vcd_lb_service_monitor":                        {"gateway", edgeGatewayPath},
+	"vcd_lb_virtual_server":                         {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway":                          {"gateway", edgeGatewayPath},
+	"vcd_nsxt_firewall":                             {"gateway", edgeGatewayPath},
+	"vcd_nsxt_nat_rule":                             {"gateway", edgeGatewayPath},
+	"vcd_nsxt_ipsec_vpn_tunnel":                     {"gateway", edgeGatewayPath},
+	"vcd_nsxt_route_advertisement":                  {"gateway", edgeGatewayPath},
+	"vcd_nsxt_alb_settings":                         {"gateway", edgeGatewayPath},
+	"vcd_nsxt_alb_edgegateway_service_engine_group": {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_bgp_configuration":        {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_bgp_neighbor":             {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_bgp_ip_prefix_list":       {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_dhcp_forwarding":          {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_dhcpv6":                   {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_dns":                      {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_l2_vpn_tunnel":            {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_rate_limiting":            {"gateway", edgeGatewayPath},
+	"vcd_nsxt_edgegateway_static_route":             {"gateway", edgeGatewayPath},
+	"vcd_nsxt_ip_set":                               {"firewallGroup", firewallGroupPath},
+	"vcd_nsxt_security_group":                       {"firewallGroup", firewallGroupPath},
+	"vcd_nsxt_dynamic_security_group":               {"firewallGroup", firewallGroupPath},
+	"vcd_nsxt_app_port_profile":                     {"applicationPortProfile", appPortProfilePath},
+	"vcd_nsxt_segment_profile_template":             {"segmentProfileTemplate", segmentProfileTemplatePath},
+	"vcd_nsxt_alb_pool":                             {"loadBalancerPool", albPoolPath},
+	"vcd_nsxt_alb_virtual_service":                  {"loadBalancerVirtualService", albVirtualServicePath},
+	"vcd_nsxt_alb_virtual_service_http_req_rules":   {"loadBalancerVirtualService", albVirtualServicePath},
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":  {"loadBalancerVirtualService", albVirtualServicePath},
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":   {"loadBalancerVirtualService", albVirtualServicePath},
+	"vcd_nsxt_alb_controller":                       {"loadBalancerController", albControllerPath},
+	"vcd_nsxt_alb_cloud":                            {"loadBalancerCloud", albCloudPath},
+	"vcd_nsxt_alb_service_engine_group":             {"serviceEngineGroup", albServiceEngineGroupPath},
+	"vcd_catalog":                                   {"catalog", catalogPath},
+	"vcd_subscribed_catalog":                        {"catalog", catalogPath},
+	"vcd_catalog_access_control":                    {"catalog", catalogPath},
+	"vcd_catalog_item":                              {"catalogitem", catalogItemPath},
+	"vcd_catalog_vapp_template":                     {"vapptemplate", vAppTemplatePath},
+	"vcd_catalog_media":                             {"media", mediaPath},
+	"vcd_org_user":                                  {"user", userPath},
+	"vcd_org_group":                                 {"group", groupPath},
+	"vcd_role":                                      {"role", rolePath},
+	"vcd_global_role":                               {"globalRole", globalRolePath},
+	"vcd_rights_bundle":                             {"rightsBundle", rightsBundlePath},
+	"vcd_library_certificate":                       {"certificateLibraryItem", certificatePath},
+	"vcd_api_token":                                 {"token", apiTokenPath},
+	"vcd_api_filter":                                {"apiFilter", entityUrn},
+	"vcd_ui_plugin":                                 {"uiPlugin", uiPluginPath},
+	"vcd_ip_space":                                  {"ipSpace", ipSpacePath},
+	"vcd_ip_space_custom_quota":                     {"ipSpace", ipSpaceNamePath},
+	"vcd_org_vdc_template":                          {"vdctemplate", vdcTemplatePath},
+	"vcd_tm_org":                                    {"org", tmOrgPath},
+	"vcd_tm_org_vdc":                                {"virtualDatacenter", tmOrgVdcPath},
+	"vcd_tm_region":                                 {"region", tmRegionPath},
+	"vcd_tm_edge_cluster_qos":                       {"region", tmRegionPath},
+	"vcd_tm_vcenter":                                {"vimserver", tmVcenterPath},
+	"vcd_tm_nsxt_manager":                           {"nsxtmanager", tmNsxtManagerPath},
+	"vcd_tm_content_library":                        {"contentLibrary", tmContentLibraryPath},
+	"vcd_tm_content_library_item":                   {"contentLibraryItem", tmContentLibraryItemPath},
+	"vcd_tm_ip_space":                               {"ipSpace", tmIpSpacePath},
+	"vcd_tm_provider_gateway":                       {"providerGateway", tmProviderGatewayPath},
+}
+
+// withUrnImport returns a copy of the given resource map, where the importers of the resources in urnImports
+// also accept the URN or the UUID of the entity, or the URN of its parent as first element of the import path
+func withUrnImport(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		ui, found := urnImports[name]
+		if !found || resource.Importer == nil {
+			result[name] = resource
+			continue
+		}
+		wrapped := *resource
+		importer := *resource.Importer
+		if resource.Importer.StateContext != nil {
+			stateContext := resource.Importer.StateContext
+			importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				path, err := ui.resolve(d, meta)
+				if err != nil {
+					return nil, err
+				}
+				if path != nil {
+					ctx = context.WithValue(ctx, urnImportPathKey{}, *path)
+				}
+				return stateContext(ctx, d, meta)
+			}
+		}
+		if resource.Importer.State != nil {
+			state := resource.Importer.State
+			importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				_, err := ui.resolve(d, meta)
+				if err != nil {
+					return nil, err
+				}
+				return state(d, meta)
+			}
+		}
+		wrapped.Importer = &importer
+		result[name] = &wrapped
+	}
+	return result
+}
+
+// resolve retrieves the path of the entity when the import ID is its URN or UUID, or when the import ID starts
+// with a URN followed by the import separator. The import ID is replaced by the path joined with the import
+// separator, which is shown in the messages of the importers. Any other ID is left unchanged, and no path is
+// returned, as it is an import path already
+func (ui urnImport) resolve(d *schema.ResourceData, meta interface{}) (*urnImportPath, error) {
+	id := d.Id()
+	urn, rest, hasRest := strings.Cut(id, ImportSeparator)
+	switch {
+	case !hasRest && govcd.IsUuid(id):
+		urn = fmt.Sprintf("urn:vcloud:%s:%s", ui.entityType, id)
+	case urnImportRegex.MatchString(urn):
+		entityType := urnImportRegex.FindStringSubmatch(urn)[1]
+		if !strings.EqualFold(entityType, ui.entityType) {
+			return nil, fmt.Errorf("[URN import] '%s' is the URN of a '%s', while this resource needs the URN of a '%s'", urn, entityType, ui.entityType)
+		}
+	default:
+		return nil, nil
+	}
+
+	elements, err := ui.path(meta.(*VCDClient), urn)
+	if err != nil {
+		return nil, fmt.Errorf("[URN import] error retrieving the entity with ID '%s': %s", urn, err)
+	}
+	path := &urnImportPath{elements: elements, rest: rest}
+	if hasRest {
+		elements = append(slices.Clone(elements), rest)
+	}
+	d.SetId(strings.Join(elements, ImportSeparator))
+	return path, nil
+}
+
+// importIdElements splits the import ID of a resource like strings.SplitN, where a negative n returns all the
+// elements. When the import ID was resolved from a URN by withUrnImport, the path of the entity is used instead, as
+// its names can contain the import separator, and only the rest of the ID after the URN is split
+func importIdElements(ctx context.Context, d *schema.ResourceData, n int) []string {
+	path, ok := ctx.Value(urnImportPathKey{}).(urnImportPath)
+	if !ok {
+		return strings.SplitN(d.Id(), ImportSeparator, n)
+	}
+	elements := slices.Clone(path.elements)
+	if path.rest == "" {
+		return elements
+	}
+	if n > 0 {
+		n = max(n-len(elements), 1)
+	}
+	return append(elements, strings.SplitN(path.rest, ImportSeparator, n)...)
+}
+
+// entityUrn is the path function of the importers that accept the URN of the entity in place of its name
+func entityUrn(_ *VCDClient, urn string) ([]string, error) {
+	return []string{urn}, nil
+}
+
+// byId returns a path function where the last element is the URN of the entity instead of its name, for the
+// importers that accept an ID in place of the name
+func byId(path urnPathFunc) urnPathFunc {
+	return func(client *VCDClient, urn string) ([]string, error) {
+		elements, err := path(client, urn)
+		if err != nil {
+			return nil, err
+		}
+		return append(elements[:len(elements)-1], urn), nil
+	}
+}
+
+// queryById runs a query of the given type, using the admin type for system administrators, and filtering by ID
+func queryById(client *VCDClient, queryType, adminQueryType, id string) (*types.QueryResultRecordsType, error) {
+	if client.Client.IsSysAdmin {
+		queryType = adminQueryType
+	}
+	results, err := client.Client.QueryWithNotEncodedParams(nil, map[string]string{
+		"type":          queryType,
+		"filter":        "id==" + url.QueryEscape(extractUuid(id)),
+		"filterEncoded": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results.Results, nil
+}
+
+// singleRecord returns the only record of a query, or an error when there are none or many
+func singleRecord[T any](records []*T, id string) (*T, error) {
+	if len(records) == 0 {
+		return nil, govcd.ErrorEntityNotFound
+	}
+	if len(records) > 1 {
+		return nil, fmt.Errorf("found %d entities with ID '%s'", len(records), id)
+	}
+	return records[0], nil
+}
+
+// getOpenApiEntityById retrieves an entity from an OpenAPI endpoint
+func getOpenApiEntityById(client *VCDClient, endpoint, id string, entity interface{}) error {
+	urlRef, err := client.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, endpoint, id)
+	if err != nil {
+		return err
+	}
+	return client.Client.OpenApiGetItem(client.Client.APIVersion, urlRef, nil, entity, nil)
+}
+
+func orgPath(client *VCDClient, urn string) ([]string, error) {
+	org, err := client.GetOrgById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{org.Org.Name}, nil
+}
+
+// vdcPath returns the path of a VDC, given as URN or HREF
+func vdcPath(client *VCDClient, id string) ([]string, error) {
+	results, err := queryById(client, types.QtOrgVdc, types.QtAdminOrgVdc, id)
+	if err != nil {
+		return nil, err
+	}
+	records := results.OrgVdcRecord
+	if client.Client.IsSysAdmin {
+		records = results.OrgVdcAdminRecord
+	}
+	record, err := singleRecord(records, id)
+	if err != nil {
+		return nil, err
+	}
+	return []string{record.OrgName, record.Name}, nil
+}
+
+func vdcGroupPath(client *VCDClient, urn string) ([]string, error) {
+	var vdcGroup types.VdcGroup
+	err := getOpenApiEntityById(client, types.OpenApiEndpointVdcGroups, urn, &vdcGroup)
+	if err != nil {
+		return nil, err
+	}
+	path, err := orgPath(client, vdcGroup.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, vdcGroup.Name), nil
+}
+
+// ownerPath returns the path of the VDC or VDC Group owning an entity
+func ownerPath(client *VCDClient, owner *types.OpenApiReference) ([]string, error) {
+	if owner == nil || owner.ID == "" {
+		return nil, fmt.Errorf("the entity has no owner")
+	}
+	if govcd.OwnerIsVdcGroup(owner.ID) {
+		return vdcGroupPath(client, owner.ID)
+	}
+	return vdcPath(client, owner.ID)
+}
+
+func vappPath(client *VCDClient, urn string) ([]string, error) {
+	results, err := queryById(client, types.QtVapp, types.QtAdminVapp, urn)
+	if err != nil {
+		return nil, err
+	}
+	records := results.VAppRecord
+	if client.Client.IsSysAdmin {
+		records = results.AdminVAppRecord
+	}
+	record, err := singleRecord(records, urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := vdcPath(client, record.VdcHREF)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, record.Name), nil
+}
+
+// vmRecord returns the query record of a VM, identified by its URN, and the path of its VDC
+func vmRecord(client *VCDClient, urn string) (*types.QueryResultVMRecordType, []string, error) {
+	results, err := queryById(client, types.QtVm, types.QtAdminVm, urn)
+	if err != nil {
+		return nil, nil, err
+	}
+	records := results.VMRecord
+	if client.Client.IsSysAdmin {
+		records = results.AdminVMRecord
+	}
+	record, err := singleRecord(records, urn)
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err := vdcPath(client, record.VdcHREF)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, path, nil
+}
+
+// vmPath returns the path of a VM, identified by its URN. Standalone VMs have no vApp in the path
+func vmPath(client *VCDClient, urn string) ([]string, error) {
+	record, path, err := vmRecord(client, urn)
+	if err != nil {
+		return nil, err
+	}
+	if !record.AutoNature {
+		path = append(path, record.ContainerName)
+	}
+	return append(path, urn), nil
+}
+
+// vmInVappPath returns the path of a VM including its vApp, which is the hidden vApp for standalone VMs, for the
+// importers of the VM children that always have the vApp in their path
+func vmInVappPath(client *VCDClient, urn string) ([]string, error) {
+	record, path, err := vmRecord(client, urn)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, record.ContainerName, record.Name), nil
+}
+
+// diskPath returns the path of an independent disk, which is imported by ID
+func diskPath(client *VCDClient, urn string) ([]string, error) {
+	results, err := queryById(client, "disk", "adminDisk", urn)
+	if err != nil {
+		return nil, err
+	}
+	records := results.DiskRecord
+	if client.Client.IsSysAdmin {
+		records = results.AdminDiskRecord
+	}
+	record, err := singleRecord(records, urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := vdcPath(client, record.Vdc)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, urn), nil
+}
+
+// catalogPath returns the path of a catalog, given as URN or HREF
+func catalogPath(client *VCDClient, id string) ([]string, error) {
+	results, err := queryById(client, types.QtCatalog, types.QtAdminCatalog, id)
+	if err != nil {
+		return nil, err
+	}
+	records := results.CatalogRecord
+	if client.Client.IsSysAdmin {
+		records = results.AdminCatalogRecord
+	}
+	record, err := singleRecord(records, id)
+	if err != nil {
+		return nil, err
+	}
+	return []string{record.OrgName, record.Name}, nil
+}
+
+func catalogItemPath(client *VCDClient, urn string) ([]string, error) {
+	results, err := queryById(client, types.QtCatalogItem, types.QtAdminCatalogItem, urn)
+	if err != nil {
+		return nil, err
+	}
+	records := results.CatalogItemRecord
+	if client.Client.IsSysAdmin {
+		records = results.AdminCatalogItemRecord
+	}
+	record, err := singleRecord(records, urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := catalogPath(client, record.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, record.Name), nil
+}
+
+func vAppTemplatePath(client *VCDClient, urn string) ([]string, error) {
+	record, err := client.QuerySynchronizedVAppTemplateById(urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := catalogPath(client, record.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, record.Name), nil
+}
+
+func mediaPath(client *VCDClient, urn string) ([]string, error) {
+	record, err := client.QueryMediaById(urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := catalogPath(client, record.MediaRecord.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, record.MediaRecord.Name), nil
+}
+
+// networkPath returns the path of an Org VDC network, whose parent can be a VDC or a VDC Group
+func networkPath(client *VCDClient, urn string) ([]string, error) {
+	var network types.OpenApiOrgVdcNetwork
+	err := getOpenApiEntityById(client, types.OpenApiEndpointOrgVdcNetworks, urn, &network)
+	if err != nil {
+		return nil, err
+	}
+	owner := network.OwnerRef
+	if owner == nil || owner.ID == "" {
+		owner = network.OrgVdc
+	}
+	path, err := ownerPath(client, owner)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, network.Name), nil
+}
+
+func externalNetworkPath(client *VCDClient, urn string) ([]string, error) {
+	externalNetwork, err := client.GetExternalNetworkById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{externalNetwork.ExternalNetwork.Name}, nil
+}
+
+func externalNetworkV2Path(client *VCDClient, urn string) ([]string, error) {
+	externalNetwork, err := govcd.GetExternalNetworkV2ById(client.VCDClient, urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{externalNetwork.ExternalNetwork.Name}, nil
+}
+
+// edgeGatewayPath returns the path of an NSX-V or NSX-T edge gateway, whose parent can be a VDC or a VDC Group
+func edgeGatewayPath(client *VCDClient, urn string) ([]string, error) {
+	var edgeGateway types.OpenAPIEdgeGateway
+	err := getOpenApiEntityById(client, types.OpenApiEndpointEdgeGateways, urn, &edgeGateway)
+	if err != nil {
+		return nil, err
+	}
+	owner := edgeGateway.OwnerRef
+	if owner == nil || owner.ID == "" {
+		owner = edgeGateway.OrgVdc
+	}
+	path, err := ownerPath(client, owner)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, edgeGateway.Name), nil
+}
+
+// firewallGroupPath returns the path of an IP Set or Security Group. Those attached to an edge gateway have it in
+// their path, while dynamic security groups are children of a VDC Group
+func firewallGroupPath(client *VCDClient, urn string) ([]string, error) {
+	var firewallGroup types.NsxtFirewallGroup
+	err := getOpenApiEntityById(client, types.OpenApiEndpointFirewallGroups, urn, &firewallGroup)
+	if err != nil {
+		return nil, err
+	}
+	var path []string
+	switch {
+	case firewallGroup.EdgeGatewayRef != nil && firewallGroup.EdgeGatewayRef.ID != "":
+		path, err = edgeGatewayPath(client, firewallGroup.EdgeGatewayRef.ID)
+	case firewallGroup.OwnerRef != nil && strings.Contains(firewallGroup.OwnerRef.ID, ":gateway:"):
+		path, err = edgeGatewayPath(client, firewallGroup.OwnerRef.ID)
+	default:
+		path, err = ownerPath(client, firewallGroup.OwnerRef)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(path, firewallGroup.Name), nil
+}
+
+func albPoolPath(client *VCDClient, urn string) ([]string, error) {
+	pool, err := client.GetAlbPoolById(urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := edgeGatewayPath(client, pool.NsxtAlbPool.GatewayRef.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, pool.NsxtAlbPool.Name), nil
+}
+
+func albVirtualServicePath(client *VCDClient, urn string) ([]string, error) {
+	virtualService, err := client.GetAlbVirtualServiceById(urn)
+	if err != nil {
+		return nil, err
+	}
+	path, err := edgeGatewayPath(client, virtualService.NsxtAlbVirtualService.GatewayRef.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, virtualService.NsxtAlbVirtualService.Name), nil
+}
+
+func albServiceEngineGroupPath(client *VCDClient, urn string) ([]string, error) {
+	serviceEngineGroup, err := client.GetAlbServiceEngineGroupById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{serviceEngineGroup.NsxtAlbServiceEngineGroup.Name}, nil
+}
+
+// appPortProfilePath returns the path of an Application Port Profile, which starts with the NSX-T Manager for
+// PROVIDER scoped profiles, and with the Org and the VDC or VDC Group for TENANT scoped ones
+func appPortProfilePath(client *VCDClient, urn string) ([]string, error) {
+	var appPortProfile types.NsxtAppPortProfile
+	err := getOpenApiEntityById(client, types.OpenApiEndpointAppPortProfiles, urn, &appPortProfile)
+	if err != nil {
+		return nil, err
+	}
+	switch appPortProfile.Scope {
+	case types.ApplicationPortProfileScopeProvider:
+		nsxtManagers, err := client.QueryNsxtManagers()
+		if err != nil {
+			return nil, err
+		}
+		for _, nsxtManager := range nsxtManagers {
+			if extractUuid(nsxtManager.HREF) == extractUuid(appPortProfile.ContextEntityId) {
+				return []string{nsxtManager.Name, appPortProfile.Name}, nil
+			}
+		}
+		return nil, fmt.Errorf("NSX-T Manager '%s' not found", appPortProfile.ContextEntityId)
+	case types.ApplicationPortProfileScopeTenant:
+		path, err := ownerPath(client, &types.OpenApiReference{ID: appPortProfile.ContextEntityId})
+		if err != nil {
+			return nil, err
+		}
+		return append(path, appPortProfile.Name), nil
+	default:
+		return nil, fmt.Errorf("Application Port Profiles with scope '%s' can't be imported", appPortProfile.Scope)
+	}
+}
+
+func segmentProfileTemplatePath(client *VCDClient, urn string) ([]string, error) {
+	segmentProfileTemplate, err := client.GetSegmentProfileTemplateById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{segmentProfileTemplate.NsxtSegmentProfileTemplate.Name}, nil
+}
+
+func albControllerPath(client *VCDClient, urn string) ([]string, error) {
+	controller, err := client.GetAlbControllerById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{controller.NsxtAlbController.Name}, nil
+}
+
+func albCloudPath(client *VCDClient, urn string) ([]string, error) {
+	albCloud, err := client.GetAlbCloudById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{albCloud.NsxtAlbCloud.Name}, nil
+}
+
+// orgEntityPath looks for an entity in all the Orgs visible to the user, returning the Org and the entity names
+func orgEntityPath(client *VCDClient, getName func(adminOrg *govcd.AdminOrg) (string, error)) ([]string, error) {
+	orgList, err := client.GetOrgList()
+	if err != nil {
+		return nil, err
+	}
+	for _, orgRef := range orgList.Org {
+		adminOrg, err := client.GetAdminOrgByName(orgRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		name, err := getName(adminOrg)
+		if govcd.ContainsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []string{adminOrg.AdminOrg.Name, name}, nil
+	}
+	return nil, govcd.ErrorEntityNotFound
+}
+
+func userPath(client *VCDClient, urn string) ([]string, error) {
+	return orgEntityPath(client, func(adminOrg *govcd.AdminOrg) (string, error) {
+		user, err := adminOrg.GetUserById(urn, false)
+		if err != nil {
+			return "", err
+		}
+		return user.User.Name, nil
+	})
+}
+
+func groupPath(client *VCDClient, urn string) ([]string, error) {
+	return orgEntityPath(client, func(adminOrg *govcd.AdminOrg) (string, error) {
+		group, err := adminOrg.GetGroupById(urn, false)
+		if err != nil {
+			return "", err
+		}
+		return group.Group.Name, nil
+	})
+}
+
+func rolePath(client *VCDClient, urn string) ([]string, error) {
+	return orgEntityPath(client, func(adminOrg *govcd.AdminOrg) (string, error) {
+		role, err := adminOrg.GetRoleById(urn)
+		if err != nil {
+			return "", err
+		}
+		return role.Role.Name, nil
+	})
+}
+
+func globalRolePath(client *VCDClient, urn string) ([]string, error) {
+	globalRole, err := client.Client.GetGlobalRoleById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{globalRole.GlobalRole.Name}, nil
+}
+
+func rightsBundlePath(client *VCDClient, urn string) ([]string, error) {
+	rightsBundle, err := client.Client.GetRightsBundleById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{rightsBundle.RightsBundle.Name}, nil
+}
+
+func certificatePath(client *VCDClient, urn string) ([]string, error) {
+	return orgEntityPath(client, func(adminOrg *govcd.AdminOrg) (string, error) {
+		certificate, err := adminOrg.GetCertificateFromLibraryById(urn)
+		if err != nil {
+			return "", err
+		}
+		return certificate.CertificateLibrary.Alias, nil
+	})
+}
+
+// apiTokenPath returns the path of an API token, which can be imported only by the user owning it
+func apiTokenPath(client *VCDClient, urn string) ([]string, error) {
+	token, err := client.GetTokenById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{token.Token.Name}, nil
+}
+
+func uiPluginPath(client *VCDClient, urn string) ([]string, error) {
+	uiPlugin, err := client.GetUIPluginById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{uiPlugin.UIPluginMetadata.Vendor, uiPlugin.UIPluginMetadata.PluginName, uiPlugin.UIPluginMetadata.Version}, nil
+}
+
+// ipSpacePath returns the path of an IP Space, which includes the Org only for private IP Spaces
+func ipSpacePath(client *VCDClient, urn string) ([]string, error) {
+	ipSpace, err := client.GetIpSpaceById(urn)
+	if err != nil {
+		return nil, err
+	}
+	if ipSpace.IpSpace.OrgRef != nil && ipSpace.IpSpace.OrgRef.Name != "" {
+		return []string{ipSpace.IpSpace.OrgRef.Name, ipSpace.IpSpace.Name}, nil
+	}
+	return []string{ipSpace.IpSpace.Name}, nil
+}
+
+// ipSpaceNamePath returns the name of an IP Space, for the importers that don't have the Org in the path
+func ipSpaceNamePath(client *VCDClient, urn string) ([]string, error) {
+	ipSpace, err := client.GetIpSpaceById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{ipSpace.IpSpace.Name}, nil
+}
+
+func ipSpaceUplinkPath(client *VCDClient, urn string) ([]string, error) {
+	ipSpaceUplink, err := client.GetIpSpaceUplinkById(urn)
+	if err != nil {
+		return nil, err
+	}
+	if ipSpaceUplink.IpSpaceUplink.ExternalNetworkRef == nil {
+		return nil, fmt.Errorf("the IP Space Uplink has no external network")
+	}
+	path, err := externalNetworkV2Path(client, ipSpaceUplink.IpSpaceUplink.ExternalNetworkRef.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append(path, ipSpaceUplink.IpSpaceUplink.Name), nil
+}
+
+func vdcTemplatePath(client *VCDClient, urn string) ([]string, error) {
+	vdcTemplate, err := client.GetVdcTemplateById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{vdcTemplate.VdcTemplate.Name}, nil
+}
+
+func tmOrgPath(client *VCDClient, urn string) ([]string, error) {
+	tmOrg, err := client.GetTmOrgById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{tmOrg.TmOrg.Name}, nil
+}
+
+func tmOrgVdcPath(client *VCDClient, urn string) ([]string, error) {
+	tmVdc, err := client.GetTmVdcById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{tmVdc.TmVdc.Name}, nil
+}
+
+func tmRegionPath(client *VCDClient, urn string) ([]string, error) {
+	region, err := client.GetRegionById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{region.Region.Name}, nil
+}
+
+func tmVcenterPath(client *VCDClient, urn string) ([]string, error) {
+	vCenter, err := client.GetVCenterById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{vCenter.VSphereVCenter.Name}, nil
+}
+
+func tmNsxtManagerPath(client *VCDClient, urn string) ([]string, error) {
+	nsxtManager, err := client.GetNsxtManagerOpenApiById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{nsxtManager.NsxtManagerOpenApi.Name}, nil
+}
+
+func tmContentLibraryPath(client *VCDClient, urn string) ([]string, error) {
+	contentLibrary, err := client.GetContentLibraryById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{contentLibrary.ContentLibrary.Name}, nil
+}
+
+func tmContentLibraryItemPath(client *VCDClient, urn string) ([]string, error) {
+	item, err := client.GetContentLibraryItemById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{item.ContentLibraryItem.ContentLibrary.Name, item.ContentLibraryItem.Name}, nil
+}
+
+func tmIpSpacePath(client *VCDClient, urn string) ([]string, error) {
+	ipSpace, err := client.GetTmIpSpaceById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{ipSpace.TmIpSpace.RegionRef.Name, ipSpace.TmIpSpace.Name}, nil
+}
+
+func tmProviderGatewayPath(client *VCDClient, urn string) ([]string, error) {
+	providerGateway, err := client.GetTmProviderGatewayById(urn)
+	if err != nil {
+		return nil, err
+	}
+	return []string{providerGateway.TmProviderGateway.RegionRef.Name, providerGateway.TmProviderGateway.Name}, nil
+}
@@ -0,0 +1,89 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_withUrnImport(t *testing.T) {
+	const uuid = "6f3a2b1c-0d4e-4f5a-8b6c-7d8e9f0a1b2c"
+	var requested string
+	urnImports["vcd_test"] = urnImport{
+		entityType: "vapp",
+		path: func(_ *VCDClient, urn string) ([]string, error) {
+			requested = urn
+			if urn == "urn:vcloud:vapp:00000000-0000-0000-0000-000000000000" {
+				return []string{"my-org", "my.vdc", "my-vapp"}, nil
+			}
+			return []string{"my-org", "my-vdc", "my-vapp"}, nil
+		},
+	}
+	defer delete(urnImports, "vcd_test")
+
+	var imported []string
+	resources := withUrnImport(map[string]*schema.Resource{
+		"vcd_test": {
+			Importer: &schema.ResourceImporter{
+				StateContext: func(ctx context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+					imported = importIdElements(ctx, d, 5)
+					return []*schema.ResourceData{d}, nil
+				},
+			},
+		},
+		"vcd_other": {},
+	})
+	if resources["vcd_other"].Importer != nil {
+		t.Fatalf("resources without an importer must not be changed")
+	}
+
+	const dottedUrn = "urn:vcloud:vapp:00000000-0000-0000-0000-000000000000"
+	tests := []struct {
+		id            string
+		wantUrn       string
+		wantElements  []string
+		wantErrorText string
+	}{
+		{id: "urn:vcloud:vapp:" + uuid, wantUrn: "urn:vcloud:vapp:" + uuid, wantElements: []string{"my-org", "my-vdc", "my-vapp"}},
+		{id: "urn:vcloud:vApp:" + uuid, wantUrn: "urn:vcloud:vApp:" + uuid, wantElements: []string{"my-org", "my-vdc", "my-vapp"}},
+		{id: uuid, wantUrn: "urn:vcloud:vapp:" + uuid, wantElements: []string{"my-org", "my-vdc", "my-vapp"}},
+		{id: dottedUrn, wantUrn: dottedUrn, wantElements: []string{"my-org", "my.vdc", "my-vapp"}},
+		// The URN of the parent, followed by the rest of the path
+		{id: dottedUrn + ".my-network", wantUrn: dottedUrn, wantElements: []string{"my-org", "my.vdc", "my-vapp", "my-network"}},
+		{id: dottedUrn + ".ui-no.3", wantUrn: dottedUrn, wantElements: []string{"my-org", "my.vdc", "my-vapp", "ui-no", "3"}},
+		{id: dottedUrn + ".10.0.0.0/24", wantUrn: dottedUrn, wantElements: []string{"my-org", "my.vdc", "my-vapp", "10", "0.0.0/24"}},
+		// Import paths are split as usual
+		{id: "my-org.my-vdc.my-vapp", wantElements: []string{"my-org", "my-vdc", "my-vapp"}},
+		{id: "my-org.my-vdc.urn:vcloud:vapp:" + uuid, wantElements: []string{"my-org", "my-vdc", "urn:vcloud:vapp:" + uuid}},
+		{id: uuid + ".my-network", wantElements: []string{uuid, "my-network"}},
+		{id: "urn:vcloud:vm:" + uuid, wantErrorText: "needs the URN of a 'vapp'"},
+		{id: "urn:vcloud:vm:" + uuid + ".my-network", wantErrorText: "needs the URN of a 'vapp'"},
+	}
+	for _, test := range tests {
+		requested, imported = "", nil
+		d := resources["vcd_test"].Data(nil)
+		d.SetId(test.id)
+		_, err := resources["vcd_test"].Importer.StateContext(context.Background(), d, &VCDClient{})
+		if test.wantErrorText != "" {
+			if err == nil || !strings.Contains(err.Error(), test.wantErrorText) {
+				t.Errorf("%s: expected error containing %q, got %v", test.id, test.wantErrorText, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.id, err)
+			continue
+		}
+		if requested != test.wantUrn {
+			t.Errorf("%s: expected the path of %q to be requested, got %q", test.id, test.wantUrn, requested)
+		}
+		if !reflect.DeepEqual(imported, test.wantElements) {
+			t.Errorf("%s: expected import path elements %q, got %q", test.id, test.wantElements, imported)
+		}
+	}
+}
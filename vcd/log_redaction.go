@@ -0,0 +1,245 @@
+package vcd
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/util"
+)
+
+// The API log written by go-vcloud-director (see provider `logging` argument) contains the body of requests and
+// responses, which can include credentials. go-vcloud-director hides a few of them, and the provider adds a further
+// redaction layer on the log output, so that nothing reaches the log file before being filtered:
+// * values of fields whose name ends with one of builtinSecretFields or with the name of a Sensitive attribute in
+// the provider schemas, in JSON, XML and form-encoded payloads
+// * headers in builtinSecretHeaders
+// * PEM private keys
+// * values of Sensitive attributes of the resources being handled, and the provider credentials, wherever they appear
+//
+// Redaction is disabled when GOVCD_LOG_PASSWORDS is set, as go-vcloud-director does for its own filters.
+
+// redactedText replaces the secrets in the API log
+const redactedText = "********"
+
+// minRedactedValueLength is the minimum length of a secret value to be replaced wherever it appears in the log. Shorter
+// values would hide unrelated text, and they are still hidden when they are in a known secret field
+const minRedactedValueLength = 4
+
+// builtinSecretFields are field name suffixes whose values are always redacted. Each "_" matches also an empty string
+// or "-", so that the names match the snake_case, camelCase and PascalCase variants used by VCD APIs
+var builtinSecretFields = []string{
+	"password",
+	"passwd",
+	"passphrase",
+	"secret",
+	"token",
+	"private_key",
+	"pre_shared_key",
+	"kubeconfig",
+	"assertion",
+}
+
+// builtinSecretHeaders are HTTP headers whose values are always redacted
+var builtinSecretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Vcloud-Authorization",
+	"X-Vmware-Vcloud-Access-Token",
+	"Config-Secret",
+}
+
+// logRedactor replaces secrets in the text written to the API log
+type logRedactor struct {
+	patterns []logRedactionPattern
+
+	valuesLock sync.RWMutex
+	values     map[string]bool
+}
+
+type logRedactionPattern struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+// apiLogRedactor is the redactor set in the API log by setApiLogRedaction. Secret values found while the provider
+// runs are registered here
+var apiLogRedactor = newLogRedactor(sensitiveAttributeNames(globalResourceMap, globalDataSourceMap))
+
+// newLogRedactor creates a redactor for the built-in secret fields and the given additional field names
+func newLogRedactor(fieldNames []string) *logRedactor {
+	names := map[string]bool{}
+	for _, name := range append(append([]string{}, builtinSecretFields...), fieldNames...) {
+		names[strings.ToLower(name)] = true
+	}
+	var namePatterns []string
+	for name := range names {
+		namePatterns = append(namePatterns, strings.ReplaceAll(regexp.QuoteMeta(name), "_", "[_-]?"))
+	}
+	// Longer names first, so that the alternation doesn't stop at a shorter suffix
+	sort.Slice(namePatterns, func(i, j int) bool {
+		if len(namePatterns[i]) != len(namePatterns[j]) {
+			return len(namePatterns[i]) > len(namePatterns[j])
+		}
+		return namePatterns[i] < namePatterns[j]
+	})
+	field := `[\w.\-]*(?i:` + strings.Join(namePatterns, "|") + `)`
+
+	var headers []string
+	for _, header := range builtinSecretHeaders {
+		headers = append(headers, regexp.QuoteMeta(header))
+	}
+
+	return &logRedactor{
+		values: map[string]bool{},
+		patterns: []logRedactionPattern{
+			// JSON: "field": "value"
+			{regexp.MustCompile(`("` + field + `"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"` + redactedText + `"`},
+			// JSON escaped inside a JSON string: \"field\": \"value\"
+			{regexp.MustCompile(`(\\"` + field + `\\"\s*:\s*)\\"(?:[^"\\]|\\[^"])*\\"`), `${1}\"` + redactedText + `\"`},
+			// XML element: <ns:Field attr="...">value</ns:Field>
+			{regexp.MustCompile(`(<(?:[\w.\-]+:)?` + field + `(?:\s[^>]*)?>)[^<]+(</)`), `${1}` + redactedText + `${2}`},
+			// XML attribute: field="value"
+			{regexp.MustCompile(`(\s(?:[\w.\-]+:)?` + field + `=")[^"]*(")`), `${1}` + redactedText + `${2}`},
+			// Form-encoded and query parameters: field=value
+			{regexp.MustCompile(`([?&\s]` + field + `=)[^&\s"]+`), `${1}` + redactedText},
+			// Headers, logged as "<tab>Name: [value]"
+			{regexp.MustCompile(`(?im)(\t(?:` + strings.Join(headers, "|") + `):) .*$`), `${1} [` + redactedText + `]`},
+			// PEM private keys
+			{regexp.MustCompile(`(-----BEGIN [A-Z ]*PRIVATE KEY-----)[\s\S]*?(-----END [A-Z ]*PRIVATE KEY-----)`), `${1}` + redactedText + `${2}`},
+		},
+	}
+}
+
+// addValue registers a secret value, which is replaced wherever it appears in the log
+func (r *logRedactor) addValue(value string) {
+	if len(value) < minRedactedValueLength {
+		return
+	}
+	r.valuesLock.Lock()
+	defer r.valuesLock.Unlock()
+	r.values[value] = true
+}
+
+// redact returns the given text with all the secrets replaced
+func (r *logRedactor) redact(text string) string {
+	for _, pattern := range r.patterns {
+		text = pattern.regex.ReplaceAllString(text, pattern.replacement)
+	}
+
+	r.valuesLock.RLock()
+	defer r.valuesLock.RUnlock()
+	for value := range r.values {
+		text = strings.ReplaceAll(text, value, redactedText)
+	}
+	return text
+}
+
+// redactingWriter is an io.Writer that redacts the text before writing it. log.Logger calls Write once for each
+// message, so that secrets are never split between two writes
+type redactingWriter struct {
+	writer   io.Writer
+	redactor *logRedactor
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	_, err := w.writer.Write([]byte(w.redactor.redact(string(p))))
+	if err != nil {
+		return 0, err
+	}
+	// The caller expects the length of its own buffer
+	return len(p), nil
+}
+
+// setApiLogRedaction sets the redaction layer in the go-vcloud-director logger. It must be called after any call to
+// util.InitLogging, which replaces the logger
+func setApiLogRedaction(ctx context.Context) {
+	if util.LogPasswords {
+		tflog.Warn(ctx, "GOVCD_LOG_PASSWORDS is set: secrets are not redacted from the API log")
+		return
+	}
+	if _, alreadySet := util.Logger.Writer().(*redactingWriter); alreadySet {
+		return
+	}
+	util.Logger.SetOutput(&redactingWriter{writer: util.Logger.Writer(), redactor: apiLogRedactor})
+}
+
+// sensitiveAttributeNames returns the names of all the Sensitive attributes, including nested ones, in the given
+// resource maps
+func sensitiveAttributeNames(resourceMaps ...map[string]*schema.Resource) []string {
+	names := map[string]bool{}
+	var collect func(attributes map[string]*schema.Schema)
+	collect = func(attributes map[string]*schema.Schema) {
+		for name, attribute := range attributes {
+			if attribute.Sensitive {
+				names[name] = true
+			}
+			if elem, ok := attribute.Elem.(*schema.Resource); ok {
+				collect(elem.Schema)
+			}
+		}
+	}
+	for _, resourceMap := range resourceMaps {
+		for _, resource := range resourceMap {
+			collect(resource.Schema)
+		}
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// registerSensitiveValues registers for redaction the values of the Sensitive attributes, including nested ones,
+// of the given resource data
+func registerSensitiveValues(attributes map[string]*schema.Schema, d *schema.ResourceData) {
+	for name, attribute := range attributes {
+		registerSensitiveValue(attribute, d.Get(name))
+	}
+}
+
+func registerSensitiveValue(attribute *schema.Schema, value interface{}) {
+	if attribute.Sensitive {
+		switch typedValue := value.(type) {
+		case string:
+			apiLogRedactor.addValue(typedValue)
+		case map[string]interface{}:
+			for _, item := range typedValue {
+				if stringItem, ok := item.(string); ok {
+					apiLogRedactor.addValue(stringItem)
+				}
+			}
+		}
+	}
+
+	elem, ok := attribute.Elem.(*schema.Resource)
+	if !ok {
+		return
+	}
+	var items []interface{}
+	switch typedValue := value.(type) {
+	case []interface{}:
+		items = typedValue
+	case *schema.Set:
+		items = typedValue.List()
+	}
+	for _, item := range items {
+		block, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, nestedAttribute := range elem.Schema {
+			registerSensitiveValue(nestedAttribute, block[name])
+		}
+	}
+}
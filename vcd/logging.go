@@ -0,0 +1,121 @@
+package vcd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+// The provider writes its log messages with tflog, using the context that the Terraform SDK passes to resource
+// and data source functions. Such messages are leveled and can be filtered with TF_LOG_PROVIDER. The context is
+// enriched with the fields below, so that each message can be tied to the entity it refers to.
+//
+// The code that the SDK calls without a context (the locks in mutexkv.go, the hash functions of sets, the
+// DiffSuppressFunc callbacks, the HTTP transport and the resource functions without context) logs with the
+// context of the provider configuration, returned by providerLogContext. API calls are also logged by
+// go-vcloud-director in its own log file (see `logging` argument).
+const (
+	logFieldResourceType = "vcd_resource_type"
+	logFieldOrg          = "vcd_org"
+	logFieldVdc          = "vcd_vdc"
+	logFieldTaskId       = "vcd_task_id"
+)
+
+var (
+	providerLogCtx      = context.Background()
+	providerLogCtxMutex sync.RWMutex
+)
+
+// setProviderLogContext stores the context of the provider configuration, which carries the provider logger
+func setProviderLogContext(ctx context.Context) {
+	providerLogCtxMutex.Lock()
+	defer providerLogCtxMutex.Unlock()
+	providerLogCtx = ctx
+}
+
+// providerLogContext returns the context to log with when the SDK does not pass one.
+// Until the provider is configured, the messages written with it are discarded.
+func providerLogContext() context.Context {
+	providerLogCtxMutex.RLock()
+	defer providerLogCtxMutex.RUnlock()
+	return providerLogCtx
+}
+
+// withLogContext returns a copy of the given resource or data source map, where the functions that receive a
+// context add to it the log fields that identify the resource type, Org and VDC
+func withLogContext(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		withContext := *resource
+		if resource.CreateContext != nil {
+			withContext.CreateContext = withResourceLogContext(name, resource, resource.CreateContext)
+		}
+		if resource.ReadContext != nil {
+			withContext.ReadContext = withResourceLogContext(name, resource, resource.ReadContext)
+		}
+		if resource.UpdateContext != nil {
+			withContext.UpdateContext = withResourceLogContext(name, resource, resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			withContext.DeleteContext = withResourceLogContext(name, resource, resource.DeleteContext)
+		}
+		if resource.Importer != nil && resource.Importer.StateContext != nil {
+			importer := *resource.Importer
+			stateContext := resource.Importer.StateContext
+			importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				return stateContext(resourceLogContext(ctx, name, resource, d, meta), d, meta)
+			}
+			withContext.Importer = &importer
+		}
+		result[name] = &withContext
+	}
+	return result
+}
+
+// withResourceLogContext wraps a resource function so that it receives a context with the resource log fields
+func withResourceLogContext(resourceType string, resource *schema.Resource, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(resourceLogContext(ctx, resourceType, resource, d, meta), d, meta)
+	}
+}
+
+// resourceLogContext adds the resource type to the log fields of the context, together with the Org and VDC when
+// the resource has such arguments. Empty values are taken from the provider configuration.
+// The values of the Sensitive attributes are also registered, to be redacted from the API log.
+func resourceLogContext(ctx context.Context, resourceType string, resource *schema.Resource, d *schema.ResourceData, meta interface{}) context.Context {
+	registerSensitiveValues(resource.Schema, d)
+	ctx = tflog.SetField(ctx, logFieldResourceType, resourceType)
+	vcdClient, _ := meta.(*VCDClient)
+
+	if _, hasOrg := resource.Schema["org"]; hasOrg {
+		org, _ := d.Get("org").(string)
+		if org == "" && vcdClient != nil {
+			org = vcdClient.Org
+		}
+		if org != "" {
+			ctx = tflog.SetField(ctx, logFieldOrg, org)
+		}
+	}
+	if _, hasVdc := resource.Schema["vdc"]; hasVdc {
+		vdc, _ := d.Get("vdc").(string)
+		if vdc == "" && vcdClient != nil {
+			vdc = vcdClient.Vdc
+		}
+		if vdc != "" {
+			ctx = tflog.SetField(ctx, logFieldVdc, vdc)
+		}
+	}
+	return ctx
+}
+
+// taskLogContext adds the ID of the given task to the log fields of the context
+func taskLogContext(ctx context.Context, task govcd.Task) context.Context {
+	if task.Task == nil {
+		return ctx
+	}
+	return tflog.SetField(ctx, logFieldTaskId, task.Task.ID)
+}
@@ -0,0 +1,61 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_withLogContext(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"org":  {Type: schema.TypeString, Optional: true},
+			"vdc":  {Type: schema.TypeString, Optional: true},
+			"name": {Type: schema.TypeString, Required: true},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			tflog.Debug(ctx, "reading")
+			return nil
+		},
+	}
+	resources := withLogContext(map[string]*schema.Resource{"vcd_test": resource})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"name": "test", "org": "my-org"})
+	diags := resources["vcd_test"].ReadContext(ctx, d, &VCDClient{Org: "default-org", Vdc: "default-vdc"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("error decoding log output: %s", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	expectedFields := map[string]string{
+		"@message":           "reading",
+		logFieldResourceType: "vcd_test",
+		logFieldOrg:          "my-org",
+		logFieldVdc:          "default-vdc",
+	}
+	for field, value := range expectedFields {
+		if entries[0][field] != value {
+			t.Errorf("expected log field '%s' to be '%s', got '%v'", field, value, entries[0][field])
+		}
+	}
+
+	// The original resource must not be modified
+	if resources["vcd_test"] == resource {
+		t.Errorf("expected a copy of the resource")
+	}
+}
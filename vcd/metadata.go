@@ -1,17 +1,19 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 var (
@@ -21,6 +23,13 @@ var (
 	IgnoreMetadataChangesConflictActions map[string]string
 )
 
+// defaultMetadataSchema returns the schema associated to default_metadata for the provider configuration.
+func defaultMetadataSchema() *schema.Schema {
+	defaultMetadata := metadataEntryResourceSchema("resource")
+	defaultMetadata.Description = "Metadata entries added to every resource that supports `metadata_entry`. Entries with the same key in the resource take precedence"
+	return defaultMetadata
+}
+
 // ignoreMetadataSchema returns the schema associated to ignore_metadata_changes for the provider configuration.
 func ignoreMetadataSchema() *schema.Schema {
 	return &schema.Schema{
@@ -283,9 +292,12 @@ type metadataCompatible interface {
 }
 
 // createOrUpdateMetadataEntryInVcd creates or updates metadata entries in VCD for the given resource, only if the attribute
-// metadata_entry has been set or updated in the state.
-func createOrUpdateMetadataEntryInVcd(d *schema.ResourceData, resource metadataCompatible) error {
-	if !d.HasChange("metadata_entry") {
+// metadata_entry has been set or updated in the state, or if the resource is being created and the provider has default
+// metadata. The default metadata is merged with the metadata_entry of the resource, which takes precedence.
+func createOrUpdateMetadataEntryInVcd(d *schema.ResourceData, vcdClient *VCDClient, resource metadataCompatible) error {
+	defaultMetadata := vcdClient.defaultMetadata
+	applyDefaultMetadata := len(defaultMetadata) > 0 && d.IsNewResource()
+	if !d.HasChange("metadata_entry") && !applyDefaultMetadata {
 		return nil
 	}
 
@@ -295,7 +307,10 @@ func createOrUpdateMetadataEntryInVcd(d *schema.ResourceData, resource metadataC
 	oldKeyMapWithDomain := getMetadataKeyWithDomainMap(oldRaw.(*schema.Set).List())
 	newKeyMapWithDomain := getMetadataKeyWithDomainMap(newMetadata)
 	for oldKey, isSystem := range oldKeyMapWithDomain {
-		if _, newKeyPresent := newKeyMapWithDomain[oldKey]; !newKeyPresent {
+		_, newKeyPresent := newKeyMapWithDomain[oldKey]
+		// Default entries removed from the resource are restored to their default value below
+		_, isDefaultKey := defaultMetadata[oldKey]
+		if !newKeyPresent && !isDefaultKey {
 			err := resource.DeleteMetadataEntryWithDomain(oldKey, isSystem)
 			if err != nil {
 				return fmt.Errorf("error deleting metadata entry corresponding to key %s: %s", oldKey, err)
@@ -304,13 +319,14 @@ func createOrUpdateMetadataEntryInVcd(d *schema.ResourceData, resource metadataC
 	}
 
 	// Update metadata
-	if len(newMetadata) == 0 {
+	if len(newMetadata) == 0 && len(defaultMetadata) == 0 {
 		return nil
 	}
 	metadataToMerge, err := convertFromStateToMetadataValues(newMetadata)
 	if err != nil {
 		return err
 	}
+	metadataToMerge = mergeDefaultMetadata(defaultMetadata, metadataToMerge)
 	if len(metadataToMerge) == 0 {
 		return nil
 	}
@@ -323,7 +339,7 @@ func createOrUpdateMetadataEntryInVcd(d *schema.ResourceData, resource metadataC
 
 // checkIgnoredMetadataConflicts checks that no `metadata_entry` managed by Terraform is ignored due to being filtered out
 // in any `ignore_metadata_changes` block and errors/warns if so, depending on the value of `conflict_action`.
-func checkIgnoredMetadataConflicts(d *schema.ResourceData, vcdClient *VCDClient, resourceType string) diag.Diagnostics {
+func checkIgnoredMetadataConflicts(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, resourceType string) diag.Diagnostics {
 	metadataEntryList := d.Get("metadata_entry").(*schema.Set).List()
 	if len(metadataEntryList) == 0 {
 		return nil
@@ -341,7 +357,11 @@ func checkIgnoredMetadataConflicts(d *schema.ResourceData, vcdClient *VCDClient,
 				continue
 			}
 
-			util.Logger.Printf("[DEBUG] detected a conflict with metadata_entry with key '%s' and value '%v', it is being being ignored with the ignore_metadata_changes block '%v'", newEntry["key"].(string), newEntry["value"].(string), ignoredMetadata)
+			tflog.Debug(ctx, "detected a conflict with metadata_entry, it is being ignored with the ignore_metadata_changes block", map[string]interface{}{
+				"key":              newEntry["key"].(string),
+				"value":            newEntry["value"].(string),
+				"ignored_metadata": ignoredMetadata.String(),
+			})
 			var severity diag.Severity
 			action := IgnoreMetadataChangesConflictActions[ignoredMetadata.String()]
 			switch action {
@@ -374,7 +394,7 @@ func checkIgnoredMetadataConflicts(d *schema.ResourceData, vcdClient *VCDClient,
 // updateMetadataInStateDeprecated updates deprecated metadata and the new metadata_entry in the Terraform state for the given receiver object.
 // This can be done as both are Computed, for compatibility reasons.
 // TODO: Remove this function once "metadata" attribute is deleted in a future major release.
-func updateMetadataInStateDeprecated(d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject metadataCompatible) diag.Diagnostics {
+func updateMetadataInStateDeprecated(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject metadataCompatible) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	// We temporarily remove the ignored metadata filter to retrieve the deprecated metadata contents,
@@ -402,13 +422,13 @@ func updateMetadataInStateDeprecated(d *schema.ResourceData, vcdClient *VCDClien
 	}
 
 	// Set deprecated metadata attribute, just for compatibility reasons
-	err = d.Set("metadata", getMetadataStruct(deprecatedMetadata.MetadataEntry))
+	err = d.Set("metadata", getMetadataStruct(hideDefaultMetadata(ctx, d, vcdClient, "metadata", deprecatedMetadata.MetadataEntry)))
 	if err != nil {
 		return diag.Errorf("error setting metadata in state: %s", err)
 	}
 
 	// We get metadata again with the original metadata ignore filtering
-	diags = append(diags, updateMetadataInState(d, vcdClient, resourceType, receiverObject)...)
+	diags = append(diags, updateMetadataInState(ctx, d, vcdClient, resourceType, receiverObject)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -422,8 +442,8 @@ func updateMetadataInStateDeprecated(d *schema.ResourceData, vcdClient *VCDClien
 }
 
 // updateMetadataInState updates ONLY metadata_entry in the Terraform state for the given receiver object.
-func updateMetadataInState(d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject metadataCompatible) diag.Diagnostics {
-	diags := checkIgnoredMetadataConflicts(d, vcdClient, resourceType)
+func updateMetadataInState(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject metadataCompatible) diag.Diagnostics {
+	diags := checkIgnoredMetadataConflicts(ctx, d, vcdClient, resourceType)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -443,7 +463,7 @@ func updateMetadataInState(d *schema.ResourceData, vcdClient *VCDClient, resourc
 		}
 	}
 
-	err = setMetadataEntryInState(d, metadata.MetadataEntry)
+	err = setMetadataEntryInState(ctx, d, vcdClient, metadata.MetadataEntry)
 	if err != nil {
 		return append(diags, diag.Errorf("error setting metadata entry in state: %s", err)...)
 	}
@@ -482,7 +502,9 @@ func filterAndGetVcdInheritedMetadata(metadata *types.Metadata) StringMap {
 
 // setMetadataEntryInState sets the given metadata entries retrieved from VCD in the Terraform state.
 // TODO: Refactor this function once "metadata" attribute is deleted in a future major release.
-func setMetadataEntryInState(d *schema.ResourceData, metadataFromVcd []*types.MetadataEntry) error {
+func setMetadataEntryInState(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, metadataFromVcd []*types.MetadataEntry) error {
+	metadataFromVcd = hideDefaultMetadata(ctx, d, vcdClient, "metadata_entry", metadataFromVcd)
+
 	// A consequence of having metadata_entry computed is that to remove the entries one needs to write `metadata_entry {}`.
 	// This snippet guarantees that if we try to delete metadata with `metadata_entry {}`, we don't
 	// set an empty Set as attribute in state, which would taint it and ask for an update all the time.
@@ -512,6 +534,224 @@ func setMetadataEntryInState(d *schema.ResourceData, metadataFromVcd []*types.Me
 	return err
 }
 
+// mergeDefaultMetadata returns the default metadata entries merged with the given ones, which take precedence
+func mergeDefaultMetadata(defaultMetadata, metadata map[string]types.MetadataValue) map[string]types.MetadataValue {
+	if len(defaultMetadata) == 0 {
+		return metadata
+	}
+	result := make(map[string]types.MetadataValue, len(defaultMetadata)+len(metadata))
+	for key, value := range defaultMetadata {
+		result[key] = value
+	}
+	for key, value := range metadata {
+		result[key] = value
+	}
+	return result
+}
+
+// hideDefaultMetadata removes from the metadata retrieved from VCD the entries that are identical to the default metadata,
+// so that they don't appear as changes in resources that don't define them. Entries whose key is already in the given
+// state attribute (either a metadata_entry set or a deprecated metadata map) are kept, as the resource manages them.
+// Default entries whose value in VCD is different are kept too. For metadata_entry, the default entries missing in VCD
+// are added without type and value, so that the plan shows the difference (see customizeDefaultMetadataDiff) and the
+// update restores them.
+// Data sources are not affected, as they always show the full metadata.
+func hideDefaultMetadata(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, attributeName string, metadataFromVcd []*types.MetadataEntry) []*types.MetadataEntry {
+	defaultMetadata := vcdClient.defaultMetadata
+	if len(defaultMetadata) == 0 || isDataSourceContext(ctx) {
+		return metadataFromVcd
+	}
+
+	managedKeys := map[string]bool{}
+	switch stateMetadata := d.Get(attributeName).(type) {
+	case *schema.Set:
+		for key := range getMetadataKeyWithDomainMap(stateMetadata.List()) {
+			managedKeys[key] = true
+		}
+	case map[string]interface{}:
+		for key := range stateMetadata {
+			managedKeys[key] = true
+		}
+	}
+
+	var result []*types.MetadataEntry
+	foundKeys := map[string]bool{}
+	for _, metadataEntry := range metadataFromVcd {
+		foundKeys[metadataEntry.Key] = true
+		if !managedKeys[metadataEntry.Key] && isDefaultMetadataEntry(defaultMetadata, metadataEntry) {
+			continue
+		}
+		result = append(result, metadataEntry)
+	}
+	if attributeName != "metadata_entry" {
+		return result
+	}
+	ignoredMetadata := getIgnoredMetadataFromClient(vcdClient)
+	defaultKeys := make([]string, 0, len(defaultMetadata))
+	for key := range defaultMetadata {
+		defaultKeys = append(defaultKeys, key)
+	}
+	sort.Strings(defaultKeys)
+	for _, key := range defaultKeys {
+		if foundKeys[key] || isIgnoredDefaultMetadata(ignoredMetadata, key, defaultMetadata[key]) {
+			continue
+		}
+		tflog.Debug(ctx, "default metadata entry not found in VCD", map[string]interface{}{"key": key})
+		result = append(result, &types.MetadataEntry{Key: key})
+	}
+	return result
+}
+
+// getIgnoredMetadataFromClient returns the metadata ignored by the client. The lock is needed as
+// updateMetadataInStateDeprecated temporarily removes the ignored metadata from the client
+func getIgnoredMetadataFromClient(vcdClient *VCDClient) []govcd.IgnoredMetadata {
+	vcdMutexKV.kvLock("metadata")
+	defer vcdMutexKV.kvUnlock("metadata")
+	return vcdClient.Client.IgnoredMetadata
+}
+
+// isIgnoredDefaultMetadata returns true if a default metadata entry can be filtered out by the given ignored metadata,
+// and so it can't be found in VCD. The object type and name of the ignored metadata are not considered, as the default
+// entries apply to every resource
+func isIgnoredDefaultMetadata(ignoredMetadata []govcd.IgnoredMetadata, key string, value types.MetadataValue) bool {
+	for _, ignored := range ignoredMetadata {
+		if ignored.KeyRegex != nil && !ignored.KeyRegex.MatchString(key) {
+			continue
+		}
+		if ignored.ValueRegex != nil && (value.TypedValue == nil || !ignored.ValueRegex.MatchString(value.TypedValue.Value)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// customizeDefaultMetadataDiff shows in the plan the default metadata entries that are different or missing in VCD,
+// when the resource doesn't define any metadata_entry. Otherwise, the computed attribute would keep the values
+// read from VCD. Such entries are removed from the planned metadata_entry, and the update restores their default value.
+// When the resource defines metadata_entry blocks, the entries read from VCD are already compared with them.
+func customizeDefaultMetadataDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil || len(vcdClient.defaultMetadata) == 0 || d.Id() == "" {
+		return nil
+	}
+	configured, isSet := rawConfigAttribute(d.GetRawConfig(), "metadata_entry")
+	if isSet || !configured.IsKnown() {
+		return nil
+	}
+
+	stateEntries := d.Get("metadata_entry").(*schema.Set).List()
+	var plannedEntries []interface{}
+	for _, rawEntry := range stateEntries {
+		entry := rawEntry.(map[string]interface{})
+		key, _ := entry["key"].(string)
+		if _, isDefaultKey := vcdClient.defaultMetadata[key]; isDefaultKey && !isDefaultMetadataStateEntry(vcdClient.defaultMetadata, entry) {
+			continue
+		}
+		plannedEntries = append(plannedEntries, rawEntry)
+	}
+	if len(plannedEntries) == len(stateEntries) {
+		return nil
+	}
+	return d.SetNew("metadata_entry", plannedEntries)
+}
+
+// isDefaultMetadataStateEntry returns true if the given metadata_entry from the state has the same type, value and
+// domain as the default metadata entry with the same key
+func isDefaultMetadataStateEntry(defaultMetadata map[string]types.MetadataValue, entry map[string]interface{}) bool {
+	key, _ := entry["key"].(string)
+	metadataType, _ := entry["type"].(string)
+	value, _ := entry["value"].(string)
+	userAccess, _ := entry["user_access"].(string)
+	isSystem, _ := entry["is_system"].(bool)
+	domain := "GENERAL"
+	if isSystem {
+		domain = "SYSTEM"
+	}
+	return isDefaultMetadataEntry(defaultMetadata, &types.MetadataEntry{
+		Key:        key,
+		TypedValue: &types.MetadataTypedValue{XsiType: metadataType, Value: value},
+		Domain:     &types.MetadataDomainTag{Visibility: userAccess, Domain: domain},
+	})
+}
+
+// withDefaultMetadata returns a copy of the given resource map, where the resources that support metadata_entry
+// plan the differences between the default metadata and VCD (see customizeDefaultMetadataDiff)
+func withDefaultMetadata(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		// Only the metadata_entry attributes with the legacy API format support default metadata. Those that are not
+		// computed are always compared with the configuration.
+		metadataEntry, found := resource.Schema["metadata_entry"]
+		if !found || !metadataEntry.Computed || !hasDefaultMetadataSupport(metadataEntry) {
+			result[name] = resource
+			continue
+		}
+		withDefaults := *resource
+		customizeDiff := resource.CustomizeDiff
+		withDefaults.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if customizeDiff != nil {
+				if err := customizeDiff(ctx, diff, meta); err != nil {
+					return err
+				}
+			}
+			return customizeDefaultMetadataDiff(ctx, diff, meta)
+		}
+		result[name] = &withDefaults
+	}
+	return result
+}
+
+// hasDefaultMetadataSupport returns true if the given metadata_entry schema has the legacy API format
+func hasDefaultMetadataSupport(metadataEntry *schema.Schema) bool {
+	elem, isResource := metadataEntry.Elem.(*schema.Resource)
+	return isResource && elem.Schema["user_access"] != nil
+}
+
+// dataSourceContextKey is the context key that identifies the functions of data sources
+type dataSourceContextKey struct{}
+
+// withDataSourceContext returns a copy of the given data source map, where the Read functions receive a context
+// that identifies them as data sources (see isDataSourceContext)
+func withDataSourceContext(dataSources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(dataSources))
+	for name, dataSource := range dataSources {
+		withContext := *dataSource
+		if dataSource.ReadContext != nil {
+			readContext := dataSource.ReadContext
+			withContext.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				return readContext(context.WithValue(ctx, dataSourceContextKey{}, true), d, meta)
+			}
+		}
+		result[name] = &withContext
+	}
+	return result
+}
+
+// isDataSourceContext returns true if the given context was passed to a data source function
+func isDataSourceContext(ctx context.Context) bool {
+	isDataSource, _ := ctx.Value(dataSourceContextKey{}).(bool)
+	return isDataSource
+}
+
+// isDefaultMetadataEntry returns true if the given metadata entry has the same type, value and domain as the default
+// metadata entry with the same key
+func isDefaultMetadataEntry(defaultMetadata map[string]types.MetadataValue, metadataEntry *types.MetadataEntry) bool {
+	defaultValue, found := defaultMetadata[metadataEntry.Key]
+	if !found || metadataEntry.TypedValue == nil || defaultValue.TypedValue == nil {
+		return false
+	}
+	domain := &types.MetadataDomainTag{Visibility: types.MetadataReadWriteVisibility, Domain: "GENERAL"}
+	if metadataEntry.Domain != nil {
+		domain = metadataEntry.Domain
+	}
+	return metadataEntry.TypedValue.XsiType == defaultValue.TypedValue.XsiType &&
+		metadataEntry.TypedValue.Value == defaultValue.TypedValue.Value &&
+		defaultValue.Domain != nil &&
+		domain.Visibility == defaultValue.Domain.Visibility &&
+		domain.Domain == defaultValue.Domain.Domain
+}
+
 // convertFromStateToMetadataValues converts the structure retrieved from Terraform state to a structure compatible
 // with the Go SDK.
 // TODO: Refactor this function once "metadata" attribute is deleted in a future major release.
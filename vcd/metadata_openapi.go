@@ -1,15 +1,17 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
-	"reflect"
-	"strconv"
 )
 
 // openApiMetadataEntryDatasourceSchema returns the schema associated to the OpenAPI metadata_entry for a given data source.
@@ -136,13 +138,13 @@ type openApiMetadataCompatible interface {
 
 // createOrUpdateOpenApiMetadataEntryInVcd creates or updates OpenAPI metadata entries in VCD for the given resource, only if the attribute
 // metadata_entry has been set or updated in the state.
-func createOrUpdateOpenApiMetadataEntryInVcd(d *schema.ResourceData, resource openApiMetadataCompatible) error {
+func createOrUpdateOpenApiMetadataEntryInVcd(ctx context.Context, d *schema.ResourceData, resource openApiMetadataCompatible) error {
 	if !d.HasChange("metadata_entry") {
 		return nil
 	}
 
 	oldRaw, newRaw := d.GetChange("metadata_entry")
-	metadataToAdd, metadataToUpdate, metadataToDelete, err := getOpenApiMetadataOperations(oldRaw.(*schema.Set).List(), newRaw.(*schema.Set).List())
+	metadataToAdd, metadataToUpdate, metadataToDelete, err := getOpenApiMetadataOperations(ctx, oldRaw.(*schema.Set).List(), newRaw.(*schema.Set).List())
 	if err != nil {
 		return fmt.Errorf("could not calculate the needed metadata operations: %s", err)
 	}
@@ -180,7 +182,7 @@ func createOrUpdateOpenApiMetadataEntryInVcd(d *schema.ResourceData, resource op
 
 // getOpenApiMetadataOperations retrieves the metadata that needs to be added, to be updated and to be deleted depending
 // on the old and new attribute values from Terraform state.
-func getOpenApiMetadataOperations(oldMetadata []interface{}, newMetadata []interface{}) ([]types.OpenApiMetadataEntry, []types.OpenApiMetadataEntry, []types.OpenApiMetadataEntry, error) {
+func getOpenApiMetadataOperations(ctx context.Context, oldMetadata []interface{}, newMetadata []interface{}) ([]types.OpenApiMetadataEntry, []types.OpenApiMetadataEntry, []types.OpenApiMetadataEntry, error) {
 	oldMetadataEntries, err := getOpenApiMetadataEntryMap(oldMetadata)
 	if err != nil {
 		return nil, nil, nil, err
@@ -207,7 +209,10 @@ func getOpenApiMetadataOperations(oldMetadata []interface{}, newMetadata []inter
 			// If a metadata property that is not "Value" or "IsPersistent" is changed, it needs to be recreated
 			if oldEntry.IsReadOnly != newEntry.IsReadOnly || oldEntry.KeyValue.Namespace != newEntry.KeyValue.Namespace ||
 				oldEntry.KeyValue.Domain != newEntry.KeyValue.Domain || oldEntry.KeyValue.Value.Type != newEntry.KeyValue.Value.Type {
-				util.Logger.Printf("[DEBUG] entry with namespace '%s' and key '%s' is being deleted and re-created", oldEntry.KeyValue.Namespace, oldEntry.KeyValue.Key)
+				tflog.Debug(ctx, "entry with namespace and key is being deleted and re-created", map[string]interface{}{
+					"namespace": oldEntry.KeyValue.Namespace,
+					"key":       oldEntry.KeyValue.Key,
+				})
 				metadataToRemove = append(metadataToRemove, oldMetadataEntries[newNamespacedKey])
 				metadataToCreate = append(metadataToCreate, newMetadataEntries[newNamespacedKey])
 			} else {
@@ -276,8 +281,8 @@ func getOpenApiMetadataEntryMap(metadataAttribute []interface{}) (map[string]typ
 
 // updateOpenApiMetadataInState updates metadata_entry in the Terraform state for the given receiver object.
 // This can be done as both are Computed, for compatibility reasons.
-func updateOpenApiMetadataInState(d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject openApiMetadataCompatible) diag.Diagnostics {
-	diags := checkIgnoredMetadataConflicts(d, vcdClient, resourceType)
+func updateOpenApiMetadataInState(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject openApiMetadataCompatible) diag.Diagnostics {
+	diags := checkIgnoredMetadataConflicts(ctx, d, vcdClient, resourceType)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
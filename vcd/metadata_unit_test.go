@@ -0,0 +1,118 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_defaultMetadata(t *testing.T) {
+	defaultMetadata, err := convertFromStateToMetadataValues([]interface{}{
+		map[string]interface{}{"key": "owner", "value": "team-a", "type": types.MetadataStringValue, "user_access": types.MetadataReadWriteVisibility, "is_system": false},
+		map[string]interface{}{"key": "env", "value": "prod", "type": types.MetadataStringValue, "user_access": types.MetadataReadWriteVisibility, "is_system": false},
+	})
+	if err != nil {
+		t.Fatalf("error converting default metadata: %s", err)
+	}
+
+	merged := mergeDefaultMetadata(defaultMetadata, map[string]types.MetadataValue{
+		"env":  {TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "test"}},
+		"name": {TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "vm1"}},
+	})
+	expectedValues := map[string]string{"owner": "team-a", "env": "test", "name": "vm1"}
+	if len(merged) != len(expectedValues) {
+		t.Errorf("expected %d merged entries, got %d", len(expectedValues), len(merged))
+	}
+	for key, value := range expectedValues {
+		if merged[key].TypedValue == nil || merged[key].TypedValue.Value != value {
+			t.Errorf("expected merged entry '%s' with value '%s', got %v", key, value, merged[key].TypedValue)
+		}
+	}
+
+	metadataFromVcd := []*types.MetadataEntry{
+		{Key: "owner", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "team-a"}},
+		{Key: "env", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "prod"},
+			Domain: &types.MetadataDomainTag{Visibility: types.MetadataReadWriteVisibility, Domain: "GENERAL"}},
+		{Key: "name", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "vm1"}},
+	}
+	if !isDefaultMetadataEntry(defaultMetadata, metadataFromVcd[0]) || !isDefaultMetadataEntry(defaultMetadata, metadataFromVcd[1]) || isDefaultMetadataEntry(defaultMetadata, metadataFromVcd[2]) {
+		t.Errorf("default metadata entries were not identified correctly")
+	}
+	changedDefault := &types.MetadataEntry{Key: "owner", TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "team-b"}}
+	if isDefaultMetadataEntry(defaultMetadata, changedDefault) {
+		t.Errorf("entry with a value different from the default must not be identified as default")
+	}
+
+	resourceSchema := map[string]*schema.Schema{"metadata_entry": metadataEntryResourceSchema("test")}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"metadata_entry": []interface{}{
+			map[string]interface{}{"key": "env", "value": "prod"},
+			map[string]interface{}{"key": "name", "value": "vm1"},
+		},
+	})
+
+	vcdClient := &VCDClient{VCDClient: &govcd.VCDClient{}, defaultMetadata: defaultMetadata}
+
+	// Data sources keep all entries
+	if got := hideDefaultMetadata(context.WithValue(context.Background(), dataSourceContextKey{}, true), d, vcdClient, "metadata_entry", metadataFromVcd); len(got) != 3 {
+		t.Errorf("expected all entries for a data source, got %d", len(got))
+	}
+
+	// For resources, default entries are hidden unless they are managed in the resource
+	got := hideDefaultMetadata(context.Background(), d, vcdClient, "metadata_entry", metadataFromVcd)
+	if len(got) != 2 || got[0].Key != "env" || got[1].Key != "name" {
+		t.Errorf("expected entries 'env' and 'name', got %v", got)
+	}
+
+	// Default entries missing in VCD are added without value, unless they are ignored
+	got = hideDefaultMetadata(context.Background(), d, vcdClient, "metadata_entry", metadataFromVcd[1:])
+	if len(got) != 3 || got[2].Key != "owner" || got[2].TypedValue != nil {
+		t.Errorf("expected entries 'env', 'name' and 'owner' without value, got %v", got)
+	}
+	vcdClient.Client.IgnoredMetadata = []govcd.IgnoredMetadata{{KeyRegex: regexp.MustCompile("^own")}}
+	got = hideDefaultMetadata(context.Background(), d, vcdClient, "metadata_entry", metadataFromVcd[1:])
+	if len(got) != 2 {
+		t.Errorf("expected ignored default entry not to be added, got %v", got)
+	}
+	vcdClient.Client.IgnoredMetadata = nil
+
+	// The deprecated metadata attribute doesn't get the missing entries
+	if got := hideDefaultMetadata(context.Background(), d, vcdClient, "metadata", metadataFromVcd[2:]); len(got) != 1 {
+		t.Errorf("expected only entry 'name' for the deprecated metadata, got %v", got)
+	}
+
+	// Entries in the state are compared with the defaults, to plan the restore of those changed in VCD
+	stateEntry := map[string]interface{}{"key": "owner", "value": "team-a", "type": types.MetadataStringValue, "user_access": types.MetadataReadWriteVisibility, "is_system": false}
+	if !isDefaultMetadataStateEntry(defaultMetadata, stateEntry) {
+		t.Errorf("state entry with the default value was not identified as default")
+	}
+	for _, changed := range []map[string]interface{}{
+		{"key": "owner", "value": "team-b", "type": types.MetadataStringValue, "user_access": types.MetadataReadWriteVisibility, "is_system": false},
+		{"key": "owner"},
+	} {
+		if isDefaultMetadataStateEntry(defaultMetadata, changed) {
+			t.Errorf("state entry %v must not be identified as default", changed)
+		}
+	}
+}
+
+func Test_withDefaultMetadata(t *testing.T) {
+	resources := withDefaultMetadata(map[string]*schema.Resource{
+		"vcd_with_deprecated": {Schema: map[string]*schema.Schema{"metadata_entry": metadataEntryResourceSchemaDeprecated("test")}},
+		"vcd_not_computed":    {Schema: map[string]*schema.Schema{"metadata_entry": metadataEntryResourceSchema("test")}},
+		"vcd_open_api":        {Schema: map[string]*schema.Schema{"metadata_entry": openApiMetadataEntryResourceSchema("test")}},
+		"vcd_no_metadata":     {Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}}},
+	})
+	for name, resource := range resources {
+		expected := name == "vcd_with_deprecated"
+		if (resource.CustomizeDiff != nil) != expected {
+			t.Errorf("resource %s: expected CustomizeDiff %t", name, expected)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package vcd
 
 import (
-	"log"
 	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Imported from Hashicorp (https://www.terraform.io/docs/extend/guides/v2-upgrade-guide.html)
@@ -23,22 +24,22 @@ type mutexKV struct {
 // for the same key
 func (m *mutexKV) kvLock(key string) {
 	if !m.silent {
-		log.Printf("[DEBUG] Locking %q", key)
+		tflog.Debug(providerLogContext(), "locking", map[string]interface{}{"key": key})
 	}
 	m.get(key).Lock()
 	if !m.silent {
-		log.Printf("[DEBUG] Locked %q", key)
+		tflog.Debug(providerLogContext(), "locked", map[string]interface{}{"key": key})
 	}
 }
 
 // kvUnlock the mutex for the given key. Caller must have called kvLock for the same key first
 func (m *mutexKV) kvUnlock(key string) {
 	if !m.silent {
-		log.Printf("[DEBUG] Unlocking %q", key)
+		tflog.Debug(providerLogContext(), "unlocking", map[string]interface{}{"key": key})
 	}
 	m.get(key).Unlock()
 	if !m.silent {
-		log.Printf("[DEBUG] Unlocked %q", key)
+		tflog.Debug(providerLogContext(), "unlocked", map[string]interface{}{"key": key})
 	}
 }
 
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -191,6 +193,7 @@ var globalDataSourceMap = map[string]*schema.Resource{
 	"vcd_tm_provider_gateway":                          datasourceVcdTmProviderGateway(),                       // 4.0
 	"vcd_tm_edge_cluster":                              datasourceVcdTmEdgeCluster(),                           // 4.0
 	"vcd_tm_edge_cluster_qos":                          datasourceVcdTmEdgeClusterQos(),                        // 4.0
+	"vcd_vm_console_ticket":                            datasourceVcdVmConsoleTicket(),                         // 4.0
 }
 
 var globalResourceMap = map[string]*schema.Resource{
@@ -327,6 +330,7 @@ var globalResourceMap = map[string]*schema.Resource{
 	"vcd_tm_ip_space":                                  resourceVcdTmIpSpace(),                               // 4.0
 	"vcd_tm_provider_gateway":                          resourceVcdTmProviderGateway(),                       // 4.0
 	"vcd_tm_edge_cluster_qos":                          resourceVcdTmEdgeClusterQos(),                        // 4.0
+	"vcd_vm_snapshot":                                  resourceVcdVmSnapshot(),                              // 4.0
 }
 
 // Provider returns a terraform.ResourceProvider.
@@ -351,8 +355,8 @@ func Provider() *schema.Provider {
 				Type:         schema.TypeString,
 				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc("VCD_AUTH_TYPE", "integrated"),
-				Description:  "'integrated', 'saml_adfs', 'token', 'api_token', 'api_token_file' and 'service_account_token_file' are supported. 'integrated' is default.",
-				ValidateFunc: validation.StringInSlice([]string{"integrated", "saml_adfs", "token", "api_token", "api_token_file", "service_account_token_file"}, false),
+				Description:  "'integrated', 'saml_adfs', 'token', 'api_token', 'api_token_file', 'service_account_token_file' and 'oidc_jwt' are supported. 'integrated' is default.",
+				ValidateFunc: validation.StringInSlice([]string{"integrated", "saml_adfs", "token", "api_token", "api_token_file", "service_account_token_file", "oidc_jwt"}, false),
 			},
 
 			"saml_adfs_rpt_id": {
@@ -403,6 +407,23 @@ func Provider() *schema.Provider {
 				Description: "The Service Account API token file instead of username/password for VCD API operations. (Requires VCD 10.4.0+)",
 			},
 
+			"jwt": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCD_JWT", nil),
+				ConflictsWith: []string{"jwt_file"},
+				Description:   "JWT issued by an external identity provider, exchanged for a VCD token with auth_type=oidc_jwt",
+			},
+
+			"jwt_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCD_JWT_FILE", nil),
+				ConflictsWith: []string{"jwt"},
+				Description:   "File containing a JWT issued by an external identity provider, exchanged for a VCD token with auth_type=oidc_jwt",
+			},
+
 			"allow_service_account_token_file": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -452,6 +473,36 @@ func Provider() *schema.Provider {
 				Description: "If set, VCDClient will permit unverifiable SSL certificates.",
 			},
 
+			"ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCD_CA_FILE", nil),
+				ConflictsWith: []string{"ca_pem"},
+				Description:   "File containing one or more PEM encoded certificate authorities trusted to verify the VCD certificates",
+			},
+
+			"ca_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCD_CA_PEM", nil),
+				ConflictsWith: []string{"ca_file"},
+				Description:   "One or more PEM encoded certificate authorities trusted to verify the VCD certificates",
+			},
+
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_PROXY_URL", nil),
+				Description: "URL of the proxy used for all connections to VCD. When not set, HTTPS_PROXY and HTTP_PROXY environment variables are used",
+			},
+
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_NO_PROXY", nil),
+				Description: "Comma-separated list of hosts, domains and CIDRs that are reached without proxy. When not set, NO_PROXY environment variable is used",
+			},
+
 			"logging": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -472,14 +523,69 @@ func Provider() *schema.Provider {
 				Description: "Defines the import separation string to be used with 'terraform import'",
 			},
 			"ignore_metadata_changes": ignoreMetadataSchema(),
+			"default_metadata":        defaultMetadataSchema(),
+			"session_cache_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_SESSION_CACHE_FILE", nil),
+				Description: "File where authenticated sessions are stored, encrypted, to be reused by following Terraform runs. The session cache is disabled when empty",
+			},
+			"session_cache_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_SESSION_CACHE_KEY", nil),
+				Description: "Secret used to identify and encrypt the sessions stored in 'session_cache_file'. When empty, a random secret is generated in the user configuration directory",
+			},
+			"session_cache_max_age": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_SESSION_CACHE_MAX_AGE", 60),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum age, in minutes, of a session stored in 'session_cache_file' before a new login is performed",
+			},
+			"retry": retrySchema(),
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests in flight at the same time. 0 means no limit",
+			},
+			"max_concurrent_operations_per_org": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCD_MAX_CONCURRENT_OPERATIONS_PER_ORG", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of create, update and delete operations running at the same time in each Org. 0 means no limit",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_READ_ONLY", false),
+				Description: "When true, the provider refuses to create, update or delete anything in VCD. Only read requests and logins are sent to the API",
+			},
+			"tenant_context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_TENANT_CONTEXT", nil),
+				Description: "Name or ID of the Organization used as tenant context for all the operations. Only for System administrators",
+			},
+			"audit_log_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCD_AUDIT_LOG_FILE", nil),
+				Description: "File where a JSON line is appended for each create, update, delete and import of a resource",
+			},
 		},
-		ResourcesMap:         globalResourceMap,
-		DataSourcesMap:       globalDataSourceMap,
+		ResourcesMap:         withLogContext(withVersionRequirements(withOrgOperationLimits(withTenantContexts(withAuditLog(withUrnImport(withDefaultMetadata(globalResourceMap))))), resourceVersionRequirements)),
+		DataSourcesMap:       withLogContext(withDataSourceContext(withDataSourceVersionRequirements(globalDataSourceMap, dataSourceVersionRequirements))),
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	setProviderLogContext(ctx)
 	maxRetryTimeout := d.Get("max_retry_timeout").(int)
 
 	if err := validateProviderSchema(d); err != nil {
@@ -502,12 +608,18 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		AllowApiTokenFile:       d.Get("allow_api_token_file").(bool),
 		ServiceAccountTokenFile: d.Get("service_account_token_file").(string),
 		AllowSATokenFile:        d.Get("allow_service_account_token_file").(bool),
+		Jwt:                     d.Get("jwt").(string),
+		JwtFile:                 d.Get("jwt_file").(string),
 		SysOrg:                  connectOrg,            // Connection org
 		Org:                     d.Get("org").(string), // Default org for operations
 		Vdc:                     d.Get("vdc").(string), // Default vdc
 		Href:                    d.Get("url").(string),
 		MaxRetryTimeout:         maxRetryTimeout,
 		InsecureFlag:            d.Get("allow_unverified_ssl").(bool),
+		CaFile:                  d.Get("ca_file").(string),
+		CaPem:                   d.Get("ca_pem").(string),
+		ProxyUrl:                d.Get("proxy_url").(string),
+		NoProxy:                 d.Get("no_proxy").(string),
 	}
 
 	// auth_type dependent configuration
@@ -533,11 +645,18 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		if config.ApiTokenFile == "" {
 			return nil, diag.Errorf("api token file not provided with 'auth_type' == 'service_account_token_file'")
 		}
+	case "oidc_jwt":
+		if config.Jwt == "" && config.JwtFile == "" {
+			return nil, diag.Errorf("neither 'jwt' nor 'jwt_file' provided with 'auth_type' == 'oidc_jwt'")
+		}
 	default:
 		if config.ApiToken != "" || config.Token != "" {
 			return nil, diag.Errorf("to use a token, the appropriate 'auth_type' (either 'token' or 'api_token') must be set")
 		}
 	}
+	if authType != "oidc_jwt" && (config.Jwt != "" || config.JwtFile != "") {
+		return nil, diag.Errorf("'jwt' and 'jwt_file' can only be used with 'auth_type' == 'oidc_jwt'")
+	}
 	if config.ApiToken != "" && config.Token != "" {
 		return nil, diag.Errorf("only one of 'token' or 'api_token' should be set")
 	}
@@ -578,6 +697,11 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 			util.InitLogging()
 		}
 	}
+	// Secrets are removed from the API log, which can also be enabled with GOVCD_LOG environment variables
+	setApiLogRedaction(ctx)
+	for _, secret := range []string{config.Password, config.Token, config.ApiToken, config.Jwt} {
+		apiLogRedactor.addValue(secret)
+	}
 
 	separator := os.Getenv("VCD_IMPORT_SEPARATOR")
 	if separator != "" {
@@ -597,10 +721,37 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		IgnoreMetadataChangesConflictActions[im.IgnoredMetadata.String()] = ignoredMetadata[i].ConflictAction
 	}
 
-	vcdClient, err := config.Client()
+	config.DefaultMetadata, err = convertFromStateToMetadataValues(d.Get("default_metadata").(*schema.Set).List())
+	if err != nil {
+		return nil, diag.Errorf("could not process 'default_metadata': %s", err)
+	}
+
+	config.SessionCacheFile = d.Get("session_cache_file").(string)
+	config.SessionCacheKey = d.Get("session_cache_key").(string)
+	config.SessionCacheMaxAge = time.Duration(d.Get("session_cache_max_age").(int)) * time.Minute
+
+	config.Retry, err = getRetryPolicy(d)
+	if err != nil {
+		return nil, diag.Errorf("invalid 'retry' configuration: %s", err)
+	}
+
+	config.MaxConcurrentRequests = d.Get("max_concurrent_requests").(int)
+	config.MaxConcurrentOrgOperations = d.Get("max_concurrent_operations_per_org").(int)
+	config.ReadOnly = d.Get("read_only").(bool)
+	config.TenantContext = d.Get("tenant_context").(string)
+	config.AuditLogFile = d.Get("audit_log_file").(string)
+
+	vcdClient, err := config.Client(ctx)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	tflog.Debug(ctx, "provider configured", map[string]interface{}{
+		"vcd_url":    config.Href,
+		"vcd_sysorg": config.SysOrg,
+		"vcd_org":    config.Org,
+		"vcd_vdc":    config.Vdc,
+		"read_only":  config.ReadOnly,
+	})
 	return vcdClient, providerDiagnostics
 }
 
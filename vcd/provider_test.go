@@ -67,7 +67,7 @@ func createTemporaryVCDConnection(acceptNil bool) *VCDClient {
 		InsecureFlag:    testConfig.Provider.AllowInsecure,
 		MaxRetryTimeout: testConfig.Provider.MaxRetryTimeout,
 	}
-	conn, err := config.Client()
+	conn, err := config.Client(context.Background())
 	if err != nil {
 		if acceptNil {
 			return nil
@@ -110,7 +110,7 @@ func createSystemTemporaryVCDConnection() *VCDClient {
 		InsecureFlag:    configStruct.Provider.AllowInsecure,
 		MaxRetryTimeout: configStruct.Provider.MaxRetryTimeout,
 	}
-	conn, err := config.Client()
+	conn, err := config.Client(context.Background())
 	if err != nil {
 		panic("unable to initialize VCD connection :" + err.Error())
 	}
@@ -150,7 +150,7 @@ func TestAccClientUserAgent(t *testing.T) {
 		InsecureFlag:    testConfig.Provider.AllowInsecure,
 	}
 
-	vcdClient, err := clientConfig.Client()
+	vcdClient, err := clientConfig.Client(context.Background())
 	if err != nil {
 		t.Fatal("error initializing go-vcloud-director client: " + err.Error())
 	}
@@ -181,7 +181,7 @@ func createOrgVCDConnection(orgSuffix string) *VCDClient {
 		InsecureFlag:    testConfig.Provider.AllowInsecure,
 		MaxRetryTimeout: testConfig.Provider.MaxRetryTimeout,
 	}
-	conn, err := config.Client()
+	conn, err := config.Client(context.Background())
 	if err != nil {
 		panic("unable to initialize VCD connection :" + err.Error())
 	}
@@ -232,7 +232,7 @@ func createSysVCDConnection(vcdUrl, user, password, org string) *VCDClient {
 		InsecureFlag:    testConfig.Provider.AllowInsecure,
 		MaxRetryTimeout: testConfig.Provider.MaxRetryTimeout,
 	}
-	conn, err := config.Client()
+	conn, err := config.Client(context.Background())
 	if err != nil {
 		panic("unable to initialize VCD connection :" + err.Error())
 	}
@@ -0,0 +1,88 @@
+package vcd
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// In read-only mode (provider `read_only` argument) the provider refuses to change anything in VCD. The check is
+// done in two places:
+// * the generic create, update and delete functions fail before calling VCD (see checkReadOnly)
+// * readOnlyTransport, set in the HTTP client used by govcd, fails any request that is not a GET, HEAD or OPTIONS,
+// except for the login endpoints and the POST requests that only read data (see readOnlyPostPathRegex). This covers
+// all the resources, including the ones that don't use the generic functions, and the API calls that change VCD
+// while reading data
+//
+// Data sources and reads that still need a refused POST request, and therefore fail in read-only mode:
+// * vcd_cse_kubernetes_cluster (and the resource read), when the cluster is provisioned: the kubeconfig is retrieved
+// with a RDE behavior invocation, which is refused because behaviors can change the entity
+
+// loginPathRegex matches the endpoints used for authentication. They are allowed in read-only mode and don't receive
+// the tenant context headers (see tenantContextTransport):
+// * /api/sessions - legacy login and SAML
+// * /cloudapi/1.0.0/sessions and /cloudapi/1.0.0/sessions/provider - CloudAPI login
+// * /oauth/provider/token and /oauth/tenant/{org}/token - API tokens and service accounts
+// * /adfs/services/trust/... - SAML token requests to ADFS
+var loginPathRegex = regexp.MustCompile(`(?i)(?:^/api/sessions|^/cloudapi/[\d.]+/sessions(?:/provider)?|^/oauth/(?:provider|tenant/[^/]+)/token|^/adfs/services/trust/.*)/?$`)
+
+// readOnlyPostPathRegex matches the POST requests that don't change VCD and are allowed in read-only mode:
+// * /api/vApp/vm-{id}/screen/action/acquireMksTicket - console ticket of a VM (vcd_vm_console_ticket)
+var readOnlyPostPathRegex = regexp.MustCompile(`(?i)^/api/vApp/vm-[^/]+/screen/action/acquireMksTicket/?$`)
+
+// readOnlyError is the error returned by operations refused in read-only mode
+type readOnlyError struct {
+	operation string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf("%s is not allowed: the provider is configured with read_only = true", e.operation)
+}
+
+// readOnlyTransport is an http.RoundTripper that fails the requests that could change VCD
+type readOnlyTransport struct {
+	transport http.RoundTripper
+}
+
+// newReadOnlyTransport wraps the given transport so that only read requests and logins reach VCD
+func newReadOnlyTransport(transport http.RoundTripper) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &readOnlyTransport{transport: transport}
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isReadOnlyRequestAllowed(req) {
+		return nil, &readOnlyError{operation: fmt.Sprintf("API request %s %s", req.Method, req.URL.Path)}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// isReadOnlyRequestAllowed returns true for the requests that don't change VCD
+func isReadOnlyRequestAllowed(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return isLoginRequest(req) || readOnlyPostPathRegex.MatchString(req.URL.Path)
+	}
+	return false
+}
+
+// isLoginRequest returns true for the requests sent to the authentication endpoints
+func isLoginRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && loginPathRegex.MatchString(req.URL.Path)
+}
+
+// checkReadOnly returns an error diagnostic when the provider is in read-only mode. 'operation' describes what
+// would be done, e.g. "creating vcd_network_pool"
+func checkReadOnly(meta interface{}, operation string) diag.Diagnostics {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || !vcdClient.readOnly {
+		return nil
+	}
+	return diag.FromErr(&readOnlyError{operation: operation})
+}
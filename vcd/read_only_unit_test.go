@@ -0,0 +1,94 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_readOnlyTransport(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Method+" "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newReadOnlyTransport(nil)}
+
+	tests := []struct {
+		method  string
+		path    string
+		allowed bool
+	}{
+		{http.MethodGet, "/api/org", true},
+		{http.MethodHead, "/api/org", true},
+		{http.MethodPost, "/api/sessions", true},
+		{http.MethodPost, "/cloudapi/1.0.0/sessions", true},
+		{http.MethodPost, "/cloudapi/1.0.0/sessions/provider", true},
+		{http.MethodPost, "/oauth/tenant/my-org/token", true},
+		{http.MethodPost, "/oauth/provider/token", true},
+		{http.MethodPost, "/adfs/services/trust/13/usernamemixed", true},
+		{http.MethodPost, "/api/vApp/vm-8a2f1c6e-4d1b-4c1e-9f43-0c0a6f7d1b2a/screen/action/acquireMksTicket", true},
+		{http.MethodPost, "/api/vApp/vm-8a2f1c6e-4d1b-4c1e-9f43-0c0a6f7d1b2a/power/action/powerOn", false},
+		{http.MethodPost, "/cloudapi/1.0.0/entities/urn:vcloud:entity:vmware:capvcdCluster:1/behaviors/getFullEntity/invocations", false},
+		{http.MethodPost, "/cloudapi/1.0.0/edgeGateways", false},
+		{http.MethodPost, "/api/sessions/extra", false},
+		{http.MethodPost, "/oauth/tenant/my-org/register", false},
+		{http.MethodPut, "/cloudapi/1.0.0/edgeGateways/1", false},
+		{http.MethodDelete, "/api/sessions", false},
+		{http.MethodPatch, "/cloudapi/1.0.0/vdcs/1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+tt.path, func(t *testing.T) {
+			received = nil
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				_ = resp.Body.Close()
+				if len(received) != 1 {
+					t.Errorf("expected the request to reach the server")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "read_only = true") {
+				t.Errorf("expected read-only error, got %v", err)
+			}
+			if len(received) != 0 {
+				t.Errorf("request reached the server: %v", received)
+			}
+		})
+	}
+}
+
+func Test_readOnlyGenericCrud(t *testing.T) {
+	c := crudConfig[*fakeUpdateDeleter, struct{}]{entityLabel: "test entity"}
+	meta := &VCDClient{readOnly: true}
+
+	diags := createResource(context.Background(), nil, meta, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "creating test entity is not allowed") {
+		t.Errorf("expected read-only error on create, got %v", diags)
+	}
+	diags = updateResource(context.Background(), nil, meta, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "updating test entity is not allowed") {
+		t.Errorf("expected read-only error on update, got %v", diags)
+	}
+	diags = deleteResource(context.Background(), nil, meta, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "deleting test entity is not allowed") {
+		t.Errorf("expected read-only error on delete, got %v", diags)
+	}
+}
+
+type fakeUpdateDeleter struct{}
+
+func (f *fakeUpdateDeleter) Update(*struct{}) (*fakeUpdateDeleter, error) { return f, nil }
+func (f *fakeUpdateDeleter) Delete() error                                { return nil }
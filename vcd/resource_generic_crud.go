@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 // crudConfig defines a generic approach for managing Terraform resources where the parent entity is
@@ -18,7 +18,7 @@ type crudConfig[O updateDeleter[O, I], I any] struct {
 	entityLabel string
 
 	// getTypeFunc is responsible for converting schema fields to inner type
-	getTypeFunc func(*VCDClient, *schema.ResourceData) (*I, error)
+	getTypeFunc func(context.Context, *VCDClient, *schema.ResourceData) (*I, error)
 	// stateStoreFunc is responsible for storing state
 	stateStoreFunc func(vcdClient *VCDClient, d *schema.ResourceData, outerType O) error
 
@@ -56,28 +56,31 @@ type updateDeleter[O any, I any] interface {
 type outerEntityHook[O any] func(O) error
 
 // schemaHook defines a type for hook that can be fed into generic CRUD operations
-type schemaHook func(*VCDClient, *schema.ResourceData) error
+type schemaHook func(context.Context, *VCDClient, *schema.ResourceData) error
 
 // outerEntityHookInnerEntityType defines a type for hook that will provide retrieved outer entity
 // with a newly computed inner entity type (useful for modifying update body before submitting it)
 type outerEntityHookInnerEntityType[O, I any] func(*schema.ResourceData, O, I) error
 
 func createResource[O updateDeleter[O, I], I any](ctx context.Context, d *schema.ResourceData, meta interface{}, c crudConfig[O, I]) diag.Diagnostics {
+	if diags := checkReadOnly(meta, "creating "+c.entityLabel); diags != nil {
+		return diags
+	}
 	vcdClient := meta.(*VCDClient)
 
-	t, err := c.getTypeFunc(vcdClient, d)
+	t, err := c.getTypeFunc(ctx, vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting %s type on create: %s", c.entityLabel, err)
 	}
 
-	err = execSchemaHook(vcdClient, d, c.preCreateHooks)
+	err = execSchemaHook(ctx, vcdClient, d, c.preCreateHooks)
 	if err != nil {
 		return diag.Errorf("error executing pre-create %s hooks: %s", c.entityLabel, err)
 	}
 
 	createdEntity, err := c.createFunc(t)
 	if err != nil {
-		return diag.Errorf("error creating %s: %s", c.entityLabel, err)
+		return errorDiagnostics(d, err, "error creating %s: %s", c.entityLabel, err)
 	}
 
 	err = c.stateStoreFunc(vcdClient, d, createdEntity)
@@ -89,8 +92,11 @@ func createResource[O updateDeleter[O, I], I any](ctx context.Context, d *schema
 }
 
 func updateResource[O updateDeleter[O, I], I any](ctx context.Context, d *schema.ResourceData, meta interface{}, c crudConfig[O, I]) diag.Diagnostics {
+	if diags := checkReadOnly(meta, "updating "+c.entityLabel); diags != nil {
+		return diags
+	}
 	vcdClient := meta.(*VCDClient)
-	t, err := c.getTypeFunc(vcdClient, d)
+	t, err := c.getTypeFunc(ctx, vcdClient, d)
 	if err != nil {
 		return diag.Errorf("error getting %s type on update: %s", c.entityLabel, err)
 	}
@@ -104,31 +110,34 @@ func updateResource[O updateDeleter[O, I], I any](ctx context.Context, d *schema
 		return diag.Errorf("error getting %s for update: %s", c.entityLabel, err)
 	}
 
-	err = execUpdateEntityHookWithNewInnerType(d, retrievedEntity, t, c.preUpdateHooks)
+	err = execUpdateEntityHookWithNewInnerType(ctx, d, retrievedEntity, t, c.preUpdateHooks)
 	if err != nil {
 		return diag.Errorf("error executing pre-update %s hooks: %s", c.entityLabel, err)
 	}
 
 	_, err = retrievedEntity.Update(t)
 	if err != nil {
-		return diag.Errorf("error updating %s with ID: %s", c.entityLabel, err)
+		return errorDiagnostics(d, err, "error updating %s with ID: %s", c.entityLabel, err)
 	}
 
 	return c.resourceReadFunc(ctx, d, meta)
 }
 
-func readResource[O updateDeleter[O, I], I any](_ context.Context, d *schema.ResourceData, meta interface{}, c crudConfig[O, I]) diag.Diagnostics {
+func readResource[O updateDeleter[O, I], I any](ctx context.Context, d *schema.ResourceData, meta interface{}, c crudConfig[O, I]) diag.Diagnostics {
 	retrievedEntity, err := c.getEntityFunc(d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			util.Logger.Printf("[DEBUG] entity '%s' with ID '%s' not found. Removing from state", c.entityLabel, d.Id())
+			tflog.Debug(ctx, "entity with ID not found. Removing from state", map[string]interface{}{
+				"entity_label": c.entityLabel,
+				"id":           d.Id(),
+			})
 			d.SetId("")
 			return nil
 		}
 		return diag.Errorf("error getting %s: %s", c.entityLabel, err)
 	}
 
-	err = execEntityHook(retrievedEntity, c.readHooks)
+	err = execEntityHook(ctx, retrievedEntity, c.readHooks)
 	if err != nil {
 		return diag.Errorf("error executing read %s hooks: %s", c.entityLabel, err)
 	}
@@ -142,34 +151,37 @@ func readResource[O updateDeleter[O, I], I any](_ context.Context, d *schema.Res
 	return nil
 }
 
-func deleteResource[O updateDeleter[O, I], I any](_ context.Context, d *schema.ResourceData, _ interface{}, c crudConfig[O, I]) diag.Diagnostics {
+func deleteResource[O updateDeleter[O, I], I any](ctx context.Context, d *schema.ResourceData, meta interface{}, c crudConfig[O, I]) diag.Diagnostics {
+	if diags := checkReadOnly(meta, "deleting "+c.entityLabel); diags != nil {
+		return diags
+	}
 	retrievedEntity, err := c.getEntityFunc(d.Id())
 	if err != nil {
 		return diag.Errorf("error getting %s for delete: %s", c.entityLabel, err)
 	}
 
-	err = execEntityHook(retrievedEntity, c.preDeleteHooks)
+	err = execEntityHook(ctx, retrievedEntity, c.preDeleteHooks)
 	if err != nil {
 		return diag.Errorf("error executing pre-delete %s hooks: %s", c.entityLabel, err)
 	}
 
 	err = retrievedEntity.Delete()
 	if err != nil {
-		return diag.Errorf("error deleting %s with ID '%s': %s", c.entityLabel, d.Id(), err)
+		return errorDiagnostics(d, err, "error deleting %s with ID '%s': %s", c.entityLabel, d.Id(), err)
 	}
 
 	return nil
 }
 
-func execSchemaHook(vcdClient *VCDClient, d *schema.ResourceData, runList []schemaHook) error {
+func execSchemaHook(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData, runList []schemaHook) error {
 	if len(runList) == 0 {
-		util.Logger.Printf("[DEBUG] No hooks to execute")
+		tflog.Debug(ctx, "No hooks to execute")
 		return nil
 	}
 
 	var err error
 	for i := range runList {
-		err = runList[i](vcdClient, d)
+		err = runList[i](ctx, vcdClient, d)
 		if err != nil {
 			return fmt.Errorf("error executing hook: %s", err)
 		}
@@ -179,9 +191,9 @@ func execSchemaHook(vcdClient *VCDClient, d *schema.ResourceData, runList []sche
 	return nil
 }
 
-func execEntityHook[O any](outerEntity O, runList []outerEntityHook[O]) error {
+func execEntityHook[O any](ctx context.Context, outerEntity O, runList []outerEntityHook[O]) error {
 	if len(runList) == 0 {
-		util.Logger.Printf("[DEBUG] No hooks to execute")
+		tflog.Debug(ctx, "No hooks to execute")
 		return nil
 	}
 
@@ -197,9 +209,9 @@ func execEntityHook[O any](outerEntity O, runList []outerEntityHook[O]) error {
 	return nil
 }
 
-func execUpdateEntityHookWithNewInnerType[O, I any](d *schema.ResourceData, outerEntity O, newInnerEntity I, runList []outerEntityHookInnerEntityType[O, I]) error {
+func execUpdateEntityHookWithNewInnerType[O, I any](ctx context.Context, d *schema.ResourceData, outerEntity O, newInnerEntity I, runList []outerEntityHookInnerEntityType[O, I]) error {
 	if len(runList) == 0 {
-		util.Logger.Printf("[DEBUG] No hooks to execute")
+		tflog.Debug(ctx, "No hooks to execute")
 		return nil
 	}
 
@@ -239,9 +251,9 @@ type dsReadConfig[O any, I any] struct {
 }
 
 // readDatasource will read a data source by a 'name' field in Terraform schema
-func readDatasource[O any, I any](_ context.Context, d *schema.ResourceData, meta interface{}, c dsReadConfig[O, I]) diag.Diagnostics {
+func readDatasource[O any, I any](ctx context.Context, d *schema.ResourceData, meta interface{}, c dsReadConfig[O, I]) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	err := execSchemaHook(vcdClient, d, c.preReadHooks)
+	err := execSchemaHook(ctx, vcdClient, d, c.preReadHooks)
 	if err != nil {
 		return diag.Errorf("error executing pre-read %s hooks: %s", c.entityLabel, err)
 	}
@@ -249,7 +261,10 @@ func readDatasource[O any, I any](_ context.Context, d *schema.ResourceData, met
 	fieldName := "name"
 	if c.overrideDefaultNameField != "" {
 		fieldName = c.overrideDefaultNameField
-		util.Logger.Printf("[DEBUG] Overriding %s field 'name' to '%s' for datasource lookup", c.entityLabel, c.overrideDefaultNameField)
+		tflog.Debug(ctx, "Overriding field 'name' for datasource lookup", map[string]interface{}{
+			"entity_label": c.entityLabel,
+			"name_field":   c.overrideDefaultNameField,
+		})
 	}
 	entityName := d.Get(fieldName).(string)
 	retrievedEntity, err := c.getEntityFunc(entityName)
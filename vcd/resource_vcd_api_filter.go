@@ -4,15 +4,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"net/url"
-	"strings"
-	"text/tabwriter"
 )
 
 func resourceVcdApiFilter() *schema.Resource {
@@ -76,13 +77,16 @@ func resourceVcdApiFilterRead(ctx context.Context, d *schema.ResourceData, meta
 	return genericVcdApiFilterRead(ctx, d, meta, "resource")
 }
 
-func genericVcdApiFilterRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdApiFilterRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	// ID must be populated during Create, as API filters don't have any other identifier
 	af, err := vcdClient.GetApiFilterById(d.Id())
 	if govcd.ContainsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find API Filter '%s': %s. Removing from state", d.Id(), err)
+		tflog.Info(ctx, "unable to find API Filter. Removing from state", map[string]interface{}{
+			"id":    d.Id(),
+			"error": err,
+		})
 		d.SetId("")
 		return nil
 	}
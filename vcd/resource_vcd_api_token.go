@@ -3,9 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -83,7 +82,7 @@ func resourceVcdApiTokenRead(ctx context.Context, d *schema.ResourceData, meta i
 	token, err := vcdClient.GetTokenById(d.Id())
 	if govcd.ContainsNotFound(err) {
 		d.SetId("")
-		log.Printf("[DEBUG] API token no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "API token no longer exists. Removing from tfstate")
 	}
 	if err != nil {
 		return diag.Errorf("[API token read] error getting API token: %s", err)
@@ -112,9 +111,9 @@ func resourceVcdApiTokenDelete(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceVcdApiTokenImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] API token import initiated")
+	tflog.Trace(ctx, "API token import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 1 {
 		return nil, fmt.Errorf("resource name must be specified as token-name")
 	}
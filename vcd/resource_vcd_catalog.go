@@ -3,25 +3,21 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"github.com/kr/pretty"
-	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
-	"log"
 	"sort"
-	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 // moreUpdateCatalogFunc is a typed func used to pass actions to the catalog update
-type moreUpdateCatalogFunc func(d *schema.ResourceData, vcdClient *VCDClient, catalog *govcd.AdminCatalog, operation string) error
+type moreUpdateCatalogFunc func(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, catalog *govcd.AdminCatalog, operation string) error
 
 func resourceVcdCatalog() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdCatalogCreate,
 		DeleteContext: resourceVcdCatalogDelete,
 		ReadContext:   resourceVcdCatalogRead,
@@ -163,11 +159,11 @@ func resourceVcdCatalog() *schema.Resource {
 				Description: "URL to which other catalogs can subscribe",
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdCatalogCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] Catalog creation initiated")
+	tflog.Trace(ctx, "Catalog creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -193,7 +189,7 @@ func resourceVcdCatalogCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	catalog, err := adminOrg.CreateCatalogWithStorageProfile(name, description, storageProfiles)
 	if err != nil {
-		log.Printf("[TRACE] Error creating Catalog: %#v", err)
+		tflog.Trace(ctx, "Error creating Catalog", map[string]interface{}{"error": err})
 		return diag.Errorf("error creating Catalog: %#v", err)
 	}
 
@@ -207,13 +203,13 @@ func resourceVcdCatalogCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
-	log.Printf("[TRACE] adding metadata for catalog")
-	err = createOrUpdateMetadata(d, catalog, "metadata")
+	tflog.Trace(ctx, "adding metadata for catalog")
+	err = createOrUpdateMetadata(d, vcdClient, catalog, "metadata")
 	if err != nil {
 		return diag.Errorf("error adding catalog metadata: %s", err)
 	}
 
-	log.Printf("[TRACE] Catalog created: %#v", catalog)
+	tflog.Trace(ctx, "Catalog created", map[string]interface{}{"catalog_name": catalog.AdminCatalog.Name})
 	return resourceVcdCatalogRead(ctx, d, meta)
 }
 
@@ -230,9 +226,9 @@ func updatePublishToExternalOrgSettings(d *schema.ResourceData, adminCatalog *go
 	return nil
 }
 
-func resourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCatalogRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	log.Printf("[TRACE] Catalog read initiated")
+	tflog.Trace(ctx, "Catalog read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -244,7 +240,7 @@ func resourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta inte
 	adminCatalog, err := adminOrg.GetAdminCatalogByNameOrId(d.Id(), false)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("[DEBUG] Unable to find catalog. Removing from tfstate")
+			tflog.Debug(ctx, "Unable to find catalog. Removing from tfstate")
 			d.SetId("")
 			return nil
 		}
@@ -281,7 +277,7 @@ func resourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta inte
 		dSet(d, "password", "")
 	}
 
-	err = setCatalogData(d, vcdClient, adminOrg.AdminOrg.Name, adminOrg.AdminOrg.ID, adminCatalog)
+	err = setCatalogData(ctx, d, vcdClient, adminOrg.AdminOrg.Name, adminOrg.AdminOrg.ID, adminCatalog)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -289,12 +285,12 @@ func resourceVcdCatalogRead(_ context.Context, d *schema.ResourceData, meta inte
 	dSet(d, "href", adminCatalog.AdminCatalog.HREF)
 	d.SetId(adminCatalog.AdminCatalog.ID)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_catalog", adminCatalog)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_catalog", adminCatalog)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to update catalog metadata: %v", diags)
+		tflog.Debug(ctx, "Unable to update catalog metadata", map[string]interface{}{"diags": diags})
 		return diags
 	}
-	log.Printf("[TRACE] Catalog read completed: %#v", adminCatalog.AdminCatalog)
+	tflog.Trace(ctx, "Catalog read completed", map[string]interface{}{"catalog_name": adminCatalog.AdminCatalog.Name})
 
 	// This must be checked at the end as updateMetadataInStateDeprecated can throw Warning diagnostics
 	if len(diags) > 0 {
@@ -322,7 +318,7 @@ func genericResourceVcdCatalogUpdate(ctx context.Context, d *schema.ResourceData
 
 	adminCatalog, err := adminOrg.GetAdminCatalogByNameOrId(d.Id(), false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog. Removing from tfstate")
+		tflog.Debug(ctx, "Unable to find catalog. Removing from tfstate")
 		d.SetId("")
 		return diag.Errorf("error retrieving catalog %s : %s", d.Id(), err)
 	}
@@ -381,8 +377,8 @@ func genericResourceVcdCatalogUpdate(ctx context.Context, d *schema.ResourceData
 			}
 		}
 
-		log.Printf("[TRACE] updating metadata for catalog")
-		err = createOrUpdateMetadata(d, adminCatalog, "metadata")
+		tflog.Trace(ctx, "updating metadata for catalog")
+		err = createOrUpdateMetadata(d, vcdClient, adminCatalog, "metadata")
 		if err != nil {
 			return diag.Errorf("error updating catalog metadata: %s", err)
 		}
@@ -391,7 +387,7 @@ func genericResourceVcdCatalogUpdate(ctx context.Context, d *schema.ResourceData
 	// If there are custom catalog update functions, we run them one at the time
 	for _, f := range mucf {
 		if f != nil {
-			err = f(d, vcdClient, newAdminCatalog, "update")
+			err = f(ctx, d, vcdClient, newAdminCatalog, "update")
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -400,8 +396,8 @@ func genericResourceVcdCatalogUpdate(ctx context.Context, d *schema.ResourceData
 	return readFunc(ctx, d, meta)
 }
 
-func resourceVcdCatalogDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] Catalog delete started")
+func resourceVcdCatalogDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "Catalog delete started")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -412,7 +408,7 @@ func resourceVcdCatalogDelete(_ context.Context, d *schema.ResourceData, meta in
 
 	sessionText := fmt.Sprintf("[catalog delete - org: %s - user: %s]", sessionInfo.Org.Name, sessionInfo.User.Name)
 
-	result, err := runWithRetry(sessionText,
+	result, err := runWithRetry(ctx, sessionText,
 		fmt.Sprintf("%s error fetching org '%s'", sessionText, d.Get("org").(string)),
 		time.Second*30,
 		nil,
@@ -428,18 +424,18 @@ func resourceVcdCatalogDelete(_ context.Context, d *schema.ResourceData, meta in
 
 	adminCatalog, err := adminOrg.GetAdminCatalogByNameOrId(d.Id(), false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog. Removing from tfstate")
+		tflog.Debug(ctx, "Unable to find catalog. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
 
 	err = adminCatalog.Delete(d.Get("delete_force").(bool), d.Get("delete_recursive").(bool))
 	if err != nil {
-		log.Printf("[DEBUG] Error removing catalog %#v", err)
+		tflog.Debug(ctx, "Error removing catalog", map[string]interface{}{"error": err})
 		return diag.Errorf("error removing catalog %#v", err)
 	}
 
-	log.Printf("[TRACE] Catalog delete completed: %#v", adminCatalog.AdminCatalog)
+	tflog.Trace(ctx, "Catalog delete completed", map[string]interface{}{"catalog_name": adminCatalog.AdminCatalog.Name})
 	return nil
 }
 
@@ -450,7 +446,7 @@ func resourceVcdCatalogDelete(_ context.Context, d *schema.ResourceData, meta in
 // Example import path (id): org_name.catalog_name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdCatalogImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog")
 	}
@@ -481,11 +477,11 @@ func resourceVcdCatalogImport(ctx context.Context, d *schema.ResourceData, meta
 	return []*schema.ResourceData{d}, nil
 }
 
-func setCatalogData(d *schema.ResourceData, vcdClient *VCDClient, orgName, orgId string, adminCatalog *govcd.AdminCatalog) error {
+func setCatalogData(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, orgName, orgId string, adminCatalog *govcd.AdminCatalog) error {
 	// Catalog record is retrieved to get the owner name, number of vApp templates and medias, and if the catalog is shared and published
 	catalogRecords, err := vcdClient.VCDClient.Client.QueryCatalogRecords(adminCatalog.AdminCatalog.Name, govcd.TenantContext{OrgName: orgName, OrgId: orgId})
 	if err != nil {
-		log.Printf("[DEBUG] [setCatalogData] Unable to retrieve catalog records: %s", err)
+		tflog.Debug(ctx, "[setCatalogData] Unable to retrieve catalog records", map[string]interface{}{"error": err})
 		return fmt.Errorf("[setCatalogData] unable to retrieve catalog records - %s", err)
 	}
 	var catalogRecord *types.CatalogRecord
@@ -500,7 +496,10 @@ func setCatalogData(d *schema.ResourceData, vcdClient *VCDClient, orgName, orgId
 		return fmt.Errorf("[setCatalogData] error retrieving catalog record for catalog '%s' from org '%s'", adminCatalog.AdminCatalog.Name, orgName)
 	}
 
-	util.Logger.Printf("[setCatalogData] catalogRecord %# v\n", pretty.Formatter(catalogRecord))
+	tflog.Debug(ctx, "[setCatalogData] catalogRecord", map[string]interface{}{
+		"catalog_name":    catalogRecord.Name,
+		"catalog_version": catalogRecord.Version,
+	})
 	dSet(d, "catalog_version", catalogRecord.Version)
 	dSet(d, "owner_name", catalogRecord.OwnerName)
 	dSet(d, "is_published", catalogRecord.IsPublished)
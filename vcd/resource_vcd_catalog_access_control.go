@@ -3,14 +3,14 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
-	"strings"
-	"time"
 )
 
 func resourceVcdCatalogAccessControl() *schema.Resource {
@@ -149,7 +149,7 @@ func resourceVcdCatalogAccessControlCreateUpdate(ctx context.Context, d *schema.
 		EveryoneAccessLevel: everyoneAccessLevel,
 		AccessSettings:      accessSettingsList,
 	}
-	_, err = runWithRetry(sessionText,
+	_, err = runWithRetry(ctx, sessionText,
 		"error when setting Catalog control access parameters",
 		time.Second*30,
 		nil, //func() error { return catalog.Refresh() },
@@ -176,7 +176,7 @@ func resourceVcdCatalogAccessControlRead(ctx context.Context, d *schema.Resource
 	return genericVcdCatalogAccessControlRead(ctx, d, meta, "resource")
 }
 
-func genericVcdCatalogAccessControlRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdCatalogAccessControlRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	sessionInfo, err := vcdClient.Client.GetSessionInfo()
 	if err != nil {
@@ -205,7 +205,7 @@ func genericVcdCatalogAccessControlRead(_ context.Context, d *schema.ResourceDat
 	sessionText = fmt.Sprintf("[ vcd_catalog_access_control read - org: %s - user: %s - catalog: %s]",
 		sessionInfo.Org.Name, sessionInfo.User.Name, catalog.Catalog.Name)
 
-	sharedReadOnly, err := runWithRetry(sessionText,
+	sharedReadOnly, err := runWithRetry(ctx, sessionText,
 		fmt.Sprintf("%s error checking catalog read-only sharing status", sessionText),
 		time.Second*30,
 		nil,
@@ -219,7 +219,7 @@ func genericVcdCatalogAccessControlRead(_ context.Context, d *schema.ResourceDat
 
 	dSet(d, "read_only_shared_with_all_orgs", sharedReadOnly.(bool))
 
-	result, err := runWithRetry(
+	result, err := runWithRetry(ctx,
 		fmt.Sprintf("%s getting control access parameters", sessionText),
 		fmt.Sprintf("%s error getting control access parameters", sessionText),
 		time.Second*30,
@@ -256,7 +256,7 @@ func genericVcdCatalogAccessControlRead(_ context.Context, d *schema.ResourceDat
 	return nil
 }
 
-func resourceVcdCatalogAccessControlDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCatalogAccessControlDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// When deleting Catalog access control, Catalog won't be shared with anyone
 	vcdClient := meta.(*VCDClient)
 
@@ -276,7 +276,7 @@ func resourceVcdCatalogAccessControlDelete(_ context.Context, d *schema.Resource
 	}
 
 	if sharedReadOnly {
-		_, err = runWithRetry(fmt.Sprintf("removing catalog '%s' shared read-only ", catalog.AdminCatalog.Name),
+		_, err = runWithRetry(ctx, fmt.Sprintf("removing catalog '%s' shared read-only ", catalog.AdminCatalog.Name),
 			"error removing catalog read-only access control",
 			30*time.Second,
 			nil,
@@ -290,7 +290,7 @@ func resourceVcdCatalogAccessControlDelete(_ context.Context, d *schema.Resource
 		d.SetId("")
 		return nil
 	}
-	_, err = runWithRetry(fmt.Sprintf("deleting catalog %s access control", catalog.AdminCatalog.Name),
+	_, err = runWithRetry(ctx, fmt.Sprintf("deleting catalog %s access control", catalog.AdminCatalog.Name),
 		fmt.Sprintf("error when deleting catalog '%s' access control", catalog.AdminCatalog.Name),
 		time.Second*30,
 		nil,
@@ -306,8 +306,8 @@ func resourceVcdCatalogAccessControlDelete(_ context.Context, d *schema.Resource
 	return nil
 }
 
-func resourceVcdCatalogAccessControlImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdCatalogAccessControlImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalogID or org.catalogName")
 	}
@@ -337,7 +337,7 @@ func resourceVcdCatalogAccessControlImport(_ context.Context, d *schema.Resource
 // * timeout is for how long we retry in case of failure
 // * preRun is an (optional) operation to run before attempting the operation
 // * operation is the main operation we are running
-func runWithRetry(operationDescription, errorMessage string, timeout time.Duration, preRun func() error, operation func() (any, error)) (any, error) {
+func runWithRetry(ctx context.Context, operationDescription, errorMessage string, timeout time.Duration, preRun func() error, operation func() (any, error)) (any, error) {
 	if operation == nil {
 		return nil, fmt.Errorf("argument 'operation' cannot be null")
 	}
@@ -357,7 +357,11 @@ func runWithRetry(operationDescription, errorMessage string, timeout time.Durati
 		}
 		result, err = operation()
 		if err == nil {
-			util.Logger.Printf("%s attempts: %d - elapsed: %s\n", operationDescription, attempts, elapsed)
+			tflog.Debug(ctx, "operation retried", map[string]interface{}{
+				"operation_description": operationDescription,
+				"attempts":              attempts,
+				"elapsed":               elapsed,
+			})
 			return result, nil
 		}
 		elapsed = time.Since(start)
@@ -3,17 +3,17 @@ package vcd
 import (
 	"context"
 	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 )
 
 func resourceVcdCatalogItem() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdCatalogItemCreate,
 		DeleteContext: resourceVcdCatalogItemDelete,
 		ReadContext:   resourceVcdCatalogItemRead,
@@ -87,11 +87,11 @@ func resourceVcdCatalogItem() *schema.Resource {
 				ConflictsWith: []string{"metadata_entry"},
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdCatalogItemCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] Catalog item creation initiated")
+	tflog.Trace(ctx, "Catalog item creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -103,16 +103,19 @@ func resourceVcdCatalogItemCreate(ctx context.Context, d *schema.ResourceData, m
 	catalogName := d.Get("catalog").(string)
 	catalog, err := vcdClient.Client.GetCatalogByName(orgName, catalogName)
 	if err != nil {
-		log.Printf("[DEBUG] Error finding Catalog: %s", err)
+		tflog.Debug(ctx, "Error finding Catalog", map[string]interface{}{"error": err})
 		return diag.Errorf("error finding Catalog: %s", err)
 	}
 
 	var diagError diag.Diagnostics
 	itemName := d.Get("name").(string)
+	// vcd_catalog_item does not define a 'timeouts' block, so uploads are not bounded by the
+	// default Terraform timeout. The log fields of the context are kept
+	uploadCtx := context.WithoutCancel(ctx)
 	if d.Get("ova_path").(string) != "" {
-		diagError = uploadOvaFromFilePath(d, catalog, itemName, "vcd_catalog_item")
+		diagError = uploadOvaFromFilePath(uploadCtx, d, catalog, itemName, "vcd_catalog_item")
 	} else if d.Get("ovf_url").(string) != "" {
-		diagError = uploadFromUrl(d, catalog, itemName, "vcd_catalog_item")
+		diagError = uploadFromUrl(uploadCtx, d, catalog, itemName, "vcd_catalog_item")
 	} else {
 		return diag.Errorf("`ova_path` or `ovf_url` value is missing %s", err)
 	}
@@ -126,9 +129,9 @@ func resourceVcdCatalogItemCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	d.SetId(item.CatalogItem.ID)
 
-	log.Printf("[TRACE] Catalog item created: %s", itemName)
+	tflog.Trace(ctx, "Catalog item created", map[string]interface{}{"item_name": itemName})
 
-	err = createOrUpdateCatalogItemMetadata(d, meta)
+	err = createOrUpdateCatalogItemMetadata(ctx, d, meta)
 	if diagError != nil {
 		return diag.FromErr(err)
 	}
@@ -136,19 +139,19 @@ func resourceVcdCatalogItemCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceVcdCatalogItemRead(ctx, d, meta)
 }
 
-func resourceVcdCatalogItemRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdCatalogItemRead(d, meta, "resource")
+func resourceVcdCatalogItemRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdCatalogItemRead(ctx, d, meta, "resource")
 }
 
-func genericVcdCatalogItemRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdCatalogItemRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	catalogItem, err := findCatalogItem(d, vcdClient, origin)
+	catalogItem, err := findCatalogItem(ctx, d, vcdClient, origin)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog item: %s", err)
+		tflog.Debug(ctx, "Unable to find catalog item", map[string]interface{}{"error": err})
 		return diag.Errorf("Unable to find catalog item: %s", err)
 	}
 	if catalogItem == nil {
-		log.Printf("[DEBUG] Unable to find catalog item: %s. Removing from tfstate", err)
+		tflog.Debug(ctx, "Unable to find catalog item. Removing from tfstate", map[string]interface{}{"error": err})
 		return diag.Errorf("Unable to find catalog item")
 	}
 
@@ -162,7 +165,7 @@ func genericVcdCatalogItemRead(d *schema.ResourceData, meta interface{}, origin
 	dSet(d, "description", catalogItem.CatalogItem.Description)
 
 	// Catalog item metadata:
-	// We can't use updateMetadataInStateDeprecated(d, catalogItem) because the attribute name is different.
+	// We can't use updateMetadataInStateDeprecated(ctx, d, catalogItem) because the attribute name is different.
 
 	// We temporarily remove the ignored metadata filter to retrieve the deprecated metadata and vApp Template metadata contents,
 	// which should not be affected by it.
@@ -199,7 +202,7 @@ func genericVcdCatalogItemRead(d *schema.ResourceData, meta interface{}, origin
 	}
 
 	// We finally enter the `metadata_entry` metadata with filtering enabled
-	diagErr := checkIgnoredMetadataConflicts(d, vcdClient, "vcd_catalog_item")
+	diagErr := checkIgnoredMetadataConflicts(ctx, d, vcdClient, "vcd_catalog_item")
 	if diagErr != nil {
 		return diagErr
 	}
@@ -209,7 +212,7 @@ func genericVcdCatalogItemRead(d *schema.ResourceData, meta interface{}, origin
 		return diag.Errorf("Unable to find catalog item's metadata: %s", err)
 	}
 
-	err = setMetadataEntryInState(d, metadata.MetadataEntry)
+	err = setMetadataEntryInState(ctx, d, vcdClient, metadata.MetadataEntry)
 	if err != nil {
 		return diag.Errorf("Unable to set catalog item's metadata entries: %s", err)
 	}
@@ -217,19 +220,19 @@ func genericVcdCatalogItemRead(d *schema.ResourceData, meta interface{}, origin
 	return nil
 }
 
-func resourceVcdCatalogItemDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return deleteCatalogItem(d, meta.(*VCDClient))
+func resourceVcdCatalogItemDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteCatalogItem(ctx, d, meta.(*VCDClient))
 }
 
-func resourceVcdCatalogItemUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCatalogItemUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.HasChange("description") || d.HasChange("name") {
-		catalogItem, err := findCatalogItem(d, meta.(*VCDClient), "resource")
+		catalogItem, err := findCatalogItem(ctx, d, meta.(*VCDClient), "resource")
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find media item: %s", err)
+			tflog.Debug(ctx, "Unable to find media item", map[string]interface{}{"error": err})
 			return diag.Errorf("Unable to find media item: %s", err)
 		}
 		if catalogItem == nil {
-			log.Printf("[DEBUG] Unable to find media item: %s. Removing from tfstate", err)
+			tflog.Debug(ctx, "Unable to find media item. Removing from tfstate", map[string]interface{}{"error": err})
 			return diag.Errorf("Unable to find media item")
 		}
 
@@ -246,20 +249,20 @@ func resourceVcdCatalogItemUpdate(_ context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	err := createOrUpdateCatalogItemMetadata(d, meta)
+	err := createOrUpdateCatalogItemMetadata(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("error updating catalog item metadata: %s", err)
 	}
 	return nil
 }
 
-func createOrUpdateCatalogItemMetadata(d *schema.ResourceData, meta interface{}) error {
+func createOrUpdateCatalogItemMetadata(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 
-	log.Printf("[TRACE] adding/updating metadata for catalog item")
+	tflog.Trace(ctx, "adding/updating metadata for catalog item")
 
-	catalogItem, err := findCatalogItem(d, meta.(*VCDClient), "resource")
+	catalogItem, err := findCatalogItem(ctx, d, meta.(*VCDClient), "resource")
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find media item: %s", err)
+		tflog.Debug(ctx, "Unable to find media item", map[string]interface{}{"error": err})
 		return fmt.Errorf("%s", err)
 	}
 
@@ -270,7 +273,7 @@ func createOrUpdateCatalogItemMetadata(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	err = createOrUpdateMetadata(d, catalogItem, "catalog_item_metadata")
+	err = createOrUpdateMetadata(d, meta.(*VCDClient), catalogItem, "catalog_item_metadata")
 	if err != nil {
 		return err
 	}
@@ -311,8 +314,8 @@ func createOrUpdateCatalogItemMetadata(d *schema.ResourceData, meta interface{})
 //
 // Example import path (id): org_name.catalog_name.catalog_item_name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdCatalogItemImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdCatalogItemImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog.catalog_item")
 	}
@@ -3,12 +3,11 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"path"
-	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,7 +15,7 @@ import (
 )
 
 func resourceVcdCatalogMedia() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdMediaCreate,
 		DeleteContext: resourceVcdMediaDelete,
 		ReadContext:   resourceVcdMediaRead,
@@ -137,11 +136,11 @@ func resourceVcdCatalogMedia() *schema.Resource {
 				Description: "Catalog Item ID of this media item",
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] Catalog media creation initiated")
+	tflog.Trace(ctx, "Catalog media creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -164,7 +163,7 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 		catalog, err = vcdClient.Client.GetCatalogById(catalogId)
 	}
 	if err != nil {
-		log.Printf("Error finding Catalog: %s", err)
+		tflog.Debug(ctx, "Error finding Catalog", map[string]interface{}{"error": err})
 		return diag.Errorf("error finding Catalog: %s", err)
 	}
 
@@ -179,13 +178,13 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 		task, err = catalog.UploadMediaImage(mediaName, d.Get("description").(string), mediaPath, int64(uploadPieceSize)*1024*1024) // Convert from megabytes to bytes)
 	}
 	if err != nil {
-		log.Printf("Error uploading new catalog media: %s", err)
+		tflog.Debug(ctx, "Error uploading new catalog media", map[string]interface{}{"error": err})
 		return diag.Errorf("error uploading new catalog media: %s", err)
 	}
 
 	if d.Get("show_upload_progress").(bool) {
 		for {
-			if err := getError(task); err != nil {
+			if err := getError(ctx, task); err != nil {
 				return diag.FromErr(err)
 			}
 
@@ -201,7 +200,7 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 		for {
 			progress, err := task.GetTaskProgress()
 			if err != nil {
-				log.Printf("VCD Error importing new catalog item: %s", err)
+				tflog.Debug(ctx, "VCD Error importing new catalog item", map[string]interface{}{"error": err})
 				return diag.Errorf("VCD Error importing new catalog item: %s", err)
 			}
 			logForScreen("vcd_catalog_media", fmt.Sprintf("vcd_catalog_media.%s: VCD import catalog item progress %s%%\n", mediaName, progress))
@@ -221,9 +220,9 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("error waiting for task to complete: %+v", err)
 	}
 
-	log.Printf("[TRACE] Catalog media created: %#v", mediaName)
+	tflog.Trace(ctx, "Catalog media created", map[string]interface{}{"media_name": mediaName})
 
-	err = createOrUpdateMediaItemMetadata(d, meta)
+	err = createOrUpdateMediaItemMetadata(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("error adding media item metadata: %s", err)
 	}
@@ -231,11 +230,11 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 	return resourceVcdMediaRead(ctx, d, meta)
 }
 
-func resourceVcdMediaRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdMediaRead(d, meta, "resource")
+func resourceVcdMediaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdMediaRead(ctx, d, meta, "resource")
 }
 
-func genericVcdMediaRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdMediaRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
 
@@ -255,7 +254,7 @@ func genericVcdMediaRead(d *schema.ResourceData, meta interface{}, origin string
 		catalog, err = vcdClient.Client.GetCatalogById(catalogId)
 	}
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog.")
+		tflog.Debug(ctx, "Unable to find catalog")
 		return diag.Errorf("unable to find catalog: %s", err)
 	}
 
@@ -286,12 +285,15 @@ func genericVcdMediaRead(d *schema.ResourceData, meta interface{}, origin string
 		media, err = catalog.GetMediaByNameOrId(identifier, false)
 	}
 	if govcd.IsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find media with ID %s: %s. Removing from state", identifier, err)
+		tflog.Info(ctx, "unable to find media. Removing from state", map[string]interface{}{
+			"identifier": identifier,
+			"error":      err,
+		})
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find media: %s", err)
+		tflog.Debug(ctx, "Unable to find media", map[string]interface{}{"error": err})
 		return diag.FromErr(err)
 	}
 
@@ -299,7 +301,7 @@ func genericVcdMediaRead(d *schema.ResourceData, meta interface{}, origin string
 
 	mediaRecord, err := catalog.QueryMedia(media.Media.Name)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to query media: %s", err)
+		tflog.Debug(ctx, "Unable to query media", map[string]interface{}{"error": err})
 		return diag.FromErr(err)
 	}
 
@@ -333,9 +335,9 @@ func genericVcdMediaRead(d *schema.ResourceData, meta interface{}, origin string
 			}
 		}
 	}
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_catalog_media", media)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_catalog_media", media)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to update media item metadata: %v", diags)
+		tflog.Debug(ctx, "Unable to update media item metadata", map[string]interface{}{"diags": diags})
 		return diags
 	}
 
@@ -346,22 +348,22 @@ func genericVcdMediaRead(d *schema.ResourceData, meta interface{}, origin string
 	return nil
 }
 
-func resourceVcdMediaDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return deleteCatalogItem(d, meta.(*VCDClient))
+func resourceVcdMediaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteCatalogItem(ctx, d, meta.(*VCDClient))
 }
 
 // currently updates only metadata
 func resourceVcdMediaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	err := createOrUpdateMediaItemMetadata(d, meta)
+	err := createOrUpdateMediaItemMetadata(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("error updating media item metadata: %s", err)
 	}
 	return resourceVcdMediaRead(ctx, d, meta)
 }
 
-func createOrUpdateMediaItemMetadata(d *schema.ResourceData, meta interface{}) error {
+func createOrUpdateMediaItemMetadata(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 
-	log.Printf("[TRACE] adding/updating metadata for media item")
+	tflog.Trace(ctx, "adding/updating metadata for media item")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -380,17 +382,17 @@ func createOrUpdateMediaItemMetadata(d *schema.ResourceData, meta interface{}) e
 		catalog, err = vcdClient.Client.GetCatalogById(catalogId)
 	}
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find catalog.")
+		tflog.Debug(ctx, "Unable to find catalog")
 		return fmt.Errorf("unable to find catalog: %s", err)
 	}
 
 	media, err := catalog.GetMediaByName(d.Get("name").(string), false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find media item: %s", err)
+		tflog.Debug(ctx, "Unable to find media item", map[string]interface{}{"error": err})
 		return fmt.Errorf("unable to find media item: %s", err)
 	}
 
-	return createOrUpdateMetadata(d, media, "metadata")
+	return createOrUpdateMetadata(d, vcdClient, media, "metadata")
 }
 
 // resourceVcdCatalogMediaImport is responsible for importing the resource.
@@ -405,8 +407,8 @@ func createOrUpdateMediaItemMetadata(d *schema.ResourceData, meta interface{}) e
 //
 // Example resource name (_resource_name_): vcd_catalog_media.my-media
 // Example import path (_the_id_string_): org.catalog.my-media-name
-func resourceVcdCatalogMediaImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdCatalogMediaImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog.my-media-name")
 	}
@@ -3,21 +3,27 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 const maximumSynchronisationCheckDuration = 60 * time.Second
 
+// Default values for the 'timeouts' block of `vcd_catalog_vapp_template`
+const (
+	vappTemplateDefaultCreateTimeout = 120 * time.Minute
+	vappTemplateDefaultUpdateTimeout = 20 * time.Minute
+	vappTemplateDefaultDeleteTimeout = 30 * time.Minute
+)
+
 func resourceVcdCatalogVappTemplate() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdCatalogVappTemplateCreate,
 		ReadContext:   resourceVcdCatalogVappTemplateRead,
 		UpdateContext: resourceVcdCatalogVappTemplateUpdate,
@@ -25,6 +31,7 @@ func resourceVcdCatalogVappTemplate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogVappTemplateImport,
 		},
+		Timeouts: resourceTimeouts(vappTemplateDefaultCreateTimeout, vappTemplateDefaultUpdateTimeout, vappTemplateDefaultDeleteTimeout),
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -159,7 +166,7 @@ func resourceVcdCatalogVappTemplate() *schema.Resource {
 				Description: "A map that contains metadata that is automatically added by VCD (10.5.1+) and provides details on the origin of the VM",
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -168,7 +175,7 @@ func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.Resourc
 	catalogId := d.Get("catalog_id").(string)
 	catalog, err := vcdClient.Client.GetCatalogById(catalogId)
 	if err != nil {
-		log.Printf("[DEBUG] Error finding Catalog: %s", err)
+		tflog.Debug(ctx, "Error finding Catalog", map[string]interface{}{"error": err})
 		return diag.Errorf("error finding Catalog: %s", err)
 	}
 
@@ -182,9 +189,9 @@ func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.Resourc
 
 	switch {
 	case ovaPath != "":
-		diagError = uploadOvaFromFilePath(d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
+		diagError = uploadOvaFromFilePath(ctx, d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
 	case ovfUrl != "":
-		diagError = uploadFromUrl(d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
+		diagError = uploadFromUrl(ctx, d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
 	case len(capturevAppTemplate) == 1:
 		templateCaptureSettings := capturevAppTemplate[0].(map[string]interface{})
 		sourceId := templateCaptureSettings["source_id"].(string)
@@ -245,9 +252,18 @@ func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.Resourc
 		unlock := vcdClient.lockVappWithName(org.Org.Name, parentVdc.Vdc.Name, vapp.VApp.Name)
 		defer unlock()
 
-		createdTemplate, err := catalog.CaptureVappTemplate(vAppCaptureParams)
+		task, err := catalog.CaptureVappTemplateAsync(vAppCaptureParams)
 		if err != nil {
-			return diag.FromErr(err)
+			return diag.Errorf("error capturing vApp Template from vApp %s: %s", vapp.VApp.Name, err)
+		}
+		// After the task is finished, its owner is the captured vApp Template
+		templateHref, err := waitTaskOwnerWithContext(ctx, task)
+		if err != nil {
+			return errorDiagnostics(d, err, "error capturing vApp Template from vApp %s: %s", vapp.VApp.Name, err)
+		}
+		createdTemplate, err := catalog.GetVappTemplateByHref(templateHref)
+		if err != nil {
+			return diag.Errorf("error retrieving captured vApp Template: %s", err)
 		}
 
 		// Explicitly rename created template to what is specified in `name` field because by
@@ -273,13 +289,13 @@ func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("error retrieving vApp Template %s: %s", vappTemplateName, err)
 	}
 
-	err = createOrUpdateMetadata(d, vAppTemplate, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, vAppTemplate, "metadata")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(vAppTemplate.VAppTemplate.ID)
-	log.Printf("[TRACE] Catalog vApp Template created: %s", vappTemplateName)
+	tflog.Trace(ctx, "Catalog vApp Template created", map[string]interface{}{"vapp_template_name": vappTemplateName})
 
 	err = vappTemplateLeaseUpdate(vcdClient, vAppTemplate, d)
 	if err != nil {
@@ -294,12 +310,12 @@ func resourceVcdCatalogVappTemplateRead(ctx context.Context, d *schema.ResourceD
 
 // genericVcdCatalogVappTemplateRead performs a Read operation for the vApp Template resource (origin="resource")
 // and data source (origin="datasource").
-func genericVcdCatalogVappTemplateRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdCatalogVappTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
-	vAppTemplate, err := findVAppTemplate(d, vcdClient, origin)
+	vAppTemplate, err := findVAppTemplate(ctx, d, vcdClient, origin)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find vApp Template: %s", err)
+		tflog.Debug(ctx, "Unable to find vApp Template", map[string]interface{}{"error": err})
 		return diag.Errorf("Unable to find vApp Template: %s", err)
 	}
 
@@ -357,7 +373,7 @@ func genericVcdCatalogVappTemplateRead(_ context.Context, d *schema.ResourceData
 
 	d.SetId(vAppTemplate.VAppTemplate.ID)
 
-	catalogItemId, err := runWithRetry("retrieving catalog item ID",
+	catalogItemId, err := runWithRetry(ctx, "retrieving catalog item ID",
 		"error retrieving Catalog Item ID for vApp template",
 		10*time.Second,
 		func() error {
@@ -371,7 +387,7 @@ func genericVcdCatalogVappTemplateRead(_ context.Context, d *schema.ResourceData
 	}
 	dSet(d, "catalog_item_id", catalogItemId)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_catalog_vapp_template", vAppTemplate)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_catalog_vapp_template", vAppTemplate)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -383,9 +399,9 @@ func genericVcdCatalogVappTemplateRead(_ context.Context, d *schema.ResourceData
 	return nil
 }
 
-func resourceVcdCatalogVappTemplateUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCatalogVappTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	vAppTemplate, err := findVAppTemplate(d, vcdClient, "resource")
+	vAppTemplate, err := findVAppTemplate(ctx, d, vcdClient, "resource")
 
 	if d.HasChange("description") || d.HasChange("name") {
 		if err != nil {
@@ -404,33 +420,36 @@ func resourceVcdCatalogVappTemplateUpdate(_ context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.Errorf("error updating VApp template lease terms: %s", err)
 	}
-	err = createOrUpdateMetadata(d, vAppTemplate, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, vAppTemplate, "metadata")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceVcdCatalogVappTemplateDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCatalogVappTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	catalogId := d.Get("catalog_id").(string)
 	catalog, err := vcdClient.Client.GetCatalogById(catalogId)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find Catalog with ID %s", catalogId)
+		tflog.Debug(ctx, "Unable to find Catalog", map[string]interface{}{"catalog_id": catalogId})
 		return diag.Errorf("unable to find Catalog with ID %s", catalogId)
 	}
 
 	vAppTemplateName := d.Get("name").(string)
 	vAppTemplate, err := catalog.GetVAppTemplateByName(vAppTemplateName)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find vApp Template with name %s", vAppTemplateName)
+		tflog.Debug(ctx, "Unable to find vApp Template", map[string]interface{}{"vapp_template_name": vAppTemplateName})
 		return diag.Errorf("unable to find vApp Template with name %s", vAppTemplateName)
 	}
 
-	err = vAppTemplate.Delete()
+	task, err := vAppTemplate.DeleteAsync()
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
 	if err != nil {
-		log.Printf("[DEBUG] Error removing vApp Template %s", err)
+		tflog.Debug(ctx, "Error removing vApp Template", map[string]interface{}{"error": err})
 		return diag.Errorf("error removing vApp Template %s", err)
 	}
 
@@ -438,7 +457,7 @@ func resourceVcdCatalogVappTemplateDelete(_ context.Context, d *schema.ResourceD
 	if err == nil {
 		return diag.Errorf("vApp Template %s still found after deletion", vAppTemplateName)
 	}
-	log.Printf("[TRACE] vApp Template delete completed: %s", vAppTemplateName)
+	tflog.Trace(ctx, "vApp Template delete completed", map[string]interface{}{"vapp_template_name": vAppTemplateName})
 
 	return nil
 }
@@ -470,8 +489,8 @@ func vappTemplateLeaseUpdate(vcdClient *VCDClient, vAppTemplate *govcd.VAppTempl
 // Example import path (id): myOrg1.myCatalog2.myvAppTemplate3
 // Example import path (id): myOrg1.myVdc2.myvAppTemplate3
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdCatalogVappTemplateImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdCatalogVappTemplateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog_name.vapp_template_name")
 	}
@@ -522,8 +541,8 @@ func resourceVcdCatalogVappTemplateImport(_ context.Context, d *schema.ResourceD
 
 // Finds a vApp Template with the information given in the resource data. If it's a data source it uses a filtering
 // mechanism, if it's a resource it just gets the information.
-func findVAppTemplate(d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd.VAppTemplate, error) {
-	log.Printf("[TRACE] vApp template search initiated")
+func findVAppTemplate(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd.VAppTemplate, error) {
+	tflog.Trace(ctx, "vApp template search initiated")
 
 	identifier := d.Id()
 	// Check if identifier is still in deprecated style `catalogName:mediaName`
@@ -539,7 +558,7 @@ func findVAppTemplate(d *schema.ResourceData, vcdClient *VCDClient, origin strin
 	if isSearchedByCatalog {
 		catalog, err = vcdClient.Client.GetCatalogById(catalogId.(string))
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find Catalog.")
+			tflog.Debug(ctx, "Unable to find Catalog")
 			return nil, fmt.Errorf("unable to find Catalog: %s", err)
 		}
 	} else {
@@ -550,7 +569,7 @@ func findVAppTemplate(d *schema.ResourceData, vcdClient *VCDClient, origin strin
 		}
 		vdc, err = adminOrg.GetVDCById(d.Get("vdc_id").(string), false)
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VDC.")
+			tflog.Debug(ctx, "Unable to find VDC")
 			return nil, fmt.Errorf("unable to find VDC: %s", err)
 		}
 	}
@@ -575,7 +594,7 @@ func findVAppTemplate(d *schema.ResourceData, vcdClient *VCDClient, origin strin
 			if err != nil {
 				return nil, err
 			}
-			err := checkSynchronisedVappTemplate(vcdClient, vAppTemplate)
+			err := checkSynchronisedVappTemplate(ctx, vcdClient, vAppTemplate)
 			if err != nil {
 				return nil, err
 			}
@@ -596,7 +615,7 @@ func findVAppTemplate(d *schema.ResourceData, vcdClient *VCDClient, origin strin
 	// call QuerySynchronizedVAppTemplateById, but the ID will have changed, hence it will fail with a NotFoundError.
 
 	if govcd.IsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] Unable to find vApp Template %s. Removing from tfstate", identifier)
+		tflog.Info(ctx, "Unable to find vApp Template. Removing from tfstate", map[string]interface{}{"identifier": identifier})
 		d.SetId("")
 		return nil, nil
 	}
@@ -606,17 +625,17 @@ func findVAppTemplate(d *schema.ResourceData, vcdClient *VCDClient, origin strin
 	}
 	if origin == "datasource" {
 		// This checks that the vApp Template is synchronized in the catalog
-		err := checkSynchronisedVappTemplate(vcdClient, vAppTemplate)
+		err := checkSynchronisedVappTemplate(ctx, vcdClient, vAppTemplate)
 		if err != nil {
 			return nil, err
 		}
 	}
 	d.SetId(vAppTemplate.VAppTemplate.ID)
-	log.Printf("[TRACE] vApp Template read completed: %#v", vAppTemplate.VAppTemplate)
+	tflog.Trace(ctx, "vApp Template read completed", map[string]interface{}{"vapp_template_name": vAppTemplate.VAppTemplate.Name})
 	return vAppTemplate, nil
 }
 
-func checkSynchronisedVappTemplate(vcdClient *VCDClient, vAppTemplate *govcd.VAppTemplate) error {
+func checkSynchronisedVappTemplate(ctx context.Context, vcdClient *VCDClient, vAppTemplate *govcd.VAppTemplate) error {
 	startCheck := time.Now()
 	var err error
 	timeout := false
@@ -631,7 +650,12 @@ func checkSynchronisedVappTemplate(vcdClient *VCDClient, vAppTemplate *govcd.VAp
 		timeout = time.Since(startCheck) > maximumSynchronisationCheckDuration
 		time.Sleep(500 * time.Millisecond)
 	}
-	util.Logger.Printf("[DEBUG] [checkSynchronisedVappTemplate] {timeout: %v - iterations %d} synchronisation query for %s completed after %s\n", timeout, iterations, vAppTemplate.VAppTemplate.Name, time.Since(startCheck))
+	tflog.Debug(ctx, "[checkSynchronisedVappTemplate] synchronisation query completed", map[string]interface{}{
+		"timeout":            timeout,
+		"iterations":         iterations,
+		"vapp_template_name": vAppTemplate.VAppTemplate.Name,
+		"elapsed":            time.Since(startCheck),
+	})
 	if err != nil {
 		return fmt.Errorf("the found vApp Template %s (%s) is not usable: %s", vAppTemplate.VAppTemplate.Name, vAppTemplate.VAppTemplate.ID, err)
 	}
@@ -639,34 +663,36 @@ func checkSynchronisedVappTemplate(vcdClient *VCDClient, vAppTemplate *govcd.VAp
 }
 
 // uploadOvaFromFilePath uploads an OVA file specified in the resource to the given catalog
-func uploadOvaFromFilePath(d *schema.ResourceData, catalog *govcd.Catalog, vappTemplate, resourceName string) diag.Diagnostics {
+// The import task is interrupted when the context is done. The upload of the file is not, as govcd
+// doesn't accept a context
+func uploadOvaFromFilePath(ctx context.Context, d *schema.ResourceData, catalog *govcd.Catalog, vappTemplate, resourceName string) diag.Diagnostics {
 	uploadPieceSize := d.Get("upload_piece_size").(int)
 	task, err := catalog.UploadOvf(d.Get("ova_path").(string), vappTemplate, d.Get("description").(string), int64(uploadPieceSize)*1024*1024) // Convert from megabytes to bytes
 	if err != nil {
-		log.Printf("[DEBUG] Error uploading file: %s", err)
+		tflog.Debug(ctx, "Error uploading file", map[string]interface{}{"error": err})
 		return diag.Errorf("error uploading file: %s", err)
 	}
 
-	return finishHandlingTask(d, *task.Task, vappTemplate, resourceName)
+	return finishHandlingTask(ctx, d, *task.Task, vappTemplate, resourceName)
 }
 
-func uploadFromUrl(d *schema.ResourceData, catalog *govcd.Catalog, itemName, resourceName string) diag.Diagnostics {
+func uploadFromUrl(ctx context.Context, d *schema.ResourceData, catalog *govcd.Catalog, itemName, resourceName string) diag.Diagnostics {
 	task, err := catalog.UploadOvfByLink(d.Get("ovf_url").(string), itemName, d.Get("description").(string))
 	if err != nil {
-		log.Printf("[DEBUG] Error uploading OVF from URL: %s", err)
+		tflog.Debug(ctx, "Error uploading OVF from URL", map[string]interface{}{"error": err})
 		return diag.Errorf("error uploading OVF from URL: %s", err)
 	}
 
-	return finishHandlingTask(d, task, itemName, resourceName)
+	return finishHandlingTask(ctx, d, task, itemName, resourceName)
 }
 
-func finishHandlingTask(d *schema.ResourceData, task govcd.Task, itemName string, resourceName string) diag.Diagnostics {
+func finishHandlingTask(ctx context.Context, d *schema.ResourceData, task govcd.Task, itemName string, resourceName string) diag.Diagnostics {
 	// This is a deprecated feature from vcd_catalog_item, to be removed with vcd_catalog_item
 	if resourceName == "vcd_catalog_item" && d.Get("show_upload_progress").(bool) {
 		for {
 			progress, err := task.GetTaskProgress()
 			if err != nil {
-				log.Printf("VCD Error importing new catalog item: %s", err)
+				tflog.Debug(ctx, "VCD Error importing new catalog item", map[string]interface{}{"error": err})
 				return diag.Errorf("VCD Error importing new catalog item: %s", err)
 			}
 			logForScreen("vcd_catalog_item", fmt.Sprintf("vcd_catalog_item."+itemName+": VCD import catalog item progress "+progress+"%%\n"))
@@ -677,9 +703,9 @@ func finishHandlingTask(d *schema.ResourceData, task govcd.Task, itemName string
 		}
 	}
 
-	err := task.WaitTaskCompletion()
+	err := waitTaskCompletionWithContext(ctx, task)
 	if err != nil {
-		return diag.Errorf("error waiting for task to complete: %+v", err)
+		return errorDiagnostics(d, err, "error waiting for task to complete: %+v", err)
 	}
 	return nil
 }
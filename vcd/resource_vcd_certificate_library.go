@@ -190,8 +190,8 @@ func resourceVcdAlbLibraryCertificateDelete(_ context.Context, d *schema.Resourc
 	return diag.FromErr(certificateToDelete.Delete())
 }
 
-func resourceLibraryCertificateImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceLibraryCertificateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.certificate-name")
 	}
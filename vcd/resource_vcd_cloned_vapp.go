@@ -2,9 +2,10 @@ package vcd
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
 	"sort"
 	"strings"
 
@@ -19,6 +20,9 @@ func resourceVcdClonedVApp() *schema.Resource {
 		CreateContext: resourceVcdClonedVAppCreate,
 		ReadContext:   resourceVcdClonedVAppRead,
 		DeleteContext: resourceVcdClonedVAppDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdClonedVAppImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -65,12 +69,16 @@ func resourceVcdClonedVApp() *schema.Resource {
 				ForceNew:     true,
 				Description:  "The type of the source to use for the creation of this vApp (one of 'vapp' or 'template')",
 				ValidateFunc: validation.StringInSlice([]string{"vapp", "template"}, true),
+				// The source of a vApp can't be retrieved after creation
+				DiffSuppressFunc: suppressTextAfterImport(),
 			},
 			"source_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
 				Description: "The identifier of the source to use for the creation of this vApp",
+				// The source of a vApp can't be retrieved after creation
+				DiffSuppressFunc: suppressTextAfterImport(),
 			},
 			"vm_list": {
 				Type:        schema.TypeList,
@@ -212,7 +220,7 @@ func resourceVcdClonedVAppRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 	vapp, err := vdc.GetVAppByNameOrId(identifier, false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find vApp. Removing from tfstate")
+		tflog.Debug(ctx, "Unable to find vApp. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
@@ -245,3 +253,45 @@ func resourceVcdClonedVAppRead(ctx context.Context, d *schema.ResourceData, meta
 func resourceVcdClonedVAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return resourceVcdVAppDelete(ctx, d, meta)
 }
+
+// resourceVcdClonedVAppImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains a dot formatted path to the vApp like org-name.vdc-name.vapp-name
+// 3. The function read is called to fill the computed fields
+//
+// The source of the vApp can't be retrieved: `source_type` and `source_id` are set to a placeholder that is
+// ignored when comparing with the configuration. `power_on` is set from the vApp status, and `delete_source`
+// is set to false.
+func resourceVcdClonedVAppImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("[cloned vApp import] resource name must be specified as org-name.vdc-name.vapp-name")
+	}
+	orgName, vdcName, vappName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf("[cloned vApp import] unable to find VDC %s: %s ", vdcName, err)
+	}
+
+	vapp, err := vdc.GetVAppByName(vappName, false)
+	if err != nil {
+		return nil, fmt.Errorf("[cloned vApp import] error retrieving vApp %s: %s", vappName, err)
+	}
+	vappStatus, err := vapp.GetStatus()
+	if err != nil {
+		return nil, fmt.Errorf("[cloned vApp import] error retrieving status of vApp %s: %s", vappName, err)
+	}
+
+	dSet(d, "name", vappName)
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "power_on", vappStatus == "POWERED_ON")
+	dSet(d, "delete_source", false)
+	dSet(d, "source_type", defaultImportedValue)
+	dSet(d, "source_id", defaultImportedValue)
+	d.SetId(vapp.VApp.ID)
+	return []*schema.ResourceData{d}, nil
+}
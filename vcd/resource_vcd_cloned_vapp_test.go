@@ -90,6 +90,14 @@ func TestAccVcdClonedVApp(t *testing.T) {
 					resource.TestCheckResourceAttr("data.vcd_vapp_vm.first_vm_from_vapp", "status", "4"),
 				),
 			},
+			{
+				ResourceName:      resourceVappFromTemplate,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgNsxtVdcObject(vappFromTemplate),
+				// The source of the vApp can't be retrieved
+				ImportStateVerifyIgnore: []string{"source_type", "source_id"},
+			},
 		},
 	})
 	postTestChecks(t)
@@ -4,15 +4,16 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
 	"github.com/hashicorp/go-cty/cty"
 	semver "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
-	"regexp"
-	"sort"
-	"time"
 )
 
 func resourceVcdCseKubernetesCluster() *schema.Resource {
@@ -29,6 +30,8 @@ func resourceVcdCseKubernetesCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCseKubernetesImport,
 		},
+		// CSE operations are also bounded by 'operations_timeout_minutes'. The shortest of both values applies
+		Timeouts: resourceTimeouts(180*time.Minute, 180*time.Minute, 180*time.Minute),
 		Schema: map[string]*schema.Schema{
 			"cse_version": {
 				Type:         schema.TypeString,
@@ -306,7 +309,7 @@ func resourceVcdCseKubernetesCluster() *schema.Resource {
 				Default:  60,
 				Description: "The time, in minutes, to wait for the cluster operations to be successfully completed. For example, during cluster creation, it should be in `provisioned`" +
 					"state before the timeout is reached, otherwise the operation will return an error. For cluster deletion, this timeout" +
-					"specifies the time to wait until the cluster is completely deleted. Setting this argument to `0` means to wait indefinitely",
+					"specifies the time to wait until the cluster is completely deleted. Setting this argument to `0` means to wait until the 'timeouts' limit is reached",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 			},
 			"kubernetes_version": {
@@ -504,7 +507,7 @@ func resourceVcdCseKubernetesClusterCreate(ctx context.Context, d *schema.Resour
 		}
 	}
 
-	cluster, err := org.CseCreateKubernetesCluster(creationData, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute)
+	cluster, err := org.CseCreateKubernetesCluster(creationData, remainingTimeout(ctx, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute))
 	if err != nil && cluster == nil {
 		return diag.Errorf("Kubernetes cluster creation failed: %s", err)
 	}
@@ -703,7 +706,7 @@ func resourceVcdCseKubernetesUpdate(ctx context.Context, d *schema.ResourceData,
 // the flags "markForDelete" and "forceDelete" back to true, so the CSE Server is able to delete all cluster elements
 // and perform a cleanup. Hence, this function sends an update of just these two properties and waits for the cluster RDE
 // to be gone.
-func resourceVcdCseKubernetesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCseKubernetesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	cluster, err := vcdClient.CseGetKubernetesClusterById(d.Id())
 	if err != nil {
@@ -712,7 +715,7 @@ func resourceVcdCseKubernetesDelete(_ context.Context, d *schema.ResourceData, m
 		}
 		return diag.FromErr(err)
 	}
-	err = cluster.Delete(time.Duration(d.Get("operations_timeout_minutes").(int)) * time.Minute)
+	err = cluster.Delete(remainingTimeout(ctx, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute))
 	if err != nil {
 		return diag.FromErr(err)
 	}
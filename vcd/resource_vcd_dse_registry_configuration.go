@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 // Name of Data Solutions Operator package. It cannot be published itself, but it is still seen in
@@ -153,7 +153,7 @@ func resourceVcdDseRegistryConfigurationUpdate(ctx context.Context, d *schema.Re
 }
 
 func resourceVcdDseRegistryConfigurationCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, operation string) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Data Solution Registry Configuration %s started", operation)
+	tflog.Trace(ctx, "Data Solution Registry Configuration started", map[string]interface{}{"operation": operation})
 	vcdClient := meta.(*VCDClient)
 
 	dseEntryConfig, err := vcdClient.GetDataSolutionByName(d.Get("name").(string))
@@ -236,7 +236,7 @@ func resourceVcdDseRegistryConfigurationCreateUpdate(ctx context.Context, d *sch
 	}
 
 	d.SetId(dseEntryConfig.RdeId())
-	util.Logger.Printf("[TRACE] Data Solution Configuration %s ended", operation)
+	tflog.Trace(ctx, "Data Solution Configuration ended", map[string]interface{}{"operation": operation})
 
 	return resourceVcdDseRegistryConfigurationRead(ctx, d, meta)
 }
@@ -245,8 +245,8 @@ func resourceVcdDseRegistryConfigurationRead(ctx context.Context, d *schema.Reso
 	return genericVcdDseRegistryConfigurationRead(ctx, d, meta, "resource")
 }
 
-func genericVcdDseRegistryConfigurationRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Data Solution Registry Configuration read for %s started", origin)
+func genericVcdDseRegistryConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+	tflog.Trace(ctx, "Data Solution Registry Configuration read started", map[string]interface{}{"origin": origin})
 	vcdClient := meta.(*VCDClient)
 
 	configInstance, err := vcdClient.GetDataSolutionByName(d.Get("name").(string))
@@ -292,7 +292,7 @@ func genericVcdDseRegistryConfigurationRead(_ context.Context, d *schema.Resourc
 }
 
 func resourceVcdDseRegistryConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Data Solution Registry Configuration delete started")
+	tflog.Trace(ctx, "Data Solution Registry Configuration delete started")
 	vcdClient := meta.(*VCDClient)
 
 	dseEntryConfig, err := vcdClient.GetDataSolutionByName(d.Get("name").(string))
@@ -336,7 +336,7 @@ func resourceVcdDseRegistryConfigurationDelete(ctx context.Context, d *schema.Re
 
 func resourceVcdDseRegistryConfigurationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
-	util.Logger.Printf("[TRACE] Data Solution Registry Configuration import started with ID %s", d.Id())
+	tflog.Trace(ctx, "Data Solution Registry Configuration import started", map[string]interface{}{"id": d.Id()})
 
 	configInstance, err := vcdClient.GetDataSolutionByName(d.Id())
 	if err != nil {
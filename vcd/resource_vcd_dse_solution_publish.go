@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 const confluentLicenseTypeWithLicense = "With License"
@@ -87,7 +87,7 @@ func resourceVcdDsePublish() *schema.Resource {
 }
 
 func resourceVcdDsePublishCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Data Solution publishing started")
+	tflog.Trace(ctx, "Data Solution publishing started")
 	vcdClient := meta.(*VCDClient)
 
 	// The operations are quick, but performing them concurrently on multiple entries
@@ -108,7 +108,7 @@ func resourceVcdDsePublishCreate(ctx context.Context, d *schema.ResourceData, me
 		licenseType := d.Get("confluent_license_type").(string)
 		licenseKey := d.Get("confluent_license_key").(string)
 
-		dsOrgConfigId, err := createConfluentOrgConfig(vcdClient, dataSolution, orgId, licenseType, licenseKey)
+		dsOrgConfigId, err := createConfluentOrgConfig(ctx, vcdClient, dataSolution, orgId, licenseType, licenseKey)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -149,8 +149,8 @@ func resourceVcdDsePublishRead(ctx context.Context, d *schema.ResourceData, meta
 	return genericVcdDsePublishRead(ctx, d, meta, "resource")
 }
 
-func genericVcdDsePublishRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Data Solution Publishing read started with origin %s", origin)
+func genericVcdDsePublishRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+	tflog.Trace(ctx, "Data Solution Publishing read started with origin", map[string]interface{}{"origin": origin})
 	vcdClient := meta.(*VCDClient)
 	orgId := d.Get("org_id").(string)
 
@@ -254,7 +254,7 @@ func genericVcdDsePublishRead(_ context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceVcdDsePublishDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] Data Solution unpublishing started")
+	tflog.Trace(ctx, "Data Solution unpublishing started")
 	vcdClient := meta.(*VCDClient)
 
 	dataSolution, err := vcdClient.GetDataSolutionById(d.Get("data_solution_id").(string))
@@ -296,7 +296,10 @@ func resourceVcdDsePublishImport(ctx context.Context, d *schema.ResourceData, me
 		return nil, fmt.Errorf("resource name must be specified as \"data solution name\".org-name")
 	}
 	dataSolutionName, orgName := resourceURI[0], resourceURI[1]
-	util.Logger.Printf("[TRACE] Data Solution publishing import started. Data Solution Name '%s', Org Name '%s'", dataSolutionName, orgName)
+	tflog.Trace(ctx, "Data Solution publishing import started", map[string]interface{}{
+		"data_solution_name": dataSolutionName,
+		"org_name":           orgName,
+	})
 
 	dataSolution, err := vcdClient.GetDataSolutionByName(dataSolutionName)
 	if err != nil {
@@ -322,7 +325,7 @@ func resourceVcdDsePublishImport(ctx context.Context, d *schema.ResourceData, me
 
 // licensetype: "No License" or "With License".
 // privateSecureData.LicenseKey must have license key set when licensetype=="With License"
-func createConfluentOrgConfig(vcdClient *VCDClient, dataSolution *govcd.DataSolution, orgId string, licenseType, licenseKey string) (string, error) {
+func createConfluentOrgConfig(ctx context.Context, vcdClient *VCDClient, dataSolution *govcd.DataSolution, orgId string, licenseType, licenseKey string) (string, error) {
 	// validation
 	if licenseType == "" {
 		return "", fmt.Errorf("'confluent_license_type' must be specified for 'Confluent Platform'")
@@ -357,8 +360,10 @@ func createConfluentOrgConfig(vcdClient *VCDClient, dataSolution *govcd.DataSolu
 	if err != nil {
 		return "", fmt.Errorf("error creating Data Solution Org Configuration: %s", err)
 	}
-	util.Logger.Printf("[TRACE] Created Org Config for Data Solution '%s' %s",
-		dataSolution.Name(), dsOrgConfig.RdeId())
+	tflog.Trace(ctx, "Created Org Config for Data Solution", map[string]interface{}{
+		"data_solution_name": dataSolution.Name(),
+		"rde_id":             dsOrgConfig.RdeId(),
+	})
 
 	err = dsOrgConfig.DefinedEntity.Resolve()
 	if err != nil {
@@ -2,10 +2,11 @@ package vcd
 
 //lint:file-ignore SA1019 ignore deprecated functions
 import (
+	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -107,12 +108,12 @@ var externalNetworkResource = &schema.Resource{
 func resourceVcdEdgeGateway() *schema.Resource {
 
 	return &schema.Resource{
-		Create: resourceVcdEdgeGatewayCreate,
-		Read:   resourceVcdEdgeGatewayRead,
-		Update: resourceVcdEdgeGatewayUpdate,
-		Delete: resourceVcdEdgeGatewayDelete,
+		CreateContext: resourceVcdEdgeGatewayCreate,
+		ReadContext:   resourceVcdEdgeGatewayRead,
+		UpdateContext: resourceVcdEdgeGatewayUpdate,
+		DeleteContext: resourceVcdEdgeGatewayDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdEdgeGatewayImport,
+			StateContext: resourceVcdEdgeGatewayImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -243,8 +244,8 @@ func resourceVcdEdgeGateway() *schema.Resource {
 }
 
 // Creates a new edge gateway from a resource definition
-func resourceVcdEdgeGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[TRACE] edge gateway creation initiated")
+func resourceVcdEdgeGatewayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "edge gateway creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -260,31 +261,31 @@ func resourceVcdEdgeGatewayCreate(d *schema.ResourceData, meta interface{}) erro
 		missing = append(missing, "vdc")
 	}
 	if len(missing) > 0 {
-		return fmt.Errorf("missing properties. %v should be given either in the resource or at provider level", missing)
+		return diag.Errorf("missing properties. %v should be given either in the resource or at provider level", missing)
 	}
 
 	org, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if org == nil {
-		return fmt.Errorf("no valid Organization named '%s' was found", orgName)
+		return diag.Errorf("no valid Organization named '%s' was found", orgName)
 	}
 	if vdc == nil || vdc.Vdc.HREF == "" || vdc.Vdc.ID == "" || vdc.Vdc.Name == "" {
-		return fmt.Errorf("no valid VDC named '%s' was found", vdcName)
+		return diag.Errorf("no valid VDC named '%s' was found", vdcName)
 	}
 
 	if vdc.IsNsxt() {
-		return fmt.Errorf("please use 'vcd_nsxt_edgegateway' for NSX-T backed VDC")
+		return diag.Errorf("please use 'vcd_nsxt_edgegateway' for NSX-T backed VDC")
 	}
 
 	var gwInterfaces []*types.GatewayInterface
 
-	log.Printf("[TRACE] creating edge gateway using 'external_network' blocks")
+	tflog.Trace(ctx, "creating edge gateway using 'external_network' blocks")
 	// Get gateway interfaces from complex structure
 	gwInterfaces, err = getGatewayInterfacesType(vcdClient, d.Get("external_network").(*schema.Set))
 	if err != nil {
-		return fmt.Errorf("could not process 'external_network' block(s): %s", err)
+		return diag.Errorf("could not process 'external_network' block(s): %s", err)
 	}
 
 	egwName := d.Get("name").(string)
@@ -306,61 +307,63 @@ func resourceVcdEdgeGatewayCreate(d *schema.ResourceData, meta interface{}) erro
 
 	if fipsModeEnabled, ok := d.GetOkExists("fips_mode_enabled"); ok {
 		fipsModeEnabledBool := fipsModeEnabled.(bool)
-		log.Printf("[TRACE] edge gateway creation. FIPS mode was set with value %t", fipsModeEnabledBool)
+		tflog.Trace(ctx, "edge gateway creation. FIPS mode was set", map[string]interface{}{
+			"fips_mode_enabled": fipsModeEnabledBool,
+		})
 		egwConfiguration.Configuration.FipsModeEnabled = &fipsModeEnabledBool
 	}
 
 	edge, err := govcd.CreateAndConfigureEdgeGateway(vcdClient.VCDClient, orgName, vdcName, egwName, egwConfiguration)
 	if err != nil {
-		log.Printf("[DEBUG] Error creating edge gateway: %s", err)
-		return fmt.Errorf("error creating edge gateway: %s", err)
+		tflog.Debug(ctx, "Error creating edge gateway", map[string]interface{}{"error": err})
+		return diag.Errorf("error creating edge gateway: %s", err)
 	}
 	// Edge gateway creation succeeded therefore we save related fields now to preserve Id.
 	// Edge gateway is already created even if further process fails
-	log.Printf("[TRACE] flushing edge gateway creation fields")
-	err = setEdgeGatewayValues(vcdClient, d, edge, "resource")
+	tflog.Trace(ctx, "flushing edge gateway creation fields")
+	err = setEdgeGatewayValues(ctx, vcdClient, d, edge, "resource")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	log.Printf("[TRACE] edge gateway load balancer configuration started")
+	tflog.Trace(ctx, "edge gateway load balancer configuration started")
 
 	err = updateLoadBalancer(d, edge)
 	if err != nil {
-		return fmt.Errorf("unable to update general load balancer settings: %s", err)
+		return diag.Errorf("unable to update general load balancer settings: %s", err)
 	}
 
-	log.Printf("[TRACE] edge gateway load balancer configured")
+	tflog.Trace(ctx, "edge gateway load balancer configured")
 
-	log.Printf("[TRACE] edge gateway firewall configuration started")
+	tflog.Trace(ctx, "edge gateway firewall configuration started")
 
 	err = updateFirewall(d, edge)
 	if err != nil {
-		return fmt.Errorf("unable to update firewall settings: %s", err)
+		return diag.Errorf("unable to update firewall settings: %s", err)
 	}
 
-	log.Printf("[TRACE] edge gateway firewall configured")
+	tflog.Trace(ctx, "edge gateway firewall configured")
 
 	// update load balancer and firewall configuration in statefile
 	err = setEdgeGatewayComponentValues(d, edge)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// TODO double validate if we need to use partial state here
 	// https://www.terraform.io/docs/extend/writing-custom-providers.html#error-handling-amp-partial-state
 	d.SetId(edge.EdgeGateway.ID)
-	log.Printf("[TRACE] edge gateway created: %#v", edge.EdgeGateway.Name)
-	return resourceVcdEdgeGatewayRead(d, meta)
+	tflog.Trace(ctx, "edge gateway created", map[string]interface{}{"edge_name": edge.EdgeGateway.Name})
+	return resourceVcdEdgeGatewayRead(ctx, d, meta)
 }
 
 // Fetches information about an existing edge gateway for a data definition
-func resourceVcdEdgeGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdEdgeGatewayRead(d, meta, "resource")
+func resourceVcdEdgeGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdEdgeGatewayRead(ctx, d, meta, "resource"))
 }
 
-func genericVcdEdgeGatewayRead(d *schema.ResourceData, meta interface{}, origin string) error {
-	log.Printf("[TRACE] edge gateway read initiated from origin %s", origin)
+func genericVcdEdgeGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) error {
+	tflog.Trace(ctx, "edge gateway read initiated", map[string]interface{}{"origin": origin})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -404,14 +407,17 @@ func genericVcdEdgeGatewayRead(d *schema.ResourceData, meta interface{}, origin
 	}
 	if err != nil {
 		if origin == "resource" {
-			log.Printf("[edgegateway read] edge gateway %s not found. Removing from state file: %s", identifier, err)
+			tflog.Debug(ctx, "[edgegateway read] edge gateway not found. Removing from state file", map[string]interface{}{
+				"identifier": identifier,
+				"error":      err,
+			})
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("[edgegateway read] error retrieving edge gateway %s: %s", identifier, err)
 	}
 
-	if err := setEdgeGatewayValues(vcdClient, d, *edgeGateway, origin); err != nil {
+	if err := setEdgeGatewayValues(ctx, vcdClient, d, *edgeGateway, origin); err != nil {
 		return err
 	}
 
@@ -425,12 +431,12 @@ func genericVcdEdgeGatewayRead(d *schema.ResourceData, meta interface{}, origin
 
 	d.SetId(edgeGateway.EdgeGateway.ID)
 
-	log.Printf("[TRACE] edge gateway read completed: %#v", edgeGateway.EdgeGateway)
+	tflog.Trace(ctx, "edge gateway read completed", map[string]interface{}{"edge_gateway_name": edgeGateway.EdgeGateway.Name})
 	return nil
 }
 
 // resourceVcdEdgeGatewayUpdate updates general load balancer settings only at the moment
-func resourceVcdEdgeGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockEdgeGateway(d)
 	defer vcdClient.unlockEdgeGateway(d)
@@ -444,7 +450,7 @@ func resourceVcdEdgeGatewayUpdate(d *schema.ResourceData, meta interface{}) erro
 		d.HasChange("lb_logging_enabled") || d.HasChange("lb_loglevel") {
 		err := updateLoadBalancer(d, *edgeGateway)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -452,16 +458,16 @@ func resourceVcdEdgeGatewayUpdate(d *schema.ResourceData, meta interface{}) erro
 		d.HasChange("fw_default_rule_action") {
 		err := updateFirewall(d, *edgeGateway)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return resourceVcdEdgeGatewayRead(d, meta)
+	return resourceVcdEdgeGatewayRead(ctx, d, meta)
 }
 
 // Deletes a edge gateway, optionally removing all objects in it as well
-func resourceVcdEdgeGatewayDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[TRACE] edge gateway delete started")
+func resourceVcdEdgeGatewayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "edge gateway delete started")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -470,13 +476,13 @@ func resourceVcdEdgeGatewayDelete(d *schema.ResourceData, meta interface{}) erro
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "name")
 	if err != nil {
-		return fmt.Errorf("error fetching edge gateway details %#v", err)
+		return diag.Errorf("error fetching edge gateway details %#v", err)
 	}
 
 	err = edgeGateway.Delete(true, true)
 
-	log.Printf("[TRACE] edge gateway deletion completed\n")
-	return err
+	tflog.Trace(ctx, "edge gateway deletion completed")
+	return diag.FromErr(err)
 }
 
 // resourceVcdEdgeGatewayImport is responsible for importing the resource.
@@ -493,8 +499,8 @@ func resourceVcdEdgeGatewayDelete(d *schema.ResourceData, meta interface{}) erro
 // Example import path (_the_id_string_): org.vdc.my-edge-gw
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 // Note: the edge gateway can be identified by either the name or the ID
-func resourceVcdEdgeGatewayImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdEdgeGatewayImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge-gw-name (or edge-gw-ID)")
 	}
@@ -689,7 +695,7 @@ func getGatewayInterfaceIpRangeType(suballocatePoolSet *schema.Set) ([]*types.IP
 // It must only convert to such structure only
 // `uplink` interfaces. One uplink exception in the case of distributed routing support (DLR) is an
 // `uplink` network `DLR_to_EDGE_%s` which is a transit interface
-func getExternalNetworkData(vcdClient *VCDClient, d *schema.ResourceData, gatewayInterfaces []*types.GatewayInterface) (*schema.Set, error) {
+func getExternalNetworkData(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData, gatewayInterfaces []*types.GatewayInterface) (*schema.Set, error) {
 	edgeGatewayName := d.Get("name").(string)
 	isDistributedRouter := d.Get("distributed_routing").(bool)
 
@@ -699,8 +705,10 @@ func getExternalNetworkData(vcdClient *VCDClient, d *schema.ResourceData, gatewa
 			// Only when InterfaceType == "uplink" this interface (vNic) is connected to external
 			// network
 			if extNetwork.InterfaceType != "uplink" {
-				log.Printf("[TRACE] edge gateway - skipping read of network %s because it is not of type 'uplink' (%s)",
-					extNetwork.Network.Name, extNetwork.InterfaceType)
+				tflog.Trace(ctx, "edge gateway - skipping read of network because it is not of type 'uplink", map[string]interface{}{
+					"network_name":   extNetwork.Network.Name,
+					"interface_type": extNetwork.InterfaceType,
+				})
 				continue
 			}
 			// One of gateway interfaces can be named `DLR_to_EDGE_%s` where %s=edge_gateway_name
@@ -708,8 +716,9 @@ func getExternalNetworkData(vcdClient *VCDClient, d *schema.ResourceData, gatewa
 			// transit interface when distributed logical routing (DLR) is enabled. It is not being
 			// defined by user and we do not need to read/set it into `external_network` block.
 			if isDistributedRouter && extNetwork.Network.Name == fmt.Sprintf("DLR_to_EDGE_%s", edgeGatewayName) {
-				log.Printf("[TRACE] edge gateway - skipping read of uplink interface network %s because it is a DLR interface",
-					extNetwork.Network.Name)
+				tflog.Trace(ctx, "edge gateway - skipping read of uplink interface network because it is a DLR interface", map[string]interface{}{
+					"network_name": extNetwork.Network.Name,
+				})
 				continue
 			}
 
@@ -812,8 +821,8 @@ func wasIpAddressSet(vcdClient *VCDClient, d *schema.ResourceData, extNetworkNam
 }
 
 // Convenience function to fill edge gateway values from resource data
-func setEdgeGatewayValues(vcdClient *VCDClient, d *schema.ResourceData, egw govcd.EdgeGateway, origin string) error {
-	log.Printf("[TRACE] edge gateway read - setting values")
+func setEdgeGatewayValues(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData, egw govcd.EdgeGateway, origin string) error {
+	tflog.Trace(ctx, "edge gateway read - setting values")
 	d.SetId(egw.EdgeGateway.ID)
 	err := d.Set("name", egw.EdgeGateway.Name)
 	if err != nil {
@@ -829,7 +838,7 @@ func setEdgeGatewayValues(vcdClient *VCDClient, d *schema.ResourceData, egw govc
 	}
 
 	if origin == "datasource" {
-		externalNetworkData, err := getExternalNetworkData(vcdClient, d, egw.EdgeGateway.Configuration.GatewayInterfaces.GatewayInterface)
+		externalNetworkData, err := getExternalNetworkData(ctx, vcdClient, d, egw.EdgeGateway.Configuration.GatewayInterfaces.GatewayInterface)
 		if err != nil {
 			return fmt.Errorf("[edgegateway read] could not process network interface data: %s", err)
 		}
@@ -862,8 +871,10 @@ func setEdgeGatewayValues(vcdClient *VCDClient, d *schema.ResourceData, egw govc
 
 	for _, gw := range egw.EdgeGateway.Configuration.GatewayInterfaces.GatewayInterface {
 		if len(gw.SubnetParticipation) < 1 {
-			log.Printf("[DEBUG] [setEdgeGatewayValues] gateway %s is missing SubnetParticipation elements: %+#v",
-				egw.EdgeGateway.Name, gw)
+			tflog.Debug(ctx, "[setEdgeGatewayValues] gateway is missing SubnetParticipation elements", map[string]interface{}{
+				"egw_name":       egw.EdgeGateway.Name,
+				"interface_name": gw.Name,
+			})
 
 			return fmt.Errorf("[setEdgeGatewayValues] gateway %s is missing SubnetParticipation elements",
 				egw.EdgeGateway.Name)
@@ -2,10 +2,11 @@ package vcd
 
 //lint:file-ignore SA1019 ignore deprecated functions
 import (
+	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -13,12 +14,12 @@ import (
 
 func resourceVcdEdgeGatewaySettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdEdgeGatewaySettingsCreate,
-		Read:   resourceVcdEdgeGatewaySettingsRead,
-		Update: resourceVcdEdgeGatewaySettingsUpdate,
-		Delete: resourceVcdEdgeGatewaySettingsDelete,
+		CreateContext: resourceVcdEdgeGatewaySettingsCreate,
+		ReadContext:   resourceVcdEdgeGatewaySettingsRead,
+		UpdateContext: resourceVcdEdgeGatewaySettingsUpdate,
+		DeleteContext: resourceVcdEdgeGatewaySettingsDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdEdgeGatewaySettingsImport,
+			StateContext: resourceVcdEdgeGatewaySettingsImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -99,13 +100,13 @@ func resourceVcdEdgeGatewaySettings() *schema.Resource {
 	}
 }
 
-func resourceVcdEdgeGatewaySettingsCreate(d *schema.ResourceData, meta interface{}) error {
-	return resourceVcdEdgeGatewaySettingsUpdate(d, meta)
+func resourceVcdEdgeGatewaySettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdEdgeGatewaySettingsUpdate(ctx, d, meta)
 }
 
-func getVcdEdgeGateway(d *schema.ResourceData, meta interface{}) (*govcd.EdgeGateway, error) {
+func getVcdEdgeGateway(ctx context.Context, d *schema.ResourceData, meta interface{}) (*govcd.EdgeGateway, error) {
 
-	log.Printf("[TRACE] edge gateway settings read initiated")
+	tflog.Trace(ctx, "edge gateway settings read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -133,43 +134,45 @@ func getVcdEdgeGateway(d *schema.ResourceData, meta interface{}) (*govcd.EdgeGat
 	return edgeGateway, nil
 }
 
-func resourceVcdEdgeGatewaySettingsRead(d *schema.ResourceData, meta interface{}) error {
-	edgeGateway, err := getVcdEdgeGateway(d, meta)
+func resourceVcdEdgeGatewaySettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	edgeGateway, err := getVcdEdgeGateway(ctx, d, meta)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("[edgegateway settings read] edge gateway not found. Removing from state file: %s", err)
+			tflog.Debug(ctx, "[edgegateway settings read] edge gateway not found. Removing from state file", map[string]interface{}{
+				"error": err,
+			})
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("[edgegateway settings read] edge gateway not found: %s", err)
+		return diag.Errorf("[edgegateway settings read] edge gateway not found: %s", err)
 	}
 	if err := setLoadBalancerData(d, *edgeGateway); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := setFirewallData(d, *edgeGateway); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	dSet(d, "edge_gateway_id", edgeGateway.EdgeGateway.ID)
 	dSet(d, "edge_gateway_name", edgeGateway.EdgeGateway.Name)
 	d.SetId(edgeGateway.EdgeGateway.ID)
 
-	log.Printf("[TRACE] edge gateway settings read completed: %#v", edgeGateway.EdgeGateway)
+	tflog.Trace(ctx, "edge gateway settings read completed", map[string]interface{}{"edge_gateway_name": edgeGateway.EdgeGateway.Name})
 	return nil
 }
 
-func resourceVcdEdgeGatewaySettingsUpdate(d *schema.ResourceData, meta interface{}) error {
-	edgeGateway, err := getVcdEdgeGateway(d, meta)
+func resourceVcdEdgeGatewaySettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	edgeGateway, err := getVcdEdgeGateway(ctx, d, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("lb_enabled") || d.HasChange("lb_acceleration_enabled") ||
 		d.HasChange("lb_logging_enabled") || d.HasChange("lb_loglevel") {
 		err := updateLoadBalancer(d, *edgeGateway)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -177,16 +180,16 @@ func resourceVcdEdgeGatewaySettingsUpdate(d *schema.ResourceData, meta interface
 		d.HasChange("fw_default_rule_action") {
 		err := updateFirewall(d, *edgeGateway)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	log.Printf("[TRACE] edge gateway settings update completed: %#v", edgeGateway.EdgeGateway)
-	return resourceVcdEdgeGatewaySettingsRead(d, meta)
+	tflog.Trace(ctx, "edge gateway settings update completed", map[string]interface{}{"edge_gateway_name": edgeGateway.EdgeGateway.Name})
+	return resourceVcdEdgeGatewaySettingsRead(ctx, d, meta)
 }
 
-func resourceVcdEdgeGatewaySettingsDelete(d *schema.ResourceData, meta interface{}) error {
-	return resourceVcdEdgeGatewaySettingsUpdate(d, meta)
+func resourceVcdEdgeGatewaySettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdEdgeGatewaySettingsUpdate(ctx, d, meta)
 }
 
 // resourceVcdEdgeGatewaySettingsImport is responsible for importing the resource.
@@ -203,8 +206,8 @@ func resourceVcdEdgeGatewaySettingsDelete(d *schema.ResourceData, meta interface
 // Example import path (_the_id_string_): org.vdc.my-edge-gw
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 // Note: the edge gateway can be identified by either the name or the ID
-func resourceVcdEdgeGatewaySettingsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdEdgeGatewaySettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[resourceVcdEdgeGatewaySettingsImport] resource name must be specified as org-name.vdc-name.edge-gw-name (or edge-gw-ID)")
 	}
@@ -4,9 +4,11 @@ package vcd
 //lint:file-ignore U1000 ignore because it is a working example
 
 import (
+	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
@@ -51,9 +53,12 @@ var edgeVpnPeerSubnetResource = &schema.Resource{
 
 func resourceVcdEdgeGatewayVpn() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdEdgeGatewayVpnCreate,
-		Read:   resourceVcdEdgeGatewayVpnRead,
-		Delete: resourceVcdEdgeGatewayVpnDelete,
+		CreateContext: resourceVcdEdgeGatewayVpnCreate,
+		ReadContext:   resourceVcdEdgeGatewayVpnRead,
+		DeleteContext: resourceVcdEdgeGatewayVpnDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdEdgeGatewayVpnImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -128,6 +133,8 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 				Required:  true,
 				ForceNew:  true,
 				Sensitive: true,
+				// The shared secret may not be returned by VCD when importing
+				DiffSuppressFunc: suppressTextAfterImport(),
 			},
 
 			"local_subnets": {
@@ -135,28 +142,36 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 				Elem:     edgeVpnLocalSubnetResource,
+				// Subnets can't be read (see resourceVcdEdgeGatewayVpnRead), so they are empty after import
+				DiffSuppressFunc: suppressUnreadableSetAfterImport("local_subnets"),
 			},
 
 			"peer_subnets": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				ForceNew: true,
-				Elem:     edgeVpnPeerSubnetResource,
+				Type:             schema.TypeSet,
+				Optional:         true,
+				ForceNew:         true,
+				Elem:             edgeVpnPeerSubnetResource,
+				DiffSuppressFunc: suppressUnreadableSetAfterImport("peer_subnets"),
+			},
+			"imported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Set to true when the resource was imported. 'local_subnets' and 'peer_subnets' are then not compared with the configuration",
 			},
 		},
 	}
 }
 
-func resourceVcdEdgeGatewayVpnCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdEdgeGatewayVpnCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] CLIENT: %#v", vcdClient)
+	tflog.Trace(ctx, "edge gateway VPN creation initiated")
 
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	localSubnetsList := d.Get("local_subnets").(*schema.Set).List()
@@ -213,40 +228,45 @@ func resourceVcdEdgeGatewayVpnCreate(d *schema.ResourceData, meta interface{}) e
 		},
 	}
 
-	log.Printf("[INFO] ipsecVPNConfig: %#v", ipsecVPNConfig)
+	tflog.Info(ctx, "ipsecVPNConfig", map[string]interface{}{
+		"edge_gateway_name": edgeGateway.EdgeGateway.Name,
+		"tunnel_name":       tunnel.Name,
+		"enabled":           ipsecVPNConfig.GatewayIpsecVpnService.IsEnabled,
+	})
 
 	err = edgeGateway.Refresh()
 	if err != nil {
-		log.Printf("[INFO] Error refreshing edge gateway: %#v", err)
-		return fmt.Errorf("error refreshing edge gateway: %#v", err)
+		tflog.Info(ctx, "Error refreshing edge gateway", map[string]interface{}{"error": err})
+		return diag.Errorf("error refreshing edge gateway: %#v", err)
 	}
 	task, err := edgeGateway.AddIpsecVPN(ipsecVPNConfig)
 	if err != nil {
-		log.Printf("[INFO] Error setting ipsecVPNConfig rules: %s", err)
-		return fmt.Errorf("error setting ipsecVPNConfig rules: %#v", err)
+		tflog.Info(ctx, "Error setting ipsecVPNConfig rules", map[string]interface{}{"error": err})
+		return diag.Errorf("error setting ipsecVPNConfig rules: %#v", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf(errorCompletingTask, err)
+		return diag.Errorf(errorCompletingTask, err)
 	}
 
 	d.SetId(d.Get("edge_gateway").(string))
+	dSet(d, "imported", false)
 
-	return resourceVcdEdgeGatewayVpnRead(d, meta)
+	return resourceVcdEdgeGatewayVpnRead(ctx, d, meta)
 }
 
-func resourceVcdEdgeGatewayVpnDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdEdgeGatewayVpnDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
-	log.Printf("[TRACE] CLIENT: %#v", vcdClient)
+	tflog.Trace(ctx, "edge gateway VPN deletion initiated")
 
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	ipsecVPNConfig := &types.EdgeGatewayServiceConfiguration{
@@ -256,22 +276,25 @@ func resourceVcdEdgeGatewayVpnDelete(d *schema.ResourceData, meta interface{}) e
 		},
 	}
 
-	log.Printf("[INFO] ipsecVPNConfig: %#v", ipsecVPNConfig)
+	tflog.Info(ctx, "ipsecVPNConfig", map[string]interface{}{
+		"edge_gateway_name": edgeGateway.EdgeGateway.Name,
+		"enabled":           ipsecVPNConfig.GatewayIpsecVpnService.IsEnabled,
+	})
 
 	err = edgeGateway.Refresh()
 	if err != nil {
-		log.Printf("[INFO] Error refreshing edge gateway: %#v", err)
-		return fmt.Errorf("error refreshing edge gateway: %#v", err)
+		tflog.Info(ctx, "Error refreshing edge gateway", map[string]interface{}{"error": err})
+		return diag.Errorf("error refreshing edge gateway: %#v", err)
 	}
 	task, err := edgeGateway.AddIpsecVPN(ipsecVPNConfig)
 	if err != nil {
-		log.Printf("[INFO] Error setting ipsecVPNConfig rules: %s", err)
-		return fmt.Errorf("error setting ipsecVPNConfig rules: %#v", err)
+		tflog.Info(ctx, "Error setting ipsecVPNConfig rules", map[string]interface{}{"error": err})
+		return diag.Errorf("error setting ipsecVPNConfig rules: %#v", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		return fmt.Errorf(errorCompletingTask, err)
+		return diag.Errorf(errorCompletingTask, err)
 	}
 
 	d.SetId(d.Get("edge_gateway").(string))
@@ -279,12 +302,12 @@ func resourceVcdEdgeGatewayVpnDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdEdgeGatewayVpnRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	egsc := edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.GatewayIpsecVpnService
@@ -339,12 +362,63 @@ func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) err
 		// }
 
 	} else {
-		return fmt.Errorf("multiple tunnels not currently supported")
+		return diag.Errorf("multiple tunnels not currently supported")
 	}
 
 	return nil
 }
 
+// resourceVcdEdgeGatewayVpnImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains a dot formatted path to the edge gateway, as org-name.vdc-name.edge-gw-name
+// 3. The function read is called to retrieve the VPN tunnel
+//
+// `local_subnets` and `peer_subnets` can't be retrieved (see resourceVcdEdgeGatewayVpnRead). The `imported` flag makes
+// them ignored when comparing with the configuration, so that the tunnel is not re-created. When the shared secret is
+// not returned by VCD, it is set to a placeholder that is ignored when comparing with the configuration.
+func resourceVcdEdgeGatewayVpnImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge-gw-name")
+	}
+	orgName, vdcName, edgeName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find VDC %s: %s", vdcName, err)
+	}
+	if vdc.IsNsxt() {
+		return nil, fmt.Errorf("please use 'vcd_nsxt_ipsec_vpn_tunnel' for NSX-T backed VDC")
+	}
+
+	edgeGateway, err := vdc.GetEdgeGatewayByName(edgeName, false)
+	if err != nil {
+		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
+	}
+	var tunnels []*types.GatewayIpsecVpnTunnel
+	if edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration != nil &&
+		edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.GatewayIpsecVpnService != nil {
+		tunnels = edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration.GatewayIpsecVpnService.Tunnel
+	}
+	if len(tunnels) != 1 {
+		return nil, fmt.Errorf("edge gateway %s must have exactly one VPN tunnel, found %d", edgeName, len(tunnels))
+	}
+
+	sharedSecret := tunnels[0].SharedSecret
+	if sharedSecret == "" {
+		sharedSecret = defaultImportedValue
+	}
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "edge_gateway", edgeGateway.EdgeGateway.Name)
+	dSet(d, "shared_secret", sharedSecret)
+	dSet(d, "imported", true)
+	d.SetId(edgeGateway.EdgeGateway.Name)
+	return []*schema.ResourceData{d}, nil
+}
+
 func convertAndSet(key, prefix string, hashObejct *schema.Resource, subNets []*types.IpsecVpnSubnet, d *schema.ResourceData) error {
 	var items []interface{}
 
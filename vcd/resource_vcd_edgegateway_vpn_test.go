@@ -55,6 +55,21 @@ func TestAccVcdVpn_Basic(t *testing.T) {
 						"vcd_edgegateway_vpn."+vpnName, "encryption_protocol", "AES256"),
 				),
 			},
+			{
+				ResourceName:      "vcd_edgegateway_vpn." + vpnName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgVdcObject(testConfig.Networking.EdgeGateway),
+				// Subnets can't be read, and the shared secret may not be returned
+				ImportStateVerifyIgnore: []string{"local_subnets", "peer_subnets", "shared_secret", "imported"},
+				// The imported state replaces the one of the created resource, to be checked in the next step
+				ImportStatePersist: true,
+			},
+			{
+				// After import, the same configuration must not re-create the tunnel
+				Config:   configText,
+				PlanOnly: true,
+			},
 		},
 	})
 	postTestChecks(t)
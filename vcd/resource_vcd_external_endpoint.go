@@ -3,13 +3,14 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"strings"
 )
 
 func resourceVcdExternalEndpoint() *schema.Resource {
@@ -92,7 +93,7 @@ func resourceVcdExternalEndpointRead(ctx context.Context, d *schema.ResourceData
 	return genericVcdExternalEndpointRead(ctx, d, meta, "resource")
 }
 
-func genericVcdExternalEndpointRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdExternalEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	var ep *govcd.ExternalEndpoint
@@ -105,7 +106,10 @@ func genericVcdExternalEndpointRead(_ context.Context, d *schema.ResourceData, m
 		ep, err = vcdClient.GetExternalEndpointById(d.Id())
 	}
 	if govcd.ContainsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find External Endpoint '%s': %s. Removing from state", identifier, err)
+		tflog.Info(ctx, "unable to find External Endpoint. Removing from state", map[string]interface{}{
+			"identifier": identifier,
+			"error":      err,
+		})
 		d.SetId("")
 		return nil
 	}
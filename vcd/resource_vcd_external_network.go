@@ -2,11 +2,13 @@ package vcd
 
 //lint:file-ignore SA1019 ignore deprecated functions
 import (
+	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -15,11 +17,11 @@ import (
 
 func resourceVcdExternalNetwork() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdExternalNetworkCreate,
-		Delete: resourceVcdExternalNetworkDelete,
-		Read:   resourceVcdExternalNetworkRead,
+		CreateContext: resourceVcdExternalNetworkCreate,
+		DeleteContext: resourceVcdExternalNetworkDelete,
+		ReadContext:   resourceVcdExternalNetworkRead,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdExternalNetworkImport,
+			StateContext: resourceVcdExternalNetworkImport,
 		},
 		DeprecationMessage: "Please use resource vcd_external_network_v2 instead",
 		Schema: map[string]*schema.Schema{
@@ -142,34 +144,34 @@ func resourceVcdExternalNetwork() *schema.Resource {
 }
 
 // resourceVcdExternalNetworkCreate creates a new external network from a resource definition
-func resourceVcdExternalNetworkCreate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[TRACE] external network creation initiated")
+func resourceVcdExternalNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "external network creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
 	params, err := getExternalNetworkInput(d, vcdClient)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	task, err := govcd.CreateExternalNetwork(vcdClient.VCDClient, params)
 	if err != nil {
-		log.Printf("[DEBUG] Error creating external network: %#v", err)
-		return fmt.Errorf("error creating external network: %#v", err)
+		tflog.Debug(ctx, "Error creating external network", map[string]interface{}{"error": err})
+		return diag.Errorf("error creating external network: %#v", err)
 	}
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		log.Printf("[DEBUG] Error waiting for external network to finish: %#v", err)
-		return fmt.Errorf("error waiting for external network to finish: %#v", err)
+		tflog.Debug(ctx, "Error waiting for external network to finish", map[string]interface{}{"error": err})
+		return diag.Errorf("error waiting for external network to finish: %#v", err)
 	}
 
 	d.SetId(d.Get("name").(string))
-	log.Printf("[TRACE] external network created: %#v", task)
-	return resourceVcdExternalNetworkRead(d, meta)
+	tflog.Trace(ctx, "external network created", map[string]interface{}{"task_id": task.Task.ID})
+	return resourceVcdExternalNetworkRead(ctx, d, meta)
 }
 
-func setExternalNetworkData(d *schema.ResourceData, extNetRes StringMap) error {
+func setExternalNetworkData(ctx context.Context, d *schema.ResourceData, extNetRes StringMap) error {
 	dSet(d, "name", extNetRes["name"])
 	dSet(d, "description", extNetRes["description"])
 	dSet(d, "retain_net_info_across_deployments", extNetRes["retain_net_info_across_deployments"])
@@ -184,17 +186,17 @@ func setExternalNetworkData(d *schema.ResourceData, extNetRes StringMap) error {
 		return err
 	}
 
-	log.Printf("[TRACE] external network read completed: %#v", extNetRes)
+	tflog.Trace(ctx, "external network read completed", map[string]interface{}{"external_network_name": d.Get("name").(string)})
 	return nil
 }
 
-func resourceVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdExternalNetworkRead(d, meta, "resource")
+func resourceVcdExternalNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdExternalNetworkRead(ctx, d, meta, "resource"))
 }
 
 // genericVcdExternalNetworkRead fetches information about an existing external network
-func genericVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}, origin string) error {
-	log.Printf("[TRACE] external network read initiated")
+func genericVcdExternalNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) error {
+	tflog.Trace(ctx, "external network read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -206,13 +208,15 @@ func genericVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}, ori
 
 	if err != nil {
 		if origin == "resource" && govcd.ContainsNotFound(err) {
-			log.Printf("[external network read] external network not found. Removing from state file: %s", err)
+			tflog.Debug(ctx, "[external network read] external network not found. Removing from state file", map[string]interface{}{
+				"error": err,
+			})
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("error fetching external network (%s) details %s", identifier, err)
 	}
-	err = setExternalNetworkData(d, extNeRes)
+	err = setExternalNetworkData(ctx, d, extNeRes)
 	if err != nil {
 		return err
 	}
@@ -222,24 +226,24 @@ func genericVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}, ori
 }
 
 // resourceVcdExternalNetworkDelete deletes an external network, optionally removing all objects in it as well
-func resourceVcdExternalNetworkDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[TRACE] external network delete started")
+func resourceVcdExternalNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "external network delete started")
 
 	vcdClient := meta.(*VCDClient)
 
 	externalNetwork, err := vcdClient.GetExternalNetworkByNameOrId(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Error fetching external network details %s", err)
-		return fmt.Errorf("error fetching external network details %s", err)
+		tflog.Debug(ctx, "Error fetching external network details", map[string]interface{}{"error": err})
+		return diag.Errorf("error fetching external network details %s", err)
 	}
 
 	err = externalNetwork.DeleteWait()
 	if err != nil {
-		log.Printf("[DEBUG] Error removing external network %#v", err)
-		return fmt.Errorf("error removing external network %s", err)
+		tflog.Debug(ctx, "Error removing external network", map[string]interface{}{"error": err})
+		return diag.Errorf("error removing external network %s", err)
 	}
 
-	log.Printf("[TRACE] external network delete completed: %#v", externalNetwork)
+	tflog.Trace(ctx, "external network delete completed", map[string]interface{}{"external_network_name": externalNetwork.ExternalNetwork.Name})
 	return nil
 }
 
@@ -444,7 +448,7 @@ func getExternalNetworkResource(vcdClient *govcd.VCDClient, extNetIdentifier str
 //
 // Example import path (id): externalNetworkName
 // Example import command:   terraform import vcd_external_network.externalNetworkName externalNetworkName
-func resourceVcdExternalNetworkImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdExternalNetworkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 	vcdClient := meta.(*VCDClient)
 	extNetRes, ID, err := getExternalNetworkResource(vcdClient.VCDClient, d.Id())
@@ -452,7 +456,7 @@ func resourceVcdExternalNetworkImport(d *schema.ResourceData, meta interface{})
 		return nil, fmt.Errorf("error fetching external network details %s", err)
 	}
 
-	err = setExternalNetworkData(d, extNetRes)
+	err = setExternalNetworkData(ctx, d, extNetRes)
 	if err != nil {
 		return nil, err
 	}
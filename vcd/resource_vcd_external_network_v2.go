@@ -4,8 +4,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -198,7 +198,7 @@ func resourceVcdExternalNetworkV2() *schema.Resource {
 
 func resourceVcdExternalNetworkV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] external network V2 creation initiated")
+	tflog.Trace(ctx, "external network V2 creation initiated")
 
 	netType, err := getExternalNetworkV2Type(vcdClient, d, "", false)
 	if err != nil {
@@ -218,7 +218,7 @@ func resourceVcdExternalNetworkV2Create(ctx context.Context, d *schema.ResourceD
 
 func resourceVcdExternalNetworkV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] update network V2 creation initiated")
+	tflog.Trace(ctx, "update network V2 creation initiated")
 
 	extNet, err := govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, d.Id())
 	if err != nil {
@@ -246,9 +246,9 @@ func resourceVcdExternalNetworkV2Update(ctx context.Context, d *schema.ResourceD
 	return resourceVcdExternalNetworkV2Read(ctx, d, meta)
 }
 
-func resourceVcdExternalNetworkV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdExternalNetworkV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] external network V2 read initiated")
+	tflog.Trace(ctx, "external network V2 read initiated")
 
 	extNet, err := govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, d.Id())
 	if err != nil {
@@ -267,9 +267,9 @@ func resourceVcdExternalNetworkV2Read(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func resourceVcdExternalNetworkV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdExternalNetworkV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] external network V2 creation initiated")
+	tflog.Trace(ctx, "external network V2 creation initiated")
 
 	extNet, err := govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, d.Id())
 	if err != nil {
@@ -3,7 +3,6 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -307,8 +306,8 @@ func getTenants(client *VCDClient, label string, d *schema.ResourceData) ([]type
 	return inputTenants, nil
 }
 
-func resourceVcdGlobalRoleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdGlobalRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 1 {
 		return nil, fmt.Errorf("resource name must be specified as globalrole-name")
 	}
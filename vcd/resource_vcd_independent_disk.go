@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -20,7 +20,7 @@ import (
 const globalIndependentDiskLockKey = "globalIndependentDiskLockKey"
 
 func resourceVcdIndependentDisk() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdIndependentDiskCreate,
 		ReadContext:   resourceVcdIndependentDiskRead,
 		UpdateContext: resourceVcdIndependentDiskUpdate,
@@ -132,7 +132,7 @@ func resourceVcdIndependentDisk() *schema.Resource {
 			},
 			"metadata_entry": metadataEntryResourceSchemaDeprecated("Disk"),
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 var busTypes = map[string]string{
@@ -237,7 +237,7 @@ func resourceVcdIndependentDiskCreate(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(disk.Disk.Id)
 
-	err = createOrUpdateMetadata(d, disk, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, disk, "metadata")
 	if err != nil {
 		return diag.Errorf("error adding metadata to independent disk: %s", err)
 	}
@@ -331,7 +331,7 @@ func resourceVcdIndependentDiskUpdate(ctx context.Context, d *schema.ResourceDat
 
 	}
 
-	err = createOrUpdateMetadata(d, disk, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, disk, "metadata")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -433,7 +433,7 @@ func attachBackVms(vcdClient *VCDClient, disk *govcd.Disk, diskDetailsForReAttac
 	return nil
 }
 
-func resourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdIndependentDiskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -446,7 +446,10 @@ func resourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData, m
 	if identifier != "" {
 		disk, err = vdc.GetDiskById(identifier, true)
 		if govcd.IsNotFound(err) {
-			log.Printf("unable to find disk with ID %s: %s. Removing from state", identifier, err)
+			tflog.Debug(ctx, "unable to find disk. Removing from state", map[string]interface{}{
+				"identifier": identifier,
+				"error":      err,
+			})
 			d.SetId("")
 			return nil
 		}
@@ -457,7 +460,10 @@ func resourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData, m
 		identifier = d.Get("name").(string)
 		disks, err := vdc.GetDisksByName(identifier, true)
 		if govcd.IsNotFound(err) {
-			log.Printf("unable to find disk with name %s: %s. Removing from state", identifier, err)
+			tflog.Debug(ctx, "unable to find disk with name. Removing from state", map[string]interface{}{
+				"identifier": identifier,
+				"error":      err,
+			})
 			d.SetId("")
 			return nil
 		}
@@ -486,16 +492,16 @@ func resourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData, m
 		return diag.Errorf("unable to find queried disk with name %s: and href: %s, %s", identifier, disk.Disk.HREF, err)
 	}
 
-	diagErr := setMainData(d, vcdClient, disk, diskRecord)
+	diagErr := setMainData(ctx, d, vcdClient, disk, diskRecord)
 	if diagErr != nil {
 		return diagErr
 	}
 
-	log.Printf("[TRACE] Disk read completed.")
+	tflog.Trace(ctx, "Disk read completed")
 	return nil
 }
 
-func setMainData(d *schema.ResourceData, vcdClient *VCDClient, disk *govcd.Disk, diskRecord *types.DiskRecordType) diag.Diagnostics {
+func setMainData(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, disk *govcd.Disk, diskRecord *types.DiskRecordType) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	d.SetId(disk.Disk.Id)
@@ -530,9 +536,9 @@ func setMainData(d *schema.ResourceData, vcdClient *VCDClient, disk *govcd.Disk,
 		return diag.Errorf("[Independent disk read] error setting the list of attached VM IDs: %s ", err)
 	}
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_independent_disk", disk)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_independent_disk", disk)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to set Independent disk metadata")
+		tflog.Debug(ctx, "Unable to set Independent disk metadata")
 		return diags
 	}
 	// This must be checked at the end as updateMetadataInStateDeprecated can throw Warning diagnostics
@@ -602,12 +608,12 @@ var errHelpDiskImport = fmt.Errorf(`resource id must be specified in one of thes
 // Example resource name (_resource_name_): vcd_independent_disk.my-disk
 // Example import path (_the_id_string_): org-name.vdc-name.my-independent-disk-id
 // Example list path (_the_id_string_): list@org-name.vdc-name.my-independent-disk-name
-func resourceVcdIndependentDiskImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdIndependentDiskImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, diskName, diskId string
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 
-	log.Printf("[DEBUG] importing vcd_independent_disk resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_independent_disk resource", map[string]interface{}{"id": d.Id()})
 
 	if len(resourceURI) != 3 && len(resourceURI) != 2 {
 		return nil, errHelpDiskImport
@@ -625,6 +631,9 @@ func resourceVcdIndependentDiskImport(_ context.Context, d *schema.ResourceData,
 		orgName = commandOrgNameSplit[1]
 		return listDisksForImport(meta, orgName, vdcName, diskName)
 	} else {
+		if len(resourceURI) != 3 {
+			return nil, errHelpDiskImport
+		}
 		orgName, vdcName, diskId = resourceURI[0], resourceURI[1], resourceURI[2]
 		return getDiskForImport(d, meta, orgName, vdcName, diskId)
 	}
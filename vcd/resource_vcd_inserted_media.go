@@ -3,8 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +18,9 @@ func resourceVcdInsertedMedia() *schema.Resource {
 		DeleteContext: resourceVcdMediaEject,
 		ReadContext:   resourceVcdVmInsertedMediaRead,
 		UpdateContext: resourceVcdMediaEjectUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdInsertedMediaImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"vdc": {
@@ -69,14 +72,14 @@ func resourceVcdInsertedMedia() *schema.Resource {
 }
 
 func resourceVcdMediaInsert(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] VM media insert initiated")
+	tflog.Trace(ctx, "VM media insert initiated")
 
 	vcdClient := meta.(*VCDClient)
 
 	vcdClient.lockParentVapp(d)
 	defer vcdClient.unLockParentVapp(d)
 
-	vm, org, err := getVM(d, meta)
+	vm, org, err := getVM(ctx, d, meta)
 	if err != nil || org == nil {
 		return diag.Errorf("error: %s", err)
 	}
@@ -95,10 +98,10 @@ func resourceVcdMediaInsert(ctx context.Context, d *schema.ResourceData, meta in
 	return resourceVcdVmInsertedMediaRead(ctx, d, meta)
 }
 
-func resourceVcdVmInsertedMediaRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] VM insert media read initiated")
+func resourceVcdVmInsertedMediaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "VM insert media read initiated")
 
-	vm, _, err := getVM(d, meta)
+	vm, _, err := getVM(ctx, d, meta)
 	if err != nil {
 		// error logged and d.SetId("") is done in getVM function
 		return nil
@@ -113,22 +116,22 @@ func resourceVcdVmInsertedMediaRead(_ context.Context, d *schema.ResourceData, m
 	}
 
 	if !isIsoMounted {
-		log.Printf("[DEBUG] Didn't find mounted iso in VM. Removing from tfstate")
+		tflog.Debug(ctx, "Didn't find mounted iso in VM. Removing from tfstate")
 		d.SetId("")
 	}
 
-	log.Printf("[TRACE] VM insert media read completed.")
+	tflog.Trace(ctx, "VM insert media read completed")
 	return nil
 }
 
-func resourceVcdMediaEject(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdMediaEject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	vcdClient := meta.(*VCDClient)
 
 	vcdClient.lockParentVapp(d)
 	defer vcdClient.unLockParentVapp(d)
 
-	vm, org, err := getVM(d, meta)
+	vm, org, err := getVM(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("error: %s", err)
 	}
@@ -146,7 +149,7 @@ func resourceVcdMediaEject(_ context.Context, d *schema.ResourceData, meta inter
 	return nil
 }
 
-func getVM(d *schema.ResourceData, meta interface{}) (*govcd.VM, *govcd.Org, error) {
+func getVM(ctx context.Context, d *schema.ResourceData, meta interface{}) (*govcd.VM, *govcd.Org, error) {
 	vcdClient := meta.(*VCDClient)
 
 	org, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -156,14 +159,14 @@ func getVM(d *schema.ResourceData, meta interface{}) (*govcd.VM, *govcd.Org, err
 
 	vmRecord, err := vdc.QueryVM(d.Get("vapp_name").(string), d.Get("vm_name").(string))
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM. Removing from tfstate")
+		tflog.Debug(ctx, "Unable to find VM. Removing from tfstate")
 		d.SetId("")
 		return nil, nil, fmt.Errorf("unable to find VM. Removing from tfstate. Err: #%v", err)
 	}
 
 	vm, err := vcdClient.Client.GetVMByHref(vmRecord.VM.HREF)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to get VM data")
+		tflog.Debug(ctx, "Unable to get VM data")
 		return nil, nil, fmt.Errorf("error getting VM data: %s", err)
 	}
 	return vm, org, nil
@@ -174,3 +177,100 @@ func resourceVcdMediaEjectUpdate(_ context.Context, d *schema.ResourceData, m in
 	dSet(d, "eject_force", d.Get("eject_force"))
 	return nil
 }
+
+// resourceVcdInsertedMediaImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains a dot formatted path to the media, as org-name.vdc-name.vapp-name.vm-name.media-name
+// or, when the media name exists in more than one catalog, as org-name.vdc-name.vapp-name.vm-name.catalog-name.media-name
+// 3. The function read is called to check that the VM has a media inserted
+func resourceVcdInsertedMediaImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
+	var orgName, vdcName, vappName, vmName, catalogName, mediaName string
+	switch len(resourceURI) {
+	case 5:
+		orgName, vdcName, vappName, vmName, mediaName = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3], resourceURI[4]
+	case 6:
+		orgName, vdcName, vappName, vmName, catalogName, mediaName = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3], resourceURI[4], resourceURI[5]
+	default:
+		return nil, fmt.Errorf("[inserted media import] resource name must be specified as " +
+			"org-name.vdc-name.vapp-name.vm-name.media-name or org-name.vdc-name.vapp-name.vm-name.catalog-name.media-name")
+	}
+
+	vcdClient := meta.(*VCDClient)
+	org, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] unable to find VDC %s: %s ", vdcName, err)
+	}
+
+	// The VM only refers to the inserted media, without its catalog, which needs to be found from the media name
+	if catalogName == "" {
+		mediaRecords, err := vdc.QueryAllMedia(mediaName)
+		if err != nil {
+			return nil, fmt.Errorf("[inserted media import] error retrieving media %s: %s", mediaName, err)
+		}
+		var catalogNames []string
+		for _, mediaRecord := range mediaRecords {
+			if !contains(catalogNames, mediaRecord.MediaRecord.CatalogName) {
+				catalogNames = append(catalogNames, mediaRecord.MediaRecord.CatalogName)
+			}
+		}
+		if len(catalogNames) != 1 {
+			return nil, fmt.Errorf("[inserted media import] media %s was found in catalogs %v: the catalog must be specified as "+
+				"org-name.vdc-name.vapp-name.vm-name.catalog-name.media-name", mediaName, catalogNames)
+		}
+		catalogName = catalogNames[0]
+	}
+	catalog, err := org.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] error retrieving catalog %s: %s", catalogName, err)
+	}
+	media, err := catalog.GetMediaByName(mediaName, false)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] error retrieving media %s from catalog %s: %s", mediaName, catalogName, err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "vapp_name", vappName)
+	dSet(d, "vm_name", vmName)
+	dSet(d, "catalog", catalogName)
+	dSet(d, "name", mediaName)
+	dSet(d, "eject_force", true)
+
+	vm, _, err := getVM(ctx, d, meta)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] error retrieving VM %s in vApp %s: %s", vmName, vappName, err)
+	}
+	// The media must be the one inserted in a CD device of the VM, not just any media with the same name
+	var insertedNames []string
+	isMediaInserted := false
+	for _, mediaImage := range getInsertedMediaImages(vm.VM) {
+		if extractUuid(mediaImage.HREF) == extractUuid(media.Media.HREF) {
+			isMediaInserted = true
+			break
+		}
+		insertedNames = append(insertedNames, mediaImage.Name)
+	}
+	if !isMediaInserted {
+		return nil, fmt.Errorf("[inserted media import] media %s of catalog %s is not inserted in VM %s in vApp %s (inserted media: %v)",
+			mediaName, catalogName, vmName, vappName, insertedNames)
+	}
+
+	d.SetId(vappName + "_" + vmName + "_" + mediaName)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getInsertedMediaImages returns the references to the media inserted in the CD devices of the given VM
+func getInsertedMediaImages(vm *types.Vm) []*types.Reference {
+	if vm == nil || vm.VmSpecSection == nil || vm.VmSpecSection.MediaSection == nil {
+		return nil
+	}
+	var mediaImages []*types.Reference
+	for _, mediaSettings := range vm.VmSpecSection.MediaSection.MediaSettings {
+		if mediaSettings != nil && mediaSettings.MediaImage != nil && mediaSettings.MediaImage.HREF != "" {
+			mediaImages = append(mediaImages, mediaSettings.MediaImage)
+		}
+	}
+	return mediaImages
+}
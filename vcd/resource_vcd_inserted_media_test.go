@@ -60,6 +60,13 @@ func TestAccVcdMediaInsertBasic(t *testing.T) {
 					testAccCheckMediaEjected("vcd_inserted_media."+TestAccVcdMediaInsert, params),
 				),
 			},
+			{
+				ResourceName:      "vcd_inserted_media." + TestAccVcdMediaInsert,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importCustomObject([]string{testConfig.VCD.Org, testConfig.Nsxt.Vdc, vappNameForInsert,
+					vmNameForInsert, TestAccVcdCatalogMediaForInsert}),
+			},
 		},
 	})
 	postTestChecks(t)
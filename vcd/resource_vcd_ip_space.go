@@ -3,17 +3,16 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 var ipSpaceIpRangeRange = &schema.Resource{
@@ -176,9 +175,9 @@ func resourceVcdIpSpace() *schema.Resource {
 
 func resourceVcdIpSpaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space creation initiated")
+	tflog.Trace(ctx, "IP Space creation initiated")
 
-	ipSpaceConfig, err := getIpSpaceType(vcdClient, d, "create")
+	ipSpaceConfig, err := getIpSpaceType(ctx, vcdClient, d, "create")
 	if err != nil {
 		return diag.Errorf("could not get IP Space type: %s", err)
 	}
@@ -195,9 +194,9 @@ func resourceVcdIpSpaceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceVcdIpSpaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space update initiated")
+	tflog.Trace(ctx, "IP Space update initiated")
 
-	ipSpaceConfig, err := getIpSpaceType(vcdClient, d, "update")
+	ipSpaceConfig, err := getIpSpaceType(ctx, vcdClient, d, "update")
 	if err != nil {
 		return diag.Errorf("could not get IP Space type: %s", err)
 	}
@@ -246,7 +245,7 @@ func resourceVcdIpSpaceUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceVcdIpSpaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space read initiated")
+	tflog.Trace(ctx, "IP Space read initiated")
 
 	ipSpace, err := vcdClient.GetIpSpaceById(d.Id())
 	if err != nil {
@@ -267,7 +266,7 @@ func resourceVcdIpSpaceRead(ctx context.Context, d *schema.ResourceData, meta in
 
 func resourceVcdIpSpaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space deletion initiated")
+	tflog.Trace(ctx, "IP Space deletion initiated")
 
 	ipSpace, err := vcdClient.GetIpSpaceById(d.Id())
 	if err != nil {
@@ -296,9 +295,9 @@ func resourceVcdIpSpaceDelete(ctx context.Context, d *schema.ResourceData, meta
 // * Import global (provider level) IP Space - just a name is required
 // * Import Private IP space for an Organization - org-name.ip-space-name is required
 func resourceVcdIpSpaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] IP Space import initiated")
+	tflog.Trace(ctx, "IP Space import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 1 && len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as ip-space-name or org-name.ip-space-name")
 	}
@@ -359,7 +358,7 @@ func getIpSpaceDefaultGatewayServiceConfig(vcdClient *VCDClient, d *schema.Resou
 	return gwSvcConfig, nil
 }
 
-func getIpSpaceType(vcdClient *VCDClient, d *schema.ResourceData, operation string) (*types.IpSpace, error) {
+func getIpSpaceType(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData, operation string) (*types.IpSpace, error) {
 	ipSpace := &types.IpSpace{
 		Name:                      d.Get("name").(string),
 		Description:               d.Get("description").(string),
@@ -396,7 +395,7 @@ func getIpSpaceType(vcdClient *VCDClient, d *schema.ResourceData, operation stri
 			// This is important for update - an ID of IP range must be supplied to prevent
 			// recreating an IP Space
 			if operation == "update" {
-				foundIdInState := getIpRangeIdFromFromPreviousState(d, ipRangeStrings["start_address"], ipRangeStrings["end_address"])
+				foundIdInState := getIpRangeIdFromFromPreviousState(ctx, d, ipRangeStrings["start_address"], ipRangeStrings["end_address"])
 				ipSpace.IPSpaceRanges.IPRanges[ipRangeIndex].ID = foundIdInState
 			}
 
@@ -443,7 +442,7 @@ func getIpSpaceType(vcdClient *VCDClient, d *schema.ResourceData, operation stri
 
 			// Update operation requires the ID of prefix, otherwise it recreates the IP sequence
 			if operation == "update" {
-				foundId := getIpPrefixSequenceIdFromFromPreviousState(d, ipPrefixMap["first_ip"], ipPrefixMap["prefix_length"], ipPrefixMap["prefix_count"])
+				foundId := getIpPrefixSequenceIdFromFromPreviousState(ctx, d, ipPrefixMap["first_ip"], ipPrefixMap["prefix_length"], ipPrefixMap["prefix_count"])
 				singlePrefix.ID = foundId
 			}
 
@@ -557,12 +556,15 @@ func setIpSpaceData(d *schema.ResourceData, ipSpace *types.IpSpace) error {
 }
 
 // getIpRangeIdFromFromPreviousState helps to find ip_range ID from previous state (because the current does not have it) and match it for current configuration
-func getIpRangeIdFromFromPreviousState(d *schema.ResourceData, startAddress, endAddress string) string {
+func getIpRangeIdFromFromPreviousState(ctx context.Context, d *schema.ResourceData, startAddress, endAddress string) string {
 	ipRangesOld, _ := d.GetChange("ip_range")
 	ipRangesOldSchema := ipRangesOld.(*schema.Set)
 	ipRangesSlice := ipRangesOldSchema.List()
 
-	util.Logger.Printf("[TRACE] Looking for ID of 'ip_range' with start_address '%s' and end_address '%s'\n", startAddress, endAddress)
+	tflog.Trace(ctx, "Looking for ID of 'ip_range' with start_address and end_address", map[string]interface{}{
+		"start_address": startAddress,
+		"end_address":   endAddress,
+	})
 
 	// Looping over ip_range definitions from state which contained all values and also stored ID. It looks for this ID with 2 priority levels:
 	// 1. An exact match with the same start and end IP addresses is found - return it immediately
@@ -581,40 +583,57 @@ func getIpRangeIdFromFromPreviousState(d *schema.ResourceData, startAddress, end
 		// If both - start and end IP addresses remained the same - we have found the ID and can
 		// return it immediatelly
 		if ipRangeStrings["start_address"] == startAddress && ipRangeStrings["end_address"] == endAddress {
-			util.Logger.Printf("[TRACE] Found exact match for 'ip_range' with start_address '%s' and end_address '%s' - ID is '%s'\n",
-				startAddress, endAddress, ipRangeStrings["id"])
+			tflog.Trace(ctx, "Found exact match for 'ip_range'", map[string]interface{}{
+				"start_address": startAddress,
+				"end_address":   endAddress,
+				"id":            ipRangeStrings["id"],
+			})
 			return ipRangeStrings["id"]
 		}
 
 		// Search for a partial match where either start_address or end_address matches
 		if ipRangeStrings["start_address"] == startAddress || ipRangeStrings["end_address"] == endAddress {
-			util.Logger.Printf("[TRACE] Found a partial match for 'ip_range' with start_address '%s' (looked) '%s' (found) and end_address '%s' (looked) '%s' (found) - ID is '%s'. "+
-				"Storing until search finalizes.",
-				startAddress, ipRangeStrings["start_address"], endAddress, ipRangeStrings["end_address"], ipRangeStrings["id"])
+			tflog.Trace(ctx, "Found a partial match for 'ip_range'. Storing until search finalizes", map[string]interface{}{
+				"start_address":       startAddress,
+				"found_start_address": ipRangeStrings["start_address"],
+				"end_address":         endAddress,
+				"found_end_address":   ipRangeStrings["end_address"],
+				"id":                  ipRangeStrings["id"],
+			})
 			foundPartialId = ipRangeStrings["id"]
 		}
 	}
 
 	if foundPartialId != "" {
-		util.Logger.Printf("[TRACE] Returning partial match for 'ip_range' with start_address '%s' and end_address '%s'  - ID is '%s'.",
-			startAddress, endAddress, foundPartialId)
+		tflog.Trace(ctx, "Returning partial match for 'ip_range'", map[string]interface{}{
+			"start_address":    startAddress,
+			"end_address":      endAddress,
+			"found_partial_id": foundPartialId,
+		})
 		return foundPartialId
 	}
 
-	util.Logger.Printf("[TRACE] No matches found for 'ip_range' with start_address '%s' and end_address '%s'  - ID is '%s'.",
-		startAddress, endAddress, foundPartialId)
+	tflog.Trace(ctx, "No matches found for 'ip_range'", map[string]interface{}{
+		"start_address":    startAddress,
+		"end_address":      endAddress,
+		"found_partial_id": foundPartialId,
+	})
 
 	return ""
 }
 
 // getIpPrefixSequenceIdFromFromPreviousState helps to find ip_prefix ID from previous state
 // (because the current does not have it) and match it for current configuration
-func getIpPrefixSequenceIdFromFromPreviousState(d *schema.ResourceData, firstIp, prefixLength, prefixCount string) string {
+func getIpPrefixSequenceIdFromFromPreviousState(ctx context.Context, d *schema.ResourceData, firstIp, prefixLength, prefixCount string) string {
 	ipPrefixOld, _ := d.GetChange("ip_prefix")
 	ipPrefixOldSchema := ipPrefixOld.(*schema.Set)
 	ipPrefixesSlice := ipPrefixOldSchema.List()
 
-	util.Logger.Printf("[TRACE] Looking for ID of 'ip_prefix' with first_ip '%s', prefix_length '%s' and prefix_count '%s'\n", firstIp, prefixLength, prefixCount)
+	tflog.Trace(ctx, "Looking for ID of 'ip_prefix' with first_ip, prefix_length and prefix_count", map[string]interface{}{
+		"first_ip":      firstIp,
+		"prefix_length": prefixLength,
+		"prefix_count":  prefixCount,
+	})
 	var foundPartialId string
 	for ipPrefixIndex := range ipPrefixesSlice {
 		singleIpPrefix := ipPrefixesSlice[ipPrefixIndex]
@@ -629,23 +648,34 @@ func getIpPrefixSequenceIdFromFromPreviousState(d *schema.ResourceData, firstIp,
 
 			// Exact match
 			if ipPrefixMap["first_ip"] == firstIp && ipPrefixMap["prefix_length"] == prefixLength && ipPrefixMap["prefix_count"] == prefixCount {
-				util.Logger.Printf("[TRACE] Found exact match for ID '%s' of 'ip_prefix' with first_ip '%s', prefix_length '%s' and prefix_count '%s'\n", ipPrefixMap["id"], firstIp, prefixLength, prefixCount)
+				tflog.Trace(ctx, "Found exact match for ID of 'ip_prefix' with first_ip, prefix_length and prefix_count", map[string]interface{}{
+					"id":            ipPrefixMap["id"],
+					"first_ip":      firstIp,
+					"prefix_length": prefixLength,
+					"prefix_count":  prefixCount,
+				})
 				return ipPrefixMap["id"]
 			}
 
 			if ipPrefixMap["first_ip"] == firstIp {
-				util.Logger.Printf("[TRACE] Found partial match for ID '%s' of 'ip_prefix' with first_ip '%s'. 'prefix_length' and 'prefix_count' are ignored'\n", ipPrefixMap["id"], firstIp)
+				tflog.Trace(ctx, "Found partial match for ID of 'ip_prefix' with first_ip. 'prefix_length' and 'prefix_count' are ignored'", map[string]interface{}{
+					"id":       ipPrefixMap["id"],
+					"first_ip": firstIp,
+				})
 				foundPartialId = ipPrefixMap["id"]
 			}
 		}
 	}
 
 	if foundPartialId != "" {
-		util.Logger.Printf("[TRACE] Returning partial match for ID '%s' of 'ip_prefix' with first_ip '%s'. 'prefix_length' and 'prefix_count' are ignored'\n", foundPartialId, firstIp)
+		tflog.Trace(ctx, "Returning partial match for ID of 'ip_prefix' with first_ip. 'prefix_length' and 'prefix_count' are ignored'", map[string]interface{}{
+			"found_partial_id": foundPartialId,
+			"first_ip":         firstIp,
+		})
 		return foundPartialId
 	}
 
-	util.Logger.Printf("[TRACE] Not found 'ip_prefix' ID \n")
+	tflog.Trace(ctx, "Not found 'ip_prefix' ID")
 	// No ID was found at all
 	return ""
 }
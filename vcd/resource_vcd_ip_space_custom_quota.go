@@ -3,10 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -87,7 +86,7 @@ func resourceVcdIpSpaceCustomQuotaUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceVcdIpSpaceCustomQuotaCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, operation string) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space Custom Quota %s initiated", operation)
+	tflog.Trace(ctx, "IP Space Custom Quota initiated", map[string]interface{}{"operation": operation})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -117,7 +116,7 @@ func resourceVcdIpSpaceCustomQuotaCreateUpdate(ctx context.Context, d *schema.Re
 }
 
 func resourceVcdIpSpaceCustomQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space Custom quota read initiated")
+	tflog.Trace(ctx, "IP Space Custom quota read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -141,7 +140,7 @@ func resourceVcdIpSpaceCustomQuotaRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceVcdIpSpaceCustomQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space Custom quota deletion initiated")
+	tflog.Trace(ctx, "IP Space Custom quota deletion initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -168,9 +167,9 @@ func resourceVcdIpSpaceCustomQuotaDelete(ctx context.Context, d *schema.Resource
 }
 
 func resourceVcdIpSpaceCustomQuotaImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] IP Space Custom Quota import initiated")
+	tflog.Trace(ctx, "IP Space Custom Quota import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as ip-space-name.org-name")
 	}
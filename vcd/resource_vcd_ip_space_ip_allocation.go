@@ -3,16 +3,15 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 func resourceVcdIpAllocation() *schema.Resource {
@@ -118,7 +117,7 @@ func resourceVcdIpAllocation() *schema.Resource {
 }
 
 func resourceVcdIpAllocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space IP Allocation creation initiated")
+	tflog.Trace(ctx, "IP Space IP Allocation creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -201,7 +200,7 @@ func resourceVcdIpAllocationCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceVcdIpAllocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space IP Allocation update initiated")
+	tflog.Trace(ctx, "IP Space IP Allocation update initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -233,7 +232,7 @@ func resourceVcdIpAllocationUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceVcdIpAllocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space IP Allocation read initiated")
+	tflog.Trace(ctx, "IP Space IP Allocation read initiated")
 
 	vcdClient := meta.(*VCDClient)
 	orgId := d.Get("org_id").(string)
@@ -270,8 +269,10 @@ func resourceVcdIpAllocationRead(ctx context.Context, d *schema.ResourceData, me
 			dSet(d, "ip", splitCidr[0])
 			dSet(d, "prefix_length", splitCidr[1])
 		} else {
-			util.Logger.Printf("[TRACE] resourceVcdIpAllocationRead Unable to store split CIDR (value %s, split length %d)\n",
-				ipAllocation.IpSpaceIpAllocation.Value, len(ipAllocation.IpSpaceIpAllocation.Value))
+			tflog.Trace(ctx, "resourceVcdIpAllocationRead Unable to store split CIDR", map[string]interface{}{
+				"value":        ipAllocation.IpSpaceIpAllocation.Value,
+				"split_length": len(splitCidr),
+			})
 		}
 	}
 
@@ -283,7 +284,7 @@ func resourceVcdIpAllocationRead(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceVcdIpAllocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] IP Space IP Allocation deletion initiated")
+	tflog.Trace(ctx, "IP Space IP Allocation deletion initiated")
 
 	vcdClient := meta.(*VCDClient)
 	orgId := d.Get("org_id").(string)
@@ -311,9 +312,9 @@ func resourceVcdIpAllocationDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceVcdIpAllocationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] IP Allocation import initiated")
+	tflog.Trace(ctx, "IP Allocation import initiated")
 
-	resourceURI := strings.SplitN(d.Id(), ImportSeparator, 4)
+	resourceURI := importIdElements(ctx, d, 4)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.ip-space-name.ip-allocation-type.ip-allocation-ip")
 	}
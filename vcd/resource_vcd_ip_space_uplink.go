@@ -3,10 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -70,7 +69,7 @@ func resourceVcdIpSpaceUplink() *schema.Resource {
 
 func resourceVcdIpSpaceUplinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space Uplink creation initiated")
+	tflog.Trace(ctx, "IP Space Uplink creation initiated")
 	vcdClient.lockParentExternalNetwork(d)
 	defer vcdClient.unlockParentExternalNetwork(d)
 
@@ -97,7 +96,7 @@ func resourceVcdIpSpaceUplinkCreate(ctx context.Context, d *schema.ResourceData,
 
 func resourceVcdIpSpaceUplinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space Uplink update initiated")
+	tflog.Trace(ctx, "IP Space Uplink update initiated")
 	vcdClient.lockParentExternalNetwork(d)
 	defer vcdClient.unlockParentExternalNetwork(d)
 
@@ -128,7 +127,7 @@ func resourceVcdIpSpaceUplinkUpdate(ctx context.Context, d *schema.ResourceData,
 
 func resourceVcdIpSpaceUplinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space Uplink read initiated")
+	tflog.Trace(ctx, "IP Space Uplink read initiated")
 
 	ipSpaceUplink, err := vcdClient.GetIpSpaceUplinkById(d.Id())
 	if err != nil {
@@ -159,7 +158,7 @@ func resourceVcdIpSpaceUplinkRead(ctx context.Context, d *schema.ResourceData, m
 
 func resourceVcdIpSpaceUplinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	log.Printf("[TRACE] IP Space Uplink deletion initiated")
+	tflog.Trace(ctx, "IP Space Uplink deletion initiated")
 	vcdClient.lockParentExternalNetwork(d)
 	defer vcdClient.unlockParentExternalNetwork(d)
 
@@ -187,9 +186,9 @@ func resourceVcdIpSpaceUplinkDelete(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceVcdIpSpaceUplinkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] IP Space Uplink import initiated")
+	tflog.Trace(ctx, "IP Space Uplink import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as external-network-name.uplink-name")
 	}
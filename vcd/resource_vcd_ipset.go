@@ -1,10 +1,12 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -12,12 +14,12 @@ import (
 
 func resourceVcdIpSet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdIpSetCreate,
-		Read:   resourceVcdIpSetRead,
-		Update: resourceVcdIpSetUpdate,
-		Delete: resourceVcdIpSetDelete,
+		CreateContext: resourceVcdIpSetCreate,
+		ReadContext:   resourceVcdIpSetRead,
+		UpdateContext: resourceVcdIpSetUpdate,
+		DeleteContext: resourceVcdIpSetDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdIpSetImport,
+			StateContext: resourceVcdIpSetImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -63,69 +65,72 @@ func resourceVcdIpSet() *schema.Resource {
 }
 
 // resourceVcdIpSetCreate creates an IP set based on schema data
-func resourceVcdIpSetCreate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] Creating IP set with name %s", d.Get("name"))
+func resourceVcdIpSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Creating IP set", map[string]interface{}{"name": d.Get("name")})
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
 	ipSet, err := getIpSet(d, vdc)
 	if err != nil {
-		return fmt.Errorf("unable to make IP set query: %s", err)
+		return diag.Errorf("unable to make IP set query: %s", err)
 	}
 
 	createdIpSet, err := vdc.CreateNsxvIpSet(ipSet)
 	if err != nil {
-		return fmt.Errorf("error creating new IP set: %s", err)
+		return diag.Errorf("error creating new IP set: %s", err)
 	}
 
-	log.Printf("[DEBUG] IP set with name %s created. Id: %s", createdIpSet.Name, createdIpSet.ID)
+	tflog.Debug(ctx, "IP set created", map[string]interface{}{
+		"name": createdIpSet.Name,
+		"id":   createdIpSet.ID,
+	})
 	d.SetId(createdIpSet.ID)
-	return resourceVcdIpSetRead(d, meta)
+	return resourceVcdIpSetRead(ctx, d, meta)
 }
 
 // resourceVcdIpSetUpdate updates an IP set based on schema data
-func resourceVcdIpSetUpdate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] Updating IP set with ID %s", d.Id())
+func resourceVcdIpSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Updating IP set", map[string]interface{}{"id": d.Id()})
 
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
 	ipSet, err := getIpSet(d, vdc)
 	if err != nil {
-		return fmt.Errorf("unable to make IP set query: %s", err)
+		return diag.Errorf("unable to make IP set query: %s", err)
 	}
 	ipSet.ID = d.Id() // ID is needed to update IP set
 
 	_, err = vdc.UpdateNsxvIpSet(ipSet)
 	if err != nil {
-		return fmt.Errorf("error updating IP set with ID %s: %s", d.Id(), err)
+		return diag.Errorf("error updating IP set with ID %s: %s", d.Id(), err)
 	}
 
-	log.Printf("[DEBUG] Updated IP set with ID %s", d.Id())
-	return resourceVcdIpSetRead(d, meta)
+	tflog.Debug(ctx, "Updated IP set", map[string]interface{}{"id": d.Id()})
+	return resourceVcdIpSetRead(ctx, d, meta)
 }
 
-func datasourceVcdIpSetRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdIpSetRead(d, meta, "datasource")
+func datasourceVcdIpSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdIpSetRead(ctx, d, meta, "datasource"))
 }
 
-func resourceVcdIpSetRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdIpSetRead(d, meta, "resource")
+func resourceVcdIpSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdIpSetRead(ctx, d, meta, "resource"))
 }
 
 // genericVcdIpSetRead reads all data and persists it on statefile.
 // When "origin" == "datasource" it will search for IP set by name and use d.SetId
 // When "origin" != "datasource" it will search for IP set by ID and do not perform d.SetId
-func genericVcdIpSetRead(d *schema.ResourceData, meta interface{}, origin string) error {
-	log.Printf("[DEBUG] Reading IP set with ID %s", d.Id())
+func genericVcdIpSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) error {
+	tflog.Debug(ctx, "Reading IP set", map[string]interface{}{"id": d.Id()})
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -143,7 +148,10 @@ func genericVcdIpSetRead(d *schema.ResourceData, meta interface{}, origin string
 	}
 
 	if govcd.IsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find IP set with ID %s: %s. Removing from state", d.Id(), err)
+		tflog.Info(ctx, "unable to find IP set. Removing from state", map[string]interface{}{
+			"id":    d.Id(),
+			"error": err,
+		})
 		d.SetId("")
 		return nil
 	}
@@ -161,34 +169,34 @@ func genericVcdIpSetRead(d *schema.ResourceData, meta interface{}, origin string
 		d.SetId(ipSet.ID)
 	}
 
-	log.Printf("[DEBUG] Read IP set with ID %s", d.Id())
+	tflog.Debug(ctx, "Read IP set", map[string]interface{}{"id": d.Id()})
 	return nil
 }
 
 // resourceVcdIpSetDelete delete IP set based on its ID
-func resourceVcdIpSetDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] Deleting IP set with ID %s", d.Id())
+func resourceVcdIpSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "Deleting IP set", map[string]interface{}{"id": d.Id()})
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
 	err = vdc.DeleteNsxvIpSetById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting IP set with id %s: %s", d.Id(), err)
+		return diag.Errorf("error deleting IP set with id %s: %s", d.Id(), err)
 	}
 
-	log.Printf("[DEBUG] Deleted IP set with ID %s", d.Id())
+	tflog.Debug(ctx, "Deleted IP set", map[string]interface{}{"id": d.Id()})
 	d.SetId("")
 	return nil
 }
 
 // resourceVcdIpSetImport
-func resourceVcdIpSetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdIpSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.ipset-name")
 	}
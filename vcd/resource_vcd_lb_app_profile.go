@@ -1,21 +1,23 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 func resourceVcdLBAppProfile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdLBAppProfileCreate,
-		Read:   resourceVcdLBAppProfileRead,
-		Update: resourceVcdLBAppProfileUpdate,
-		Delete: resourceVcdLBAppProfileDelete,
+		CreateContext: resourceVcdLBAppProfileCreate,
+		ReadContext:   resourceVcdLBAppProfileRead,
+		UpdateContext: resourceVcdLBAppProfileUpdate,
+		DeleteContext: resourceVcdLBAppProfileDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdLBAppProfileImport,
+			StateContext: resourceVcdLBAppProfileImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -111,93 +113,93 @@ func resourceVcdLBAppProfile() *schema.Resource {
 	}
 }
 
-func resourceVcdLBAppProfileCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppProfileCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	LBProfile, err := getLBAppProfileType(d)
 	if err != nil {
-		return fmt.Errorf("unable to create load balancer application profile type: %s", err)
+		return diag.Errorf("unable to create load balancer application profile type: %s", err)
 	}
 
 	createdPool, err := edgeGateway.CreateLbAppProfile(LBProfile)
 	if err != nil {
-		return fmt.Errorf("error creating new load balancer application profile: %s", err)
+		return diag.Errorf("error creating new load balancer application profile: %s", err)
 	}
 
 	// We store the values once again because response include pool member IDs
 	err = setLBAppProfileData(d, createdPool)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(createdPool.ID)
-	return resourceVcdLBAppProfileRead(d, meta)
+	return resourceVcdLBAppProfileRead(ctx, d, meta)
 }
 
-func resourceVcdLBAppProfileRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppProfileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBProfile, err := edgeGateway.GetLbAppProfileById(d.Id())
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("unable to find load balancer application profile with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to find load balancer application profile with ID %s: %s", d.Id(), err)
 	}
 
-	return setLBAppProfileData(d, readLBProfile)
+	return diag.FromErr(setLBAppProfileData(d, readLBProfile))
 }
 
-func resourceVcdLBAppProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppProfileUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	updateLBProfileConfig, err := getLBAppProfileType(d)
 	updateLBProfileConfig.ID = d.Id() // We already know an ID for update and it allows to change name
 	if err != nil {
-		return fmt.Errorf("unable to create load balancer application profile type for update: %s", err)
+		return diag.Errorf("unable to create load balancer application profile type for update: %s", err)
 	}
 
 	updatedLBProfile, err := edgeGateway.UpdateLbAppProfile(updateLBProfileConfig)
 	if err != nil {
-		return fmt.Errorf("unable to update load balancer application profile with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to update load balancer application profile with ID %s: %s", d.Id(), err)
 	}
 
 	if err := setLBAppProfileData(d, updatedLBProfile); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceVcdLBAppProfileDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppProfileDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.DeleteLbAppProfileById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting load balancer application profile: %s", err)
+		return diag.Errorf("error deleting load balancer application profile: %s", err)
 	}
 
 	d.SetId("")
@@ -211,8 +213,8 @@ func resourceVcdLBAppProfileDelete(d *schema.ResourceData, meta interface{}) err
 //
 // Example import path (id): org.vdc.edge-gw.existing-app-profile
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdLBAppProfileImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdLBAppProfileImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified in such way org.vdc.edge-gw.existing-app-profile")
 	}
@@ -1,21 +1,22 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 func resourceVcdLBAppRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdLBAppRuleCreate,
-		Read:   resourceVcdLBAppRuleRead,
-		Update: resourceVcdLBAppRuleUpdate,
-		Delete: resourceVcdLBAppRuleDelete,
+		CreateContext: resourceVcdLBAppRuleCreate,
+		ReadContext:   resourceVcdLBAppRuleRead,
+		UpdateContext: resourceVcdLBAppRuleUpdate,
+		DeleteContext: resourceVcdLBAppRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdLBAppRuleImport,
+			StateContext: resourceVcdLBAppRuleImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,92 +54,92 @@ func resourceVcdLBAppRule() *schema.Resource {
 	}
 }
 
-func resourceVcdLBAppRuleCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	LBRule, err := getLBAppRuleType(d)
 	if err != nil {
-		return fmt.Errorf("unable to create load balancer application rule type: %s", err)
+		return diag.Errorf("unable to create load balancer application rule type: %s", err)
 	}
 
 	createdPool, err := edgeGateway.CreateLbAppRule(LBRule)
 	if err != nil {
-		return fmt.Errorf("error creating new load balancer application rule: %s", err)
+		return diag.Errorf("error creating new load balancer application rule: %s", err)
 	}
 
 	err = setLBAppRuleData(d, createdPool)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(createdPool.ID)
-	return resourceVcdLBAppRuleRead(d, meta)
+	return resourceVcdLBAppRuleRead(ctx, d, meta)
 }
 
-func resourceVcdLBAppRuleRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBRule, err := edgeGateway.GetLbAppRuleById(d.Id())
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("unable to find load balancer application rule with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to find load balancer application rule with ID %s: %s", d.Id(), err)
 	}
 
-	return setLBAppRuleData(d, readLBRule)
+	return diag.FromErr(setLBAppRuleData(d, readLBRule))
 }
 
-func resourceVcdLBAppRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	updateLBRuleConfig, err := getLBAppRuleType(d)
 	updateLBRuleConfig.ID = d.Id() // We already know an ID for update and it allows to change name
 	if err != nil {
-		return fmt.Errorf("could not create load balancer application rule type for update: %s", err)
+		return diag.Errorf("could not create load balancer application rule type for update: %s", err)
 	}
 
 	updatedLBRule, err := edgeGateway.UpdateLbAppRule(updateLBRuleConfig)
 	if err != nil {
-		return fmt.Errorf("unable to update load balancer application rule with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to update load balancer application rule with ID %s: %s", d.Id(), err)
 	}
 
 	if err := setLBAppRuleData(d, updatedLBRule); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceVcdLBAppRuleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBAppRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.DeleteLbAppRuleById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting load balancer application rule: %s", err)
+		return diag.Errorf("error deleting load balancer application rule: %s", err)
 	}
 
 	d.SetId("")
@@ -158,8 +159,8 @@ func resourceVcdLBAppRuleDelete(d *schema.ResourceData, meta interface{}) error
 // Example resource name (_resource_name_): vcd_lb_app_rule.my-test-app-rule
 // Example import path (_the_id_string_): org.vdc.edge-gw.existing-app-rule
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdLBAppRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdLBAppRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified in such way org.vdc.edge-gw.existing-app-rule")
 	}
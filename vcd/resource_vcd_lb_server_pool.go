@@ -1,21 +1,22 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 func resourceVcdLBServerPool() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdLBServerPoolCreate,
-		Read:   resourceVcdLBServerPoolRead,
-		Update: resourceVcdLBServerPoolUpdate,
-		Delete: resourceVcdLBServerPoolDelete,
+		CreateContext: resourceVcdLBServerPoolCreate,
+		ReadContext:   resourceVcdLBServerPoolRead,
+		UpdateContext: resourceVcdLBServerPoolUpdate,
+		DeleteContext: resourceVcdLBServerPoolDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdLBServerPoolImport,
+			StateContext: resourceVcdLBServerPoolImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -139,88 +140,88 @@ func resourceVcdLBServerPool() *schema.Resource {
 	}
 }
 
-func resourceVcdLBServerPoolCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBServerPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	LBPool, err := getLBPoolType(d)
 	if err != nil {
-		return fmt.Errorf("unable to create load balancer server pool type: %s", err)
+		return diag.Errorf("unable to create load balancer server pool type: %s", err)
 	}
 
 	createdPool, err := edgeGateway.CreateLbServerPool(LBPool)
 	if err != nil {
-		return fmt.Errorf("error creating new load balancer server pool: %s", err)
+		return diag.Errorf("error creating new load balancer server pool: %s", err)
 	}
 
 	// We store the values once again because response includes pool member IDs
 	if err := setLBPoolData(d, createdPool); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(createdPool.ID)
-	return resourceVcdLBServerPoolRead(d, meta)
+	return resourceVcdLBServerPoolRead(ctx, d, meta)
 }
 
-func resourceVcdLBServerPoolRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBServerPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBPool, err := edgeGateway.GetLbServerPoolById(d.Id())
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("unable to find load balancer server pool with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to find load balancer server pool with ID %s: %s", d.Id(), err)
 	}
 
-	return setLBPoolData(d, readLBPool)
+	return diag.FromErr(setLBPoolData(d, readLBPool))
 }
 
-func resourceVcdLBServerPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBServerPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	updateLBPoolConfig, err := getLBPoolType(d)
 	updateLBPoolConfig.ID = d.Id() // We already know an ID for update and it allows to change name
 	if err != nil {
-		return fmt.Errorf("could not create load balancer server pool type for update: %s", err)
+		return diag.Errorf("could not create load balancer server pool type for update: %s", err)
 	}
 
 	updatedLBPool, err := edgeGateway.UpdateLbServerPool(updateLBPoolConfig)
 	if err != nil {
-		return fmt.Errorf("unable to update load balancer server pool with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to update load balancer server pool with ID %s: %s", d.Id(), err)
 	}
 
-	return setLBPoolData(d, updatedLBPool)
+	return diag.FromErr(setLBPoolData(d, updatedLBPool))
 }
 
-func resourceVcdLBServerPoolDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBServerPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.DeleteLbServerPoolById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting load balancer server pool: %s", err)
+		return diag.Errorf("error deleting load balancer server pool: %s", err)
 	}
 
 	d.SetId("")
@@ -234,8 +235,8 @@ func resourceVcdLBServerPoolDelete(d *schema.ResourceData, meta interface{}) err
 //
 // Example import path (id): org.vdc.edge-gw.lb-server-pool
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdLBServerPoolImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdLBServerPoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-server-pool")
 	}
@@ -1,21 +1,23 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
 
 func resourceVcdLbServiceMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdLbServiceMonitorCreate,
-		Read:   resourceVcdLbServiceMonitorRead,
-		Update: resourceVcdLbServiceMonitorUpdate,
-		Delete: resourceVcdLbServiceMonitorDelete,
+		CreateContext: resourceVcdLbServiceMonitorCreate,
+		ReadContext:   resourceVcdLbServiceMonitorRead,
+		UpdateContext: resourceVcdLbServiceMonitorUpdate,
+		DeleteContext: resourceVcdLbServiceMonitorDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdLbServiceMonitorImport,
+			StateContext: resourceVcdLbServiceMonitorImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -102,85 +104,85 @@ func resourceVcdLbServiceMonitor() *schema.Resource {
 	}
 }
 
-func resourceVcdLbServiceMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLbServiceMonitorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	lbMonitor, err := getLBMonitorType(d)
 	if err != nil {
-		return fmt.Errorf("unable to create load balancer service monitor type: %s", err)
+		return diag.Errorf("unable to create load balancer service monitor type: %s", err)
 	}
 
 	createdMonitor, err := edgeGateway.CreateLbServiceMonitor(lbMonitor)
 	if err != nil {
-		return fmt.Errorf("error creating new load balancer service monitor: %s", err)
+		return diag.Errorf("error creating new load balancer service monitor: %s", err)
 	}
 
 	d.SetId(createdMonitor.ID)
-	return resourceVcdLbServiceMonitorRead(d, meta)
+	return resourceVcdLbServiceMonitorRead(ctx, d, meta)
 }
 
-func resourceVcdLbServiceMonitorRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLbServiceMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBMonitor, err := edgeGateway.GetLbServiceMonitorById(d.Id())
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("unable to find load balancer service monitor with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to find load balancer service monitor with ID %s: %s", d.Id(), err)
 	}
 
-	return setLBMonitorData(d, readLBMonitor)
+	return diag.FromErr(setLBMonitorData(d, readLBMonitor))
 }
 
-func resourceVcdLbServiceMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLbServiceMonitorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	updateLBMonitorConfig, err := getLBMonitorType(d)
 	updateLBMonitorConfig.ID = d.Id() // We already know an ID for update and it allows to change name
 
 	if err != nil {
-		return fmt.Errorf("could not create service monitor type for update: %s", err)
+		return diag.Errorf("could not create service monitor type for update: %s", err)
 	}
 
 	updatedLBMonitor, err := edgeGateway.UpdateLbServiceMonitor(updateLBMonitorConfig)
 	if err != nil {
-		return fmt.Errorf("unable to update load balancer service monitor with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to update load balancer service monitor with ID %s: %s", d.Id(), err)
 	}
 
-	return setLBMonitorData(d, updatedLBMonitor)
+	return diag.FromErr(setLBMonitorData(d, updatedLBMonitor))
 }
 
-func resourceVcdLbServiceMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLbServiceMonitorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.DeleteLbServiceMonitorById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting load balancer service monitor: %s", err)
+		return diag.Errorf("error deleting load balancer service monitor: %s", err)
 	}
 
 	d.SetId("")
@@ -194,9 +196,9 @@ func resourceVcdLbServiceMonitorDelete(d *schema.ResourceData, meta interface{})
 //
 // Example import path (id): org.vdc.edge-gw.lb-service-monitor
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdLbServiceMonitorImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdLbServiceMonitorImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-service-monitor")
 	}
@@ -1,9 +1,10 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -11,12 +12,12 @@ import (
 
 func resourceVcdLBVirtualServer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdLBVirtualServerCreate,
-		Read:   resourceVcdLBVirtualServerRead,
-		Update: resourceVcdLBVirtualServerUpdate,
-		Delete: resourceVcdLBVirtualServerDelete,
+		CreateContext: resourceVcdLBVirtualServerCreate,
+		ReadContext:   resourceVcdLBVirtualServerRead,
+		UpdateContext: resourceVcdLBVirtualServerUpdate,
+		DeleteContext: resourceVcdLBVirtualServerDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdLBVirtualServerImport,
+			StateContext: resourceVcdLBVirtualServerImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -109,85 +110,85 @@ func resourceVcdLBVirtualServer() *schema.Resource {
 	}
 }
 
-func resourceVcdLBVirtualServerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBVirtualServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	lBVirtualServer, err := getLBVirtualServerType(d)
 	if err != nil {
-		return fmt.Errorf("unable to make load balancer virtual server query: %s", err)
+		return diag.Errorf("unable to make load balancer virtual server query: %s", err)
 	}
 
 	createdVirtualServer, err := edgeGateway.CreateLbVirtualServer(lBVirtualServer)
 	if err != nil {
-		return fmt.Errorf("error creating new load balancer virtual server: %s", err)
+		return diag.Errorf("error creating new load balancer virtual server: %s", err)
 	}
 
 	d.SetId(createdVirtualServer.ID)
-	return resourceVcdLBVirtualServerRead(d, meta)
+	return resourceVcdLBVirtualServerRead(ctx, d, meta)
 }
 
-func resourceVcdLBVirtualServerRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBVirtualServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readVirtualServer, err := edgeGateway.GetLbVirtualServerById(d.Id())
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("unable to find load balancer virtual server with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to find load balancer virtual server with ID %s: %s", d.Id(), err)
 	}
 
-	return setlBVirtualServerData(d, readVirtualServer)
+	return diag.FromErr(setlBVirtualServerData(d, readVirtualServer))
 }
 
-func resourceVcdLBVirtualServerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBVirtualServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	updateVirtualServerConfig, err := getLBVirtualServerType(d)
 	updateVirtualServerConfig.ID = d.Id() // We already know an ID for update and it allows to change name
 
 	if err != nil {
-		return fmt.Errorf("could not create load balancer virtual server type for update: %s", err)
+		return diag.Errorf("could not create load balancer virtual server type for update: %s", err)
 	}
 
 	updatedVirtualServer, err := edgeGateway.UpdateLbVirtualServer(updateVirtualServerConfig)
 	if err != nil {
-		return fmt.Errorf("unable to update load balancer virtual server with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to update load balancer virtual server with ID %s: %s", d.Id(), err)
 	}
 
-	return setlBVirtualServerData(d, updatedVirtualServer)
+	return diag.FromErr(setlBVirtualServerData(d, updatedVirtualServer))
 }
 
-func resourceVcdLBVirtualServerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdLBVirtualServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.DeleteLbVirtualServerById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting load balancer virtual server: %s", err)
+		return diag.Errorf("error deleting load balancer virtual server: %s", err)
 	}
 
 	d.SetId("")
@@ -207,8 +208,8 @@ func resourceVcdLBVirtualServerDelete(d *schema.ResourceData, meta interface{})
 // Example resource name (_resource_name_): vcd_lb_virtual_server.my-test-virtual-server
 // Example import path (_the_id_string_): org.vdc.edge-gw.existing-virtual-server
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdLBVirtualServerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdLBVirtualServerImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-virtual-server")
 	}
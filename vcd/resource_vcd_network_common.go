@@ -1,10 +1,12 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
 	"net/netip"
 	"strconv"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 )
@@ -88,3 +90,53 @@ func setOpenApiOrgVdcNetworkStaticPoolData(d *schema.ResourceData, ipRanges []ty
 
 	return nil
 }
+
+// resourceVcdNetworkV2CustomizeDiff validates at plan time that static IP pools of NSX-T Org VDC
+// networks are within the subnet defined by gateway and prefix length, so that the error is
+// reported before any network is created
+func resourceVcdNetworkV2CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+
+	gateway, gatewayKnown := rawConfigString(rawConfig, "gateway")
+	prefixLength, prefixLengthKnown := rawConfigInt(rawConfig, "prefix_length")
+	if gatewayKnown && prefixLengthKnown {
+		err := validateNetworkV2StaticPools(rawConfig, "static_ip_pool", gateway, prefixLength)
+		if err != nil {
+			return err
+		}
+	}
+
+	secondaryGateway, secondaryGatewayKnown := rawConfigString(rawConfig, "secondary_gateway")
+	secondaryPrefixLength, secondaryPrefixLengthKnown := rawConfigString(rawConfig, "secondary_prefix_length")
+	if secondaryGatewayKnown && secondaryPrefixLengthKnown {
+		// Not a number is reported by schema validation
+		secondaryPrefixLengthInt, err := strconv.Atoi(secondaryPrefixLength)
+		if err != nil {
+			return nil
+		}
+		return validateNetworkV2StaticPools(rawConfig, "secondary_static_ip_pool", secondaryGateway, secondaryPrefixLengthInt)
+	}
+
+	return nil
+}
+
+// validateNetworkV2StaticPools checks that all known ranges in static pool 'fieldName' are within
+// the subnet defined by 'gateway' and 'prefixLength'
+func validateNetworkV2StaticPools(rawConfig cty.Value, fieldName, gateway string, prefixLength int) error {
+	ranges, ok := rawConfigElements(rawConfig, fieldName)
+	if !ok {
+		return nil
+	}
+	for _, ipRange := range ranges {
+		startAddress, startKnown := rawConfigString(ipRange, "start_address")
+		endAddress, endKnown := rawConfigString(ipRange, "end_address")
+		if !startKnown || !endKnown {
+			continue
+		}
+		err := validateIpRangeInSubnet(gateway, prefixLength, startAddress, endAddress)
+		if err != nil {
+			return fmt.Errorf("%s: %s", fieldName, err)
+		}
+	}
+	return nil
+}
@@ -3,9 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -13,7 +13,7 @@ import (
 )
 
 func resourceVcdNetworkDirect() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdNetworkDirectCreate,
 		ReadContext:   resourceVcdNetworkDirectRead,
 		UpdateContext: resourceVcdNetworkDirectUpdate,
@@ -97,7 +97,7 @@ func resourceVcdNetworkDirect() *schema.Resource {
 			},
 			"metadata_entry": metadataEntryResourceSchemaDeprecated("Network"),
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdNetworkDirectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -145,7 +145,7 @@ func resourceVcdNetworkDirectCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(network.OrgVDCNetwork.ID)
 
-	err = createOrUpdateMetadata(d, network, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, network, "metadata")
 	if err != nil {
 		return diag.Errorf("error adding metadata to direct network: %s", err)
 	}
@@ -157,7 +157,7 @@ func resourceVcdNetworkDirectRead(ctx context.Context, d *schema.ResourceData, m
 	return genericVcdNetworkDirectRead(ctx, d, meta, "resource")
 }
 
-func genericVcdNetworkDirectRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdNetworkDirectRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
 
@@ -170,7 +170,7 @@ func genericVcdNetworkDirectRead(_ context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		if origin == "resource" {
 			networkName := d.Get("name").(string)
-			log.Printf("[DEBUG] Network %s no longer exists. Removing from tfstate", networkName)
+			tflog.Debug(ctx, "Network no longer exists. Removing from tfstate", map[string]interface{}{"network_name": networkName})
 			d.SetId("")
 			return nil
 		}
@@ -207,9 +207,9 @@ func genericVcdNetworkDirectRead(_ context.Context, d *schema.ResourceData, meta
 	dSet(d, "description", network.OrgVDCNetwork.Description)
 	d.SetId(network.OrgVDCNetwork.ID)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_network_direct", network)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_network_direct", network)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to set direct network metadata: %v", diags)
+		tflog.Debug(ctx, "Unable to set direct network metadata", map[string]interface{}{"diags": diags})
 		return diags
 	}
 
@@ -240,7 +240,7 @@ func resourceVcdNetworkDirectUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("[direct network update] error updating network %s: %s", network.OrgVDCNetwork.Name, err)
 	}
 
-	err = createOrUpdateMetadata(d, network, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, network, "metadata")
 	if err != nil {
 		return diag.Errorf("[direct network update] error updating network metadata: %s", err)
 	}
@@ -299,8 +299,8 @@ func getNetwork(d *schema.ResourceData, vcdClient *VCDClient, isDataSource bool,
 // Example resource name (_resource_name_): vcd_network_direct.my-network
 // Example import path (_the_id_string_): org.vdc.my-network
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdNetworkDirectImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNetworkDirectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[direct network import] resource name must be specified as org-name.vdc-name.network-name")
 	}
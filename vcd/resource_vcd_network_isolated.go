@@ -3,9 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -14,7 +14,7 @@ import (
 )
 
 func resourceVcdNetworkIsolated() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdNetworkIsolatedCreate,
 		ReadContext:   resourceVcdNetworkIsolatedRead,
 		UpdateContext: resourceVcdNetworkIsolatedUpdate,
@@ -165,7 +165,7 @@ func resourceVcdNetworkIsolated() *schema.Resource {
 			},
 			"metadata_entry": metadataEntryResourceSchemaDeprecated("Network"),
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdNetworkIsolatedCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -259,7 +259,7 @@ func resourceVcdNetworkIsolatedCreate(ctx context.Context, d *schema.ResourceDat
 	}
 	d.SetId(network.OrgVDCNetwork.ID)
 
-	err = createOrUpdateMetadata(d, network, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, network, "metadata")
 	if err != nil {
 		return diag.Errorf("error adding metadata to isolated network: %s", err)
 	}
@@ -271,7 +271,7 @@ func resourceVcdNetworkIsolatedRead(ctx context.Context, d *schema.ResourceData,
 	return genericVcdNetworkIsolatedRead(ctx, d, meta, "resource", nil)
 }
 
-func genericVcdNetworkIsolatedRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string, updatedNetwork *govcd.OrgVDCNetwork) diag.Diagnostics {
+func genericVcdNetworkIsolatedRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string, updatedNetwork *govcd.OrgVDCNetwork) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var network *govcd.OrgVDCNetwork
 	var err error
@@ -286,7 +286,7 @@ func genericVcdNetworkIsolatedRead(_ context.Context, d *schema.ResourceData, me
 		if err != nil {
 			if origin == "resource" {
 				networkName := d.Get("name").(string)
-				log.Printf("[DEBUG] Network %s no longer exists. Removing from tfstate", networkName)
+				tflog.Debug(ctx, "Network no longer exists. Removing from tfstate", map[string]interface{}{"network_name": networkName})
 				d.SetId("")
 				return nil
 			}
@@ -344,9 +344,9 @@ func genericVcdNetworkIsolatedRead(_ context.Context, d *schema.ResourceData, me
 	dSet(d, "description", network.OrgVDCNetwork.Description)
 	d.SetId(network.OrgVDCNetwork.ID)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, meta.(*VCDClient), "vcd_network_isolated", network)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, meta.(*VCDClient), "vcd_network_isolated", network)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to set isolated network metadata: %v", diags)
+		tflog.Debug(ctx, "Unable to set isolated network metadata", map[string]interface{}{"diags": diags})
 		return diags
 	}
 
@@ -397,8 +397,8 @@ func resourceVcdNetworkIsolatedDhcpPoolHash(v interface{}) int {
 // Example resource name (_resource_name_): vcd_network_isolated.my-network
 // Example import path (_the_id_string_): org.vdc.my-network
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdNetworkIsolatedImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNetworkIsolatedImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[isolated network import] resource name must be specified as org-name.vdc-name.network-name")
 	}
@@ -494,7 +494,7 @@ func resourceVcdNetworkIsolatedUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("error updating isolated network: %s", err)
 	}
 
-	err = createOrUpdateMetadata(d, network, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, network, "metadata")
 	if err != nil {
 		return diag.Errorf("error updating isolated network metadata: %s", err)
 	}
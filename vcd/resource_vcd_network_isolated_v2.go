@@ -3,18 +3,17 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceVcdNetworkIsolatedV2() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdNetworkIsolatedV2Create,
 		ReadContext:   resourceVcdNetworkIsolatedV2Read,
 		UpdateContext: resourceVcdNetworkIsolatedV2Update,
@@ -22,6 +21,7 @@ func resourceVcdNetworkIsolatedV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkIsolatedV2Import,
 		},
+		CustomizeDiff: resourceVcdNetworkV2CustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -132,7 +132,7 @@ func resourceVcdNetworkIsolatedV2() *schema.Resource {
 			},
 			"metadata_entry": metadataEntryResourceSchemaDeprecated("Network"),
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdNetworkIsolatedV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -148,7 +148,7 @@ func resourceVcdNetworkIsolatedV2Create(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("[isolated network create v2] error retrieving Org: %s", err)
 	}
 
-	networkType, err := getOpenApiOrgVdcIsolatedNetworkType(d, vcdClient)
+	networkType, err := getOpenApiOrgVdcIsolatedNetworkType(ctx, d, vcdClient)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -160,7 +160,7 @@ func resourceVcdNetworkIsolatedV2Create(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(orgNetwork.OpenApiOrgVdcNetwork.ID)
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(ctx, d, vcdClient, orgNetwork)
 	if err != nil {
 		return diag.Errorf("[isolated network v2 create] error adding metadata to Isolated network: %s", err)
 	}
@@ -198,7 +198,7 @@ func resourceVcdNetworkIsolatedV2Update(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("[isolated network v2 update] error getting Isolated network: %s", err)
 	}
 
-	networkType, err := getOpenApiOrgVdcIsolatedNetworkType(d, vcdClient)
+	networkType, err := getOpenApiOrgVdcIsolatedNetworkType(ctx, d, vcdClient)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -211,7 +211,7 @@ func resourceVcdNetworkIsolatedV2Update(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("[isolated network v2 update] error updating Isolated network: %s", err)
 	}
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(ctx, d, vcdClient, orgNetwork)
 	if err != nil {
 		return diag.Errorf("[isolated network v2 update] error updating Isolated network metadata: %s", err)
 	}
@@ -219,7 +219,7 @@ func resourceVcdNetworkIsolatedV2Update(ctx context.Context, d *schema.ResourceD
 	return resourceVcdNetworkIsolatedV2Read(ctx, d, meta)
 }
 
-func resourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdNetworkIsolatedV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	org, err := vcdClient.GetOrgFromResource(d)
@@ -248,7 +248,7 @@ func resourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceData,
 	// Hence, we skip the read to preserve its value in state.
 	var diags diag.Diagnostics
 	if !govcd.OwnerIsVdcGroup(orgNetwork.OpenApiOrgVdcNetwork.OwnerRef.ID) {
-		diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_network_isolated_v2", orgNetwork)...)
+		diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_network_isolated_v2", orgNetwork)...)
 	} else if _, ok := d.GetOk("metadata"); !ok {
 		// If it's a VDC Group and metadata is not set, we explicitly compute it to empty. Otherwise, its value should
 		// be preserved as it is still present in the entity.
@@ -295,8 +295,8 @@ func resourceVcdNetworkIsolatedV2Delete(_ context.Context, d *schema.ResourceDat
 	return nil
 }
 
-func resourceVcdNetworkIsolatedV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNetworkIsolatedV2Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[isolated network v2 import] resource name must be specified as org-name.vdc-name.network-name")
 	}
@@ -362,12 +362,12 @@ func setOpenApiOrgVdcIsolatedNetworkData(d *schema.ResourceData, orgVdcNetwork *
 	return nil
 }
 
-func getOpenApiOrgVdcIsolatedNetworkType(d *schema.ResourceData, vcdClient *VCDClient) (*types.OpenApiOrgVdcNetwork, error) {
+func getOpenApiOrgVdcIsolatedNetworkType(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient) (*types.OpenApiOrgVdcNetwork, error) {
 	inheritedVdcField := vcdClient.Vdc
 	vdcField := d.Get("vdc").(string)
 	ownerIdField := d.Get("owner_id").(string)
 
-	ownerId, err := getOwnerId(d, vcdClient, ownerIdField, vdcField, inheritedVdcField)
+	ownerId, err := getOwnerId(ctx, d, vcdClient, ownerIdField, vdcField, inheritedVdcField)
 	if err != nil {
 		return nil, fmt.Errorf("error finding owner reference: %s", err)
 	}
@@ -405,13 +405,13 @@ func getOpenApiOrgVdcIsolatedNetworkType(d *schema.ResourceData, vcdClient *VCDC
 	return orgVdcNetworkConfig, nil
 }
 
-func createOrUpdateOpenApiNetworkMetadata(d *schema.ResourceData, network *govcd.OpenApiOrgVdcNetwork) error {
-	log.Printf("[TRACE] adding/updating metadata to Network V2")
+func createOrUpdateOpenApiNetworkMetadata(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, network *govcd.OpenApiOrgVdcNetwork) error {
+	tflog.Trace(ctx, "adding/updating metadata to Network V2")
 
 	// Metadata is not supported when the network is in a VDC Group
 	if govcd.OwnerIsVdcGroup(network.OpenApiOrgVdcNetwork.OwnerRef.ID) {
 		return nil
 	}
 
-	return createOrUpdateMetadata(d, network, "metadata")
+	return createOrUpdateMetadata(d, vcdClient, network, "metadata")
 }
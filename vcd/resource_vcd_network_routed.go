@@ -4,20 +4,19 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 func resourceVcdNetworkRouted() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdNetworkRoutedCreate,
 		ReadContext:   resourceVcdNetworkRoutedRead,
 		DeleteContext: resourceVcdNetworkDeleteLocked,
@@ -188,7 +187,7 @@ func resourceVcdNetworkRouted() *schema.Resource {
 			},
 			"metadata_entry": metadataEntryResourceSchemaDeprecated("Network"),
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdNetworkRoutedCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -294,7 +293,7 @@ func resourceVcdNetworkRoutedCreate(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(network.OrgVDCNetwork.ID)
 
-	err = createOrUpdateMetadata(d, network, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, network, "metadata")
 	if err != nil {
 		return diag.Errorf("error adding metadata to routed network: %s", err)
 	}
@@ -306,7 +305,7 @@ func resourceVcdNetworkRoutedRead(ctx context.Context, d *schema.ResourceData, m
 	return genericVcdNetworkRoutedRead(ctx, d, meta, "resource")
 }
 
-func genericVcdNetworkRoutedRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdNetworkRoutedRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
 
@@ -319,7 +318,7 @@ func genericVcdNetworkRoutedRead(_ context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		if origin == "resource" {
 			networkName := d.Get("name").(string)
-			log.Printf("[DEBUG] Network %s no longer exists. Removing from tfstate", networkName)
+			tflog.Debug(ctx, "Network no longer exists. Removing from tfstate", map[string]interface{}{"network_name": networkName})
 			d.SetId("")
 			return nil
 		}
@@ -337,7 +336,7 @@ func genericVcdNetworkRoutedRead(_ context.Context, d *schema.ResourceData, meta
 	}
 	edgeGateway, err := vdc.GetEdgeGatewayByName(edgeGatewayName, false)
 	if err != nil {
-		log.Printf("[DEBUG] error retrieving edge gateway")
+		tflog.Debug(ctx, "error retrieving edge gateway")
 		return diag.Errorf("[routed network read] error retrieving edge gateway %s: %s", edgeGatewayName, err)
 	}
 
@@ -396,9 +395,9 @@ func genericVcdNetworkRoutedRead(_ context.Context, d *schema.ResourceData, meta
 	dSet(d, "description", network.OrgVDCNetwork.Description)
 	d.SetId(network.OrgVDCNetwork.ID)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_network_routed", network)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_network_routed", network)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to set routed network metadata: %v", diags)
+		tflog.Debug(ctx, "Unable to set routed network metadata", map[string]interface{}{"diags": diags})
 		return diags
 	}
 
@@ -507,12 +506,12 @@ func resourceVcdNetworkStaticIpPoolHash(v interface{}) int {
 	_, err := buf.WriteString(fmt.Sprintf("%s-",
 		strings.ToLower(m["start_address"].(string))))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%s-",
 		strings.ToLower(m["end_address"].(string))))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	return hashcodeString(buf.String())
 }
@@ -525,24 +524,24 @@ func genericResourceVcdNetworkDhcpPoolHash(v interface{}, networkType string) in
 	_, err := buf.WriteString(fmt.Sprintf("%s-",
 		strings.ToLower(m["start_address"].(string))))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%s-",
 		strings.ToLower(m["end_address"].(string))))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%d-",
 		m["max_lease_time"].(int)))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 
 	switch networkType {
 	case "isolated":
 		_, err = buf.WriteString(fmt.Sprintf("%d-", m["default_lease_time"].(int)))
 		if err != nil {
-			util.Logger.Printf("[ERROR] error writing to string: %s", err)
+			tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 		}
 	case "routed":
 		// do nothing
@@ -570,8 +569,8 @@ func resourceVcdNetworkRoutedDhcpPoolHash(v interface{}) int {
 // Example resource name (_resource_name_): vcd_network_routed.my-network
 // Example import path (_the_id_string_): org.vdc.my-network
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdNetworkRoutedImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNetworkRoutedImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[routed network import] resource name must be specified as org-name.vdc-name.network-name")
 	}
@@ -677,7 +676,7 @@ func resourceVcdNetworkRoutedUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	err = createOrUpdateMetadata(d, network, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, network, "metadata")
 	if err != nil {
 		return diag.Errorf("[routed network update] error updating network metadata: %s", err)
 	}
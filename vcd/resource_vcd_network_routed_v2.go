@@ -15,7 +15,7 @@ import (
 )
 
 func resourceVcdNetworkRoutedV2() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdNetworkRoutedV2Create,
 		ReadContext:   resourceVcdNetworkRoutedV2Read,
 		UpdateContext: resourceVcdNetworkRoutedV2Update,
@@ -23,6 +23,7 @@ func resourceVcdNetworkRoutedV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkRoutedV2Import,
 		},
+		CustomizeDiff: resourceVcdNetworkV2CustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -144,7 +145,7 @@ func resourceVcdNetworkRoutedV2() *schema.Resource {
 				Description: "Whether this network is advertised so that it can be routed out to the external networks.",
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdNetworkRoutedV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -180,7 +181,7 @@ func resourceVcdNetworkRoutedV2Create(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(orgNetwork.OpenApiOrgVdcNetwork.ID)
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(ctx, d, vcdClient, orgNetwork)
 	if err != nil {
 		return diag.Errorf("[routed network create v2] error adding metadata to Routed network: %s", err)
 	}
@@ -232,7 +233,7 @@ func resourceVcdNetworkRoutedV2Update(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("[routed network update v2] error updating Routed network: %s", err)
 	}
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(ctx, d, vcdClient, orgNetwork)
 	if err != nil {
 		return diag.Errorf("[routed network v2 update] error updating Routed network metadata: %s", err)
 	}
@@ -240,7 +241,7 @@ func resourceVcdNetworkRoutedV2Update(ctx context.Context, d *schema.ResourceDat
 	return resourceVcdNetworkRoutedV2Read(ctx, d, meta)
 }
 
-func resourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdNetworkRoutedV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	org, err := vcdClient.GetOrgFromResource(d)
@@ -269,7 +270,7 @@ func resourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData, m
 	// Hence, we skip the read to preserve its value in state.
 	var diags diag.Diagnostics
 	if !govcd.OwnerIsVdcGroup(orgNetwork.OpenApiOrgVdcNetwork.OwnerRef.ID) {
-		diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_network_routed_v2", orgNetwork)...)
+		diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_network_routed_v2", orgNetwork)...)
 	} else if _, ok := d.GetOk("metadata"); !ok {
 		// If it's a VDC Group and metadata is not set, we explicitly compute it to empty. Otherwise, its value should
 		// be preserved as it is still present in the entity.
@@ -323,8 +324,8 @@ func resourceVcdNetworkRoutedV2Delete(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func resourceVcdNetworkRoutedV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNetworkRoutedV2Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[routed network import v2] resource name must be specified as org-name.vdc-name.network-name or org-name.vdc-group-name.network-name")
 	}
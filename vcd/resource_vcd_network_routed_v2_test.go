@@ -118,6 +118,13 @@ func TestAccVcdNetworkRoutedV2NsxvDistributedInterface(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateIdFunc: importStateIdOrgVdcObject(t.Name()),
 			},
+			// Check that import works using the network URN
+			{
+				ResourceName:      "vcd_network_routed_v2.net1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdUrnViaResource("vcd_network_routed_v2.net1"),
+			},
 		},
 	})
 	postTestChecks(t)
@@ -3,14 +3,13 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -126,13 +125,15 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroupUpdate(ctx context.Context, d *s
 	return resourceVcdAlbEdgeGatewayServiceEngineGroupRead(ctx, d, meta)
 }
 
-func resourceVcdAlbEdgeGatewayServiceEngineGroupRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdAlbEdgeGatewayServiceEngineGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeAlbServiceEngineGroupAssignment, err := vcdClient.GetAlbServiceEngineGroupAssignmentById(d.Id())
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("ALB Service Engine Group assignment not found. Removing from state file: %s", err)
+			tflog.Debug(ctx, "ALB Service Engine Group assignment not found. Removing from state file", map[string]interface{}{
+				"error": err,
+			})
 			d.SetId("")
 			return nil
 		}
@@ -164,10 +165,10 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroupDelete(_ context.Context, d *sch
 	return nil
 }
 
-func resourceVcdAlbEdgeGatewayServiceEngineGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T ALB Service Engine Group assignment import initiated")
+func resourceVcdAlbEdgeGatewayServiceEngineGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T ALB Service Engine Group assignment import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.vdc-name.nsxt-edge-gw-name.se-group-name")
 	}
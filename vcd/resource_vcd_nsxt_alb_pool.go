@@ -3,11 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
-
-	"github.com/vmware/go-vcloud-director/v3/util"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -279,7 +276,7 @@ func resourceVcdAlbPoolCreate(ctx context.Context, d *schema.ResourceData, meta
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
-	albPoolConfig, err := getNsxtAlbPoolType(d)
+	albPoolConfig, err := getNsxtAlbPoolType(ctx, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T ALB Pool type: %s", err)
 	}
@@ -303,7 +300,7 @@ func resourceVcdAlbPoolUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(fmt.Errorf("could not retrieve NSX-T ALB Pool: %s", err))
 	}
 
-	updatePoolConfig, err := getNsxtAlbPoolType(d)
+	updatePoolConfig, err := getNsxtAlbPoolType(ctx, d)
 	if err != nil {
 		return diag.Errorf("error getting NSX-T ALB Pool type: %s", err)
 	}
@@ -355,10 +352,10 @@ func resourceVcdAlbPoolDelete(_ context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceVcdAlbPoolImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T ALB Pool import initiated")
+func resourceVcdAlbPoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T ALB Pool import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.pool_name")
 	}
@@ -394,7 +391,7 @@ func resourceVcdAlbPoolImport(_ context.Context, d *schema.ResourceData, meta in
 
 // getNsxtAlbPoolType is the main function for getting *types.NsxtAlbPool for API request. It nests multiple smaller
 // functions for smaller types.
-func getNsxtAlbPoolType(d *schema.ResourceData) (*types.NsxtAlbPool, error) {
+func getNsxtAlbPoolType(ctx context.Context, d *schema.ResourceData) (*types.NsxtAlbPool, error) {
 	albPoolConfig := &types.NsxtAlbPool{
 		Name:                     d.Get("name").(string),
 		Description:              d.Get("description").(string),
@@ -418,7 +415,7 @@ func getNsxtAlbPoolType(d *schema.ResourceData) (*types.NsxtAlbPool, error) {
 		albPoolConfig.MemberGroupRef = &types.OpenApiReference{ID: memberGroupId}
 	}
 
-	persistenceProfile, err := getNsxtAlbPoolPersistenceProfileType(d)
+	persistenceProfile, err := getNsxtAlbPoolPersistenceProfileType(ctx, d)
 	if err != nil {
 		return nil, fmt.Errorf("error defining persistence profile: %s", err)
 	}
@@ -547,15 +544,15 @@ func getNsxtAlbPoolHealthMonitorType(d *schema.ResourceData) ([]types.NsxtAlbPoo
 	return healthMonitorSlice, nil
 }
 
-func getNsxtAlbPoolPersistenceProfileType(d *schema.ResourceData) (*types.NsxtAlbPoolPersistenceProfile, error) {
+func getNsxtAlbPoolPersistenceProfileType(ctx context.Context, d *schema.ResourceData) (*types.NsxtAlbPoolPersistenceProfile, error) {
 	if _, isSet := d.GetOk("persistence_profile"); !isSet {
-		util.Logger.Printf("[NSX-T ALB Pool Create] Persistence Profile is not set")
+		tflog.Debug(ctx, "[NSX-T ALB Pool Create] Persistence Profile is not set")
 		return nil, nil
 	}
 
 	persistenceProfileSlice := d.Get("persistence_profile").([]interface{})
 	if len(persistenceProfileSlice) < 1 {
-		util.Logger.Printf("[NSX-T ALB Pool Create] Persistence Profile has 0 elements")
+		tflog.Debug(ctx, "[NSX-T ALB Pool Create] Persistence Profile has 0 elements")
 		return nil, nil
 	}
 
@@ -3,9 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -165,10 +164,10 @@ func resourceVcdAlbSettingsDelete(_ context.Context, d *schema.ResourceData, met
 	return diag.FromErr(nsxtEdge.DisableAlb())
 }
 
-func resourceVcdAlbSettingsImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T ALB General Settings import initiated")
+func resourceVcdAlbSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T ALB General Settings import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name")
 	}
@@ -3,10 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -745,10 +744,10 @@ func setAlbVsHttpRequestRuleData(d *schema.ResourceData, rules []*types.AlbVsHtt
 	return d.Set("rule", allRules)
 }
 
-func resourceVcdAlbVirtualServiceHttpPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T ALB Virtual Service HTTP Policy import initiated")
+func resourceVcdAlbVirtualServiceHttpPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T ALB Virtual Service HTTP Policy import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name")
 	}
@@ -215,8 +215,8 @@ func resourceVcdNsxtAppPortProfileDelete(_ context.Context, d *schema.ResourceDa
 	return nil
 }
 
-func resourceVcdNsxtAppPortProfileImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNsxtAppPortProfileImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 
 	// There are two paths of possible import of differently scoped NSX-T Application Port Profiles
 	// * PROVIDER (path contains 2 pieces nsxt_manager_name.app_port_profile_name)
@@ -3,15 +3,14 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 const defaultReadLimitOfUnusedIps = 1000000 // 1 million
@@ -288,7 +287,7 @@ var nsxtEdgeExternalNetworks = &schema.Resource{
 }
 
 func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] NSX-T Edge Gateway creation initiated")
+	tflog.Trace(ctx, "NSX-T Edge Gateway creation initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -297,7 +296,7 @@ func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("error getting Org: %s", err)
 	}
 
-	nsxtEdgeGatewayType, err := getNsxtEdgeGatewayType(d, vcdClient, true, nil, nil)
+	nsxtEdgeGatewayType, err := getNsxtEdgeGatewayType(ctx, d, vcdClient, true, nil, nil)
 	if err != nil {
 		return diag.Errorf("could not create NSX-T Edge Gateway type: %s", err)
 	}
@@ -315,7 +314,9 @@ func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceDat
 	// explicitly after creation.
 	ownerIdField := d.Get("owner_id").(string)
 	if ownerIdField != "" && govcd.OwnerIsVdcGroup(ownerIdField) {
-		log.Printf("[TRACE] NSX-T Edge Gateway update - 'owner_id' is specified and is VDC Group. Moving it to VDC Group '%s'", ownerIdField)
+		tflog.Trace(ctx, "NSX-T Edge Gateway update - 'owner_id' is specified and is VDC Group. Moving it to VDC Group", map[string]interface{}{
+			"owner_id_field": ownerIdField,
+		})
 		_, err := createdEdgeGateway.MoveToVdcOrVdcGroup(ownerIdField)
 		if err != nil {
 			return diag.Errorf("error assigning NSX-T Edge Gateway to VDC Group: %s", err)
@@ -326,7 +327,7 @@ func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceVcdNsxtEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] NSX-T Edge Gateway update initiated")
+	tflog.Trace(ctx, "NSX-T Edge Gateway update initiated")
 
 	// ip_count_read_limit is only a setting that is applicable for read, it does not update
 	// anything
@@ -357,7 +358,7 @@ func resourceVcdNsxtEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("could not retrieve NSX-T Edge Gateway allocated IP count: %s", err)
 	}
 
-	updatedEdge, err := getNsxtEdgeGatewayType(d, vcdClient, false, &allocatedIpCount, edge)
+	updatedEdge, err := getNsxtEdgeGatewayType(ctx, d, vcdClient, false, &allocatedIpCount, edge)
 	if err != nil {
 		return diag.Errorf("error updating NSX-T Edge Gateway type: %s", err)
 	}
@@ -373,8 +374,8 @@ func resourceVcdNsxtEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceDat
 	return resourceVcdNsxtEdgeGatewayRead(ctx, d, meta)
 }
 
-func resourceVcdNsxtEdgeGatewayRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] NSX-T Edge Gateway read initiated")
+func resourceVcdNsxtEdgeGatewayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "NSX-T Edge Gateway read initiated")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -399,8 +400,8 @@ func resourceVcdNsxtEdgeGatewayRead(_ context.Context, d *schema.ResourceData, m
 	return nil
 }
 
-func resourceVcdNsxtEdgeGatewayDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] edge gateway deletion initiated")
+func resourceVcdNsxtEdgeGatewayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "edge gateway deletion initiated")
 
 	vcdClient := meta.(*VCDClient)
 	org, err := vcdClient.GetOrgFromResource(d)
@@ -421,10 +422,10 @@ func resourceVcdNsxtEdgeGatewayDelete(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func resourceVcdNsxtEdgeGatewayImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T Edge Gateway import initiated")
+func resourceVcdNsxtEdgeGatewayImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T Edge Gateway import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.nsxt-edge-gw-name or org-name.vdc-group-name.nsxt-edge-gw-name")
 	}
@@ -455,7 +456,7 @@ func resourceVcdNsxtEdgeGatewayImport(_ context.Context, d *schema.ResourceData,
 }
 
 // getNsxtEdgeGatewayType creates *types.OpenAPIEdgeGateway from Terraform schema
-func getNsxtEdgeGatewayType(d *schema.ResourceData, vcdClient *VCDClient, isCreateOperation bool, allocatedIpCount *int, edgeGateway *govcd.NsxtEdgeGateway) (*types.OpenAPIEdgeGateway, error) {
+func getNsxtEdgeGatewayType(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, isCreateOperation bool, allocatedIpCount *int, edgeGateway *govcd.NsxtEdgeGateway) (*types.OpenAPIEdgeGateway, error) {
 	inheritedVdcField := vcdClient.Vdc
 	vdcField := d.Get("vdc").(string)
 	ownerIdField := d.Get("owner_id").(string)
@@ -466,11 +467,11 @@ func getNsxtEdgeGatewayType(d *schema.ResourceData, vcdClient *VCDClient, isCrea
 
 	isUpdateOperation := !isCreateOperation
 	if isCreateOperation {
-		ownerId, err = getCreateOwnerIdWithStartingVdcId(d, vcdClient, ownerIdField, startingVdcId, vdcField, inheritedVdcField)
+		ownerId, err = getCreateOwnerIdWithStartingVdcId(ctx, d, vcdClient, ownerIdField, startingVdcId, vdcField, inheritedVdcField)
 	}
 
 	if isUpdateOperation {
-		ownerId, err = getOwnerId(d, vcdClient, ownerIdField, vdcField, inheritedVdcField)
+		ownerId, err = getOwnerId(ctx, d, vcdClient, ownerIdField, vdcField, inheritedVdcField)
 	}
 
 	if err != nil {
@@ -496,7 +497,7 @@ func getNsxtEdgeGatewayType(d *schema.ResourceData, vcdClient *VCDClient, isCrea
 
 	switch {
 	case isCreateOperation:
-		edgeGatewayType.EdgeGatewayUplinks, err = getNsxtEdgeGatewayUplinksPrimaryTypeForCreate(d)
+		edgeGatewayType.EdgeGatewayUplinks, err = getNsxtEdgeGatewayUplinksPrimaryTypeForCreate(ctx, d)
 		if err != nil {
 			return nil, err
 		}
@@ -506,7 +507,7 @@ func getNsxtEdgeGatewayType(d *schema.ResourceData, vcdClient *VCDClient, isCrea
 		// other uplink (the ones with backingType==IMPORTED_T_LOGICAL_SWITCH) data as they are
 		// created from scratch below.
 		edgeGateway.EdgeGateway.EdgeGatewayUplinks = []types.EdgeGatewayUplinks{edgeGateway.EdgeGateway.EdgeGatewayUplinks[0]}
-		edgeGatewayType.EdgeGatewayUplinks, err = getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(d, allocatedIpCount, edgeGateway)
+		edgeGatewayType.EdgeGatewayUplinks, err = getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(ctx, d, allocatedIpCount, edgeGateway)
 		if err != nil {
 			return nil, err
 		}
@@ -554,13 +555,19 @@ func getNsxtEdgeGatewayExternalNetworkUplink(d *schema.ResourceData) []types.Edg
 }
 
 // getNsxtEdgeGatewayUplinksPrimaryTypeForCreate handles uplink structure in create only operations
-func getNsxtEdgeGatewayUplinksPrimaryTypeForCreate(d *schema.ResourceData) ([]types.EdgeGatewayUplinks, error) {
+func getNsxtEdgeGatewayUplinksPrimaryTypeForCreate(ctx context.Context, d *schema.ResourceData) ([]types.EdgeGatewayUplinks, error) {
 	_, usingSubnetAllocation := d.GetOk("subnet")
 	_, usingAutoSubnetAllocation := d.GetOk("subnet_with_total_ip_count")
 	_, usingAutoAllocatedSubnetAllocation := d.GetOk("subnet_with_ip_count")
 
-	log.Printf("[TRACE] NSX-T Edge Gateway creation 'subnet': %t (HasChange %t), 'subnet_with_total_ip_count': %t (HasChange %t), 'subnet_with_ip_count': %t (HasChange %t)",
-		usingSubnetAllocation, d.HasChange("subnet"), usingAutoSubnetAllocation, d.HasChange("subnet_with_total_ip_count"), usingAutoAllocatedSubnetAllocation, d.HasChange("subnet_with_ip_count"))
+	tflog.Trace(ctx, "NSX-T Edge Gateway creation 'subnet', 'subnet_with_total_ip_count', 'subnet_with_ip_count'", map[string]interface{}{
+		"using_subnet_allocation":                usingSubnetAllocation,
+		"subnet":                                 d.HasChange("subnet"),
+		"using_auto_subnet_allocation":           usingAutoSubnetAllocation,
+		"subnet_with_total_ip_count":             d.HasChange("subnet_with_total_ip_count"),
+		"using_auto_allocated_subnet_allocation": usingAutoAllocatedSubnetAllocation,
+		"subnet_with_ip_count":                   d.HasChange("subnet_with_ip_count"),
+	})
 
 	switch {
 	// 'subnet' is specified
@@ -598,7 +605,7 @@ func getNsxtEdgeGatewayUplinksPrimaryTypeForCreate(d *schema.ResourceData) ([]ty
 }
 
 // getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate handles uplink structure in update only operations
-func getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(d *schema.ResourceData, currentlyAllocatedIpCount *int, edgeGateway *govcd.NsxtEdgeGateway) ([]types.EdgeGatewayUplinks, error) {
+func getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(ctx context.Context, d *schema.ResourceData, currentlyAllocatedIpCount *int, edgeGateway *govcd.NsxtEdgeGateway) ([]types.EdgeGatewayUplinks, error) {
 	if edgeGateway == nil {
 		return nil, fmt.Errorf("edge gateway cannot be nil")
 	}
@@ -611,8 +618,14 @@ func getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(d *schema.ResourceData, curre
 	_, usingAutoSubnetAllocation := d.GetOk("subnet_with_total_ip_count")
 	_, usingAutoAllocatedSubnetAllocation := d.GetOk("subnet_with_ip_count")
 
-	log.Printf("[TRACE] NSX-T Edge Gateway update 'subnet': %t (HasChange %t), 'subnet_with_total_ip_count': %t (HasChange %t), 'subnet_with_ip_count': %t (HasChange %t)",
-		usingSubnetAllocation, d.HasChange("subnet"), usingAutoSubnetAllocation, d.HasChange("subnet_with_total_ip_count"), usingAutoAllocatedSubnetAllocation, d.HasChange("subnet_with_ip_count"))
+	tflog.Trace(ctx, "NSX-T Edge Gateway update 'subnet', 'subnet_with_total_ip_count', 'subnet_with_ip_count'", map[string]interface{}{
+		"using_subnet_allocation":                usingSubnetAllocation,
+		"subnet":                                 d.HasChange("subnet"),
+		"using_auto_subnet_allocation":           usingAutoSubnetAllocation,
+		"subnet_with_total_ip_count":             d.HasChange("subnet_with_total_ip_count"),
+		"using_auto_allocated_subnet_allocation": usingAutoAllocatedSubnetAllocation,
+		"subnet_with_ip_count":                   d.HasChange("subnet_with_ip_count"),
+	})
 
 	switch {
 	// 'subnet' is specified
@@ -633,18 +646,21 @@ func getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(d *schema.ResourceData, curre
 			// If balance is negative, we need to deallocate IPs
 			// Example: 200 - 204 = -4 (4 IPs to deallocate)
 			ipBalance := desiredtotalIpCount.(int) - *currentlyAllocatedIpCount
-			util.Logger.Printf("[TRACE] Edge Gateway Ip Balance is '%d' (desired IP count '%d', currently allocated IP count'%d')",
-				ipBalance, desiredtotalIpCount.(int), *currentlyAllocatedIpCount)
+			tflog.Trace(ctx, "Edge Gateway IP balance", map[string]interface{}{
+				"ip_balance":                   ipBalance,
+				"desired_ip_count":             desiredtotalIpCount.(int),
+				"currently_allocated_ip_count": *currentlyAllocatedIpCount,
+			})
 
 			// If balance is positive, we can utilize QuickAddAllocatedIPCount field
 			if ipBalance > 0 {
-				util.Logger.Printf("[TRACE] Edge Gateway Requesting to allocate '%d' IPs", ipBalance)
+				tflog.Trace(ctx, "Edge Gateway Requesting to allocate IPs", map[string]interface{}{"ip_balance": ipBalance})
 				uplinks[0].QuickAddAllocatedIPCount = ipBalance
 			}
 
 			// If balance is negative, we need to deallocate IPs by modifying the structure
 			if ipBalance < 0 {
-				util.Logger.Printf("[TRACE] Edge Gateway Modifying structure to deallocate '%d' IPs", -ipBalance)
+				tflog.Trace(ctx, "Edge Gateway modifying structure to deallocate IPs", map[string]interface{}{"deallocated_ip_count": -ipBalance})
 				uplinks = edgeGateway.EdgeGateway.EdgeGatewayUplinks
 				edgeGatewayStructure := &govcd.NsxtEdgeGateway{
 					EdgeGateway: &types.OpenAPIEdgeGateway{
@@ -685,7 +701,7 @@ func getNsxtEdgeGatewayUplinksPrimaryTypeForUpdate(d *schema.ResourceData, curre
 //
 // Note. Only one of `vdc` or `owner_id` (with optional `starting_vdc_id`) can be supplied. This is
 // enforced by Terraform schema definition.
-func getCreateOwnerIdWithStartingVdcId(d *schema.ResourceData, vcdClient *VCDClient, ownerIdField string, startingVdcId string, vdcField string, inheritedVdcField string) (string, error) {
+func getCreateOwnerIdWithStartingVdcId(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, ownerIdField string, startingVdcId string, vdcField string, inheritedVdcField string) (string, error) {
 	var ownerId string
 
 	switch {
@@ -696,12 +712,12 @@ func getCreateOwnerIdWithStartingVdcId(d *schema.ResourceData, vcdClient *VCDCli
 	// Initial `owner_id` for create operation should be `starting_vdc_id` which is later going to
 	// be moved to a VDC by a separate API call `createdEdgeGateway.MoveToVdcGroup`
 	case ownerIdField != "" && govcd.OwnerIsVdcGroup(ownerIdField) && startingVdcId != "":
-		log.Printf("[TRACE] NSX-T Edge Gateway create 'owner_id' field is set and is VDC Group. 'starting_vdc_id' is set. Picking 'starting_vdc_id' for create operation")
+		tflog.Trace(ctx, "NSX-T Edge Gateway create 'owner_id' field is set and is VDC Group. 'starting_vdc_id' is set. Picking 'starting_vdc_id' for create operation")
 		ownerId = startingVdcId
 	// `owner_id` is specified and is VDC Group. `starting_vdc_id` is not specified.
 	// NSX-T Edge Gateway cannot be created in VDC Group therefore we are going to lookup random VDC in specified group
 	case ownerIdField != "" && govcd.OwnerIsVdcGroup(ownerIdField) && startingVdcId == "":
-		log.Printf("[TRACE] NSX-T Edge Gateway create 'owner_id' field is set and is VDC Group. 'starting_vdc_id' is not set. Choosing random starting VDC")
+		tflog.Trace(ctx, "NSX-T Edge Gateway create 'owner_id' field is set and is VDC Group. 'starting_vdc_id' is not set. Choosing random starting VDC")
 
 		// Lookup Org
 		org, err := vcdClient.GetOrgFromResource(d)
@@ -715,13 +731,15 @@ func getCreateOwnerIdWithStartingVdcId(d *schema.ResourceData, vcdClient *VCDCli
 		}
 
 		if vdcGroup.VdcGroup != nil && len(vdcGroup.VdcGroup.ParticipatingOrgVdcs) > 0 {
-			log.Printf("[TRACE] NSX-T Edge Gateway create 'owner_id' field is set and is VDC Group. 'starting_vdc_id' is not set. Picked starting VDC '%s' (%s)",
-				vdcGroup.VdcGroup.ParticipatingOrgVdcs[0].VdcRef.Name, vdcGroup.VdcGroup.ParticipatingOrgVdcs[0].VdcRef.ID)
+			tflog.Trace(ctx, "NSX-T Edge Gateway create 'owner_id' field is set and is VDC Group. 'starting_vdc_id' is not set. Picked starting VDC", map[string]interface{}{
+				"vdc_name": vdcGroup.VdcGroup.ParticipatingOrgVdcs[0].VdcRef.Name,
+				"vdc_id":   vdcGroup.VdcGroup.ParticipatingOrgVdcs[0].VdcRef.ID,
+			})
 			ownerId = vdcGroup.VdcGroup.ParticipatingOrgVdcs[0].VdcRef.ID
 		}
 	// `vdc` field is specified in the resource
 	case vdcField != "":
-		log.Printf("[TRACE] NSX-T Edge Gateway 'vdc' field is set in resource")
+		tflog.Trace(ctx, "NSX-T Edge Gateway 'vdc' field is set in resource")
 
 		org, err := vcdClient.GetOrgFromResource(d)
 		if err != nil {
@@ -736,7 +754,7 @@ func getCreateOwnerIdWithStartingVdcId(d *schema.ResourceData, vcdClient *VCDCli
 		ownerId = vdc.Vdc.ID
 	// `vdc` field is not set in the resource itself, but is inherited from `provider`
 	case inheritedVdcField != "" && vdcField == "" && ownerIdField == "":
-		log.Printf("[TRACE] NSX-T Edge Gateway 'vdc' field is inherited from provider configuration. `vdc` and `owner_id` are not set in resource.")
+		tflog.Trace(ctx, "NSX-T Edge Gateway 'vdc' field is inherited from provider configuration. `vdc` and `owner_id` are not set in resource")
 
 		org, err := vcdClient.GetOrgFromResource(d)
 		if err != nil {
@@ -764,13 +782,13 @@ func getCreateOwnerIdWithStartingVdcId(d *schema.ResourceData, vcdClient *VCDCli
 // * Neither `vdc`, nor `owner_id` fields are set in the resource. `vdc` is inherited from `provider` section
 //
 // Note. Only one of `vdc` or `owner_id`. This is enforced by Terraform schema definition.
-func getOwnerId(d *schema.ResourceData, vcdClient *VCDClient, ownerIdField, vdcField, inheritedVdcField string) (string, error) {
+func getOwnerId(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, ownerIdField, vdcField, inheritedVdcField string) (string, error) {
 	switch {
 	case ownerIdField != "":
-		log.Printf("[TRACE] 'owner_id' is set. Using it.")
+		tflog.Trace(ctx, "'owner_id' is set. Using it")
 		return ownerIdField, nil
 	case vdcField != "":
-		log.Printf("[TRACE] 'vdc' field is set in resource")
+		tflog.Trace(ctx, "'vdc' field is set in resource")
 
 		org, err := vcdClient.GetOrgFromResource(d)
 		if err != nil {
@@ -783,7 +801,7 @@ func getOwnerId(d *schema.ResourceData, vcdClient *VCDClient, ownerIdField, vdcF
 		}
 		return vdc.Vdc.ID, nil
 	case inheritedVdcField != "" && vdcField == "" && ownerIdField == "":
-		log.Printf("[TRACE] 'vdc' field is inherited from provider. `vdc` and `owner_id` are not set")
+		tflog.Trace(ctx, "'vdc' field is inherited from provider. `vdc` and `owner_id` are not set")
 
 		org, err := vcdClient.GetOrgFromResource(d)
 		if err != nil {
@@ -3,7 +3,6 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -274,8 +273,8 @@ func resourceVcdEdgeBgpNeighborDelete(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func resourceVcdEdgeBgpNeighborImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.SplitN(d.Id(), ImportSeparator, 4)
+func resourceVcdEdgeBgpNeighborImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, 4)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.edge_gateway_name.bgp_neighbor_ip, got '%s'", d.Id())
 	}
@@ -3,9 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -111,7 +110,7 @@ func resourceVcdNsxtEdgegatewayDhcpForwardingRead(ctx context.Context, d *schema
 	return genericVcdNsxtEdgegatewayDhcpForwardingRead(ctx, d, meta, "resource")
 }
 
-func genericVcdNsxtEdgegatewayDhcpForwardingRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdNsxtEdgegatewayDhcpForwardingRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	orgName := d.Get("org").(string)
@@ -125,7 +124,7 @@ func genericVcdNsxtEdgegatewayDhcpForwardingRead(_ context.Context, d *schema.Re
 			return diag.Errorf("[DHCP forwarding DS read] error retrieving NSX-T Edge Gateway DHCP forwarding: %s", err)
 		}
 		d.SetId("")
-		log.Printf("[DEBUG] Edge gateway no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Edge gateway no longer exists. Removing from tfstate")
 		return nil
 	}
 
@@ -182,9 +181,9 @@ func resourceVcdNsxtEdgegatewayDhcpForwardingDelete(ctx context.Context, d *sche
 // The import path for this resource is Edge Gateway. ID of the field is also Edge Gateway ID as
 // DHCP forwarding is a property of Edge Gateway, not a separate entity.
 func resourceVcdNsxtEdgegatewayDhcpForwardingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T Edge Gateway DHCP forwarding import initiated")
+	tflog.Trace(ctx, "NSX-T Edge Gateway DHCP forwarding import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.nsxt-edge-gw-name or org-name.vdc-group-name.nsxt-edge-gw-name")
 	}
@@ -3,9 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -167,7 +167,7 @@ func resourceVcdNsxtEdgegatewayL2VpnTunnelRead(ctx context.Context, d *schema.Re
 	return genericNsxtEdgegatewayL2VpnTunnelRead(ctx, d, meta, "resource")
 }
 
-func genericNsxtEdgegatewayL2VpnTunnelRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericNsxtEdgegatewayL2VpnTunnelRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	orgName := d.Get("org").(string)
@@ -192,7 +192,7 @@ func genericNsxtEdgegatewayL2VpnTunnelRead(_ context.Context, d *schema.Resource
 	tunnelConfig, err := nsxtEdge.GetL2VpnTunnelById(tunnelId)
 	if govcd.ContainsNotFound(err) {
 		d.SetId("")
-		log.Printf("[DEBUG] L2 VPN Tunnel no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "L2 VPN Tunnel no longer exists. Removing from tfstate")
 		return nil
 	}
 	err = readL2VpnTunnelToSchema(tunnelConfig.NsxtL2VpnTunnel, d, vcdClient)
@@ -385,9 +385,9 @@ func readL2VpnTunnelToSchema(tunnel *types.NsxtL2VpnTunnel, d *schema.ResourceDa
 }
 
 func resourceVcdNsxtEdgegatewayL2VpnTunnelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T Edge Gateway L2 VPN Tunnel import initiated")
+	tflog.Trace(ctx, "NSX-T Edge Gateway L2 VPN Tunnel import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as " +
 			"org-name.vdc-name.nsxt-edge-gw-name.l2-vpn-tunnel-name or " +
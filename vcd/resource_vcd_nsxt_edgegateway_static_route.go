@@ -227,7 +227,7 @@ func resourceVcdNsxtEdgeGatewayStaticRouteDelete(ctx context.Context, d *schema.
 }
 
 func resourceVcdNsxtEdgeGatewayStaticRouteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.SplitN(d.Id(), ImportSeparator, 4)
+	resourceURI := importIdElements(ctx, d, 4)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.edge_gateway_name.static_route_name or "+
 			"'org-name.vdc-or-vdc-group-name.edge_gateway_name.name', got '%s'", d.Id())
@@ -3,11 +3,11 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -229,10 +229,10 @@ func resourceVcdNsxtFirewallDelete(_ context.Context, d *schema.ResourceData, me
 	return nil
 }
 
-func resourceVcdNsxtFirewallImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T Edge Gateway Firewall Rule import initiated")
+func resourceVcdNsxtFirewallImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T Edge Gateway Firewall Rule import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name")
 	}
@@ -5,10 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 
@@ -444,10 +444,10 @@ func resourceVcdNsxtIpSecVpnTunnelDelete(_ context.Context, d *schema.ResourceDa
 	return nil
 }
 
-func resourceVcdNsxtIpSecVpnTunnelImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] NSX-T IPsec VPN Tunnel Import started")
+func resourceVcdNsxtIpSecVpnTunnelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tflog.Trace(ctx, "NSX-T IPsec VPN Tunnel Import started")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.ipsec_tunnel_name")
 	}
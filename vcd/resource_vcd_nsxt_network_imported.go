@@ -3,7 +3,6 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -21,6 +20,7 @@ func resourceVcdNsxtNetworkImported() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNsxtNetworkImportedImport,
 		},
+		CustomizeDiff: resourceVcdNetworkV2CustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -163,7 +163,7 @@ func resourceVcdNsxtNetworkImportedCreate(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("[nsxt imported network create] this resource supports only NSX-T: %s", err)
 	}
 
-	networkType, err := getOpenApiOrgVdcImportedNetworkType(d, vcdClient, true)
+	networkType, err := getOpenApiOrgVdcImportedNetworkType(ctx, d, vcdClient, true)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -209,7 +209,7 @@ func resourceVcdNsxtNetworkImportedUpdate(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("[nsxt imported network update] error getting Org VDC network: %s", err)
 	}
 
-	networkType, err := getOpenApiOrgVdcImportedNetworkType(d, vcdClient, false)
+	networkType, err := getOpenApiOrgVdcImportedNetworkType(ctx, d, vcdClient, false)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -288,7 +288,7 @@ func resourceVcdNsxtNetworkImportedDelete(ctx context.Context, d *schema.Resourc
 }
 
 func resourceVcdNsxtNetworkImportedImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[nsxt imported network import] resource name must be specified as org-name.vdc-name.network-name")
 	}
@@ -363,12 +363,12 @@ func setOpenApiOrgVdcImportedNetworkData(d *schema.ResourceData, orgVdcNetwork *
 	return nil
 }
 
-func getOpenApiOrgVdcImportedNetworkType(d *schema.ResourceData, vcdClient *VCDClient, isCreate bool) (*types.OpenApiOrgVdcNetwork, error) {
+func getOpenApiOrgVdcImportedNetworkType(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, isCreate bool) (*types.OpenApiOrgVdcNetwork, error) {
 	inheritedVdcField := vcdClient.Vdc
 	vdcField := d.Get("vdc").(string)
 	ownerIdField := d.Get("owner_id").(string)
 
-	ownerId, err := getOwnerId(d, vcdClient, ownerIdField, vdcField, inheritedVdcField)
+	ownerId, err := getOwnerId(ctx, d, vcdClient, ownerIdField, vdcField, inheritedVdcField)
 	if err != nil {
 		return nil, fmt.Errorf("error finding owner reference: %s", err)
 	}
@@ -284,8 +284,8 @@ func resourceVcdSecurityGroupDelete(_ context.Context, d *schema.ResourceData, m
 	return nil
 }
 
-func resourceVcdSecurityGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdSecurityGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.security_group_name or" +
 			"as org-name.vdc-group-name.edge_gateway_name.security_group_name")
@@ -1,9 +1,10 @@
 package vcd
 
 import (
+	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -27,12 +28,12 @@ var relayAgentResource = &schema.Resource{
 
 func resourceVcdNsxvDhcpRelay() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVcdNsxvDhcpRelayCreate,
-		Read:   resourceVcdNsxvDhcpRelayRead,
-		Update: resourceVcdNsxvDhcpRelayUpdate,
-		Delete: resourceVcdNsxvDhcpRelayDelete,
+		CreateContext: resourceVcdNsxvDhcpRelayCreate,
+		ReadContext:   resourceVcdNsxvDhcpRelayRead,
+		UpdateContext: resourceVcdNsxvDhcpRelayUpdate,
+		DeleteContext: resourceVcdNsxvDhcpRelayDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdNsxvDhcpRelayImport,
+			StateContext: resourceVcdNsxvDhcpRelayImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,46 +92,46 @@ func resourceVcdNsxvDhcpRelay() *schema.Resource {
 
 // resourceVcdNsxvDhcpRelayCreate sets up DHCP relay configuration as per supplied schema
 // configuration
-func resourceVcdNsxvDhcpRelayCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdNsxvDhcpRelayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	dhcpRelayConfig, err := getDhcpRelayType(d, edgeGateway, vcdClient)
 	if err != nil {
-		return fmt.Errorf("could not process DHCP relay settings: %s", err)
+		return diag.Errorf("could not process DHCP relay settings: %s", err)
 	}
 
 	_, err = edgeGateway.UpdateDhcpRelay(dhcpRelayConfig)
 	if err != nil {
-		return fmt.Errorf("unable to update DHCP relay settings for Edge Gateway %s: %s", edgeGateway.EdgeGateway.Name, err)
+		return diag.Errorf("unable to update DHCP relay settings for Edge Gateway %s: %s", edgeGateway.EdgeGateway.Name, err)
 	}
 
 	// This is not a real object but a settings property on Edge gateway - creating a fake composite
 	// ID
 	compositeId, err := getDhcpRelaySettingsId(edgeGateway)
 	if err != nil {
-		return fmt.Errorf("could not construct DHCP relay settings ID: %s", err)
+		return diag.Errorf("could not construct DHCP relay settings ID: %s", err)
 	}
 
 	d.SetId(compositeId)
 
-	return resourceVcdNsxvDhcpRelayRead(d, meta)
+	return resourceVcdNsxvDhcpRelayRead(ctx, d, meta)
 }
 
 // resourceVcdNsxvDhcpRelayUpdate is in fact exactly the same as create because there is no object,
 // just settings to modify
-func resourceVcdNsxvDhcpRelayUpdate(d *schema.ResourceData, meta interface{}) error {
-	return resourceVcdNsxvDhcpRelayCreate(d, meta)
+func resourceVcdNsxvDhcpRelayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdNsxvDhcpRelayCreate(ctx, d, meta)
 }
 
-func resourceVcdNsxvDhcpRelayRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdNsxvDhcpRelayRead(d, meta, "resource")
+func resourceVcdNsxvDhcpRelayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.FromErr(genericVcdNsxvDhcpRelayRead(d, meta, "resource"))
 }
 
 // genericVcdNsxvDhcpRelayRead reads DHCP relay configuration and persists to statefile
@@ -179,19 +180,19 @@ func genericVcdNsxvDhcpRelayRead(d *schema.ResourceData, meta interface{}, origi
 }
 
 // resourceVcdNsxvDhcpRelayDelete removes DHCP relay configuration by triggering ResetDhcpRelay()
-func resourceVcdNsxvDhcpRelayDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdNsxvDhcpRelayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.ResetDhcpRelay()
 	if err != nil {
-		return fmt.Errorf("could not reset DHCP relay settings: %s", err)
+		return diag.Errorf("could not reset DHCP relay settings: %s", err)
 	}
 
 	return nil
@@ -200,8 +201,8 @@ func resourceVcdNsxvDhcpRelayDelete(d *schema.ResourceData, meta interface{}) er
 // resourceVcdNsxvDhcpRelayImport imports DHCP relay configuration. Because DHCP relay is just a
 // settings on edge gateway and not a separate object - the ID actually does not represent any
 // object
-func resourceVcdNsxvDhcpRelayImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdNsxvDhcpRelayImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.edge-gw-name")
 	}
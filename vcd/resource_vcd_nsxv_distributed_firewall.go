@@ -3,13 +3,13 @@ package vcd
 import (
 	"context"
 	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
-	"strings"
 )
 
 var DFWElements = []string{
@@ -247,7 +247,7 @@ func resourceVcdNsxvDistributedFirewallRead(ctx context.Context, d *schema.Resou
 	return genericVcdNsxvDistributedFirewallRead(ctx, d, meta, "resource")
 }
 
-func genericVcdNsxvDistributedFirewallRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdNsxvDistributedFirewallRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	vdcId := d.Get("vdc_id").(string)
@@ -263,7 +263,7 @@ func genericVcdNsxvDistributedFirewallRead(_ context.Context, d *schema.Resource
 	}
 	d.SetId(vdcId)
 	if configuration == nil { // disabled
-		util.Logger.Println("[NSX-V DFW DISABLED]")
+		tflog.Debug(ctx, "[NSX-V DFW DISABLED]")
 		dSet(d, "enabled", false)
 		err = d.Set("rule", nil)
 		if err != nil {
@@ -273,12 +273,12 @@ func genericVcdNsxvDistributedFirewallRead(_ context.Context, d *schema.Resource
 	}
 	dSet(d, "enabled", true)
 
-	util.Logger.Println("[NSX-V DFW START]")
+	tflog.Debug(ctx, "[NSX-V DFW START]")
 	err = dfwRulesToResource(dfw.Configuration.Layer3Sections.Section.Rule, d)
 	if err != nil {
 		return diag.Errorf("error setting distributed firewall state: %s", err)
 	}
-	util.Logger.Println("[NSX-V DFW END]")
+	tflog.Debug(ctx, "[NSX-V DFW END]")
 
 	return nil
 }
@@ -318,7 +318,7 @@ func resourceVcdNsxvDistributedFirewallUpdate(ctx context.Context, d *schema.Res
 	return resourceVcdNsxvDistributedFirewallCreateUpdate(ctx, d, meta, "update")
 }
 
-func resourceVcdNsxvDistributedFirewallDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdNsxvDistributedFirewallDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	vdcId := d.Get("vdc_id").(string)
@@ -326,7 +326,7 @@ func resourceVcdNsxvDistributedFirewallDelete(_ context.Context, d *schema.Resou
 		vdcId = d.Id()
 	}
 	dfw := govcd.NewNsxvDistributedFirewall(&vcdClient.Client, vdcId)
-	util.Logger.Printf("[INFO] disabling NSX-V distributed firewall for VDC %s\n", vdcId)
+	tflog.Info(ctx, "disabling NSX-V distributed firewall for VDC", map[string]interface{}{"vdc_id": vdcId})
 	err := dfw.Disable()
 	if err != nil {
 		return diag.FromErr(err)
@@ -608,7 +608,7 @@ func dfwRulesToResource(rules []types.NsxvDistributedFirewallRule, d *schema.Res
 // or
 // terraform import vcd_nsxv_distributed_firewall.identifier org-name.vdc-name
 func resourceVcdNsxvDistributedFirewallImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 
 	vcdClient := meta.(*VCDClient)
 	var dfw *govcd.NsxvDistributedFirewall
@@ -2,29 +2,43 @@ package vcd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
-	"log"
 	"slices"
 	"strconv"
 	"strings"
 
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
+// nsxvFirewallRuleStateUpgrade moves the legacy `virtual_machine_ids` of source and destination
+// blocks to `vm_ids` (schema version 0 to 1)
+var nsxvFirewallRuleStateUpgrade = stateUpgrade{
+	legacyAttributes: map[string]*schema.Schema{
+		"source.virtual_machine_ids":      stringSetStateAttribute(),
+		"destination.virtual_machine_ids": stringSetStateAttribute(),
+	},
+	upgradeFuncs: []stateUpgradeFunc{
+		renameStateAttribute("virtual_machine_ids", "vm_ids", "source"),
+		renameStateAttribute("virtual_machine_ids", "vm_ids", "destination"),
+	},
+}
+
 func resourceVcdNsxvFirewallRule() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceVcdNsxvFirewallRuleCreate,
-		Read:   resourceVcdNsxvFirewallRuleRead,
-		Update: resourceVcdNsxvFirewallRuleUpdate,
-		Delete: resourceVcdNsxvFirewallRuleDelete,
+	return withStateUpgrades(&schema.Resource{
+		CreateContext: resourceVcdNsxvFirewallRuleCreate,
+		ReadContext:   resourceVcdNsxvFirewallRuleRead,
+		UpdateContext: resourceVcdNsxvFirewallRuleUpdate,
+		DeleteContext: resourceVcdNsxvFirewallRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceVcdNsxvFirewallRuleImport,
+			StateContext: resourceVcdNsxvFirewallRuleImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -251,79 +265,79 @@ func resourceVcdNsxvFirewallRule() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, nsxvFirewallRuleStateUpgrade)
 }
 
-func resourceVcdNsxvFirewallRuleCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdNsxvFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
-	firewallRule, err := getFirewallRule(d, edgeGateway, vdc, false)
+	firewallRule, err := getFirewallRule(ctx, d, edgeGateway, vdc, false)
 	if err != nil {
-		return fmt.Errorf("unable to make firewall rule query: %s", err)
+		return diag.Errorf("unable to make firewall rule query: %s", err)
 	}
 
 	createdFirewallRule, err := edgeGateway.CreateNsxvFirewallRule(firewallRule, d.Get("above_rule_id").(string))
 	if err != nil {
-		return fmt.Errorf("error creating new firewall rule: %s", err)
+		return diag.Errorf("error creating new firewall rule: %s", err)
 	}
 
 	d.SetId(createdFirewallRule.ID)
-	return resourceVcdNsxvFirewallRuleRead(d, meta)
+	return resourceVcdNsxvFirewallRuleRead(ctx, d, meta)
 }
 
-func resourceVcdNsxvFirewallRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdNsxvFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
-	updateFirewallRule, err := getFirewallRule(d, edgeGateway, vdc, false)
+	updateFirewallRule, err := getFirewallRule(ctx, d, edgeGateway, vdc, false)
 	updateFirewallRule.ID = d.Id() // We already know an ID for update and it allows to change name
 
 	if err != nil {
-		return fmt.Errorf("could not create firewall rule type for update: %s", err)
+		return diag.Errorf("could not create firewall rule type for update: %s", err)
 	}
 
 	_, err = edgeGateway.UpdateNsxvFirewallRule(updateFirewallRule)
 	if err != nil {
-		return fmt.Errorf("unable to update firewall rule with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to update firewall rule with ID %s: %s", d.Id(), err)
 	}
 
-	return resourceVcdNsxvFirewallRuleRead(d, meta)
+	return resourceVcdNsxvFirewallRuleRead(ctx, d, meta)
 }
 
-func resourceVcdNsxvFirewallRuleRead(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdNsxvFirewallRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
 	// Detect if this is data source or resource field and pick correct ID field
@@ -340,12 +354,12 @@ func resourceVcdNsxvFirewallRuleRead(d *schema.ResourceData, meta interface{}) e
 	readFirewallRule, err := edgeGateway.GetNsxvFirewallRuleById(id)
 	if err != nil {
 		d.SetId("")
-		return fmt.Errorf("unable to find firewall rule with ID %s: %s", d.Id(), err)
+		return diag.Errorf("unable to find firewall rule with ID %s: %s", d.Id(), err)
 	}
 
-	err = setFirewallRuleData(d, readFirewallRule, edgeGateway, vdc)
+	err = setFirewallRuleData(ctx, d, readFirewallRule, edgeGateway, vdc)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if isDatasource {
@@ -355,19 +369,19 @@ func resourceVcdNsxvFirewallRuleRead(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceVcdNsxvFirewallRuleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceVcdNsxvFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentEdgeGtw(d)
 	defer vcdClient.unLockParentEdgeGtw(d)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
 	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		return diag.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	err = edgeGateway.DeleteNsxvFirewallRuleById(d.Id())
 	if err != nil {
-		return fmt.Errorf("error deleting firewall rule with id %s: %s", d.Id(), err)
+		return diag.Errorf("error deleting firewall rule with id %s: %s", d.Id(), err)
 	}
 
 	d.SetId("")
@@ -392,17 +406,17 @@ func resourceVcdNsxvFirewallRuleDelete(d *schema.ResourceData, meta interface{})
 // Example import path (_the_id_string_): org.vdc.edge-gw.132730
 // Example import by UI ID path (_the_id_string_): org.vdc.edge-gw.ui-no.2
 // Example list path (_the_id_string_): list@org.vdc.edge-gw
-func resourceVcdNsxvFirewallRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdNsxvFirewallRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, edgeName, firewallRuleId, uiId string
 	var listRules bool
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	helpError := fmt.Errorf(`resource id must be specified in one of these formats:
 'org-name.vdc-name.edge-gw-name.real-firewall-rule-id' to import by rule id
 'org-name.vdc-name.edge-gw-name.ui-no.X' where X is the firewall rule number shown in UI
 'list@org-name.vdc-name.edge-gw-name' to get a list of rules with their respective UI numbers and real IDs`)
 
-	log.Printf("[DEBUG] importing vcd_nsxv_firewall_rule resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_nsxv_firewall_rule resource", map[string]interface{}{"id": d.Id()})
 
 	switch len(resourceURI) {
 	case 3:
@@ -508,7 +522,7 @@ func resourceVcdNsxvFirewallRuleImport(d *schema.ResourceData, meta interface{})
 }
 
 // setFirewallRuleData is the main function used for setting Terraform schema
-func setFirewallRuleData(d *schema.ResourceData, rule *types.EdgeFirewallRule, edge *govcd.EdgeGateway, vdc *govcd.Vdc) error {
+func setFirewallRuleData(ctx context.Context, d *schema.ResourceData, rule *types.EdgeFirewallRule, edge *govcd.EdgeGateway, vdc *govcd.Vdc) error {
 	dSet(d, "name", rule.Name)
 	dSet(d, "enabled", rule.Enabled)
 	dSet(d, "logging_enabled", rule.LoggingEnabled)
@@ -524,7 +538,7 @@ func setFirewallRuleData(d *schema.ResourceData, rule *types.EdgeFirewallRule, e
 	}
 
 	// Process and set "source" block
-	source, err := getEndpointData(rule.Source, edge, vdc)
+	source, err := getEndpointData(ctx, rule.Source, edge, vdc)
 	if err != nil {
 		return fmt.Errorf("could not prepare data for setting 'source' block: %s", err)
 	}
@@ -534,7 +548,7 @@ func setFirewallRuleData(d *schema.ResourceData, rule *types.EdgeFirewallRule, e
 	}
 
 	// Process and set "destination" block
-	destination, err := getEndpointData(rule.Destination, edge, vdc)
+	destination, err := getEndpointData(ctx, rule.Destination, edge, vdc)
 	if err != nil {
 		return fmt.Errorf("could not prepare data for setting 'destination' block: %s", err)
 	}
@@ -559,13 +573,13 @@ func setFirewallRuleData(d *schema.ResourceData, rule *types.EdgeFirewallRule, e
 
 // getFirewallRule is the main function  used for creating *types.EdgeFirewallRule structure from
 // Terraform schema configuration
-func getFirewallRule(d *schema.ResourceData, edge *govcd.EdgeGateway, vdc *govcd.Vdc, shortIpSetIds bool) (*types.EdgeFirewallRule, error) {
-	sourceEndpoint, err := getFirewallRuleEndpoint(d.Get("source").([]interface{}), edge, vdc, shortIpSetIds)
+func getFirewallRule(ctx context.Context, d *schema.ResourceData, edge *govcd.EdgeGateway, vdc *govcd.Vdc, shortIpSetIds bool) (*types.EdgeFirewallRule, error) {
+	sourceEndpoint, err := getFirewallRuleEndpoint(ctx, d.Get("source").([]interface{}), edge, vdc, shortIpSetIds)
 	if err != nil {
 		return nil, fmt.Errorf("could not convert 'source' block to API request: %s", err)
 	}
 
-	destinationEndpoint, err := getFirewallRuleEndpoint(d.Get("destination").([]interface{}), edge, vdc, shortIpSetIds)
+	destinationEndpoint, err := getFirewallRuleEndpoint(ctx, d.Get("destination").([]interface{}), edge, vdc, shortIpSetIds)
 	if err != nil {
 		return nil, fmt.Errorf("could not convert 'destination' block to API request: %s", err)
 	}
@@ -596,7 +610,7 @@ func getFirewallRule(d *schema.ResourceData, edge *govcd.EdgeGateway, vdc *govcd
 
 // getEndpointData formats nested set structure suitable for d.Set() for
 // 'source' and 'destination' blocks in firewall rule
-func getEndpointData(endpoint types.EdgeFirewallEndpoint, edge *govcd.EdgeGateway, vdc *govcd.Vdc) ([]interface{}, error) {
+func getEndpointData(ctx context.Context, endpoint types.EdgeFirewallEndpoint, edge *govcd.EdgeGateway, vdc *govcd.Vdc) ([]interface{}, error) {
 	// Different object types are in the same grouping object tag <groupingObjectId>
 	// They can be distinguished by 3rd element in ID
 	var (
@@ -641,7 +655,7 @@ func getEndpointData(endpoint types.EdgeFirewallEndpoint, edge *govcd.EdgeGatewa
 
 		// Log the group ID if it was not one of above
 		default:
-			log.Printf("[WARN] Unrecognized grouping object ID: %s", groupingObject)
+			tflog.Warn(ctx, "Unrecognized grouping object", map[string]interface{}{"grouping_object": groupingObject})
 		}
 	}
 
@@ -713,7 +727,7 @@ func getServiceData(firewallApplication types.EdgeFirewallApplication, edge *gov
 
 // getFirewallRuleEndpoint processes Terraform schema and converts it to *types.EdgeFirewallEndpoint
 // which is useful for 'source' or 'destination' blocks
-func getFirewallRuleEndpoint(endpoint []interface{}, edge *govcd.EdgeGateway, vdc *govcd.Vdc, shortIpSetIds bool) (*types.EdgeFirewallEndpoint, error) {
+func getFirewallRuleEndpoint(ctx context.Context, endpoint []interface{}, edge *govcd.EdgeGateway, vdc *govcd.Vdc, shortIpSetIds bool) (*types.EdgeFirewallEndpoint, error) {
 	if len(endpoint) != 1 {
 		return nil, fmt.Errorf("no source specified")
 	}
@@ -731,7 +745,7 @@ func getFirewallRuleEndpoint(endpoint []interface{}, edge *govcd.EdgeGateway, vd
 
 	// Extract 'gateway_interfaces' names, convert them to vNic indexes and add to the structure
 	endpointEdgeInterfaceIdStrings := convertSchemaSetToSliceOfStrings(endpointMap["gateway_interfaces"].(*schema.Set))
-	endpointEdgeInterfaceVnicList, err := edgeInterfaceNamesToIdStrings(endpointEdgeInterfaceIdStrings, edge)
+	endpointEdgeInterfaceVnicList, err := edgeInterfaceNamesToIdStrings(ctx, endpointEdgeInterfaceIdStrings, edge)
 	if err != nil {
 		return nil, fmt.Errorf("could not lookup vNic indexes for networks: %s", err)
 	}
@@ -821,7 +835,7 @@ func edgeVnicIdStringsToNetworkNames(groupIdStrings []string, edge *govcd.EdgeGa
 
 // edgeInterfaceNamesToIdStrings iterates over network names and returns vNic ID name list
 // Format: vnic-10, vnic-3, etc. (suitable for firewall creation)
-func edgeInterfaceNamesToIdStrings(groupNetworkNames []string, edge *govcd.EdgeGateway) ([]string, error) {
+func edgeInterfaceNamesToIdStrings(ctx context.Context, groupNetworkNames []string, edge *govcd.EdgeGateway) ([]string, error) {
 	idStrings := make([]string, len(groupNetworkNames))
 	for index, networkName := range groupNetworkNames {
 		// A list of accepted parameters as strings (not real network names). No need to look them
@@ -836,7 +850,11 @@ func edgeInterfaceNamesToIdStrings(groupNetworkNames []string, edge *govcd.EdgeG
 			return nil, fmt.Errorf("error searching for network %s: %s", networkName, err)
 		}
 		// we found the network - add it to the list
-		log.Printf("[DEBUG] found vNic index %d for network %s (type %s)", vNicIndex, networkName, networkType)
+		tflog.Debug(ctx, "found vNic index for network", map[string]interface{}{
+			"vnic_index":   vNicIndex,
+			"network_name": networkName,
+			"network_type": networkType,
+		})
 		idStrings[index] = "vnic-" + strconv.Itoa(*vNicIndex)
 	}
 	return idStrings, nil
@@ -908,15 +926,15 @@ func resourceVcdNsxvFirewallRuleServiceHash(v interface{}) int {
 
 	_, err := buf.WriteString(fmt.Sprintf("%s-", protocol))
 	if err != nil {
-		util.Logger.Printf("[ERROR] buf.WriteString failed: %s", err)
+		tflog.Error(providerLogContext(), "buf.WriteString failed", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%s-", port))
 	if err != nil {
-		util.Logger.Printf("[ERROR] buf.WriteString failed: %s", err)
+		tflog.Error(providerLogContext(), "buf.WriteString failed", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%s-", sourcePort))
 	if err != nil {
-		util.Logger.Printf("[ERROR] buf.WriteString failed: %s", err)
+		tflog.Error(providerLogContext(), "buf.WriteString failed", map[string]interface{}{"error": err})
 	}
 
 	return hashcodeString(buf.String())
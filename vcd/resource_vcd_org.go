@@ -9,11 +9,11 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -27,7 +27,7 @@ import (
 // https://code.vmware.com/apis/287/vcloud#/doc/doc/types/ReferenceType.html
 // https://code.vmware.com/apis/287/vcloud#/doc/doc/operations/DELETE-Organization.html
 func resourceOrg() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceOrgCreate,
 		ReadContext:   resourceOrgRead,
 		UpdateContext: resourceOrgUpdate,
@@ -225,7 +225,7 @@ func resourceOrg() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 // creates an organization based on defined resource
@@ -241,11 +241,11 @@ func resourceOrgCreate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	settings := getSettings(d)
 
-	log.Printf("[TRACE] Creating Org: %s", orgName)
+	tflog.Trace(ctx, "Creating Org", map[string]interface{}{"org_name": orgName})
 	task, err := govcd.CreateOrg(vcdClient.VCDClient, orgName, fullName, description, settings, isEnabled)
 
 	if err != nil {
-		log.Printf("[DEBUG] Error creating Org: %s", err)
+		tflog.Debug(ctx, "Error creating Org", map[string]interface{}{"error": err})
 		// Some 10.4 VCD versions have a bug that fail when creating a disabled Org
 		if !isEnabled && strings.Contains(err.Error(), "com.vmware.vcloud.common.model.oauth.oidc.OidcAuthorizationModel") {
 			// getting VCD version for error message but not explicitly failing if it cannot be
@@ -261,7 +261,7 @@ func resourceOrgCreate(ctx context.Context, d *schema.ResourceData, m interface{
 
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		log.Printf("[DEBUG] Error running Org creation task: %s", err)
+		tflog.Debug(ctx, "Error running Org creation task", map[string]interface{}{"error": err})
 		return diag.Errorf("[org creation] error running Org (%s) creation task: %s", orgName, err)
 	}
 
@@ -271,11 +271,14 @@ func resourceOrgCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	if err != nil {
 		return diag.Errorf("[org creation] error retrieving Org %s after creation: %s", orgName, err)
 	}
-	log.Printf("[TRACE] Org %s created with id: %s", orgName, org.AdminOrg.ID)
+	tflog.Trace(ctx, "Org created", map[string]interface{}{
+		"org_name": orgName,
+		"org_id":   org.AdminOrg.ID,
+	})
 
 	d.SetId(org.AdminOrg.ID)
 
-	err = createOrUpdateMetadata(d, org, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, org, "metadata")
 	if err != nil {
 		return diag.Errorf("error adding metadata to Org: %s", err)
 	}
@@ -373,7 +376,7 @@ func getSettings(d *schema.ResourceData) *types.OrgSettings {
 }
 
 // Deletes org
-func resourceOrgDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceOrgDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	//DELETING
 	vcdClient := m.(*VCDClient)
@@ -386,7 +389,7 @@ func resourceOrgDelete(_ context.Context, d *schema.ResourceData, m interface{})
 	}
 
 	identifier := d.Id()
-	log.Printf("[TRACE] Reading Org %s", identifier)
+	tflog.Trace(ctx, "Reading Org", map[string]interface{}{"identifier": identifier})
 
 	// The double attempt is a workaround when dealing with
 	// organizations created by previous versions, where the ID
@@ -400,17 +403,20 @@ func resourceOrgDelete(_ context.Context, d *schema.ResourceData, m interface{})
 		return diag.Errorf("error fetching Org %s: %s", orgName, err)
 	}
 
-	log.Printf("[TRACE] Org %s found", orgName)
+	tflog.Trace(ctx, "Org found", map[string]interface{}{"org_name": orgName})
 	//deletes organization
-	log.Printf("[TRACE] Deleting Org %s", orgName)
+	tflog.Trace(ctx, "Deleting Org", map[string]interface{}{"org_name": orgName})
 
 	err = adminOrg.Delete(deleteForce, deleteRecursive)
 	if err != nil {
-		log.Printf("[DEBUG] Error deleting org %s: %s", orgName, err)
+		tflog.Debug(ctx, "Error deleting org", map[string]interface{}{
+			"org_name": orgName,
+			"error":    err,
+		})
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[TRACE] Org %s deleted", orgName)
+	tflog.Trace(ctx, "Org deleted", map[string]interface{}{"org_name": orgName})
 	return nil
 }
 
@@ -425,7 +431,7 @@ func resourceOrgUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 
 	identifier := d.Id()
-	log.Printf("[TRACE] Reading Org %s", identifier)
+	tflog.Trace(ctx, "Reading Org", map[string]interface{}{"identifier": identifier})
 
 	// The double attempt is a workaround when dealing with
 	// organizations created by previous versions, where the ID
@@ -449,7 +455,7 @@ func resourceOrgUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	adminOrg.AdminOrg.OrgSettings.OrgVAppLeaseSettings = settings.OrgVAppLeaseSettings
 	adminOrg.AdminOrg.OrgSettings.OrgPasswordPolicySettings = settings.OrgPasswordPolicySettings
 
-	log.Printf("[TRACE] Org with id %s found", orgName)
+	tflog.Trace(ctx, "Org with id found", map[string]interface{}{"org_name": orgName})
 	// Check if the LDAP settings are correct.
 	// If the hostname or other required elements are missing, then invalidate the whole LDAP settings
 	// See issue 672. Trying to update with incorrect LDAP settings will result in an API 400 error
@@ -466,26 +472,32 @@ func resourceOrgUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	task, err := adminOrg.Update()
 
 	if err != nil {
-		log.Printf("[DEBUG] Error updating Org %s : %s", orgName, err)
+		tflog.Debug(ctx, "Error updating Org", map[string]interface{}{
+			"org_name": orgName,
+			"error":    err,
+		})
 		return diag.Errorf("error updating Org %s", err)
 	}
 	err = task.WaitTaskCompletion()
 	if err != nil {
-		log.Printf("[DEBUG] Error completing update of Org %s : %s", orgName, err)
+		tflog.Debug(ctx, "Error completing update of Org", map[string]interface{}{
+			"org_name": orgName,
+			"error":    err,
+		})
 		return diag.Errorf("error completing update of Org %s", err)
 	}
 
-	err = createOrUpdateMetadata(d, adminOrg, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, adminOrg, "metadata")
 	if err != nil {
 		return diag.Errorf("error updating metadata from Org: %s", err)
 	}
 
-	log.Printf("[TRACE] Org %s updated", orgName)
+	tflog.Trace(ctx, "Org updated", map[string]interface{}{"org_name": orgName})
 	return resourceOrgRead(ctx, d, m)
 }
 
 // setOrgData sets the data into the resource, taking it from the provided adminOrg
-func setOrgData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd.AdminOrg) diag.Diagnostics {
+func setOrgData(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd.AdminOrg) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	dSet(d, "name", adminOrg.AdminOrg.Name)
@@ -598,9 +610,9 @@ func setOrgData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd.Ad
 		}
 	}
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_org", adminOrg)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_org", adminOrg)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to set Org metadata")
+		tflog.Debug(ctx, "Unable to set Org metadata")
 		return diags
 	}
 
@@ -612,7 +624,7 @@ func setOrgData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd.Ad
 }
 
 // Retrieves an Org resource from VCD
-func resourceOrgRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceOrgRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := m.(*VCDClient)
 
@@ -622,7 +634,7 @@ func resourceOrgRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 	}
 
 	identifier := d.Id()
-	log.Printf("[TRACE] Reading Org %s", identifier)
+	tflog.Trace(ctx, "Reading Org", map[string]interface{}{"identifier": identifier})
 	adminOrg, err := vcdClient.VCDClient.GetAdminOrgByNameOrId(identifier)
 
 	// The double attempt is a workaround when dealing with
@@ -634,20 +646,20 @@ func resourceOrgRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 		// we try to access it using the name.
 		identifier = orgName
 		if identifier != "" {
-			log.Printf("[TRACE] Reading Org %s", identifier)
+			tflog.Trace(ctx, "Reading Org", map[string]interface{}{"identifier": identifier})
 			adminOrg, err = vcdClient.VCDClient.GetAdminOrgByNameOrId(identifier)
 		}
 	}
 
 	if err != nil {
-		log.Printf("[DEBUG] Org %s not found. Setting ID to nothing", identifier)
+		tflog.Debug(ctx, "Org not found. Setting ID to nothing", map[string]interface{}{"identifier": identifier})
 		d.SetId("")
 		return nil
 	}
-	log.Printf("[TRACE] Org with id %s found", identifier)
+	tflog.Trace(ctx, "Org with id found", map[string]interface{}{"identifier": identifier})
 	d.SetId(adminOrg.AdminOrg.ID)
 
-	diags = append(diags, setOrgData(d, vcdClient, adminOrg)...)
+	diags = append(diags, setOrgData(ctx, d, vcdClient, adminOrg)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -675,7 +687,7 @@ func resourceVcdOrgImport(ctx context.Context, d *schema.ResourceData, meta inte
 		return nil, fmt.Errorf(errorRetrievingOrg, err)
 	}
 
-	diags := setOrgData(d, vcdClient, adminOrg)
+	diags := setOrgData(ctx, d, vcdClient, adminOrg)
 	if diags != nil && diags.HasError() {
 		return []*schema.ResourceData{}, fmt.Errorf("error setting Org data: %v", diags)
 	}
@@ -3,9 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -99,7 +98,7 @@ func resourceVcdOrgGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceVcdOrgGroupRead(ctx, d, meta)
 }
 
-func resourceVcdOrgGroupRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdOrgGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
 	if err != nil {
@@ -108,7 +107,10 @@ func resourceVcdOrgGroupRead(_ context.Context, d *schema.ResourceData, meta int
 
 	group, err := adminOrg.GetGroupById(d.Id(), false)
 	if govcd.IsNotFound(err) {
-		log.Printf("error finding group %s: %s. Removing from state", d.Id(), err)
+		tflog.Debug(ctx, "error finding group. Removing from state", map[string]interface{}{
+			"id":    d.Id(),
+			"error": err,
+		})
 		d.SetId("")
 		return nil
 	}
@@ -195,8 +197,8 @@ func resourceVcdOrgGroupDelete(_ context.Context, d *schema.ResourceData, meta i
 //
 // Example import path (id): my-org.my-group
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdOrgGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdOrgGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.org_group")
 	}
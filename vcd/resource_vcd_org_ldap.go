@@ -3,8 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -249,7 +249,10 @@ func genericVcdOrgLdapRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
 	if govcd.IsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find Organization %s LDAP settings: %s. Removing from state", orgId, err)
+		tflog.Info(ctx, "unable to find Organization LDAP settings. Removing from state", map[string]interface{}{
+			"org_id": orgId,
+			"error":  err,
+		})
 		d.SetId("")
 		return nil
 	}
@@ -3,15 +3,16 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"strings"
-	"time"
 )
 
 // resourceVcdOrgOidc defines the resource that manages Open ID Connect (OIDC) settings for an Organization
@@ -371,13 +372,16 @@ func resourceVcdOrgOidcRead(ctx context.Context, d *schema.ResourceData, meta in
 	return genericVcdOrgOidcRead(ctx, d, meta, "resource")
 }
 
-func genericVcdOrgOidcRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdOrgOidcRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	orgId := d.Get("org_id").(string)
 
 	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
 	if govcd.ContainsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find Organization '%s' Open ID Connect settings: %s. Removing from state", orgId, err)
+		tflog.Info(ctx, "unable to find Organization Open ID Connect settings. Removing from state", map[string]interface{}{
+			"org_id": orgId,
+			"error":  err,
+		})
 		d.SetId("")
 		return nil
 	}
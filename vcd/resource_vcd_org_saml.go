@@ -3,11 +3,12 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
-	"log"
-	"os"
 )
 
 // resourceVcdOrgSaml handles Org SAML settings
@@ -152,7 +153,10 @@ func genericVcdOrgSamlRead(ctx context.Context, d *schema.ResourceData, meta int
 
 	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgId)
 	if govcd.IsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find Organization %s SAML settings: %s. Removing from state", orgId, err)
+		tflog.Info(ctx, "unable to find Organization SAML settings. Removing from state", map[string]interface{}{
+			"org_id": orgId,
+			"error":  err,
+		})
 		d.SetId("")
 		return nil
 	}
@@ -231,14 +231,14 @@ func resourceToUserData(d *schema.ResourceData, meta interface{}) (*govcd.OrgUse
 
 // Retrieve an OrgUser and an AdminOrg from the data in the resource.
 // Used wherever we need to read the object from vCD with input provided in the resource fields
-func resourceToOrgUser(d *schema.ResourceData, meta interface{}) (*govcd.OrgUser, *govcd.AdminOrg, error) {
+func resourceToOrgUser(ctx context.Context, d *schema.ResourceData, meta interface{}) (*govcd.OrgUser, *govcd.AdminOrg, error) {
 
 	vcdClient := meta.(*VCDClient)
 	sessionInfo, err := vcdClient.Client.GetSessionInfo()
 	if err != nil {
 		return nil, nil, fmt.Errorf("error retrieving session info: %s", err)
 	}
-	result, err := runWithRetry("[resourceToOrgUser]",
+	result, err := runWithRetry(ctx, "[resourceToOrgUser]",
 		fmt.Sprintf("error retrieving org %s", d.Get("org").(string)),
 		time.Second*30,
 		nil,
@@ -315,10 +315,10 @@ func resourceVcdOrgUserCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 // Deletes an OrgUser
-func resourceVcdOrgUserDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdOrgUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	takeOwnership := d.Get("take_ownership").(bool)
-	orgUser, _, err := resourceToOrgUser(d, meta)
+	orgUser, _, err := resourceToOrgUser(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("[user delete] %s", err)
 	}
@@ -330,9 +330,9 @@ func resourceVcdOrgUserDelete(_ context.Context, d *schema.ResourceData, meta in
 }
 
 // Reads the OrgUser from vCD and fills the resource container appropriately
-func resourceVcdOrgUserRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdOrgUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	orgUser, _, err := resourceToOrgUser(d, meta)
+	orgUser, _, err := resourceToOrgUser(ctx, d, meta)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			d.SetId("")
@@ -350,7 +350,7 @@ func resourceVcdOrgUserRead(_ context.Context, d *schema.ResourceData, meta inte
 // Updates an OrgUser with the data passed through the resource
 func resourceVcdOrgUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	orgUser, _, err := resourceToOrgUser(d, meta)
+	orgUser, _, err := resourceToOrgUser(ctx, d, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -371,8 +371,8 @@ func resourceVcdOrgUserUpdate(ctx context.Context, d *schema.ResourceData, meta
 //
 // Example import path (id): my-org.my-user-admin
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdOrgUserImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdOrgUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.org_user")
 	}
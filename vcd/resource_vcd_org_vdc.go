@@ -5,19 +5,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
+// orgVdcStateUpgrade converts the legacy `metadata` map and fills `default_compute_policy_id` from
+// the deprecated `default_vm_sizing_policy_id` (schema version 0 to 1)
+var orgVdcStateUpgrade = stateUpgrade{
+	upgradeFuncs: []stateUpgradeFunc{
+		upgradeMetadataToMetadataEntry,
+		copyStateAttribute("default_vm_sizing_policy_id", "default_compute_policy_id"),
+	},
+}
+
 func resourceVcdOrgVdc() *schema.Resource {
 	capacityWithUsage := schema.Schema{
 		Type:     schema.TypeList,
@@ -50,7 +58,7 @@ func resourceVcdOrgVdc() *schema.Resource {
 		},
 	}
 
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdVdcCreate,
 		DeleteContext: resourceVcdVdcDelete,
 		ReadContext:   resourceVcdVdcRead,
@@ -58,6 +66,8 @@ func resourceVcdOrgVdc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdOrgVdcImport,
 		},
+		CustomizeDiff: resourceVcdOrgVdcCustomizeDiff,
+		Timeouts:      resourceTimeouts(60*time.Minute, 60*time.Minute, 60*time.Minute),
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -295,13 +305,83 @@ func resourceVcdOrgVdc() *schema.Resource {
 				Description: "Set to true to enable distributed firewall - Only applies to NSX-V VDCs",
 			},
 		},
+	}, orgVdcStateUpgrade)
+}
+
+// resourceVcdOrgVdcCustomizeDiff performs plan-time validations of VDC settings that would
+// otherwise fail only after the VDC creation or update started
+func resourceVcdOrgVdcCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+
+	// `elasticity` and `include_vm_memory_overhead` can be used only with Flex
+	if allocationModel, ok := rawConfigString(rawConfig, "allocation_model"); ok && allocationModel != "Flex" {
+		for _, flexField := range []string{"elasticity", "include_vm_memory_overhead"} {
+			if _, ok := rawConfigBool(rawConfig, flexField); ok {
+				return fmt.Errorf("%s: can be used only with Flex allocation model, but 'allocation_model' is '%s'", flexField, allocationModel)
+			}
+		}
+	}
+
+	// The default compute policy must be one of the assigned policies. The check is done only when
+	// all the policy IDs are known at plan time and at least one of the policy sets is configured
+	defaultPolicyField := "default_compute_policy_id"
+	defaultPolicyId, defaultPolicySet := rawConfigString(rawConfig, defaultPolicyField)
+	if !defaultPolicySet {
+		defaultPolicyField = "default_vm_sizing_policy_id"
+		defaultPolicyId, defaultPolicySet = rawConfigString(rawConfig, defaultPolicyField)
+	}
+	if defaultPolicySet {
+		policyFields := []string{"vm_sizing_policy_ids", "vm_placement_policy_ids", "vm_vgpu_policy_ids"}
+		allKnown, anySet, found := true, false, false
+		for _, policyField := range policyFields {
+			value, _ := rawConfigAttribute(rawConfig, policyField)
+			if !value.IsWhollyKnown() {
+				allKnown = false
+				break
+			}
+			policyIds, ok := rawConfigStringSet(rawConfig, policyField)
+			if ok {
+				anySet = true
+				found = found || policyIds[defaultPolicyId]
+			}
+		}
+		if allKnown && anySet && !found {
+			return fmt.Errorf("%s: policy '%s' is not present in any of `%v`", defaultPolicyField, defaultPolicyId, policyFields)
+		}
+	}
+
+	// Exactly one enabled storage profile must be the default one
+	storageProfiles, ok := rawConfigElements(rawConfig, "storage_profile")
+	if !ok {
+		return nil
+	}
+	var defaultProfiles []string
+	for _, storageProfile := range storageProfiles {
+		isDefault, ok := rawConfigBool(storageProfile, "default")
+		if !ok {
+			// Unknown value: the number of default profiles can't be evaluated
+			return nil
+		}
+		if !isDefault {
+			continue
+		}
+		name, _ := rawConfigString(storageProfile, "name")
+		defaultProfiles = append(defaultProfiles, name)
+		if isEnabled, ok := rawConfigBool(storageProfile, "enabled"); ok && !isEnabled {
+			return fmt.Errorf("storage_profile: default storage profile '%s' must be enabled", name)
+		}
 	}
+	if len(defaultProfiles) != 1 {
+		return fmt.Errorf("storage_profile: exactly one storage profile must be set as default, found %d %v", len(defaultProfiles), defaultProfiles)
+	}
+
+	return nil
 }
 
 // Creates a new VDC from a resource definition
 func resourceVcdVdcCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	orgVdcName := d.Get("name").(string)
-	log.Printf("[TRACE] VDC creation initiated: %s", orgVdcName)
+	tflog.Trace(ctx, "VDC creation initiated", map[string]interface{}{"org_vdc_name": orgVdcName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -332,18 +412,25 @@ func resourceVcdVdcCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] Creating VDC: %#v", params)
+	tflog.Debug(ctx, "Creating VDC", map[string]interface{}{"vdc_name": params.Name})
 
-	vdc, err := adminOrg.CreateOrgVdc(params)
+	task, err := adminOrg.CreateOrgVdcAsync(params)
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
+	if err != nil {
+		tflog.Debug(ctx, "Error creating VDC", map[string]interface{}{"error": err})
+		return errorDiagnostics(d, err, "error creating VDC: %s", err)
+	}
+	vdc, err := adminOrg.GetVDCByName(orgVdcName, true)
 	if err != nil {
-		log.Printf("[DEBUG] Error creating VDC: %s", err)
-		return diag.Errorf("error creating VDC: %s", err)
+		return diag.Errorf("error retrieving VDC %s after creation: %s", orgVdcName, err)
 	}
 
 	d.SetId(vdc.Vdc.ID)
-	log.Printf("[TRACE] VDC created: %#v", vdc)
+	tflog.Trace(ctx, "VDC created", map[string]interface{}{"vdc_name": vdc.Vdc.Name})
 
-	err = createOrUpdateOrgMetadata(d, meta)
+	err = createOrUpdateOrgMetadata(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("error adding metadata to VDC: %s", err)
 	}
@@ -377,10 +464,10 @@ func resourceVcdVdcCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	return resourceVcdVdcRead(ctx, d, meta)
 }
 
-func resourceVcdVdcRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVdcRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vdcName := d.Get("name").(string)
-	log.Printf("[TRACE] VDC read initiated: %s", vdcName)
+	tflog.Trace(ctx, "VDC read initiated", map[string]interface{}{"vdc_name": vdcName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -395,11 +482,11 @@ func resourceVcdVdcRead(_ context.Context, d *schema.ResourceData, meta interfac
 			d.SetId("")
 			return nil
 		}
-		log.Printf("[DEBUG] Unable to find VDC %s", vdcName)
+		tflog.Debug(ctx, "Unable to find VDC", map[string]interface{}{"vdc_name": vdcName})
 		return diag.Errorf("unable to find VDC %s, err: %s", vdcName, err)
 	}
 
-	diags = append(diags, setOrgVdcData(d, vcdClient, adminVdc)...)
+	diags = append(diags, setOrgVdcData(ctx, d, vcdClient, adminVdc)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -426,7 +513,7 @@ func resourceVcdVdcRead(_ context.Context, d *schema.ResourceData, meta interfac
 }
 
 // setOrgVdcData sets object state from *govcd.AdminVdc
-func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminVdc *govcd.AdminVdc) diag.Diagnostics {
+func setOrgVdcData(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, adminVdc *govcd.AdminVdc) diag.Diagnostics {
 	var diags diag.Diagnostics
 	dSet(d, "allocation_model", adminVdc.AdminVdc.AllocationModel)
 	if adminVdc.AdminVdc.ResourceGuaranteedCpu != nil {
@@ -493,7 +580,7 @@ func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminVdc *govcd
 
 	assignedVmComputePolicies, err := adminVdc.GetAllAssignedVdcComputePoliciesV2(nil)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to get assigned VM Compute policies")
+		tflog.Debug(ctx, "Unable to get assigned VM Compute policies")
 		return diag.Errorf("unable to get assigned VM Compute policies %s", err)
 	}
 	var sizingPolicyIds []string
@@ -526,13 +613,13 @@ func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminVdc *govcd
 		return diag.FromErr(err)
 	}
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_org_vdc", adminVdc)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_org_vdc", adminVdc)...)
 	if diags != nil && diags.HasError() {
-		log.Printf("[DEBUG] Unable to set VDC metadata")
+		tflog.Debug(ctx, "Unable to set VDC metadata")
 		return diags
 	}
 
-	log.Printf("[TRACE] vdc read completed: %#v", adminVdc.AdminVdc)
+	tflog.Trace(ctx, "vdc read completed", map[string]interface{}{"admin_vdc_name": adminVdc.AdminVdc.Name})
 
 	// This must be checked at the end as updateMetadataInStateDeprecated can throw Warning diagnostics
 	if len(diags) > 0 {
@@ -613,7 +700,7 @@ func getMetadataStruct(metadata []*types.MetadataEntry) StringMap {
 // resourceVcdVdcUpdate function updates resource with found configurations changes
 func resourceVcdVdcUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vdcName := d.Get("name").(string)
-	log.Printf("[TRACE] VDC update initiated: %s", vdcName)
+	tflog.Trace(ctx, "VDC update initiated", map[string]interface{}{"vdc_name": vdcName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -629,23 +716,37 @@ func resourceVcdVdcUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	adminVdc, err := adminOrg.GetAdminVDCByName(vdcName, false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VDC %s", vdcName)
+		tflog.Debug(ctx, "Unable to find VDC", map[string]interface{}{"vdc_name": vdcName})
 		return diag.Errorf("unable to find VDC %s, error:  %s", vdcName, err)
 	}
 
 	changedAdminVdc, err := getUpdatedVdcInput(d, vcdClient, adminVdc)
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VDC %s with error %s", vdcName, err)
+		tflog.Debug(ctx, "Error updating VDC", map[string]interface{}{
+			"vdc_name": vdcName,
+			"error":    err,
+		})
 		return diag.Errorf("error updating VDC %s, err: %s", vdcName, err)
 	}
 
-	updatedAdminVdc, err := changedAdminVdc.Update()
+	// Same as changedAdminVdc.Update, waiting for the task with the context. ResourcePoolRefs can't be set
+	changedAdminVdc.AdminVdc.ResourcePoolRefs = nil
+	task, err := changedAdminVdc.UpdateAsync()
+	if err == nil {
+		err = waitTaskCompletionWithContext(ctx, task)
+	}
+	if err == nil {
+		err = changedAdminVdc.Refresh()
+	}
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VDC %s with error %s", vdcName, err)
-		return diag.Errorf("error updating VDC %s, err: %s", vdcName, err)
+		tflog.Debug(ctx, "Error updating VDC", map[string]interface{}{
+			"vdc_name": vdcName,
+			"error":    err,
+		})
+		return errorDiagnostics(d, err, "error updating VDC %s, err: %s", vdcName, err)
 	}
 
-	err = createOrUpdateOrgMetadata(d, meta)
+	err = createOrUpdateOrgMetadata(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("error updating VDC metadata: %s", err)
 	}
@@ -657,16 +758,16 @@ func resourceVcdVdcUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	if d.HasChange("storage_profile") {
 		vdcStorageProfilesConfigurations := d.Get("storage_profile").(*schema.Set)
-		err = updateStorageProfiles(vdcStorageProfilesConfigurations, vcdClient, adminVdc, d.Get("provider_vdc_name").(string))
+		err = updateStorageProfiles(ctx, vdcStorageProfilesConfigurations, vcdClient, adminVdc, d.Get("provider_vdc_name").(string))
 		if err != nil {
 			return diag.Errorf("[VDC update] error updating storage profiles: %s", err)
 		}
 	}
 
 	if d.HasChange("edge_cluster_id") {
-		orgVdc, err := adminOrg.GetVDCByName(updatedAdminVdc.AdminVdc.Name, false)
+		orgVdc, err := adminOrg.GetVDCByName(changedAdminVdc.AdminVdc.Name, false)
 		if orgVdc == nil || err != nil {
-			return diag.Errorf("error retrieving Org VDC from Admin VDC '%s': %s", updatedAdminVdc.AdminVdc.Name, err)
+			return diag.Errorf("error retrieving Org VDC from Admin VDC '%s': %s", changedAdminVdc.AdminVdc.Name, err)
 		}
 		err = setVdcEdgeCluster(d, orgVdc)
 		if err != nil {
@@ -689,12 +790,12 @@ func resourceVcdVdcUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		dSet(d, "enable_nsxv_distributed_firewall", enablementState)
 	}
 
-	log.Printf("[TRACE] VDC update completed: %s", adminVdc.AdminVdc.Name)
+	tflog.Trace(ctx, "VDC update completed", map[string]interface{}{"admin_vdc_name": adminVdc.AdminVdc.Name})
 	return resourceVcdVdcRead(ctx, d, meta)
 }
 
-func updateStorageProfileDetails(vcdClient *VCDClient, adminVdc *govcd.AdminVdc, storageProfile *types.Reference, storageConfiguration map[string]interface{}) error {
-	util.Logger.Printf("updating storage profile %#v", storageProfile)
+func updateStorageProfileDetails(ctx context.Context, vcdClient *VCDClient, adminVdc *govcd.AdminVdc, storageProfile *types.Reference, storageConfiguration map[string]interface{}) error {
+	tflog.Debug(ctx, "updating storage profile", map[string]interface{}{"storage_profile_name": storageProfile.Name})
 	uuid, err := govcd.GetUuidFromHref(storageProfile.HREF, true)
 	if err != nil {
 		return fmt.Errorf("error parsing VDC storage profile ID : %s", err)
@@ -720,7 +821,7 @@ func updateStorageProfileDetails(vcdClient *VCDClient, adminVdc *govcd.AdminVdc,
 	return nil
 }
 
-func updateStorageProfiles(set *schema.Set, client *VCDClient, adminVdc *govcd.AdminVdc, providerVdcName string) error {
+func updateStorageProfiles(ctx context.Context, set *schema.Set, client *VCDClient, adminVdc *govcd.AdminVdc, providerVdcName string) error {
 
 	type storageProfileCombo struct {
 		configuration map[string]interface{}
@@ -840,7 +941,7 @@ func updateStorageProfiles(set *schema.Set, client *VCDClient, adminVdc *govcd.A
 
 	// 7. Update existing storage profiles
 	for _, spCombo := range existingStorageProfiles {
-		err := updateStorageProfileDetails(client, adminVdc, spCombo.reference, spCombo.configuration)
+		err := updateStorageProfileDetails(ctx, client, adminVdc, spCombo.reference, spCombo.configuration)
 		if err != nil {
 			return fmt.Errorf("[updateStorageProfiles] error updating storage profile '%s': %s", spCombo.reference.Name, err)
 		}
@@ -858,9 +959,9 @@ func updateStorageProfiles(set *schema.Set, client *VCDClient, adminVdc *govcd.A
 }
 
 // Deletes a VDC, optionally removing all objects in it as well
-func resourceVcdVdcDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVdcDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vdcName := d.Get("name").(string)
-	log.Printf("[TRACE] VDC delete started: %s", vdcName)
+	tflog.Trace(ctx, "VDC delete started", map[string]interface{}{"vdc_name": vdcName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -875,22 +976,31 @@ func resourceVcdVdcDelete(_ context.Context, d *schema.ResourceData, meta interf
 
 	vdc, err := adminOrg.GetVDCByName(vdcName, false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VDC %s. Removing from tfstate", vdcName)
+		tflog.Debug(ctx, "Unable to find VDC. Removing from tfstate", map[string]interface{}{"vdc_name": vdcName})
 		d.SetId("")
 		return nil
 	}
 
-	err = vdc.DeleteWait(d.Get("delete_force").(bool), d.Get("delete_recursive").(bool))
+	err = retryOnBusyEntity(ctx, vcdClient, func() error {
+		task, err := vdc.Delete(d.Get("delete_force").(bool), d.Get("delete_recursive").(bool))
+		if err != nil {
+			return err
+		}
+		return waitTaskCompletionWithContext(ctx, task)
+	})
 	if err != nil {
-		log.Printf("[DEBUG] Error removing VDC %s, err: %s", vdcName, err)
-		return diag.Errorf("error removing VDC %s, err: %s", vdcName, err)
+		tflog.Debug(ctx, "Error removing VDC", map[string]interface{}{
+			"vdc_name": vdcName,
+			"error":    err,
+		})
+		return errorDiagnostics(d, err, "error removing VDC %s, err: %s", vdcName, err)
 	}
 
 	_, err = adminOrg.GetVDCByName(vdcName, true)
 	if err == nil {
 		return diag.Errorf("vdc %s still found after deletion", vdcName)
 	}
-	log.Printf("[TRACE] VDC delete completed: %s", vdcName)
+	tflog.Trace(ctx, "VDC delete completed", map[string]interface{}{"vdc_name": vdcName})
 	return nil
 }
 
@@ -1085,9 +1195,9 @@ func addAssignedComputePolicies(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func createOrUpdateOrgMetadata(d *schema.ResourceData, meta interface{}) error {
+func createOrUpdateOrgMetadata(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 
-	log.Printf("[TRACE] adding/updating metadata to VDC")
+	tflog.Trace(ctx, "adding/updating metadata to VDC")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -1101,7 +1211,7 @@ func createOrUpdateOrgMetadata(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf(errorRetrievingVdcFromOrg, d.Get("org").(string), d.Get("name").(string), err)
 	}
 
-	return createOrUpdateMetadata(d, adminVdc, "metadata")
+	return createOrUpdateMetadata(d, vcdClient, adminVdc, "metadata")
 }
 
 // helper for transforming the compute capacity section of the resource input into the VdcConfiguration structure
@@ -1399,8 +1509,8 @@ func getVcdVdcInput(d *schema.ResourceData, vcdClient *VCDClient) (*types.VdcCon
 // Example resource name (_resource_name_): vcd_org_vdc.my_existing_vdc
 // Example import path (_the_id_string_): org.my_existing_vdc
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdOrgVdcImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdOrgVdcImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.my_existing_vdc")
 	}
@@ -1415,7 +1525,7 @@ func resourceVcdOrgVdcImport(_ context.Context, d *schema.ResourceData, meta int
 
 	adminVdc, err := adminOrg.GetAdminVDCByName(vdcName, false)
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VDC %s", vdcName)
+		tflog.Debug(ctx, "Unable to find VDC", map[string]interface{}{"vdc_name": vdcName})
 		return nil, fmt.Errorf("unable to find VDC %s, err: %s", vdcName, err)
 	}
 
@@ -3,15 +3,16 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
+
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"net"
-	"strings"
 )
 
 func resourceVcdOrgVdcTemplate() *schema.Resource {
@@ -549,10 +550,10 @@ func genericVcdVdcTemplateCreateOrUpdate(ctx context.Context, d *schema.Resource
 	return resourceVcdVdcTemplateRead(ctx, d, meta)
 }
 
-func genericVcdVdcTemplateRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdVdcTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vdcTemplate, err := getVdcTemplate(d, meta.(*VCDClient))
 	if govcd.ContainsNotFound(err) && origin == "resource" {
-		log.Printf("[INFO] unable to find VDC Template: %s. Removing from state", err)
+		tflog.Info(ctx, "unable to find VDC Template. Removing from state", map[string]interface{}{"error": err})
 		d.SetId("")
 		return nil
 	}
@@ -2,10 +2,12 @@ package vcd
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
-	"log"
 )
 
 func resourceVcdOrgVdcTemplateInstance() *schema.Resource {
@@ -14,12 +16,17 @@ func resourceVcdOrgVdcTemplateInstance() *schema.Resource {
 		ReadContext:   resourceVcdVdcTemplateInstantiateRead,
 		UpdateContext: resourceVcdVdcTemplateInstantiateUpdate,
 		DeleteContext: resourceVcdVdcTemplateInstantiateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdVdcTemplateInstantiateImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"org_vdc_template_id": {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
 				Description: "ID of the VDC template to instantiate",
+				// The template of an imported VDC can't be retrieved, unless it is given in the import ID
+				DiffSuppressFunc: suppressTextAfterImport(),
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -74,9 +81,9 @@ func resourceVcdVdcTemplateInstantiateCreate(ctx context.Context, d *schema.Reso
 	return resourceVcdVdcTemplateInstantiateRead(ctx, d, meta)
 }
 
-func resourceVcdVdcTemplateInstantiateRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVdcTemplateInstantiateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.Id() == "" {
-		log.Printf("[INFO] unable to find instantiated VDC")
+		tflog.Info(ctx, "unable to find instantiated VDC")
 		return nil
 	}
 	vcdClient := meta.(*VCDClient)
@@ -100,7 +107,7 @@ func resourceVcdVdcTemplateInstantiateUpdate(ctx context.Context, d *schema.Reso
 	// No-op. This is needed as "delete_instantiated_vdc_on_removal", "delete_force" and "delete_recursive"
 	// are not marked as "ForceNew: true" (they can be modified after creation), but they are just flags, not obtained from
 	// VCD.
-	return resourceVcdVdcTemplateRead(ctx, d, meta)
+	return resourceVcdVdcTemplateInstantiateRead(ctx, d, meta)
 }
 
 func resourceVcdVdcTemplateInstantiateDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -130,3 +137,46 @@ func resourceVcdVdcTemplateInstantiateDelete(_ context.Context, d *schema.Resour
 
 	return nil
 }
+
+// resourceVcdVdcTemplateInstantiateImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains a dot formatted path to the VDC, as org-name.vdc-name, optionally followed by the
+// name of the VDC template that was used to instantiate it, as org-name.vdc-name.vdc-template-name
+// 3. The function read is called to check the instantiated VDC
+//
+// The VDC does not refer to its template: when the template is not given, `org_vdc_template_id` is set to a placeholder
+// that is ignored when comparing with the configuration. The deletion flags are set to false, so that importing
+// the VDC never causes its removal, unless set otherwise in the configuration.
+func resourceVcdVdcTemplateInstantiateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
+	if len(resourceURI) != 2 && len(resourceURI) != 3 {
+		return nil, fmt.Errorf("[VDC template instance import] resource name must be specified as org-name.vdc-name or org-name.vdc-name.vdc-template-name")
+	}
+	orgName, vdcName := resourceURI[0], resourceURI[1]
+
+	vcdClient := meta.(*VCDClient)
+	org, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf("[VDC template instance import] unable to find VDC %s: %s ", vdcName, err)
+	}
+
+	vdcTemplateId := defaultImportedValue
+	if len(resourceURI) == 3 {
+		vdcTemplate, err := vcdClient.GetVdcTemplateByName(resourceURI[2])
+		if err != nil {
+			return nil, fmt.Errorf("[VDC template instance import] unable to find VDC template %s: %s", resourceURI[2], err)
+		}
+		vdcTemplateId = vdcTemplate.VdcTemplate.ID
+	}
+
+	dSet(d, "org_vdc_template_id", vdcTemplateId)
+	dSet(d, "name", vdc.Vdc.Name)
+	dSet(d, "description", vdc.Vdc.Description)
+	dSet(d, "org_id", org.Org.ID)
+	dSet(d, "delete_instantiated_vdc_on_removal", false)
+	dSet(d, "delete_force", false)
+	dSet(d, "delete_recursive", false)
+	d.SetId(vdc.Vdc.ID)
+	return []*schema.ResourceData{d}, nil
+}
@@ -67,6 +67,15 @@ func TestAccVcdVdcTemplateInstance(t *testing.T) {
 					resource.TestCheckResourceAttrPair(vdc, "enable_fast_provisioning", template, "enable_fast_provisioning"),
 				),
 			},
+			{
+				ResourceName:      instance,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The VDC has the same name as the VDC Template
+				ImportStateIdFunc: importStateIdOrgObject(params["OrgToPublish"].(string), params["Name"].(string)+ImportSeparator+params["Name"].(string)),
+				// Deletion flags are not stored in VCD
+				ImportStateVerifyIgnore: []string{"delete_instantiated_vdc_on_removal", "delete_force", "delete_recursive"},
+			},
 		},
 	})
 	postTestChecks(t)
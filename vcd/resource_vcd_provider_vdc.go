@@ -1,14 +1,21 @@
 package vcd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"net/url"
+	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 // This internal schema defines the Root Capacity of the Provider VDC.
@@ -65,6 +72,7 @@ func resourceVcdProviderVdc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProviderVdcImport,
 		},
+		Timeouts: resourceTimeouts(60*time.Minute, 60*time.Minute, 60*time.Minute),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -330,16 +338,16 @@ func resourceVcdProviderVdcCreate(ctx context.Context, d *schema.ResourceData, m
 		NetworkPool:           networkPoolReference,
 		AutoCreateNetworkPool: false,
 	}
-	providerVdc, err := vcdClient.CreateProviderVdc(&providerVdcCreation)
+	providerVdc, err := createProviderVdc(ctx, vcdClient, &providerVdcCreation)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(d, err, "error creating Provider VDC %s: %s", providerVdcName, err)
 	}
 
 	metadataCompatiblePvdc, err := providerVdc.ToProviderVdc()
 	if err != nil {
 		return diag.Errorf("could not create metadata for Provider VDC '%s': %s", providerVdc.VMWProviderVdc.ID, err)
 	}
-	err = createOrUpdateMetadataEntryInVcd(d, metadataCompatiblePvdc)
+	err = createOrUpdateMetadataEntryInVcd(d, vcdClient, metadataCompatiblePvdc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -348,6 +356,61 @@ func resourceVcdProviderVdcCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceVcdProviderVdcRead(ctx, d, meta)
 }
 
+// createProviderVdc is the same as vcdClient.CreateProviderVdc, waiting for the tasks of the new Provider VDC with
+// the context
+func createProviderVdc(ctx context.Context, vcdClient *VCDClient, params *types.ProviderVdcCreation) (*govcd.ProviderVdcExtended, error) {
+	if !vcdClient.Client.IsSysAdmin {
+		return nil, fmt.Errorf("functionality requires System Administrator privileges")
+	}
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	createHref := vcdClient.Client.VCDHREF
+	createHref.Path += "/admin/extension/providervdcsparams"
+	apiVersion := vcdClient.Client.APIVersion
+	req := vcdClient.Client.NewRequestWithApiVersion(nil, http.MethodPost, createHref, bytes.NewReader(payload), apiVersion)
+	req.Header.Set("Accept", fmt.Sprintf("application/*+json;version=%s", apiVersion))
+	req.Header.Set("Content-Type", "application/*+json")
+	resp, err := vcdClient.Client.Http.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	util.ProcessResponseOutput(util.CallFuncName(), resp, string(body))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiError types.OpenApiError
+		if json.Unmarshal(body, &apiError) == nil && apiError.Message != "" {
+			return nil, fmt.Errorf("%s - %s", apiError.MinorErrorCode, apiError.Message)
+		}
+		return nil, fmt.Errorf("%s", body)
+	}
+
+	providerVdc, err := vcdClient.GetProviderVdcExtendedByName(params.Name)
+	if err != nil {
+		return nil, err
+	}
+	// The Provider VDC is created, but its tasks may be still running
+	if providerVdc.VMWProviderVdc.Tasks == nil {
+		err = providerVdc.Refresh()
+		if err != nil {
+			return nil, fmt.Errorf("error refreshing provider VDC %s: %s", params.Name, err)
+		}
+	}
+	if providerVdc.VMWProviderVdc.Tasks != nil {
+		for _, providerVdcTask := range providerVdc.VMWProviderVdc.Tasks.Task {
+			task := govcd.NewTask(&vcdClient.Client)
+			task.Task = providerVdcTask
+			err = waitTaskCompletionWithContext(ctx, *task)
+			if err != nil {
+				return nil, fmt.Errorf("provider VDC %s was created, but it is not ready: %s", params.Name, err)
+			}
+		}
+	}
+	return providerVdc, providerVdc.Refresh()
+}
+
 func resourceVcdProviderVdcRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return genericResourceVcdProviderVdcRead(ctx, d, meta, "resource")
 }
@@ -366,7 +429,11 @@ func genericResourceVcdProviderVdcRead(ctx context.Context, d *schema.ResourceDa
 		extendedProviderVdc, err = vcdClient.GetProviderVdcExtendedByName(providerVdcName)
 	}
 	if err != nil {
-		log.Printf("[DEBUG] (%s) Could not find any extended Provider VDC with name %s: %s", origin, providerVdcName, err)
+		tflog.Debug(ctx, "Could not find any extended Provider VDC", map[string]interface{}{
+			"origin":            origin,
+			"provider_vdc_name": providerVdcName,
+			"error":             err,
+		})
 		if origin == "datasource" {
 			return diag.Errorf("could not find any extended Provider VDC with name %s: %s", providerVdcName, err)
 		}
@@ -375,7 +442,10 @@ func genericResourceVcdProviderVdcRead(ctx context.Context, d *schema.ResourceDa
 	}
 	providerVdc, err := extendedProviderVdc.ToProviderVdc()
 	if err != nil {
-		log.Printf("[DEBUG] Could not convert extended Provider VDC with name %s to regular Provider VDC: %s", providerVdcName, err)
+		tflog.Debug(ctx, "Could not convert extended Provider VDC with name to regular Provider VDC", map[string]interface{}{
+			"provider_vdc_name": providerVdcName,
+			"error":             err,
+		})
 		return diag.Errorf("could not convert extended Provider VDC with name %s to regular one: %s", providerVdcName, err)
 	}
 
@@ -487,7 +557,7 @@ func genericResourceVcdProviderVdcRead(ctx context.Context, d *schema.ResourceDa
 		dSet(d, "vcenter_id", extendedProviderVdc.VMWProviderVdc.VimServer[0].ID)
 	}
 
-	diags = append(diags, updateMetadataInState(d, vcdClient, "vcd_provider_vdc", providerVdc)...)
+	diags = append(diags, updateMetadataInState(ctx, d, vcdClient, "vcd_provider_vdc", providerVdc)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -507,7 +577,10 @@ func resourceVcdProviderVdcUpdate(ctx context.Context, d *schema.ResourceData, m
 	providerVdcName := d.Get("name").(string)
 	pvdc, err := vcdClient.GetProviderVdcExtendedById(providerVdcId)
 	if err != nil {
-		log.Printf("[DEBUG] Could not find any extended Provider VDC with name %s: %s", providerVdcName, err)
+		tflog.Debug(ctx, "Could not find any extended Provider VDC", map[string]interface{}{
+			"provider_vdc_name": providerVdcName,
+			"error":             err,
+		})
 		return diag.Errorf("could not find any extended Provider VDC with name %s: %s", providerVdcName, err)
 	}
 
@@ -554,7 +627,7 @@ func resourceVcdProviderVdcUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.Errorf("could not create metadata for Provider VDC '%s': %s", pvdc.VMWProviderVdc.ID, err)
 	}
-	err = createOrUpdateMetadataEntryInVcd(d, metadataCompatiblePvdc)
+	err = createOrUpdateMetadataEntryInVcd(d, vcdClient, metadataCompatiblePvdc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -568,7 +641,10 @@ func resourceVcdProviderVdcDelete(ctx context.Context, d *schema.ResourceData, m
 	providerVdcName := d.Get("name").(string)
 	extendedProviderVdc, err := vcdClient.GetProviderVdcExtendedById(providerVdcId)
 	if err != nil {
-		log.Printf("[DEBUG] Could not find any extended Provider VDC with name %s: %s", providerVdcName, err)
+		tflog.Debug(ctx, "Could not find any extended Provider VDC", map[string]interface{}{
+			"provider_vdc_name": providerVdcName,
+			"error":             err,
+		})
 		return diag.Errorf("could not find any extended Provider VDC with name %s: %s", providerVdcName, err)
 	}
 	if extendedProviderVdc.IsEnabled() {
@@ -577,13 +653,15 @@ func resourceVcdProviderVdcDelete(ctx context.Context, d *schema.ResourceData, m
 			return diag.Errorf("error disabling provider VDC %s: %s", providerVdcName, err)
 		}
 	}
-	task, err := extendedProviderVdc.Delete()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	err = task.WaitTaskCompletion()
+	err = retryOnBusyEntity(ctx, vcdClient, func() error {
+		task, err := extendedProviderVdc.Delete()
+		if err != nil {
+			return err
+		}
+		return waitTaskCompletionWithContext(ctx, task)
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(d, err, "%s", err)
 	}
 	return nil
 }
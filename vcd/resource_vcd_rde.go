@@ -4,15 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 func resourceVcdRde() *schema.Resource {
@@ -126,7 +125,7 @@ func resourceVcdRdeCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	tenantContext.OrgId = org.Org.ID
 	tenantContext.OrgName = org.Org.Name
 
-	jsonSchema, err := getRdeJson(vcdClient, d)
+	jsonSchema, err := getRdeJson(ctx, vcdClient, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -150,7 +149,7 @@ func resourceVcdRdeCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	err = createOrUpdateOpenApiMetadataEntryInVcd(d, rde)
+	err = createOrUpdateOpenApiMetadataEntryInVcd(ctx, d, rde)
 	if err != nil {
 		return diag.Errorf("could not create metadata for the Runtime Defined Entity: %s", err)
 	}
@@ -159,11 +158,11 @@ func resourceVcdRdeCreate(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 // getRdeJson gets the RDE as JSON from the Terraform configuration
-func getRdeJson(vcdClient *VCDClient, d *schema.ResourceData) (map[string]interface{}, error) {
+func getRdeJson(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData) (map[string]interface{}, error) {
 	var jsonRde string
 	var err error
 	if url, isUrlSet := d.GetOk("input_entity_url"); isUrlSet {
-		jsonRde, err = fileFromUrlToString(vcdClient, url.(string), ".json")
+		jsonRde, err = fileFromUrlToString(ctx, vcdClient, url.(string), ".json")
 		if err != nil {
 			return nil, fmt.Errorf("could not download JSON RDE from url %s: %s", url, err)
 		}
@@ -180,11 +179,11 @@ func getRdeJson(vcdClient *VCDClient, d *schema.ResourceData) (map[string]interf
 	return unmarshalledJson, err
 }
 
-func resourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdRdeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	rde, err := getRde(d, vcdClient, "resource")
+	rde, err := getRde(ctx, d, vcdClient, "resource")
 	if govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] Runtime Defined Entity no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Runtime Defined Entity no longer exists. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
@@ -197,7 +196,7 @@ func resourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interfac
 	dSet(d, "state", rde.DefinedEntity.State)
 
 	if rde.DefinedEntity.State != nil && *rde.DefinedEntity.State != "RESOLVED" {
-		util.Logger.Printf("[DEBUG] RDE '%s' is not in RESOLVED state", rde.DefinedEntity.ID)
+		tflog.Debug(ctx, "RDE is not in RESOLVED state", map[string]interface{}{"defined_entity_id": rde.DefinedEntity.ID})
 	}
 
 	jsonEntity, err := jsonToCompactString(rde.DefinedEntity.Entity)
@@ -219,7 +218,7 @@ func resourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interfac
 	dSet(d, "entity_in_sync", false)
 	// These fields can be empty on imports
 	if d.Get("input_entity_url") != "" || d.Get("input_entity") != "" {
-		inputJson, err := getRdeJson(vcdClient, d)
+		inputJson, err := getRdeJson(ctx, vcdClient, d)
 		if err != nil {
 			return diag.Errorf("error getting JSON from RDE '%s' configuration: %s", rde.DefinedEntity.ID, err)
 		}
@@ -234,7 +233,7 @@ func resourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interfac
 		dSet(d, "entity_in_sync", areJsonEqual)
 	}
 
-	diags := updateOpenApiMetadataInState(d, vcdClient, "vcd_rde", rde)
+	diags := updateOpenApiMetadataInState(ctx, d, vcdClient, "vcd_rde", rde)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -249,7 +248,7 @@ func resourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interfac
 }
 
 // getRde retrieves a Runtime Defined Entity from VCD with the required attributes from the Terraform config.
-func getRde(d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd.DefinedEntity, error) {
+func getRde(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd.DefinedEntity, error) {
 	if d.Id() != "" {
 		return vcdClient.GetRdeById(d.Id())
 	}
@@ -302,7 +301,7 @@ func getRde(d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd
 	}
 
 	if len(filteredRdes) > 1 {
-		util.Logger.Printf("[WARN] %s: Choosing the first one", err.Error())
+		tflog.Warn(ctx, "Choosing the first one", map[string]interface{}{"error": err})
 	}
 	// We just perform another GET by ID to retrieve the ETag of the RDE (it is only returned when we get a specific and unique ID).
 	// We pick the first retrieved RDE from the result above. This doesn't guarantee that is the RDE we want, but it's
@@ -312,11 +311,11 @@ func getRde(d *schema.ResourceData, vcdClient *VCDClient, origin string) (*govcd
 
 func resourceVcdRdeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	rde, err := getRde(d, vcdClient, "resource")
+	rde, err := getRde(ctx, d, vcdClient, "resource")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	jsonEntity, err := getRdeJson(vcdClient, d)
+	jsonEntity, err := getRdeJson(ctx, vcdClient, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -338,7 +337,7 @@ func resourceVcdRdeUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	err = createOrUpdateOpenApiMetadataEntryInVcd(d, rde)
+	err = createOrUpdateOpenApiMetadataEntryInVcd(ctx, d, rde)
 	if err != nil {
 		return diag.Errorf("could not create metadata for the Runtime Defined Entity: %s", err)
 	}
@@ -346,9 +345,9 @@ func resourceVcdRdeUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	return resourceVcdRdeRead(ctx, d, meta)
 }
 
-func resourceVcdRdeDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdRdeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	rde, err := getRde(d, vcdClient, "resource")
+	rde, err := getRde(ctx, d, vcdClient, "resource")
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			return nil // Already deleted
@@ -383,7 +382,7 @@ func resourceVcdRdeDelete(_ context.Context, d *schema.ResourceData, meta interf
 // Example resource name (_resource_name_): vcd_rde.outer-rde
 // Example import path (_the_id_string_): my-rde.vmware.kubernetes.1.0.0
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdRdeImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdRdeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 	helpError := fmt.Errorf(`resource id must be specified in one of these formats:
 'rde-id' to import by RDE id
@@ -417,7 +416,7 @@ func resourceVcdRdeImport(_ context.Context, d *schema.ResourceData, meta interf
 		return rdes[idx-1], nil
 	}
 
-	log.Printf("[DEBUG] importing vcd_rde resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_rde resource", map[string]interface{}{"id": d.Id()})
 
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	var rde *govcd.DefinedEntity
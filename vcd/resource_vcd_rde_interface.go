@@ -3,9 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -83,10 +83,10 @@ func resourceVcdRdeInterfaceRead(ctx context.Context, d *schema.ResourceData, me
 // genericVcdRdeInterfaceRead reads a Defined Interface from VCD and sets the Terraform state accordingly.
 // If origin == "datasource", if the referenced Interface doesn't exist, it errors.
 // If origin == "resource", if the referenced Interface doesn't exist, it removes it from tfstate and exits normally.
-func genericVcdRdeInterfaceRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdRdeInterfaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	di, err := getDefinedInterface(d, meta)
 	if origin == "resource" && govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] Runtime Defined Entity Interface no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Runtime Defined Entity Interface no longer exists. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
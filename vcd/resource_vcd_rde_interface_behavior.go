@@ -4,14 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
-	"log"
-	"reflect"
-	"strings"
 )
 
 func resourceVcdRdeInterfaceBehavior() *schema.Resource {
@@ -81,12 +81,12 @@ func isBehaviorExecutionEqual(_, oldValue, newValue string, d *schema.ResourceDa
 	var unmarshaledOldJson, unmarshaledNewJson map[string]interface{}
 	err := json.Unmarshal([]byte(oldValue), &unmarshaledOldJson)
 	if err != nil {
-		util.Logger.Printf("[ERROR] Could not unmarshal old value JSON: %s", oldValue)
+		tflog.Error(providerLogContext(), "could not unmarshal old value JSON", map[string]interface{}{"error": err})
 		return false
 	}
 	err = json.Unmarshal([]byte(newValue), &unmarshaledNewJson)
 	if err != nil {
-		util.Logger.Printf("[ERROR] Could not unmarshal new value JSON: %s", newValue)
+		tflog.Error(providerLogContext(), "could not unmarshal new value JSON", map[string]interface{}{"error": err})
 		return false
 	}
 
@@ -146,7 +146,7 @@ func resourceVcdRdeInterfaceBehaviorRead(ctx context.Context, d *schema.Resource
 	return genericVcdRdeInterfaceBehaviorRead(ctx, d, meta, "resource")
 }
 
-func genericVcdRdeInterfaceBehaviorRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdRdeInterfaceBehaviorRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	interfaceId := d.Get("rde_interface_id").(string)
 	rdeInterface, err := vcdClient.GetDefinedInterfaceById(interfaceId)
@@ -161,7 +161,7 @@ func genericVcdRdeInterfaceBehaviorRead(_ context.Context, d *schema.ResourceDat
 		behavior, err = rdeInterface.GetBehaviorByName(d.Get("name").(string))
 	}
 	if origin == "resource" && govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] RDE Interface Behavior no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "RDE Interface Behavior no longer exists. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
@@ -236,10 +236,10 @@ func resourceVcdRdeInterfaceBehaviorDelete(_ context.Context, d *schema.Resource
 // Example resource name (_resource_name_): vcd_rde_interface_behavior.behavior1
 // Example import path (_the_id_string_): vmware.kubernetes.1.0.0.myBehavior
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdRdeInterfaceBehaviorImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdRdeInterfaceBehaviorImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
-	log.Printf("[DEBUG] importing vcd_rde_interface_behavior resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_rde_interface_behavior resource", map[string]interface{}{"id": d.Id()})
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	var rdeInterface *govcd.DefinedInterface
 	var behaviorName string
@@ -4,17 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"io"
-	"log"
 	"net/http"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 func getRdeTypeHookSchema(computed bool) *schema.Resource {
@@ -138,7 +138,7 @@ func resourceVcdRdeType() *schema.Resource {
 func hasJsonValueChanged(key, oldValue, newValue string, _ *schema.ResourceData) bool {
 	areEqual, err := areMarshaledJsonEqual([]byte(oldValue), []byte(newValue))
 	if err != nil {
-		util.Logger.Printf("[ERROR] Could not compare JSONs for computing difference of %s: %s", key, err)
+		tflog.Error(providerLogContext(), "could not compare JSONs for computing difference", map[string]interface{}{"key": key, "error": err})
 		return false
 	}
 	return areEqual
@@ -147,7 +147,7 @@ func hasJsonValueChanged(key, oldValue, newValue string, _ *schema.ResourceData)
 func resourceVcdRdeTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
-	jsonSchema, err := getRdeTypeSchema(vcdClient, d)
+	jsonSchema, err := getRdeTypeSchema(ctx, vcdClient, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -188,11 +188,11 @@ func getRdeTypeHooksFromSchema(d *schema.ResourceData) map[string]string {
 }
 
 // getRdeTypeSchema gets the schema as string from the Terraform configuration
-func getRdeTypeSchema(vcdClient *VCDClient, d *schema.ResourceData) (map[string]interface{}, error) {
+func getRdeTypeSchema(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData) (map[string]interface{}, error) {
 	var jsonSchema string
 	var err error
 	if url, isUrlSet := d.GetOk("schema_url"); isUrlSet {
-		jsonSchema, err = fileFromUrlToString(vcdClient, url.(string), ".json")
+		jsonSchema, err = fileFromUrlToString(ctx, vcdClient, url.(string), ".json")
 		if err != nil {
 			return nil, fmt.Errorf("could not download JSON schema from url %s: %s", url, err)
 		}
@@ -222,7 +222,7 @@ func executeRdeTypeFunctionWithMutex(rdeWriteFunction func()) {
 
 // fileFromUrlToString checks that the given url is correct and points to a given file type,
 // if so it downloads its contents and returns it as string.
-func fileFromUrlToString(vcdClient *VCDClient, url, fileType string) (string, error) {
+func fileFromUrlToString(ctx context.Context, vcdClient *VCDClient, url, fileType string) (string, error) {
 	if !strings.HasSuffix(url, fileType) {
 		return "", fmt.Errorf("it was expecting the URL to point to a %s file but it was %s", fileType, url)
 	}
@@ -234,7 +234,7 @@ func fileFromUrlToString(vcdClient *VCDClient, url, fileType string) (string, er
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
-			util.Logger.Printf("[ERROR] fileFromUrlToString: Could not close HTTP response body: %s", err)
+			tflog.Error(ctx, "fileFromUrlToString: Could not close HTTP response body", map[string]interface{}{"error": err})
 		}
 	}()
 	if resp.StatusCode != http.StatusOK {
@@ -256,11 +256,11 @@ func resourceVcdRdeTypeRead(ctx context.Context, d *schema.ResourceData, meta in
 // genericVcdRdeTypeRead reads a Runtime Defined Entity Type from VCD and sets the Terraform state accordingly.
 // If origin == "datasource", if the referenced RDE Type doesn't exist, it errors.
 // If origin == "resource", if the referenced RDE Type doesn't exist, it removes it from tfstate and exits normally.
-func genericVcdRdeTypeRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdRdeTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	rdeType, err := getRdeType(d, vcdClient)
 	if origin == "resource" && govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] Runtime Defined Entity Type no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Runtime Defined Entity Type no longer exists. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
@@ -325,13 +325,13 @@ func resourceVcdRdeTypeUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	rdeType, err := getRdeType(d, vcdClient)
 	if govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] Runtime Defined Entity Type no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Runtime Defined Entity Type no longer exists. Removing from tfstate")
 		return nil
 	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	jsonSchema, err := getRdeTypeSchema(vcdClient, d)
+	jsonSchema, err := getRdeTypeSchema(ctx, vcdClient, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -352,12 +352,12 @@ func resourceVcdRdeTypeUpdate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceVcdRdeTypeRead(ctx, d, meta)
 }
 
-func resourceVcdRdeTypeDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdRdeTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	rdeType, err := getRdeType(d, vcdClient)
 	if govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] Runtime Defined Entity Type no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Runtime Defined Entity Type no longer exists. Removing from tfstate")
 		return nil
 	}
 	if err != nil {
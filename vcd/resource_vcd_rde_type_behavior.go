@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"strings"
 )
 
 func resourceVcdRdeTypeBehavior() *schema.Resource {
@@ -119,7 +120,7 @@ func resourceVcdRdeTypeBehaviorRead(ctx context.Context, d *schema.ResourceData,
 	return genericVcdRdeTypeBehaviorRead(ctx, d, meta, "resource")
 }
 
-func genericVcdRdeTypeBehaviorRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdRdeTypeBehaviorRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	rdeTypeId := d.Get("rde_type_id").(string)
 	rdeType, err := vcdClient.GetRdeTypeById(rdeTypeId)
@@ -136,7 +137,7 @@ func genericVcdRdeTypeBehaviorRead(_ context.Context, d *schema.ResourceData, me
 
 	behavior, err := rdeType.GetBehaviorById(behaviorId)
 	if origin == "resource" && govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] RDE Type Behavior no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "RDE Type Behavior no longer exists. Removing from tfstate")
 		d.SetId("")
 		return nil
 	}
@@ -211,10 +212,10 @@ func resourceVcdRdeTypeBehaviorDelete(_ context.Context, d *schema.ResourceData,
 // Example resource name (_resource_name_): vcd_rde_type_behavior.behavior1
 // Example import path (_the_id_string_): vmware.kubernetes.1.0.0.myBehavior
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdRdeTypeBehaviorImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdRdeTypeBehaviorImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
-	log.Printf("[DEBUG] importing vcd_rde_type_behavior resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_rde_type_behavior resource", map[string]interface{}{"id": d.Id()})
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	var rdeType *govcd.DefinedEntityType
 	var behaviorName string
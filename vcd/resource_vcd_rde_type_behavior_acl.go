@@ -3,12 +3,13 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"strings"
 )
 
 func resourceVcdRdeTypeBehaviorAccessLevel() *schema.Resource {
@@ -191,10 +192,10 @@ func resourceVcdRdeTypeBehaviorAccessLevelDelete(_ context.Context, d *schema.Re
 // Example resource name (_resource_name_): vcd_rde_type_behavior_acl.behavior_acl1
 // Example import path (_the_id_string_): vmware.kubernetes.1.0.0.myBehavior
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdRdeTypeBehaviorAccessLevelImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdRdeTypeBehaviorAccessLevelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
-	log.Printf("[DEBUG] importing vcd_rde_type_behavior resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_rde_type_behavior resource", map[string]interface{}{"id": d.Id()})
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	var rdeType *govcd.DefinedEntityType
 	var behaviorName string
@@ -3,12 +3,12 @@ package vcd
 import (
 	"context"
 	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"strings"
 )
 
 func resourceVcdSecurityTag() *schema.Resource {
@@ -71,7 +71,7 @@ func resourceVcdOpenApiSecurityTagCreateUpdate(ctx context.Context, d *schema.Re
 	return resourceVcdOpenApiSecurityTagRead(ctx, d, meta)
 }
 
-func resourceVcdOpenApiSecurityTagRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdOpenApiSecurityTagRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	org, err := vcdClient.GetOrgFromResource(d)
@@ -84,7 +84,9 @@ func resourceVcdOpenApiSecurityTagRead(_ context.Context, d *schema.ResourceData
 	taggedEntities, err := org.GetAllSecurityTaggedEntitiesByName(securityTagName)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("[DEBUG] Unable to find entities with security tag name: %s. Removing from tfstate", securityTagName)
+			tflog.Debug(ctx, "Unable to find entities with security tag name. Removing from tfstate", map[string]interface{}{
+				"security_tag_name": securityTagName,
+			})
 			d.SetId("")
 			return nil
 		}
@@ -126,8 +128,8 @@ func resourceVcdOpenApiSecurityTagDelete(_ context.Context, d *schema.ResourceDa
 	return nil
 }
 
-func resourceVcdOpenApiSecurityTagImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdOpenApiSecurityTagImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog")
 	}
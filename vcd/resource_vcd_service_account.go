@@ -3,10 +3,9 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/url"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -274,7 +273,7 @@ func genericVcdServiceAccountRead(ctx context.Context, d *schema.ResourceData, m
 			return diag.Errorf("[Service Account DS read] error retrieving Service Account: %s", err)
 		}
 		d.SetId("")
-		log.Printf("[DEBUG] Service Account no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "Service Account no longer exists. Removing from tfstate")
 		return nil
 	}
 
@@ -318,9 +317,9 @@ func resourceVcdServiceAccountDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceVcdServiceAccountImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	log.Printf("[TRACE] API token import initiated")
+	tflog.Trace(ctx, "API token import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.service-account-name")
 	}
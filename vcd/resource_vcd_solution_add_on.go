@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -137,7 +137,7 @@ func resourceVcdSolutionAddonImport(ctx context.Context, d *schema.ResourceData,
 		return nil, fmt.Errorf("resource was not imported! \n%s", addOnList)
 	}
 
-	log.Printf("[DEBUG] importing vcd_solution_add_on resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_solution_add_on resource", map[string]interface{}{"id": d.Id()})
 
 	if strings.HasPrefix(d.Id(), "urn:vcloud:entity:") { // Import by id
 		addOnById, err := vcdClient.GetSolutionAddonById(d.Id())
@@ -9,12 +9,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/kr/pretty"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 type TaskIdCollection struct {
@@ -243,7 +242,7 @@ func resourceVcdSubscribedCatalog() *schema.Resource {
 
 // resourceVcdSubscribedCatalogCreate creates a subscribed catalog
 func resourceVcdSubscribedCatalogCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Println("[TRACE] entering resourceVcdSubscribedCatalogCreate")
+	tflog.Trace(ctx, "entering resourceVcdSubscribedCatalogCreate")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -282,14 +281,14 @@ func resourceVcdSubscribedCatalogCreate(ctx context.Context, d *schema.ResourceD
 
 	// Creation will start the initial synchronisation. A new one should not be run when `sync_on_refresh` is set
 	ctx = context.WithValue(ctx, contextString("operation"), contextString("create"))
-	util.Logger.Printf("[TRACE] Subscribed Catalog created: %#v\n", adminCatalog)
+	tflog.Trace(ctx, "Subscribed Catalog created", map[string]interface{}{"catalog_name": adminCatalog.AdminCatalog.Name})
 	return resourceVcdSubscribedCatalogRead(ctx, d, meta)
 }
 
 // resourceVcdSubscribedCatalogRead reads or refreshes the subscribed catalog
 // if `sync_on_refresh` was set, it also performs the catalog synchronisation
 func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Println("[TRACE] entering resourceVcdSubscribedCatalogRead")
+	tflog.Trace(ctx, "entering resourceVcdSubscribedCatalogRead")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -301,7 +300,7 @@ func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceDat
 	adminCatalog, err := adminOrg.GetAdminCatalogByNameOrId(d.Id(), false)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			util.Logger.Printf("[DEBUG] Unable to find catalog. Removing from tfstate\n")
+			tflog.Debug(ctx, "Unable to find catalog. Removing from tfstate")
 			d.SetId("")
 			return nil
 		}
@@ -321,7 +320,7 @@ func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceDat
 	var metadataStruct StringMap
 	metadata, err := adminCatalog.GetMetadata()
 	if err != nil {
-		util.Logger.Printf("[DEBUG] Unable to find catalog metadata: %s\n", err)
+		tflog.Debug(ctx, "Unable to find catalog metadata", map[string]interface{}{"error": err})
 		return diag.Errorf("%v", err)
 	}
 
@@ -340,7 +339,7 @@ func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceDat
 	}
 	dSet(d, "subscription_url", adminCatalog.AdminCatalog.ExternalCatalogSubscription.Location)
 	dSet(d, "make_local_copy", adminCatalog.AdminCatalog.ExternalCatalogSubscription.LocalCopy)
-	err = setCatalogData(d, vcdClient, adminOrg.AdminOrg.Name, adminOrg.AdminOrg.ID, adminCatalog)
+	err = setCatalogData(ctx, d, vcdClient, adminOrg.AdminOrg.Name, adminOrg.AdminOrg.ID, adminCatalog)
 	if err != nil {
 		return diag.Errorf("%v", err)
 	}
@@ -355,16 +354,16 @@ func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 	if syncOnRefresh {
-		err = runSubscribedCatalogSyncOperations(d, vcdClient, adminCatalog, "refresh")
+		err = runSubscribedCatalogSyncOperations(ctx, d, vcdClient, adminCatalog, "refresh")
 		if err != nil {
 			return diag.Errorf("error running synchronisation for catalog %s: %s", adminCatalog.AdminCatalog.Name, err)
 		}
 	}
-	taskIdCollection, err := readTaskIdCollection(vcdClient, adminCatalog.AdminCatalog.ID, d)
+	taskIdCollection, err := readTaskIdCollection(ctx, vcdClient, adminCatalog.AdminCatalog.ID, d)
 	if err != nil {
 		return diag.Errorf("error retrieving task list for catalog %s: %s", adminCatalog.AdminCatalog.Name, err)
 	}
-	newTaskIdCollection, err := skimTaskCollection(vcdClient, taskIdCollection)
+	newTaskIdCollection, err := skimTaskCollection(ctx, vcdClient, taskIdCollection)
 	if err != nil && !syncOnRefresh {
 		return diag.FromErr(err)
 	}
@@ -396,7 +395,7 @@ func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceDat
 	// give it a chance to remove finished tasks
 	if syncOnRefresh {
 		time.Sleep(3 * time.Second)
-		newTaskIdCollection, err = skimTaskCollection(vcdClient, taskIdCollection)
+		newTaskIdCollection, err = skimTaskCollection(ctx, vcdClient, taskIdCollection)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -409,19 +408,19 @@ func resourceVcdSubscribedCatalogRead(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = storeTaskIdCollection(adminCatalog.AdminCatalog.ID, newTaskIdCollection, d)
+	err = storeTaskIdCollection(ctx, adminCatalog.AdminCatalog.ID, newTaskIdCollection, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	dSet(d, "href", adminCatalog.AdminCatalog.HREF)
 	d.SetId(adminCatalog.AdminCatalog.ID)
-	util.Logger.Printf("[TRACE] Subscribed Catalog read completed: %#v\n", adminCatalog.AdminCatalog)
+	tflog.Trace(ctx, "Subscribed Catalog read completed", map[string]interface{}{"catalog_name": adminCatalog.AdminCatalog.Name})
 	return nil
 }
 
 // resourceVcdSubscribedCatalogUpdate updates a subscribed catalog
 func resourceVcdSubscribedCatalogUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Println("[TRACE] entering resourceVcdSubscribedCatalogUpdate")
+	tflog.Trace(ctx, "entering resourceVcdSubscribedCatalogUpdate")
 	// The update in this resource differs from vcd_catalog only in the subscription and synchronising data
 	// Thus, we use the vcd_catalog update with a custom function to update the subscription and sync parameters
 
@@ -433,7 +432,7 @@ func resourceVcdSubscribedCatalogUpdate(ctx context.Context, d *schema.ResourceD
 			Password:                 d.Get("subscription_password").(string),
 			LocalCopy:                d.Get("make_local_copy").(bool),
 		}
-		updateSubscriptionFunc = func(_ *schema.ResourceData, _ *VCDClient, c *govcd.AdminCatalog, _ string) error {
+		updateSubscriptionFunc = func(_ context.Context, _ *schema.ResourceData, _ *VCDClient, c *govcd.AdminCatalog, _ string) error {
 			return c.UpdateSubscriptionParams(params)
 		}
 	}
@@ -449,13 +448,13 @@ func resourceVcdSubscribedCatalogUpdate(ctx context.Context, d *schema.ResourceD
 // resourceVcdSubscribedCatalogDelete deletes a subscribed catalog
 // It defers the main operation to the regular catalog resource, and then removes the tasks file, if it exists
 func resourceVcdSubscribedCatalogDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Println("[TRACE] entering resourceVcdSubscribedCatalogDelete")
+	tflog.Trace(ctx, "entering resourceVcdSubscribedCatalogDelete")
 	diagErr := resourceVcdCatalogDelete(ctx, d, meta)
-	fileName, err := getTaskListFileName(d.Id(), nil)
+	fileName, err := getTaskListFileName(ctx, d.Id(), nil)
 	if err == nil && fileName != "" {
 		err = os.Remove(fileName)
 		if err != nil {
-			util.Logger.Printf("[INFO] no task file found for catalog %s\n", d.Get("name").(string))
+			tflog.Info(ctx, "no task file found for catalog", map[string]interface{}{"name": d.Get("name").(string)})
 		}
 	}
 	return diagErr
@@ -467,8 +466,8 @@ func resourceVcdSubscribedCatalogDelete(ctx context.Context, d *schema.ResourceD
 // or
 // terraform import vcd_subscribed_catalog.catalog-name  org-name.catalog-id
 func resourceVcdSubscribedCatalogImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	util.Logger.Println("[TRACE] entering resourceVcdSubscribedCatalogImport")
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	tflog.Trace(ctx, "entering resourceVcdSubscribedCatalogImport")
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.catalog-name or org-name.catalog-ID")
 	}
@@ -502,8 +501,8 @@ func resourceVcdSubscribedCatalogImport(ctx context.Context, d *schema.ResourceD
 
 // getTaskListFileName retrieves the task list file name.
 // The name will never change for this catalog
-func getTaskListFileName(catalogId string, d *schema.ResourceData) (string, error) {
-	util.Logger.Println("[TRACE] entering getTaskListFileName")
+func getTaskListFileName(ctx context.Context, catalogId string, d *schema.ResourceData) (string, error) {
+	tflog.Trace(ctx, "entering getTaskListFileName")
 	fileName, err := filepath.Abs(strings.Replace(taskFileName, "{ID}", extractUuid(catalogId), 1))
 	if err != nil {
 		return "", err
@@ -515,12 +514,12 @@ func getTaskListFileName(catalogId string, d *schema.ResourceData) (string, erro
 }
 
 // storeTaskIdCollection will store the task ID collections into the tasks file if "store_tasks" was set
-func storeTaskIdCollection(catalogId string, collection TaskIdCollection, d *schema.ResourceData) error {
-	util.Logger.Println("[TRACE] entering storeTaskIdCollection")
+func storeTaskIdCollection(ctx context.Context, catalogId string, collection TaskIdCollection, d *schema.ResourceData) error {
+	tflog.Trace(ctx, "entering storeTaskIdCollection")
 	if !d.Get("store_tasks").(bool) {
 		return nil
 	}
-	fileName, err := getTaskListFileName(catalogId, d)
+	fileName, err := getTaskListFileName(ctx, catalogId, d)
 	if err != nil {
 		return fmt.Errorf("error setting file name for task collection: %s", err)
 	}
@@ -533,13 +532,13 @@ func storeTaskIdCollection(catalogId string, collection TaskIdCollection, d *sch
 		return err
 	}
 
-	util.Logger.Printf(" storing %# v\n", pretty.Formatter(collection))
+	tflog.Debug(ctx, "storing task collection", map[string]interface{}{"collection": collection})
 	return nil
 }
 
 // readTaskIdCollection reads the collection of tasks from file, if "store_tasks" was set
-func readTaskIdCollection(vcdClient *VCDClient, catalogId string, d *schema.ResourceData) (TaskIdCollection, error) {
-	util.Logger.Println("[TRACE] entering readTaskIdCollection")
+func readTaskIdCollection(ctx context.Context, vcdClient *VCDClient, catalogId string, d *schema.ResourceData) (TaskIdCollection, error) {
+	tflog.Trace(ctx, "entering readTaskIdCollection")
 	var collection TaskIdCollection
 	rawRunningTasks := d.Get("running_tasks")
 	if rawRunningTasks != nil {
@@ -558,7 +557,7 @@ func readTaskIdCollection(vcdClient *VCDClient, catalogId string, d *schema.Reso
 	if !storeTasks {
 		return collection, nil
 	}
-	fileName, err := getTaskListFileName(catalogId, d)
+	fileName, err := getTaskListFileName(ctx, catalogId, d)
 	if err != nil {
 		return TaskIdCollection{}, fmt.Errorf("error setting file name for task collection: %s", err)
 	}
@@ -573,9 +572,9 @@ func readTaskIdCollection(vcdClient *VCDClient, catalogId string, d *schema.Reso
 	if err != nil {
 		return TaskIdCollection{}, err
 	}
-	util.Logger.Printf("reading %# v\n", pretty.Formatter(collection))
+	tflog.Debug(ctx, "reading task collection", map[string]interface{}{"collection": collection})
 	// before setting the list of tasks, we remove the ones that are already completed
-	collection, err = skimTaskCollection(vcdClient, collection)
+	collection, err = skimTaskCollection(ctx, vcdClient, collection)
 	if err != nil {
 		return TaskIdCollection{}, err
 	}
@@ -590,7 +589,7 @@ func readTaskIdCollection(vcdClient *VCDClient, catalogId string, d *schema.Reso
 	return collection, nil
 }
 
-func processTasks(vcdClient *VCDClient, collection TaskIdCollection, callNo int) (TaskIdCollection, error, bool) {
+func processTasks(ctx context.Context, vcdClient *VCDClient, collection TaskIdCollection, callNo int) (TaskIdCollection, error, bool) {
 	needsRepeating := false
 	running, failed, err := vcdClient.Client.SkimTasksList(collection.Running)
 	if err != nil {
@@ -616,7 +615,10 @@ func processTasks(vcdClient *VCDClient, collection TaskIdCollection, callNo int)
 			// If this message is in the task error message, we don't return the task ID to the caller,
 			// but keep it for the next run
 			if strings.Contains(task.Task.Error.Error(), "updated or deleted by another transaction") {
-				util.Logger.Printf("[TRACE] [SKIP-FAILED-TASK] task %s skipped. Error: %s\n", taskId, task.Task.Error)
+				tflog.Trace(ctx, "[SKIP-FAILED-TASK] task skipped", map[string]interface{}{
+					"task_id": taskId,
+					"error":   task.Task.Error,
+				})
 				needsRepeating = true
 				collection.skip = append(collection.skip, taskId)
 			} else {
@@ -643,16 +645,16 @@ func processTasks(vcdClient *VCDClient, collection TaskIdCollection, callNo int)
 }
 
 // skimTaskCollection will remove from the task list all the tasks that are already complete
-func skimTaskCollection(vcdClient *VCDClient, collection TaskIdCollection) (TaskIdCollection, error) {
-	util.Logger.Println("[TRACE] entering skimTaskCollection")
+func skimTaskCollection(ctx context.Context, vcdClient *VCDClient, collection TaskIdCollection) (TaskIdCollection, error) {
+	tflog.Trace(ctx, "entering skimTaskCollection")
 	var needsRepeating bool
 	var err error
-	collection, err, needsRepeating = processTasks(vcdClient, collection, 1)
+	collection, err, needsRepeating = processTasks(ctx, vcdClient, collection, 1)
 	if err != nil {
 		// If a possible race was detected, repeat the skim a second time
 		if needsRepeating {
 			time.Sleep(10 * time.Second)
-			collection, err, _ = processTasks(vcdClient, collection, 2)
+			collection, err, _ = processTasks(ctx, vcdClient, collection, 2)
 		}
 	}
 	if err != nil {
@@ -662,8 +664,11 @@ func skimTaskCollection(vcdClient *VCDClient, collection TaskIdCollection) (Task
 }
 
 // runSubscribedCatalogSyncOperations runs all the requested synchronisation operations
-func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDClient, adminCatalog *govcd.AdminCatalog, operation string) error {
-	util.Logger.Printf("[TRACE] Catalog '%s' sync initiated [%s]\n", adminCatalog.AdminCatalog.Name, operation)
+func runSubscribedCatalogSyncOperations(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, adminCatalog *govcd.AdminCatalog, operation string) error {
+	tflog.Trace(ctx, "Catalog sync initiated", map[string]interface{}{
+		"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+		"operation":          operation,
+	})
 
 	catalogId := adminCatalog.AdminCatalog.ID
 	makeLocalCopy := d.Get("make_local_copy").(bool)
@@ -677,7 +682,7 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 	var collection TaskIdCollection
 	var taskList []string
 	var err error
-	collection, err = readTaskIdCollection(vcdClient, catalogId, d)
+	collection, err = readTaskIdCollection(ctx, vcdClient, catalogId, d)
 	if err != nil {
 		return fmt.Errorf("error reading catalog task IDs: %s", err)
 	}
@@ -685,22 +690,29 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 
 	cancelFailedTasks := d.Get("cancel_failed_tasks").(bool)
 	if len(collection.Failed) > 0 {
-		util.Logger.Printf("[TRACE] Catalog '%s' sync - collecting failed tasks\n", adminCatalog.AdminCatalog.Name)
+		tflog.Trace(ctx, "Catalog sync - collecting failed tasks", map[string]interface{}{
+			"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+		})
 		for _, taskId := range collection.Failed {
 			task, err := vcdClient.Client.GetTaskById(taskId)
 			if err == nil {
-				util.Logger.Printf("[runSubscribedCatalogSyncOperations] %s (%s) %s\n",
-					task.Task.OperationName, task.Task.Status, task.Task.Operation)
+				tflog.Debug(ctx, "[runSubscribedCatalogSyncOperations]", map[string]interface{}{
+					"operation_name": task.Task.OperationName,
+					"task_status":    task.Task.Status,
+					"operation":      task.Task.Operation,
+				})
 				if cancelFailedTasks {
 					err = task.CancelTask()
 					if err != nil {
-						util.Logger.Printf("[runSubscribedCatalogSyncOperations] error canceling task %s\n", taskId)
+						tflog.Debug(ctx, "[runSubscribedCatalogSyncOperations] error canceling task", map[string]interface{}{
+							"task_id": taskId,
+						})
 					}
 				}
 			}
 		}
 		time.Sleep(3 * time.Second)
-		collection, err = skimTaskCollection(vcdClient, collection)
+		collection, err = skimTaskCollection(ctx, vcdClient, collection)
 		if err != nil {
 			return fmt.Errorf("error running skimTaskCollection: %s", err)
 		}
@@ -714,7 +726,10 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 	}
 
 	if syncCatalog || syncAll {
-		util.Logger.Printf("[TRACE] Catalog '%s' sync - sync_catalog [make_local_copy=%v]\n", adminCatalog.AdminCatalog.Name, makeLocalCopy)
+		tflog.Trace(ctx, "Catalog sync - sync_catalog", map[string]interface{}{
+			"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+			"make_local_copy":    makeLocalCopy,
+		})
 		var task *govcd.Task
 		if makeLocalCopy {
 			task, err = adminCatalog.LaunchSync()
@@ -731,10 +746,13 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 	// If the `make_local_copy` property was set, we don't need to synchronise anything more than the catalog
 	if makeLocalCopy {
 		collection.Running = taskList
-		return storeTaskIdCollection(catalogId, collection, d)
+		return storeTaskIdCollection(ctx, catalogId, collection, d)
 	}
 	if syncAllVappTemplates || syncAll {
-		util.Logger.Printf("[TRACE] Catalog '%s' sync - sync_all_vapp_templates [make_local_copy=%v]\n", adminCatalog.AdminCatalog.Name, makeLocalCopy)
+		tflog.Trace(ctx, "Catalog sync - sync_all_vapp_templates", map[string]interface{}{
+			"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+			"make_local_copy":    makeLocalCopy,
+		})
 		tasks, err := adminCatalog.LaunchSynchronisationAllVappTemplates()
 		if err != nil {
 			return fmt.Errorf("error synchronising all vApp templates for catalog %s: %s", adminCatalog.AdminCatalog.Name, err)
@@ -744,7 +762,10 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 		}
 	}
 	if syncAllMediaItems || syncAll {
-		util.Logger.Printf("[TRACE] Catalog '%s' sync - sync_all_media_items [make_local_copy=%v]\n", adminCatalog.AdminCatalog.Name, makeLocalCopy)
+		tflog.Trace(ctx, "Catalog sync - sync_all_media_items", map[string]interface{}{
+			"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+			"make_local_copy":    makeLocalCopy,
+		})
 		tasks, err := adminCatalog.LaunchSynchronisationAllMediaItems()
 		if err != nil {
 			return fmt.Errorf("error synchronising all media items for catalog %s: %s", adminCatalog.AdminCatalog.Name, err)
@@ -756,7 +777,10 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 		}
 	}
 	if len(rawSyncVappTemplates) > 0 {
-		util.Logger.Printf("[TRACE] Catalog '%s' sync - sync_vapp_templates [make_local_copy=%v]\n", adminCatalog.AdminCatalog.Name, makeLocalCopy)
+		tflog.Trace(ctx, "Catalog sync - sync_vapp_templates", map[string]interface{}{
+			"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+			"make_local_copy":    makeLocalCopy,
+		})
 		var syncVappTemplates []string
 		for _, item := range rawSyncVappTemplates {
 			syncVappTemplates = append(syncVappTemplates, item.(string))
@@ -770,7 +794,10 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 		}
 	}
 	if len(rawSyncMediaItems) > 0 {
-		util.Logger.Printf("[TRACE] Catalog '%s' sync - sync_all_media_items [make_local_copy=%v]\n", adminCatalog.AdminCatalog.Name, makeLocalCopy)
+		tflog.Trace(ctx, "Catalog sync - sync_all_media_items", map[string]interface{}{
+			"admin_catalog_name": adminCatalog.AdminCatalog.Name,
+			"make_local_copy":    makeLocalCopy,
+		})
 		var syncMediaItems []string
 		for _, item := range rawSyncMediaItems {
 			syncMediaItems = append(syncMediaItems, item.(string))
@@ -785,5 +812,5 @@ func runSubscribedCatalogSyncOperations(d *schema.ResourceData, vcdClient *VCDCl
 	}
 
 	collection.Running = taskList
-	return storeTaskIdCollection(catalogId, collection, d)
+	return storeTaskIdCollection(ctx, catalogId, collection, d)
 }
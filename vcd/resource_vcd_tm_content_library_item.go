@@ -3,7 +3,6 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -182,10 +181,10 @@ func resourceVcdTmContentLibraryItemDelete(ctx context.Context, d *schema.Resour
 	return deleteResource(ctx, d, meta, c)
 }
 
-func resourceVcdTmContentLibraryItemImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdTmContentLibraryItemImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
-	id := strings.Split(d.Id(), ImportSeparator)
+	id := importIdElements(ctx, d, -1)
 	if len(id) != 2 {
 		return nil, fmt.Errorf("ID syntax should be \"Content Library name\".\"Content Library Item name\", where '.' is a customisable import separator")
 	}
@@ -205,7 +204,7 @@ func resourceVcdTmContentLibraryItemImport(_ context.Context, d *schema.Resource
 	return []*schema.ResourceData{d}, nil
 }
 
-func getContentLibraryItemType(_ *VCDClient, d *schema.ResourceData) (*types.ContentLibraryItem, error) {
+func getContentLibraryItemType(_ context.Context, _ *VCDClient, d *schema.ResourceData) (*types.ContentLibraryItem, error) {
 	t := &types.ContentLibraryItem{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -4,13 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 const labelTmIpSpace = "TM IP Space"
@@ -147,7 +146,7 @@ func resourceVcdTmIpSpaceDelete(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceVcdTmIpSpaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as region-name.ip-space-name")
 	}
@@ -169,7 +168,7 @@ func resourceVcdTmIpSpaceImport(ctx context.Context, d *schema.ResourceData, met
 	return []*schema.ResourceData{d}, nil
 }
 
-func getTmIpSpaceType(vcdClient *VCDClient, d *schema.ResourceData) (*types.TmIpSpace, error) {
+func getTmIpSpaceType(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData) (*types.TmIpSpace, error) {
 	t := &types.TmIpSpace{
 		Name:              d.Get("name").(string),
 		Description:       d.Get("description").(string),
@@ -205,7 +204,7 @@ func getTmIpSpaceType(vcdClient *VCDClient, d *schema.ResourceData) (*types.TmIp
 			// that ID from state can be looked up based on CIDR.
 			// If there was no such cidr in previous state - it means that this is a new 'internal_scope' block
 			// and it doesn't need an ID
-			isSlice[internalScopeIndex].ID = getInternalScopeIdFromFromPreviousState(d, internalScopeBlockStrings["name"], internalScopeBlockStrings["cidr"])
+			isSlice[internalScopeIndex].ID = getInternalScopeIdFromFromPreviousState(ctx, d, internalScopeBlockStrings["name"], internalScopeBlockStrings["cidr"])
 
 		}
 		t.InternalScopeCidrBlocks = isSlice
@@ -251,12 +250,15 @@ func setTmIpSpaceData(_ *VCDClient, d *schema.ResourceData, i *govcd.TmIpSpace)
 	return nil
 }
 
-func getInternalScopeIdFromFromPreviousState(d *schema.ResourceData, desiredName, desiredCidr string) string {
+func getInternalScopeIdFromFromPreviousState(ctx context.Context, d *schema.ResourceData, desiredName, desiredCidr string) string {
 	internalScopeOld, _ := d.GetChange("internal_scope")
 	internalScopeOldSchema := internalScopeOld.(*schema.Set)
 	internalScopeOldSlice := internalScopeOldSchema.List()
 
-	util.Logger.Printf("[TRACE] Looking for ID of 'internal_scope' with name '%s', cidr '%s'\n", desiredName, desiredCidr)
+	tflog.Trace(ctx, "Looking for ID of 'internal_scope' with name, cidr", map[string]interface{}{
+		"desired_name": desiredName,
+		"desired_cidr": desiredCidr,
+	})
 	var foundPartialId string
 	for internalScopeIndex := range internalScopeOldSlice {
 		singleScopeOld := internalScopeOldSlice[internalScopeIndex]
@@ -264,23 +266,36 @@ func getInternalScopeIdFromFromPreviousState(d *schema.ResourceData, desiredName
 
 		// exact match
 		if singleScopeOldMap["cidr"] == desiredCidr && singleScopeOldMap["name"] == desiredName {
-			util.Logger.Printf("[TRACE] Found exact match for ID '%s' of 'internal_scope' with name '%s', cidr '%s' \n", singleScopeOldMap["id"], desiredName, desiredCidr)
+			tflog.Trace(ctx, "Found exact match for ID of 'internal_scope' with name, cidr", map[string]interface{}{
+				"id":           singleScopeOldMap["id"],
+				"desired_name": desiredName,
+				"desired_cidr": desiredCidr,
+			})
 			return singleScopeOldMap["id"]
 		}
 
 		// partial match based on cidr
 		if singleScopeOldMap["cidr"] == desiredCidr {
-			util.Logger.Printf("[TRACE] Found partial match for ID '%s' of 'internal_scope' with cidr '%s'. 'name' is ignored'\n", singleScopeOldMap["id"], desiredCidr)
+			tflog.Trace(ctx, "Found partial match for ID of 'internal_scope' with cidr. 'name' is ignored'", map[string]interface{}{
+				"id":           singleScopeOldMap["id"],
+				"desired_cidr": desiredCidr,
+			})
 			foundPartialId = singleScopeOldMap["id"]
 		}
 	}
 
 	if foundPartialId != "" {
-		util.Logger.Printf("[TRACE] Returning partial match for ID '%s' of 'internal_scope' with cidr '%s'. 'name' are ignored'\n", desiredCidr, desiredName)
+		tflog.Trace(ctx, "Returning partial match for ID of 'internal_scope' with cidr. 'name' are ignored'", map[string]interface{}{
+			"desired_cidr": desiredCidr,
+			"desired_name": desiredName,
+		})
 		return foundPartialId
 	}
 
-	util.Logger.Printf("[TRACE] Not found 'internal_scope' ID with name '%s', cidr '%s'\n", desiredName, desiredCidr)
+	tflog.Trace(ctx, "Not found 'internal_scope' ID with name, cidr", map[string]interface{}{
+		"desired_name": desiredName,
+		"desired_cidr": desiredCidr,
+	})
 	// No ID was found at all
 	return ""
 }
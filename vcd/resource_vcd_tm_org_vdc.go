@@ -166,7 +166,7 @@ func resourceTmOrgVdcImport(ctx context.Context, d *schema.ResourceData, meta in
 	return []*schema.ResourceData{d}, nil
 }
 
-func getTmVdcType(_ *VCDClient, d *schema.ResourceData) (*types.TmVdc, error) {
+func getTmVdcType(_ context.Context, _ *VCDClient, d *schema.ResourceData) (*types.TmVdc, error) {
 	t := &types.TmVdc{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -153,7 +153,7 @@ func resourceVcdTmRegionImport(ctx context.Context, d *schema.ResourceData, meta
 	return []*schema.ResourceData{d}, nil
 }
 
-func getRegionType(vcdClient *VCDClient, d *schema.ResourceData) (*types.Region, error) {
+func getRegionType(_ context.Context, vcdClient *VCDClient, d *schema.ResourceData) (*types.Region, error) {
 	t := &types.Region{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -6,11 +6,11 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 const labelTmVirtualCenter = "Tenant Manager vCenter Server"
@@ -123,7 +123,7 @@ func resourceVcdTmVcenter() *schema.Resource {
 	}
 }
 
-func getTmVcenterType(_ *VCDClient, d *schema.ResourceData) (*types.VSphereVirtualCenter, error) {
+func getTmVcenterType(_ context.Context, _ *VCDClient, d *schema.ResourceData) (*types.VSphereVirtualCenter, error) {
 	t := &types.VSphereVirtualCenter{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
@@ -330,10 +330,10 @@ func waitForListenerStatusConnected(v *govcd.VCenter) error {
 // * trustSchemaFieldName - Terraform schema field (TypeBool) name that defines if the certificate should be trusted
 // Note. It will not add new entry if the certificate is already trusted
 func autoTrustHostCertificate(urlSchemaFieldName, trustSchemaFieldName string) schemaHook {
-	return func(vcdClient *VCDClient, d *schema.ResourceData) error {
+	return func(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData) error {
 		shouldExecute := d.Get(trustSchemaFieldName).(bool)
 		if !shouldExecute {
-			util.Logger.Printf("[DEBUG] Skipping certificate trust execution as '%s' is false", trustSchemaFieldName)
+			tflog.Debug(ctx, "Skipping certificate trust execution as the field is false", map[string]interface{}{"field": trustSchemaFieldName})
 			return nil
 		}
 		schemaUrl := d.Get(urlSchemaFieldName).(string)
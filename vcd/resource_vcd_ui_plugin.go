@@ -3,14 +3,15 @@ package vcd
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"log"
-	"regexp"
-	"strings"
 )
 
 func resourceVcdUIPlugin() *schema.Resource {
@@ -184,7 +185,7 @@ func resourceVcdUIPluginRead(ctx context.Context, d *schema.ResourceData, meta i
 
 // getUIPlugin retrieves the UI Plugin from VCD using the resource/data source information.
 // Returns a nil govcd.UIPlugin if it doesn't exist in VCD and origin is a "resource".
-func getUIPlugin(vcdClient *VCDClient, d *schema.ResourceData, origin string) (*govcd.UIPlugin, error) {
+func getUIPlugin(ctx context.Context, vcdClient *VCDClient, d *schema.ResourceData, origin string) (*govcd.UIPlugin, error) {
 	var uiPlugin *govcd.UIPlugin
 	var err error
 	if d.Id() != "" {
@@ -194,7 +195,7 @@ func getUIPlugin(vcdClient *VCDClient, d *schema.ResourceData, origin string) (*
 	}
 
 	if origin == "resource" && govcd.ContainsNotFound(err) {
-		log.Printf("[DEBUG] UI Plugin no longer exists. Removing from tfstate")
+		tflog.Debug(ctx, "UI Plugin no longer exists. Removing from tfstate")
 		d.SetId("")
 		return nil, nil
 	}
@@ -204,10 +205,10 @@ func getUIPlugin(vcdClient *VCDClient, d *schema.ResourceData, origin string) (*
 	return uiPlugin, nil
 }
 
-func genericVcdUIPluginRead(_ context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdUIPluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
-	uiPlugin, err := getUIPlugin(vcdClient, d, origin)
+	uiPlugin, err := getUIPlugin(ctx, vcdClient, d, origin)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -248,7 +249,7 @@ func setUIPluginTenantIds(uiPlugin *govcd.UIPlugin, d *schema.ResourceData) erro
 
 func resourceVcdUIPluginUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
-	uiPlugin, err := getUIPlugin(vcdClient, d, "resource")
+	uiPlugin, err := getUIPlugin(ctx, vcdClient, d, "resource")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -269,10 +270,10 @@ func resourceVcdUIPluginUpdate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceVcdUIPluginRead(ctx, d, meta)
 }
 
-func resourceVcdUIPluginDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdUIPluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
-	uiPlugin, err := getUIPlugin(vcdClient, d, "resource")
+	uiPlugin, err := getUIPlugin(ctx, vcdClient, d, "resource")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -301,8 +302,8 @@ func resourceVcdUIPluginDelete(_ context.Context, d *schema.ResourceData, meta i
 // Example resource name (_resource_name_): vcd_ui_plugin.existing_ui_plugin
 // Example import path (_the_id_string_): VMware."Customize Portal".3.1.4
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVcdUIPluginImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdUIPluginImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) < 3 {
 		return nil, fmt.Errorf("resource identifier must be specified as vendor.pluginName.version")
 	}
@@ -3,11 +3,10 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"regexp"
 	"sort"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,7 +16,7 @@ import (
 const vAppUnknownStatus = "-unknown-status-"
 
 func resourceVcdVApp() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdVAppCreate,
 		UpdateContext: resourceVcdVAppUpdate,
 		ReadContext:   resourceVcdVAppRead,
@@ -133,7 +132,7 @@ func resourceVcdVApp() *schema.Resource {
 				Description: "A map that contains metadata that is automatically added by VCD (10.5.1+) and provides details on the origin of the vApp",
 			},
 		},
-	}
+	}, metadataStateUpgrade)
 }
 
 func resourceVcdVAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -162,12 +161,12 @@ func resourceVcdVAppCreate(ctx context.Context, d *schema.ResourceData, meta int
 			return diag.Errorf("timed out waiting for vApp to exit UNRESOLVED state: %s", err)
 		}
 
-		guestProperties, err := getGuestProperties(d)
+		guestProperties, err := getGuestProperties(ctx, d)
 		if err != nil {
 			return diag.Errorf("unable to convert guest properties to data structure")
 		}
 
-		log.Printf("[TRACE] Setting vApp guest properties")
+		tflog.Trace(ctx, "Setting vApp guest properties")
 		_, err = vapp.SetProductSectionList(guestProperties)
 		if err != nil {
 			return diag.Errorf("error setting guest properties: %s", err)
@@ -229,19 +228,19 @@ func resourceVcdVAppUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		}
 	}
 	if d.HasChange("guest_properties") {
-		vappProperties, err := getGuestProperties(d)
+		vappProperties, err := getGuestProperties(ctx, d)
 		if err != nil {
 			return diag.Errorf("unable to convert guest properties to data structure")
 		}
 
-		log.Printf("[TRACE] Updating vApp guest properties")
+		tflog.Trace(ctx, "Updating vApp guest properties")
 		_, err = vapp.SetProductSectionList(vappProperties)
 		if err != nil {
 			return diag.Errorf("error setting guest properties: %s", err)
 		}
 	}
 
-	err = createOrUpdateMetadata(d, vapp, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, vapp, "metadata")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -275,11 +274,11 @@ func resourceVcdVAppUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	return resourceVcdVAppRead(ctx, d, meta)
 }
 
-func resourceVcdVAppRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdVAppRead(d, meta, "resource")
+func resourceVcdVAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdVAppRead(ctx, d, meta, "resource")
 }
 
-func genericVcdVAppRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdVAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	vcdClient := meta.(*VCDClient)
 
@@ -298,7 +297,7 @@ func genericVcdVAppRead(d *schema.ResourceData, meta interface{}, origin string)
 	vapp, err := vdc.GetVAppByNameOrId(identifier, false)
 	if err != nil {
 		if origin == "resource" {
-			log.Printf("[DEBUG] Unable to find vApp. Removing from tfstate")
+			tflog.Debug(ctx, "Unable to find vApp. Removing from tfstate")
 			d.SetId("")
 			return nil
 		}
@@ -311,7 +310,7 @@ func genericVcdVAppRead(d *schema.ResourceData, meta interface{}, origin string)
 		return diag.Errorf("unable to read guest properties: %s", err)
 	}
 
-	err = setGuestProperties(d, guestProperties)
+	err = setGuestProperties(ctx, d, guestProperties)
 	if err != nil {
 		return diag.Errorf("unable to set guest properties in state: %s", err)
 	}
@@ -381,7 +380,7 @@ func genericVcdVAppRead(d *schema.ResourceData, meta interface{}, origin string)
 	dSet(d, "description", vapp.VApp.Description)
 	d.SetId(vapp.VApp.ID)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_vapp", vapp)...)
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_vapp", vapp)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -470,8 +469,8 @@ func tryUndeploy(vapp govcd.VApp) error {
 //
 // Example resource name (_resource_name_): vcd_vapp.vapp_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name
-func resourceVcdVappImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdVappImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[vapp import] resource name must be specified as org-name.vdc-name.vapp-name")
 	}
@@ -3,10 +3,10 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -162,7 +162,7 @@ func resourceAccessControlVappUpdate(ctx context.Context, d *schema.ResourceData
 	return resourceAccessControlVappRead(ctx, d, meta)
 }
 
-func resourceAccessControlVappRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceAccessControlVappRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -175,7 +175,7 @@ func resourceAccessControlVappRead(_ context.Context, d *schema.ResourceData, me
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
 			// If vApp is not found - it means that Access Control must be recreated as well
-			log.Printf("parent vApp not found. Removing access control from state file: %s", err)
+			tflog.Debug(ctx, "parent vApp not found. Removing access control from state file", map[string]interface{}{"error": err})
 			d.SetId("")
 			return nil
 		}
@@ -227,8 +227,8 @@ func resourceAccessControlVappDelete(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func accessControlVappImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func accessControlVappImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[vApp access control import] resource identifier must be specified as org.vdc.my-vapp")
 	}
@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -186,7 +186,7 @@ func resourceVcdVappFirewallRulesUpdate(ctx context.Context, d *schema.ResourceD
 	vappNetwork, err := vapp.UpdateNetworkFirewallRules(networkId, firewallRules, d.Get("enabled").(bool),
 		d.Get("default_action").(string), d.Get("log_default_action").(bool))
 	if err != nil {
-		log.Printf("[INFO] Error setting firewall rules: %s", err)
+		tflog.Info(ctx, "Error setting firewall rules", map[string]interface{}{"error": err})
 		return diag.Errorf("error setting firewall rules: %s", err)
 	}
 
@@ -210,7 +210,7 @@ func getVapp(vcdClient *VCDClient, d *schema.ResourceData) (*govcd.VApp, error)
 	return vapp, nil
 }
 
-func resourceVappFirewallRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVappFirewallRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vapp, err := getVapp(vcdClient, d)
 	if err != nil {
@@ -222,14 +222,14 @@ func resourceVappFirewallRulesDelete(_ context.Context, d *schema.ResourceData,
 
 	err = vapp.RemoveAllNetworkFirewallRules(d.Get("network_id").(string))
 	if err != nil {
-		log.Printf("[INFO] Error removing firewall rules: %s", err)
+		tflog.Info(ctx, "Error removing firewall rules", map[string]interface{}{"error": err})
 		return diag.Errorf("error removing firewall rules: %s", err)
 	}
 
 	return nil
 }
 
-func resourceVappFirewallRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVappFirewallRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vapp, err := getVapp(vcdClient, d)
 	if err != nil {
@@ -239,7 +239,7 @@ func resourceVappFirewallRulesRead(_ context.Context, d *schema.ResourceData, me
 	vappNetwork, err := vapp.GetVappNetworkById(d.Get("network_id").(string), false)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("vApp network not found. Removing from state file: %s", err)
+			tflog.Debug(ctx, "vApp network not found. Removing from state file", map[string]interface{}{"error": err})
 			d.SetId("")
 			return nil
 		}
@@ -377,14 +377,17 @@ var errHelpVappNetworkRulesImport = fmt.Errorf(`resource id must be specified in
 // Example import path (_the_id_string_): org.my_existing_vdc.vapp_name.network_name or org.my_existing_vdc.vapp_id.network_id
 // Example list path (_the_id_string_): list@org-name.vdc-name.vapp-name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func vappFirewallRulesImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	return vappNetworkRuleImport(d, meta, "vcd_vapp_firewall_rules")
+func vappFirewallRulesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return vappNetworkRuleImport(ctx, d, meta, "vcd_vapp_firewall_rules")
 }
-func vappNetworkRuleImport(d *schema.ResourceData, meta interface{}, resourceType string) ([]*schema.ResourceData, error) {
+func vappNetworkRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}, resourceType string) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, vappName string
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 
-	log.Printf("[DEBUG] importing %s resource with provided id %s", resourceType, d.Id())
+	tflog.Debug(ctx, "importing resource", map[string]interface{}{
+		"resource_type": resourceType,
+		"id":            d.Id(),
+	})
 
 	if len(resourceURI) != 4 && len(resourceURI) != 3 {
 		return nil, errHelpVappNetworkRulesImport
@@ -398,6 +401,9 @@ func vappNetworkRuleImport(d *schema.ResourceData, meta interface{}, resourceTyp
 		orgName = commandOrgNameSplit[1]
 		return listVappNetworksForImport(meta, orgName, vdcName, vappName)
 	} else {
+		if len(resourceURI) != 4 {
+			return nil, errHelpVappNetworkRulesImport
+		}
 		orgName, vdcName, vappId, networkId := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 		return getNetworkRules(d, meta, orgName, vdcName, vappId, networkId)
 	}
@@ -3,8 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -163,7 +163,7 @@ func resourceVappNetworkNatRulesUpdate(ctx context.Context, d *schema.ResourceDa
 	vappNetwork, err := vapp.UpdateNetworkNatRules(networkId, netRules, d.Get("enabled").(bool),
 		natType, policy)
 	if err != nil {
-		log.Printf("[INFO] Error setting NAT rules: %s", err)
+		tflog.Info(ctx, "Error setting NAT rules", map[string]interface{}{"error": err})
 		return diag.Errorf("error setting NAT rules: %s", err)
 	}
 
@@ -178,7 +178,7 @@ func resourceVappNetworkNatRulesUpdate(ctx context.Context, d *schema.ResourceDa
 	return resourceVappNetworkNatRulesRead(ctx, d, meta)
 }
 
-func resourceVAppNetworkNatRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVAppNetworkNatRulesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -197,14 +197,14 @@ func resourceVAppNetworkNatRulesDelete(_ context.Context, d *schema.ResourceData
 
 	err = vapp.RemoveAllNetworkNatRules(d.Get("network_id").(string))
 	if err != nil {
-		log.Printf("[INFO] Error deleting NAT rules: %s", err)
+		tflog.Info(ctx, "Error deleting NAT rules", map[string]interface{}{"error": err})
 		return diag.Errorf("error deleting NAT rules: %s", err)
 	}
 
 	return nil
 }
 
-func resourceVappNetworkNatRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVappNetworkNatRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -221,7 +221,7 @@ func resourceVappNetworkNatRulesRead(_ context.Context, d *schema.ResourceData,
 	vappNetwork, err := vapp.GetVappNetworkById(d.Get("network_id").(string), false)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("vApp network not found. Removing from state file: %s", err)
+			tflog.Debug(ctx, "vApp network not found. Removing from state file", map[string]interface{}{"error": err})
 			d.SetId("")
 			return nil
 		}
@@ -230,7 +230,7 @@ func resourceVappNetworkNatRulesRead(_ context.Context, d *schema.ResourceData,
 
 	var rules []map[string]interface{}
 	if vappNetwork.Configuration.Features == nil || vappNetwork.Configuration.Features.NatService == nil {
-		log.Print("no Nat rules found.")
+		tflog.Info(ctx, "no Nat rules found")
 		dSet(d, "rule", rules)
 	}
 
@@ -332,6 +332,6 @@ func expandVappNetworkNatRules(d *schema.ResourceData, vapp *govcd.VApp, natType
 // Example resource name (_resource_name_): vcd_vapp_nat_rules.my_existing_nat_rules
 // Example import path (_the_id_string_): org.my_existing_vdc.vapp_name.network_name or org.my_existing_vdc.vapp_id.network_id
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func vappNetworkNatRulesImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	return vappNetworkRuleImport(d, meta, "vcd_vapp_nat_rules")
+func vappNetworkNatRulesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return vappNetworkRuleImport(ctx, d, meta, "vcd_vapp_nat_rules")
 }
@@ -5,15 +5,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 func resourceVcdVappNetwork() *schema.Resource {
@@ -268,11 +267,11 @@ func expandDhcpPool(d *schema.ResourceData, vappNetworkSettings *govcd.VappNetwo
 	}
 }
 
-func resourceVappNetworkRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVappNetworkRead(d, meta, "resource")
+func resourceVappNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVappNetworkRead(ctx, d, meta, "resource")
 }
 
-func genericVappNetworkRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVappNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -283,7 +282,7 @@ func genericVappNetworkRead(d *schema.ResourceData, meta interface{}, origin str
 	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
 	if err != nil {
 		if origin == "resource" && govcd.ContainsNotFound(err) {
-			log.Printf("vApp found. Removing vApp network from state file: %s", err)
+			tflog.Debug(ctx, "vApp found. Removing vApp network from state file", map[string]interface{}{"error": err})
 			d.SetId("")
 			return nil
 		}
@@ -314,7 +313,7 @@ func genericVappNetworkRead(d *schema.ResourceData, meta interface{}, origin str
 
 	if vAppNetwork == (types.VAppNetworkConfiguration{}) {
 		if origin == "resource" {
-			log.Printf("[DEBUG] Network no longer exists. Removing from tfstate")
+			tflog.Debug(ctx, "Network no longer exists. Removing from tfstate")
 			d.SetId("")
 			return nil
 		}
@@ -455,7 +454,7 @@ func resourceVappNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta
 // Note. This function is used for both resource `vcd_vapp_network` and `vcd_vapp_org_network`
 // because deletion of these networks is the same operation and maintaining two functions might
 // become inconsistent. They can be split again, if required.
-func resourceVappAndVappOrgNetworkDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVappAndVappOrgNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	vcdClient.lockParentVapp(d)
 	defer vcdClient.unLockParentVapp(d)
@@ -465,8 +464,9 @@ func resourceVappAndVappOrgNetworkDelete(_ context.Context, d *schema.ResourceDa
 	vappStatusBeforeOperation := ""
 	vAppRebootEnabled, isSet := d.GetOkExists("reboot_vapp_on_removal")
 	if isSet && vAppRebootEnabled.(bool) {
-		util.Logger.Printf("[TRACE] reboot_vapp_on_removal=true is enabled with parent vApp '%s",
-			d.Get("vapp_name").(string))
+		tflog.Trace(ctx, "reboot_vapp_on_removal=true is enabled with parent vApp", map[string]interface{}{
+			"vapp_name": d.Get("vapp_name").(string),
+		})
 		rebootVAppOnRemoval = true
 	}
 
@@ -487,10 +487,12 @@ func resourceVappAndVappOrgNetworkDelete(_ context.Context, d *schema.ResourceDa
 				vapp.VApp.Name, err)
 		}
 
-		util.Logger.Printf("[TRACE] reboot_vapp_on_removal=true, vApp '%s' status before network removal is '%s'",
-			vapp.VApp.Name, vappStatusBeforeOperation)
+		tflog.Trace(ctx, "reboot_vapp_on_removal=true, vApp status before network removal", map[string]interface{}{
+			"vapp_name":                    vapp.VApp.Name,
+			"vapp_status_before_operation": vappStatusBeforeOperation,
+		})
 		if vappStatusBeforeOperation != "POWERED_OFF" && vappStatusBeforeOperation != "RESOLVED" {
-			util.Logger.Println("[TRACE] reboot_vapp_on_removal=true, powering off vApp")
+			tflog.Trace(ctx, "reboot_vapp_on_removal=true, powering off vApp")
 			task, err := vapp.Undeploy() // UI Button "Power Off" calls undeploy API endpoint
 			if err != nil {
 				return diag.Errorf("error Powering Off: %s", err)
@@ -522,8 +524,10 @@ func resourceVappAndVappOrgNetworkDelete(_ context.Context, d *schema.ResourceDa
 	// have had different states than "POWERED_OFF" and "POWERED_ON" (e.g. "PARTIALLY_POWERED_OFF"),
 	// but we cannot restore exactly such state. So we restore "POWERED_ON" state.
 	if rebootVAppOnRemoval && vappStatusBeforeOperation != "POWERED_OFF" && vappStatusBeforeOperation != "RESOLVED" {
-		util.Logger.Printf("[TRACE] reboot_vapp_on_removal=true, restoring vApp '%s' power state, was '%s'",
-			vapp.VApp.Name, vappStatusBeforeOperation)
+		tflog.Trace(ctx, "reboot_vapp_on_removal=true, restoring vApp power state", map[string]interface{}{
+			"vapp_name":                    vapp.VApp.Name,
+			"vapp_status_before_operation": vappStatusBeforeOperation,
+		})
 		task, err := vapp.PowerOn()
 		if err != nil {
 			return diag.Errorf("error powering on vApp: %s", err)
@@ -551,8 +555,8 @@ func resourceVappAndVappOrgNetworkDelete(_ context.Context, d *schema.ResourceDa
 //
 // Example resource name (_resource_name_): vcd_vapp_network.network_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.network-name
-func resourceVcdVappNetworkImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdVappNetworkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("[vApp network import] resource name must be specified as org-name.vdc-name.vapp-name.network-name")
 	}
@@ -613,26 +617,26 @@ func resourceVcdDhcpPoolHash(v interface{}) int {
 	m := v.(map[string]interface{})
 	_, err := buf.WriteString(fmt.Sprintf("%t-", m["enabled"].(bool)))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%d-", m["max_lease_time"].(int)))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	_, err = buf.WriteString(fmt.Sprintf("%d-", m["default_lease_time"].(int)))
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	if m["start_address"] != nil {
 		_, err = buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["start_address"].(string))))
 		if err != nil {
-			util.Logger.Printf("[ERROR] error writing to string: %s", err)
+			tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 		}
 	}
 	if m["end_address"] != nil {
 		_, err = buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["end_address"].(string))))
 		if err != nil {
-			util.Logger.Printf("[ERROR] error writing to string: %s", err)
+			tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 		}
 	}
 	return hashcodeString(buf.String())
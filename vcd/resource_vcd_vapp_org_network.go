@@ -3,9 +3,8 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -122,11 +121,11 @@ func resourceVappOrgNetworkCreate(ctx context.Context, d *schema.ResourceData, m
 	return resourceVappOrgNetworkRead(ctx, d, meta)
 }
 
-func resourceVappOrgNetworkRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVappOrgNetworkRead(d, meta, "resource")
+func resourceVappOrgNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVappOrgNetworkRead(ctx, d, meta, "resource")
 }
 
-func genericVappOrgNetworkRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVappOrgNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -137,7 +136,7 @@ func genericVappOrgNetworkRead(d *schema.ResourceData, meta interface{}, origin
 	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
 	if err != nil {
 		if origin == "resource" && govcd.ContainsNotFound(err) {
-			log.Printf("vApp not found. Removing vApp network from state file: %s", err)
+			tflog.Debug(ctx, "vApp not found. Removing vApp network from state file", map[string]interface{}{"error": err})
 			d.SetId("")
 			return nil
 		}
@@ -167,7 +166,7 @@ func genericVappOrgNetworkRead(d *schema.ResourceData, meta interface{}, origin
 
 	if vAppNetwork == (types.VAppNetworkConfiguration{}) {
 		if origin == "resource" {
-			log.Printf("[DEBUG] Network no longer exists. Removing from tfstate")
+			tflog.Debug(ctx, "Network no longer exists. Removing from tfstate")
 			d.SetId("")
 			return nil
 		}
@@ -235,8 +234,8 @@ func resourceVappOrgNetworkUpdate(ctx context.Context, d *schema.ResourceData, m
 //
 // Example resource name (_resource_name_): vcd_vapp_org_network.org_network_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.org-network-name
-func resourceVcdVappOrgNetworkImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdVappOrgNetworkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("[vApp org network import] resource name must be specified as org-name.vdc-name.vapp-name.org-network-name")
 	}
@@ -2,7 +2,8 @@ package vcd
 
 import (
 	"context"
-	"log"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -106,7 +107,7 @@ func resourceVappNetworkStaticRoutingUpdate(ctx context.Context, d *schema.Resou
 	}
 	vappNetwork, err := vapp.UpdateNetworkStaticRouting(networkId, staticRouting, d.Get("enabled").(bool))
 	if err != nil {
-		log.Printf("[INFO] Error setting static routing: %s", err)
+		tflog.Info(ctx, "Error setting static routing", map[string]interface{}{"error": err})
 		return diag.Errorf("error setting static routing: %s", err)
 	}
 
@@ -115,7 +116,7 @@ func resourceVappNetworkStaticRoutingUpdate(ctx context.Context, d *schema.Resou
 	return resourceVappNetworkStaticRoutingRead(ctx, d, meta)
 }
 
-func resourceVAppNetworkStaticRoutingDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVAppNetworkStaticRoutingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -134,14 +135,14 @@ func resourceVAppNetworkStaticRoutingDelete(_ context.Context, d *schema.Resourc
 
 	err = vapp.RemoveAllNetworkStaticRoutes(d.Get("network_id").(string))
 	if err != nil {
-		log.Printf("[INFO] Error deleting static routes: %s", err)
+		tflog.Info(ctx, "Error deleting static routes", map[string]interface{}{"error": err})
 		return diag.Errorf("error deleting static routes: %s", err)
 	}
 
 	return nil
 }
 
-func resourceVappNetworkStaticRoutingRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVappNetworkStaticRoutingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -158,7 +159,7 @@ func resourceVappNetworkStaticRoutingRead(_ context.Context, d *schema.ResourceD
 	vappNetwork, err := vapp.GetVappNetworkById(d.Get("network_id").(string), false)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("vApp network not found. Removing static routing from state file: %s", err)
+			tflog.Debug(ctx, "vApp network not found. Removing static routing from state file", map[string]interface{}{"error": err})
 			d.SetId("")
 			return nil
 		}
@@ -167,7 +168,7 @@ func resourceVappNetworkStaticRoutingRead(_ context.Context, d *schema.ResourceD
 
 	var rules []map[string]interface{}
 	if vappNetwork.Configuration.Features == nil || vappNetwork.Configuration.Features.StaticRoutingService == nil {
-		log.Print("[INFO] no Static routes found.")
+		tflog.Info(ctx, "no Static routes found")
 		dSet(d, "rule", nil)
 	}
 
@@ -215,6 +216,6 @@ func expandVappNetworkStaticRouting(d *schema.ResourceData) ([]*types.StaticRout
 // Example resource name (_resource_name_): vcd_vapp_static_routing.my_existing_static_routing_rules
 // Example import path (_the_id_string_): org.my_existing_vdc.vapp_name.network_name or org.my_existing_vdc.vapp_id.network_id
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func vappNetworkStaticRoutingImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	return vappNetworkRuleImport(d, meta, "vcd_vapp_static_routing")
+func vappNetworkStaticRoutingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return vappNetworkRuleImport(ctx, d, meta, "vcd_vapp_static_routing")
 }
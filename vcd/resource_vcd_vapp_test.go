@@ -101,6 +101,14 @@ func TestAccVcdVApp_Basic(t *testing.T) {
 				// These fields can't be retrieved from user data
 				ImportStateVerifyIgnore: []string{"power_on"},
 			},
+			// Check that import works using the vApp URN
+			{
+				ResourceName:            "vcd_vapp." + vappName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdUrnViaResource("vcd_vapp." + vappName),
+				ImportStateVerifyIgnore: []string{"power_on"},
+			},
 		},
 	})
 	postTestChecks(t)
@@ -4,18 +4,17 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/kr/pretty"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 type typeOfVm string
@@ -33,6 +32,13 @@ const (
 	vmSourceVmCopy          vmImageSource = "vm_copy"
 )
 
+// Default values for the 'timeouts' block of `vcd_vapp_vm` and `vcd_vm`
+const (
+	vmDefaultCreateTimeout = 60 * time.Minute
+	vmDefaultUpdateTimeout = 60 * time.Minute
+	vmDefaultDeleteTimeout = 30 * time.Minute
+)
+
 // Maintenance guide for VM code
 //
 // VM codebase grew to be quite complicated because of a few reasons:
@@ -70,7 +76,7 @@ const (
 // often changing the name requires "reconfigure" operation.
 
 func resourceVcdVAppVm() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdVAppVmCreate,
 		UpdateContext: resourceVcdVAppVmUpdate,
 		ReadContext:   resourceVcdVAppVmRead,
@@ -78,8 +84,10 @@ func resourceVcdVAppVm() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdVappVmImport,
 		},
-		Schema: vmSchemaFunc(vappVmType),
-	}
+		Schema:        vmSchemaFunc(vappVmType),
+		CustomizeDiff: resourceVcdVmCustomizeDiff,
+		Timeouts:      resourceTimeouts(vmDefaultCreateTimeout, vmDefaultUpdateTimeout, vmDefaultDeleteTimeout),
+	}, metadataStateUpgrade)
 }
 
 // VM Schema is defined as global so that it can be directly accessible in other places
@@ -569,6 +577,13 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Default:     false,
 			Description: "Expose hardware-assisted CPU virtualization to guest OS.",
 		},
+		"vtpm": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+			Description: "Adds (`true`) or removes (`false`) a virtual TPM device. When not set, the VM keeps " +
+				"the device it has (e.g. copied from the vApp template). Requires VCD 10.4.2+. Can only be changed when the VM is powered off.",
+		},
 		"guest_properties": {
 			Type:        schema.TypeMap,
 			Optional:    true,
@@ -791,7 +806,7 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 
 // resourceVcdVAppVmCreate is an entry function for VM within vApp creation. It locks parent vApp and cascades down the
 // other functions that need to be run
-func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVAppVmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	startTime := time.Now()
 
 	vappName := d.Get("vapp_name").(string)
@@ -799,7 +814,10 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("vApp name is mandatory for vApp VM (resource `vcd_vapp_vm`)")
 	}
 	vmName := d.Get("name").(string)
-	util.Logger.Printf("[DEBUG] [VM create] started VM creation in vApp (vApp name: %s, VM Name: %s)", vappName, vmName)
+	tflog.Debug(ctx, "[VM create] started VM creation in vApp", map[string]interface{}{
+		"vapp_name": vappName,
+		"vm_name":   vmName,
+	})
 
 	// vApp lock must be acquired for VMs that are vApp members
 	vcdClient := meta.(*VCDClient)
@@ -813,7 +831,9 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 	isVmCopy := d.Get("copy_from_vm_id").(string) != "" // Copy VM functionality
 	if isVmCopy {
 		identifier := d.Get("copy_from_vm_id").(string)
-		util.Logger.Printf("[DEBUG] [VM create] VM will be copied from %s. Checking if additional lock is needed", identifier)
+		tflog.Debug(ctx, "[VM create] VM will be copied from. Checking if additional lock is needed", map[string]interface{}{
+			"identifier": identifier,
+		})
 
 		destinationOrg, destinationVdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 		if err != nil {
@@ -840,36 +860,49 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 		// Parent Org will always be the same because one cannot copy VM from different Org
 		// There may be a case where source and destination vApp names are the same, but VDCs differ
 		if parentSourceVapp.VApp.Name != vappName || parentSourceVdc.Vdc.Name != destinationVdc.Vdc.Name {
-			util.Logger.Printf("[DEBUG] [VM create] locking parent vApp for source VM  (Org Name: '%s', VDC Name: '%s', vApp name: '%s', VM Name: '%s')",
-				destinationOrg.Org.Name, parentSourceVdc.Vdc.Name, parentSourceVapp.VApp.Name, sourceVm.VM.Name)
+			tflog.Debug(ctx, "[VM create] locking parent vApp for source VM", map[string]interface{}{
+				"destination_org_name":    destinationOrg.Org.Name,
+				"parent_source_vdc_name":  parentSourceVdc.Vdc.Name,
+				"parent_source_vapp_name": parentSourceVapp.VApp.Name,
+				"source_vm_name":          sourceVm.VM.Name,
+			})
 			unlock := vcdClient.lockVappWithName(destinationOrg.Org.Name, parentSourceVdc.Vdc.Name, parentSourceVapp.VApp.Name)
 			defer unlock()
 		} else {
-			util.Logger.Printf("[DEBUG] [VM create] not locking parent vApp for source VM because source and destination are the same (Source vApp: '%s', Destination vApp: '%s')  (Source VDC: '%s', Destination VDC: '%s')",
-				parentSourceVapp.VApp.Name, parentSourceVdc.Vdc.Name, parentSourceVdc.Vdc.Name, destinationVdc.Vdc.Name)
+			tflog.Debug(ctx, "[VM create] not locking parent vApp for source VM because source and destination are the same", map[string]interface{}{
+				"parent_source_vapp_name": parentSourceVapp.VApp.Name,
+				"parent_source_vdc_name":  parentSourceVdc.Vdc.Name,
+				"destination_vdc_name":    destinationVdc.Vdc.Name,
+			})
 		}
 	}
 
-	diags := genericResourceVmCreate(d, meta, vappVmType)
+	diags := genericResourceVmCreate(ctx, d, meta, vappVmType)
 	// We need to check if there were errors, as genericResourceVmCreate can also return a warning
 	if diags.HasError() {
 		return diags
 	}
 
 	timeElapsed := time.Since(startTime)
-	util.Logger.Printf("[DEBUG] [VM create] finished VM creation in vApp  (vApp name: %s, VM Name: %s) [took %f seconds]", vappName, vmName, timeElapsed.Seconds())
+	tflog.Debug(ctx, "[VM create] finished VM creation in vApp", map[string]interface{}{
+		"vapp_name":       vappName,
+		"vm_name":         vmName,
+		"elapsed_seconds": timeElapsed.Seconds(),
+	})
 
 	if len(diags) != 0 {
-		return append(diags, genericVcdVmRead(d, meta, "resource")...)
+		return append(diags, genericVcdVmRead(ctx, d, meta, "resource")...)
 	}
 	dSet(d, "imported", false)
-	return genericVcdVmRead(d, meta, "resource")
+	return genericVcdVmRead(ctx, d, meta, "resource")
 }
 
 // genericResourceVmCreate does the following:
 // * Executes VM create functions based on the type of VM (standalone or vApp member)
 // * Runs additional customization functions which are common for all 4 types of VMs
-func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+//
+// The context carries the deadline defined in the 'timeouts' block of the resource
+func genericResourceVmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	vcdClient := meta.(*VCDClient)
 
@@ -889,22 +922,22 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 	var vm *govcd.VM
 	switch {
 	case isVmFromTemplateDeprecated || isVmFromTemplate:
-		util.Logger.Printf("[DEBUG] [VM create] creating VM from template")
-		vm, err = createVmFromImage(d, meta, vmType, vmSourceCatalogTemplate)
+		tflog.Debug(ctx, "[VM create] creating VM from template")
+		vm, err = createVmFromImage(ctx, d, meta, vmType, vmSourceCatalogTemplate)
 		if err != nil {
-			return diag.Errorf("error creating VM from template: %s", err)
+			return errorDiagnostics(d, err, "error creating VM from template: %s", err)
 		}
 	case isVmCopy:
-		util.Logger.Printf("[DEBUG] [VM create] creating VM copy")
-		vm, err = createVmFromImage(d, meta, vmType, vmSourceVmCopy)
+		tflog.Debug(ctx, "[VM create] creating VM copy")
+		vm, err = createVmFromImage(ctx, d, meta, vmType, vmSourceVmCopy)
 		if err != nil {
-			return diag.Errorf("error creating VM copy: %s", err)
+			return errorDiagnostics(d, err, "error creating VM copy: %s", err)
 		}
 	case isEmptyVm:
-		util.Logger.Printf("[DEBUG] [VM create] creating empty VM")
-		vm, err = createVmEmpty(d, meta, vmType)
+		tflog.Debug(ctx, "[VM create] creating empty VM")
+		vm, err = createVmEmpty(ctx, d, meta, vmType)
 		if err != nil {
-			return diag.Errorf("error creating empty VM: %s", err)
+			return errorDiagnostics(d, err, "error creating empty VM: %s", err)
 		}
 	default:
 		return diag.Errorf("unknown VM type")
@@ -972,7 +1005,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 	// Handle Metadata
 	// Such schema fields are processed:
 	// * metadata
-	err = createOrUpdateMetadata(d, vm, "metadata")
+	err = createOrUpdateMetadata(d, vcdClient, vm, "metadata")
 	if err != nil {
 		return diag.Errorf("error setting metadata: %s", err)
 	}
@@ -985,15 +1018,23 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 		return diag.Errorf("error updating hardware virtualization setting: %s", err)
 	}
 
+	// Handle virtual TPM device
+	// Such schema fields are processed:
+	// * vtpm
+	err = handleVtpm(ctx, d, vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("error updating vTPM setting: %s", err)
+	}
+
 	// Handle Guest Properties
 	// Such schema fields are processed:
 	// * guest_properties
-	err = addRemoveGuestProperties(d, vm)
+	err = addRemoveGuestProperties(ctx, d, vm)
 	if err != nil {
 		return diag.Errorf("error setting guest properties: %s", err)
 	}
 
-	err = addRemoveExtraConfiguration(d, vm)
+	err = addRemoveExtraConfiguration(ctx, d, vm)
 	if err != nil {
 		return diag.Errorf("error setting extra configuration: %s", err)
 	}
@@ -1069,7 +1110,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 	// Such schema fields are processed:
 	// * security_tags
 	if _, isSet := d.GetOk("security_tags"); isSet {
-		err = createOrUpdateVmSecurityTags(d, vm)
+		err = createOrUpdateVmSecurityTags(ctx, d, vm)
 		if err != nil {
 			return diag.Errorf("[VM create] error creating security tags for VM %s : %s", vm.VM.Name, err)
 		}
@@ -1085,19 +1126,23 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 		// When customization is requested VM must be un-deployed before starting it
 		customizationNeeded := isForcedCustomization(d.Get("customization"))
 		if customizationNeeded {
-			log.Printf("[TRACE] Powering on VM %s with forced customization", vm.VM.Name)
-			err := vm.PowerOnAndForceCustomization()
+			tflog.Trace(ctx, "Powering on VM with forced customization", map[string]interface{}{"vm_name": vm.VM.Name})
+			err := retryOnBusyEntity(ctx, vcdClient, func() error {
+				return powerOnAndForceCustomization(ctx, vcdClient, vm)
+			})
 			if err != nil {
-				return diag.Errorf("failed powering on with customization: %s", err)
+				return errorDiagnostics(d, err, "failed powering on with customization: %s", err)
 			}
 		} else {
-			task, err := vm.PowerOn()
-			if err != nil {
-				return diag.Errorf("error powering on: %s", err)
-			}
-			err = task.WaitTaskCompletion()
+			err := retryOnBusyEntity(ctx, vcdClient, func() error {
+				task, err := vm.PowerOn()
+				if err != nil {
+					return fmt.Errorf("error powering on: %s", err)
+				}
+				return waitTaskCompletionWithContext(ctx, task)
+			})
 			if err != nil {
-				return diag.Errorf(errorCompletingTask, err)
+				return errorDiagnostics(d, err, errorCompletingTask, err)
 			}
 		}
 
@@ -1134,7 +1179,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 // 3. Perform additional operations which are common for both types of VMs
 //
 // Note. VM Power ON (if it wasn't disabled in HCL configuration) occurs as last step after all configuration is done.
-func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm, sourceImageType vmImageSource) (*govcd.VM, error) {
+func createVmFromImage(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm, sourceImageType vmImageSource) (*govcd.VM, error) {
 	vcdClient := meta.(*VCDClient)
 
 	// Step 1 - lookup common information
@@ -1165,11 +1210,13 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 	}
 
 	// Build up network configuration
-	networkConnectionSection, err := networksToConfig(d, vapp)
+	networkConnectionSection, err := networksToConfig(ctx, d, vapp)
 	if err != nil {
 		return nil, fmt.Errorf("unable to process network configuration: %s", err)
 	}
-	util.Logger.Printf("[VM create] networkConnectionSection %# v", pretty.Formatter(networkConnectionSection))
+	tflog.Debug(ctx, "[VM create] networkConnectionSection", map[string]interface{}{
+		"network_connections": len(networkConnectionSection.NetworkConnection),
+	})
 
 	// Lookup storage profile reference if it was specified
 	storageProfilePtr, err := lookupStorageProfile(d, vdc)
@@ -1225,8 +1272,11 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 			},
 		}
 
-		util.Logger.Printf("%# v", pretty.Formatter(standaloneVmParams))
-		vm, err = vdc.CreateStandaloneVMFromTemplate(&standaloneVmParams)
+		tflog.Debug(ctx, "[VM create] standalone VM parameters", map[string]interface{}{"vm_name": standaloneVmParams.Name})
+		task, err := vdc.CreateStandaloneVMFromTemplateAsync(&standaloneVmParams)
+		if err == nil {
+			vm, err = standaloneVmFromTask(ctx, vcdClient, vdc, task)
+		}
 		if err != nil {
 			d.SetId("")
 			return nil, fmt.Errorf("[VM creation] error creating standalone VM from template %s : %s", vmName, err)
@@ -1234,13 +1284,13 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 
 		d.SetId(vm.VM.ID)
 
-		util.Logger.Printf("[VM create] VM from template after creation %# v", pretty.Formatter(vm.VM))
+		tflog.Debug(ctx, "[VM create] VM from template after creation", map[string]interface{}{"vm_id": vm.VM.ID, "vm_name": vm.VM.Name})
 		vapp, err = vm.GetParentVApp()
 		if err != nil {
 			d.SetId("")
 			return nil, fmt.Errorf("[VM creation] error retrieving vApp from standalone VM %s : %s", vmName, err)
 		}
-		util.Logger.Printf("[VM create] vApp after creation %# v", pretty.Formatter(vapp.VApp))
+		tflog.Debug(ctx, "[VM create] vApp after creation", map[string]interface{}{"vapp_id": vapp.VApp.ID, "vapp_name": vapp.VApp.Name})
 		dSet(d, "vapp_name", vapp.VApp.Name)
 		dSet(d, "vapp_id", vapp.VApp.ID)
 		dSet(d, "vm_type", string(standaloneVmType))
@@ -1283,7 +1333,13 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 			},
 		}
 
-		vm, err = vapp.AddRawVM(vappVmParams)
+		// Same as vapp.AddRawVM, waiting for the task with the context
+		task, err := vcdClient.Client.ExecuteTaskRequestWithApiVersion(vapp.VApp.HREF+"/action/recomposeVApp", http.MethodPost,
+			types.MimeRecomposeVappParams, "error instantiating a new VM: %s", vappVmParams,
+			vcdClient.Client.GetSpecificApiVersionOnCondition(">=37.1", "37.1"))
+		if err == nil {
+			vm, err = vappVmFromTask(ctx, vapp, task, vmName)
+		}
 		if err != nil {
 			d.SetId("")
 			return nil, fmt.Errorf("[VM creation] error getting VM %s : %s", vmName, err)
@@ -1330,7 +1386,7 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 	// Note. Consolidating disks requires "vApp: VM Migrate, Force Undeploy, Relocate, Consolidate"
 	// right
 	if d.Get("consolidate_disks_on_create").(bool) {
-		util.Logger.Printf("[INFO] disk consolidation is requested with field 'consolidate_disks_on_create': %s", err)
+		tflog.Info(ctx, "disk consolidation is requested with field 'consolidate_disks_on_create'", map[string]interface{}{"error": err})
 		err := vm.ConsolidateDisks()
 		if err != nil {
 			return nil, fmt.Errorf("error occurred while consolidating disks for VM '%s': %s", vm.VM.Name, err)
@@ -1429,8 +1485,8 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 // 3. Perform additional operations which are common for both types of VMs
 //
 // Note. VM Power ON (if it wasn't disabled in HCL configuration) occurs as last step after all configuration is done.
-func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*govcd.VM, error) {
-	util.Logger.Printf("[TRACE] Creating empty VM: %s", d.Get("name").(string))
+func createVmEmpty(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*govcd.VM, error) {
+	tflog.Trace(ctx, "Creating empty VM", map[string]interface{}{"name": d.Get("name").(string)})
 
 	vcdClient := meta.(*VCDClient)
 	org, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -1641,7 +1697,11 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 			Media: mediaReference,
 		}
 
-		newVm, err = vdc.CreateStandaloneVm(&params)
+		task, err := vdc.CreateStandaloneVmAsync(&params)
+		if err != nil {
+			return nil, err
+		}
+		newVm, err = standaloneVmFromTask(ctx, vcdClient, vdc, task)
 		if err != nil {
 			return nil, err
 		}
@@ -1690,8 +1750,13 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 			},
 		}
 
-		util.Logger.Printf("[VM create - add empty VM] recomposeVAppParamsForEmptyVm %# v", pretty.Formatter(recomposeVAppParamsForEmptyVm))
-		newVm, err = vapp.AddEmptyVm(recomposeVAppParamsForEmptyVm)
+		tflog.Debug(ctx, "[VM create - add empty VM] recompose parameters", map[string]interface{}{
+			"vm_name": vmName,
+		})
+		task, err := vapp.AddEmptyVmAsync(recomposeVAppParamsForEmptyVm)
+		if err == nil {
+			newVm, err = vappVmFromTask(ctx, vapp, task, vmName)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("[VM creation] error creating VM %s : %s", vmName, err)
 		}
@@ -1715,12 +1780,12 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 	// __Only__ empty VMs are addressed here.
 	////////////////////////////////////////////////////////////////////////////////////////////////
 
-	util.Logger.Printf("[VM create] VM after creation %# v", pretty.Formatter(newVm.VM))
+	tflog.Debug(ctx, "[VM create] VM after creation", map[string]interface{}{"vm_id": newVm.VM.ID, "vm_name": newVm.VM.Name})
 	vapp, err = newVm.GetParentVApp()
 	if err != nil {
 		return nil, fmt.Errorf("[VM creation] error retrieving vApp from standalone VM %s : %s", vmName, err)
 	}
-	util.Logger.Printf("[VM create] vApp after creation %# v", pretty.Formatter(vapp.VApp))
+	tflog.Debug(ctx, "[VM create] vApp after creation", map[string]interface{}{"vapp_id": vapp.VApp.ID, "vapp_name": vapp.VApp.Name})
 	dSet(d, "vapp_name", vapp.VApp.Name)
 	dSet(d, "vapp_id", vapp.VApp.ID)
 
@@ -1733,7 +1798,7 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 		return nil, fmt.Errorf("unable to setup network configuration for empty VM %s", err)
 	}
 
-	networkConnectionSection, err := networksToConfig(d, vapp)
+	networkConnectionSection, err := networksToConfig(ctx, d, vapp)
 	if err != nil {
 		return nil, fmt.Errorf("unable to setup network configuration for empty VM: %s", err)
 	}
@@ -1747,12 +1812,42 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 	return newVm, nil
 }
 
-func resourceVcdVAppVmUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericResourceVcdVmUpdate(d, meta, vappVmType)
+// standaloneVmFromTask waits for the creation task of a standalone VM and returns the new VM. The task
+// is owned by the hidden vApp created for the VM, which contains only that VM
+func standaloneVmFromTask(ctx context.Context, vcdClient *VCDClient, vdc *govcd.Vdc, task govcd.Task) (*govcd.VM, error) {
+	vappHref, err := waitTaskOwnerWithContext(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	vapp, err := vdc.GetVAppByHref(vappHref)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the vApp of the new VM: %s", err)
+	}
+	if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) != 1 {
+		return nil, fmt.Errorf("expected exactly one VM in vApp %s after the creation", vapp.VApp.Name)
+	}
+	return vcdClient.Client.GetVMByHref(vapp.VApp.Children.VM[0].HREF)
+}
+
+// vappVmFromTask waits for a task adding a VM to the given vApp and returns the VM with the given name
+func vappVmFromTask(ctx context.Context, vapp *govcd.VApp, task govcd.Task, vmName string) (*govcd.VM, error) {
+	err := waitTaskCompletionWithContext(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	vm, err := vapp.GetVMByName(vmName, true)
+	if err != nil {
+		return nil, fmt.Errorf("error finding VM %s in vApp %s after creation: %s", vmName, vapp.VApp.Name, err)
+	}
+	return vm, nil
+}
+
+func resourceVcdVAppVmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericResourceVcdVmUpdate(ctx, d, meta, vappVmType)
 }
 
-func genericResourceVcdVmUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
-	log.Printf("[DEBUG] [VM update] started with lock")
+func genericResourceVcdVmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+	tflog.Debug(ctx, "[VM update] started with lock")
 	vcdClient := meta.(*VCDClient)
 
 	// When there is more then one VM in a vApp Terraform will try to parallelise their creation.
@@ -1767,20 +1862,20 @@ func genericResourceVcdVmUpdate(d *schema.ResourceData, meta interface{}, vmType
 
 	// Exit early only if "network_dhcp_wait_seconds" is changed because this field only supports
 	// update so that its value can be written into statefile and be accessible in read function
-	if onlyHasChange("network_dhcp_wait_seconds", vmSchemaFunc(vmType), d) {
-		log.Printf("[DEBUG] [VM update] exiting early because only 'network_dhcp_wait_seconds' has change")
-		return genericVcdVmRead(d, meta, "resource")
+	if onlyHasChange(ctx, "network_dhcp_wait_seconds", vmSchemaFunc(vmType), d) {
+		tflog.Debug(ctx, "[VM update] exiting early because only 'network_dhcp_wait_seconds' has change")
+		return genericVcdVmRead(ctx, d, meta, "resource")
 	}
 
-	err := resourceVmHotUpdate(d, meta, vmType)
+	err := resourceVmHotUpdate(ctx, d, meta, vmType)
 	if err != nil {
 		return err
 	}
 
-	return resourceVcdVAppVmUpdateExecute(d, meta, "update", vmType, nil)
+	return resourceVcdVAppVmUpdateExecute(ctx, d, meta, "update", vmType, nil)
 }
 
-func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+func resourceVmHotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
 	_, _, vdc, vapp, _, vm, err := getVmFromResource(d, meta, vmType)
 	if err != nil {
 		return diag.FromErr(err)
@@ -1801,7 +1896,7 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 
 	// * Primary NIC cannot be removed on a powered on VM
 	if d.HasChange("network") && !isPrimaryNicRemoved(d) {
-		networkConnectionSection, err := networksToConfig(d, vapp)
+		networkConnectionSection, err := networksToConfig(ctx, d, vapp)
 		if err != nil {
 			return diag.Errorf("unable to setup network configuration for update: %s", err)
 		}
@@ -1811,17 +1906,17 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 		}
 	}
 
-	err = createOrUpdateMetadata(d, vm, "metadata")
+	err = createOrUpdateMetadata(d, meta.(*VCDClient), vm, "metadata")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = addRemoveGuestProperties(d, vm)
+	err = addRemoveGuestProperties(ctx, d, vm)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = addRemoveExtraConfiguration(d, vm)
+	err = addRemoveExtraConfiguration(ctx, d, vm)
 	if err != nil {
 		return diag.Errorf("error setting extra configuration: %s", err)
 	}
@@ -1881,9 +1976,9 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 	return nil
 }
 
-func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm, computePolicy *types.VdcComputePolicy) diag.Diagnostics {
+func resourceVcdVAppVmUpdateExecute(ctx context.Context, d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm, computePolicy *types.VdcComputePolicy) diag.Diagnostics {
 	diags := diag.Diagnostics{}
-	log.Printf("[DEBUG] [VM update] started without lock")
+	tflog.Debug(ctx, "[VM update] started without lock")
 
 	vcd, org, vdc, vapp, identifier, vm, err := getVmFromResource(d, meta, vmType)
 	if err != nil {
@@ -1900,8 +1995,12 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 	// Update guest customization if any of the customization related fields have changed
 	if d.HasChanges("customization", "computer_name", "name") {
-		log.Printf("[TRACE] VM %s customization has changes: customization(%t), computer_name(%t), name(%t)",
-			vm.VM.Name, d.HasChange("customization"), d.HasChange("computer_name"), d.HasChange("name"))
+		tflog.Trace(ctx, "VM customization has changes", map[string]interface{}{
+			"vm_name":       vm.VM.Name,
+			"customization": d.HasChange("customization"),
+			"computer_name": d.HasChange("computer_name"),
+			"name":          d.HasChange("name"),
+		})
 		err = updateGuestCustomizationSetting(d, vm)
 		if err != nil {
 			return diag.Errorf("errors updating guest customization: %s", err)
@@ -1934,38 +2033,55 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 	if executionType == "create" && len(d.Get("network").([]interface{})) > 0 {
 		networksNeedsColdChange = true
 	}
-	log.Printf("[TRACE] VM %s requires cold changes: memory(%t), cpu(%t), network(%t)", vm.VM.Name, memoryNeedsColdChange, cpusNeedsColdChange, networksNeedsColdChange)
+	tflog.Trace(ctx, "VM requires cold changes: memory, cpu, network", map[string]interface{}{
+		"vm_name":                    vm.VM.Name,
+		"memory_needs_cold_change":   memoryNeedsColdChange,
+		"cpus_needs_cold_change":     cpusNeedsColdChange,
+		"networks_needs_cold_change": networksNeedsColdChange,
+	})
 
 	// this represents fields which have to be changed in cold (with VM power off)
 	if d.HasChanges("cpu_cores", "power_on", "disk", "expose_hardware_virtualization", "boot_image",
 		"hardware_version", "os_type", "description", "cpu_hot_add_enabled",
-		"memory_hot_add_enabled", "firmware", "boot_options.0.efi_secure_boot") || memoryNeedsColdChange || cpusNeedsColdChange || networksNeedsColdChange {
-
-		log.Printf("[TRACE] VM %s has changes: memory(%t), cpus(%t), cpu_cores(%t),"+
-			"power_on(%t), disk(%t), expose_hardware_virtualization(%t),"+
-			" boot_image(%t), hardware_version(%t), os_type(%t), description(%t),"+
-			"cpu_hot_add_enabled(%t), memory_hot_add_enabled(%t), firmware(%t),"+
-			"efi_secure_boot(%t) network(%t)",
-			vm.VM.Name, d.HasChange("memory"), d.HasChange("cpus"), d.HasChange("cpu_cores"),
-			d.HasChange("power_on"), d.HasChange("disk"), d.HasChange("expose_hardware_virtualization"),
-			d.HasChange("boot_image"), d.HasChange("hardware_version"), d.HasChange("os_type"),
-			d.HasChange("description"), d.HasChange("cpu_hot_add_enabled"),
-			d.HasChange("memory_hot_add_enabled"), d.HasChange("firmware"),
-			d.HasChange("boot_options.0.efi_secure_boot"), d.HasChange("network"))
+		"memory_hot_add_enabled", "firmware", "boot_options.0.efi_secure_boot", "vtpm") || memoryNeedsColdChange || cpusNeedsColdChange || networksNeedsColdChange {
+
+		tflog.Trace(ctx, "VM has changes: memory, cpus, cpu_cores,power_on, disk, expose_hardware_virtualization, boot_image, hardware_version, os_type, description,cpu_hot_add_enabled, memory_hot_add_enabled, firmware,efi_secure_boot, vtpm network", map[string]interface{}{
+			"vm_name":                        vm.VM.Name,
+			"memory":                         d.HasChange("memory"),
+			"cpus":                           d.HasChange("cpus"),
+			"cpu_cores":                      d.HasChange("cpu_cores"),
+			"power_on":                       d.HasChange("power_on"),
+			"disk":                           d.HasChange("disk"),
+			"expose_hardware_virtualization": d.HasChange("expose_hardware_virtualization"),
+			"boot_image":                     d.HasChange("boot_image"),
+			"hardware_version":               d.HasChange("hardware_version"),
+			"os_type":                        d.HasChange("os_type"),
+			"description":                    d.HasChange("description"),
+			"cpu_hot_add_enabled":            d.HasChange("cpu_hot_add_enabled"),
+			"memory_hot_add_enabled":         d.HasChange("memory_hot_add_enabled"),
+			"firmware":                       d.HasChange("firmware"),
+			"efi_secure_boot":                d.HasChange("boot_options.0.efi_secure_boot"),
+			"vtpm":                           d.HasChange("vtpm"),
+			"network":                        d.HasChange("network"),
+		})
 
 		if vmStatusBeforeUpdate != "POWERED_OFF" {
 			if d.Get("prevent_update_power_off").(bool) && executionType == "update" {
 				return diag.Errorf("update stopped: VM needs to power off to change properties, but `prevent_update_power_off` is `true`")
 			}
-			log.Printf("[DEBUG] Un-deploying VM %s for offline update. Previous state %s",
-				vm.VM.Name, vmStatusBeforeUpdate)
-			task, err := vm.Undeploy()
-			if err != nil {
-				return diag.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
-			}
-			err = task.WaitTaskCompletion()
+			tflog.Debug(ctx, "Un-deploying VM for offline update. Previous state", map[string]interface{}{
+				"vm_name":                 vm.VM.Name,
+				"vm_status_before_update": vmStatusBeforeUpdate,
+			})
+			err = retryOnBusyEntity(ctx, vcd, func() error {
+				task, err := vm.Undeploy()
+				if err != nil {
+					return fmt.Errorf("error triggering undeploy: %s", err)
+				}
+				return waitTaskCompletionWithContext(ctx, task)
+			})
 			if err != nil {
-				return diag.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+				return errorDiagnostics(d, err, "error undeploying VM %s: %s", vm.VM.Name, err)
 			}
 		}
 
@@ -2024,7 +2140,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 		}
 
 		if networksNeedsColdChange {
-			networkConnectionSection, err := networksToConfig(d, vapp)
+			networkConnectionSection, err := networksToConfig(ctx, d, vapp)
 			if err != nil {
 				return diag.Errorf("unable to setup network configuration for update: %s", err)
 			}
@@ -2036,14 +2152,22 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 		if d.HasChange("expose_hardware_virtualization") {
 
-			task, err := vm.ToggleHardwareVirtualization(d.Get("expose_hardware_virtualization").(bool))
+			err = retryOnBusyEntity(ctx, vcd, func() error {
+				task, err := vm.ToggleHardwareVirtualization(d.Get("expose_hardware_virtualization").(bool))
+				if err != nil {
+					return fmt.Errorf("error changing hardware assisted virtualization: %s", err)
+				}
+				return waitTaskCompletionWithContext(ctx, task)
+			})
 			if err != nil {
-				return diag.Errorf("error changing hardware assisted virtualization: %s", err)
+				return errorDiagnostics(d, err, "%s", err)
 			}
+		}
 
-			err = task.WaitTaskCompletion()
+		if d.HasChange("vtpm") {
+			err = updateVmVtpm(ctx, vcd, vm, d.Get("vtpm").(bool))
 			if err != nil {
-				return diag.FromErr(err)
+				return errorDiagnostics(d, err, "error changing vTPM: %s", err)
 			}
 		}
 
@@ -2167,7 +2291,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 		}
 
 		vm, err = vm.UpdateBootOptions(bootOptions)
-		util.Logger.Printf("[DEBUG] %v", bootOptions)
+		tflog.Debug(ctx, "[VM update] boot options", map[string]interface{}{"boot_options": bootOptions})
 		if err != nil {
 			return diag.Errorf("error changing VM boot options: %s", err)
 		}
@@ -2175,7 +2299,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 	// Update the security tags
 	if d.HasChange("security_tags") {
-		err = createOrUpdateVmSecurityTags(d, vm)
+		err = createOrUpdateVmSecurityTags(ctx, d, vm)
 		if err != nil {
 			return diag.Errorf("[VM Update] error updating security tags for VM %s : %s", vm.VM.Name, err)
 		}
@@ -2198,58 +2322,72 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 		// Simply power on if customization is not requested
 		if !customizationNeeded && vmStatus != "POWERED_ON" {
-			log.Printf("[DEBUG] Powering on VM %s after update. Previous state %s", vm.VM.Name, vmStatus)
-			task, err := vm.PowerOn()
-			if err != nil {
-				return diag.Errorf("error powering on: %s", err)
-			}
-			err = task.WaitTaskCompletion()
+			tflog.Debug(ctx, "Powering on VM after update. Previous state", map[string]interface{}{
+				"vm_name":   vm.VM.Name,
+				"vm_status": vmStatus,
+			})
+			err = retryOnBusyEntity(ctx, vcd, func() error {
+				task, err := vm.PowerOn()
+				if err != nil {
+					return fmt.Errorf("error powering on: %s", err)
+				}
+				return waitTaskCompletionWithContext(ctx, task)
+			})
 			if err != nil {
-				return diag.Errorf(errorCompletingTask, err)
+				return errorDiagnostics(d, err, errorCompletingTask, err)
 			}
 
 		}
 
 		// When customization is requested VM must be un-deployed before starting it
 		if customizationNeeded {
-			log.Printf("[TRACE] forced customization for VM %s was requested. Current state %s",
-				vm.VM.Name, vmStatus)
+			tflog.Trace(ctx, "forced customization for VM was requested. Current state", map[string]interface{}{
+				"vm_name":   vm.VM.Name,
+				"vm_status": vmStatus,
+			})
 
 			if vmStatus != "POWERED_OFF" {
-				log.Printf("[TRACE] VM %s is in state %s. Un-deploying", vm.VM.Name, vmStatus)
-				task, err := vm.Undeploy()
-				if err != nil {
-					return diag.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
-				}
-				err = task.WaitTaskCompletion()
+				tflog.Trace(ctx, "Un-deploying VM", map[string]interface{}{
+					"vm_name":   vm.VM.Name,
+					"vm_status": vmStatus,
+				})
+				err = retryOnBusyEntity(ctx, vcd, func() error {
+					task, err := vm.Undeploy()
+					if err != nil {
+						return fmt.Errorf("error triggering undeploy: %s", err)
+					}
+					return waitTaskCompletionWithContext(ctx, task)
+				})
 				if err != nil {
-					return diag.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+					return errorDiagnostics(d, err, "error undeploying VM %s: %s", vm.VM.Name, err)
 				}
 			}
 
-			log.Printf("[TRACE] Powering on VM %s with forced customization", vm.VM.Name)
-			err = vm.PowerOnAndForceCustomization()
+			tflog.Trace(ctx, "Powering on VM with forced customization", map[string]interface{}{"vm_name": vm.VM.Name})
+			err = retryOnBusyEntity(ctx, vcd, func() error {
+				return powerOnAndForceCustomization(ctx, vcd, vm)
+			})
 			if err != nil {
-				return diag.Errorf("failed powering on with customization: %s", err)
+				return errorDiagnostics(d, err, "failed powering on with customization: %s", err)
 			}
 		}
 
 	}
 
-	log.Printf("[DEBUG] [VM update] finished")
+	tflog.Debug(ctx, "[VM update] finished")
 	if len(diags) != 0 {
-		return append(diags, genericVcdVmRead(d, meta, "resource")...)
+		return append(diags, genericVcdVmRead(ctx, d, meta, "resource")...)
 	}
-	return genericVcdVmRead(d, meta, "resource")
+	return genericVcdVmRead(ctx, d, meta, "resource")
 }
 
-func resourceVcdVAppVmRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdVmRead(d, meta, "resource")
+func resourceVcdVAppVmRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdVmRead(ctx, d, meta, "resource")
 }
 
-func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+func genericVcdVmRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
 	var diags diag.Diagnostics
-	log.Printf("[DEBUG] [VM read] started with origin %s", origin)
+	tflog.Debug(ctx, "[VM read] started with origin", map[string]interface{}{"origin": origin})
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -2292,7 +2430,11 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 				dSet(d, "vapp_name", "")
 			}
 			if govcd.IsNotFound(err) {
-				log.Printf("[VM read] error finding vApp '%s': %s%s. Removing it from state.", vappName, err, additionalMessage)
+				tflog.Debug(ctx, "[VM read] error finding vApp. Removing it from state", map[string]interface{}{
+					"vapp_name":          vappName,
+					"error":              err,
+					"additional_message": additionalMessage,
+				})
 				d.SetId("")
 				return nil
 			}
@@ -2302,7 +2444,7 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 	}
 	if err != nil {
 		if origin == "resource" {
-			log.Printf("[DEBUG] Unable to find VM. Removing from tfstate")
+			tflog.Debug(ctx, "Unable to find VM. Removing from tfstate")
 			d.SetId("")
 			return nil
 		}
@@ -2329,7 +2471,7 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 	d.SetId(vm.VM.ID)
 	dSet(d, "vm_type", computedVmType)
 
-	networks, err := readNetworks(d, *vm, *vapp, vdc)
+	networks, err := readNetworks(ctx, d, *vm, *vapp, vdc)
 	if err != nil {
 		return diag.Errorf("[VM read] failed reading network details: %s", err)
 	}
@@ -2341,6 +2483,12 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 
 	dSet(d, "href", vm.VM.HREF)
 	dSet(d, "expose_hardware_virtualization", vm.VM.NestedHypervisorEnabled)
+
+	vtpm, err := getVmVtpm(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM read] %s", err)
+	}
+	dSet(d, "vtpm", vtpm)
 	dSet(d, "cpu_hot_add_enabled", vm.VM.VMCapabilities.CPUHotAddEnabled)
 	dSet(d, "memory_hot_add_enabled", vm.VM.VMCapabilities.MemoryHotAddEnabled)
 
@@ -2411,7 +2559,7 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 		return diag.Errorf("[VM read] unable to read guest properties: %s", err)
 	}
 
-	err = setGuestProperties(d, guestProperties)
+	err = setGuestProperties(ctx, d, guestProperties)
 	if err != nil {
 		return diag.Errorf("[VM read] unable to set guest properties in state: %s", err)
 	}
@@ -2460,12 +2608,24 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 	dSet(d, "status", vm.VM.Status)
 	dSet(d, "status_text", statusText)
 
-	diags = append(diags, updateMetadataInStateDeprecated(d, vcdClient, "vcd_vapp_vm", vm)...)
+	if origin == "datasource" {
+		snapshot, err := getVmSnapshot(vcdClient, vm)
+		if err != nil {
+			return diag.Errorf("[VM read] %s", err)
+		}
+		dSet(d, "has_snapshot", snapshot != nil)
+		dSet(d, "snapshot_created_at", "")
+		if snapshot != nil {
+			dSet(d, "snapshot_created_at", snapshot.Created)
+		}
+	}
+
+	diags = append(diags, updateMetadataInStateDeprecated(ctx, d, vcdClient, "vcd_vapp_vm", vm)...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
 
-	log.Printf("[DEBUG] [VM read] finished with origin %s", origin)
+	tflog.Debug(ctx, "[VM read] finished with origin", map[string]interface{}{"origin": origin})
 	// This must be checked at the end as updateMetadataInStateDeprecated can throw Warning diagnostics
 	if len(diags) > 0 {
 		return diags
@@ -2474,8 +2634,8 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 	return nil
 }
 
-func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[DEBUG] [VM delete] started")
+func resourceVcdVAppVmDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "[VM delete] started")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -2509,30 +2669,37 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 
 	// If it is a standalone VM, we remove it in one go
 	if vapp.VApp.IsAutoNature {
-		err = vm.Delete()
+		err = retryOnBusyEntity(ctx, vcdClient, func() error {
+			task, err := vm.DeleteAsync()
+			if err != nil {
+				return err
+			}
+			return waitTaskCompletionWithContext(ctx, task)
+		})
 		if err != nil {
-			return diag.FromErr(err)
+			return errorDiagnostics(d, err, "error deleting standalone VM: %s", err)
 		}
 		return nil
 	}
-	util.Logger.Printf("[VM delete] vApp before deletion %# v", pretty.Formatter(vapp.VApp))
-	util.Logger.Printf("[VM delete] VM before deletion %# v", pretty.Formatter(vm.VM))
+	tflog.Debug(ctx, "[VM delete] vApp before deletion", map[string]interface{}{"vapp_id": vapp.VApp.ID, "vapp_name": vapp.VApp.Name})
+	tflog.Debug(ctx, "[VM delete] VM before deletion", map[string]interface{}{"vm_id": vm.VM.ID, "vm_name": vm.VM.Name})
 	deployed, err := vm.IsDeployed()
 	if err != nil {
 		return diag.Errorf("error getting VM deploy status: %s", err)
 	}
 
-	log.Printf("[TRACE] VM deploy Status: %t", deployed)
+	tflog.Trace(ctx, "VM deploy Status", map[string]interface{}{"deployed": deployed})
 	if deployed {
-		log.Printf("[TRACE] Undeploying VM: %s", vm.VM.Name)
-		task, err := vm.Undeploy()
-		if err != nil {
-			return diag.Errorf("error Undeploying: %s", err)
-		}
-
-		err = task.WaitTaskCompletion()
+		tflog.Trace(ctx, "Undeploying VM", map[string]interface{}{"vm_name": vm.VM.Name})
+		err = retryOnBusyEntity(ctx, vcdClient, func() error {
+			task, err := vm.Undeploy()
+			if err != nil {
+				return err
+			}
+			return waitTaskCompletionWithContext(ctx, task)
+		})
 		if err != nil {
-			return diag.Errorf("error Undeploying VM: %s", err)
+			return errorDiagnostics(d, err, "error Undeploying VM: %s", err)
 		}
 	}
 
@@ -2546,22 +2713,87 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 		}
 
 		attachParams := &types.DiskAttachOrDetachParams{Disk: &types.Reference{HREF: disk.Disk.HREF}}
-		task, err := vm.DetachDisk(attachParams)
+		err = retryOnBusyEntity(ctx, vcdClient, func() error {
+			task, err := vm.DetachDisk(attachParams)
+			if err != nil {
+				return fmt.Errorf("error detaching disk: %s", err)
+			}
+			return waitTaskCompletionWithContext(ctx, task)
+		})
 		if err != nil {
-			return diag.Errorf("error detaching disk `%s`: %s", existingDiskHref, err)
+			return errorDiagnostics(d, err, "error waiting detaching disk task to finish`%s`: %s", existingDiskHref, err)
 		}
-		err = task.WaitTaskCompletion()
-		if err != nil {
-			return diag.Errorf("error waiting detaching disk task to finish`%s`: %s", existingDiskHref, err)
+	}
+
+	tflog.Trace(ctx, "Removing VM", map[string]interface{}{"vm_name": vm.VM.Name})
+	err = retryOnBusyEntity(ctx, vcdClient, func() error {
+		return removeVmFromVapp(ctx, vcdClient, vapp, vm)
+	})
+	if err != nil {
+		return errorDiagnostics(d, err, "error deleting: %s", err)
+	}
+	tflog.Debug(ctx, "[VM delete] finished")
+	return nil
+}
+
+// powerOnAndForceCustomization is the same as vm.PowerOnAndForceCustomization, waiting for the task with the
+// context. The VM must be undeployed
+func powerOnAndForceCustomization(ctx context.Context, vcdClient *VCDClient, vm *govcd.VM) error {
+	vmIsDeployed, err := vm.IsDeployed()
+	if err != nil {
+		return fmt.Errorf("unable to check if VM %s is un-deployed forcing customization: %s", vm.VM.Name, err)
+	}
+	if vmIsDeployed {
+		return fmt.Errorf("VM %s must be undeployed before forcing customization", vm.VM.Name)
+	}
+
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/deploy", http.MethodPost,
+		"", "error powering on VM with customization: %s", &types.DeployVAppParams{
+			Xmlns:              types.XMLNamespaceVCloud,
+			PowerOn:            true,
+			ForceCustomization: true,
+		})
+	if err != nil {
+		return err
+	}
+	return waitTaskCompletionWithContext(ctx, task)
+}
+
+// removeVmFromVapp is the same as vapp.RemoveVM, waiting for the tasks with the context
+func removeVmFromVapp(ctx context.Context, vcdClient *VCDClient, vapp *govcd.VApp, vm *govcd.VM) error {
+	err := vapp.Refresh()
+	if err != nil {
+		return fmt.Errorf("error refreshing vApp before removing VM: %s", err)
+	}
+	// Tasks still running on the vApp must finish before it can be recomposed. Failed ones can be ignored
+	if vapp.VApp.Tasks != nil {
+		for _, vappTask := range vapp.VApp.Tasks.Task {
+			if vappTask.Status == "error" || vappTask.Status == "success" {
+				continue
+			}
+			task := govcd.NewTask(&vcdClient.Client)
+			task.Task = vappTask
+			err = waitTaskCompletionWithContext(ctx, *task)
+			if err != nil {
+				return fmt.Errorf("error performing task: %s", err)
+			}
 		}
 	}
 
-	log.Printf("[TRACE] Removing VM: %s", vm.VM.Name)
-	err = vapp.RemoveVM(*vm)
+	task, err := vcdClient.Client.ExecuteTaskRequest(vapp.VApp.HREF+"/action/recomposeVApp", http.MethodPost,
+		types.MimeRecomposeVappParams, "error removing VM: %s", &types.ReComposeVAppParams{
+			Ovf:        types.XMLNamespaceOVF,
+			Xsi:        types.XMLNamespaceXSI,
+			Xmlns:      types.XMLNamespaceVCloud,
+			DeleteItem: &types.DeleteItem{HREF: vm.VM.HREF},
+		})
+	if err != nil {
+		return err
+	}
+	err = waitTaskCompletionWithContext(ctx, task)
 	if err != nil {
-		return diag.Errorf("error deleting: %s", err)
+		return fmt.Errorf("error performing removing VM task: %s", err)
 	}
-	log.Printf("[DEBUG] [VM delete] finished")
 	return nil
 }
 
@@ -2584,8 +2816,8 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 // The VM identifier can be either the VM name or its ID
 // If we are dealing with standalone VMs, the name can retrieve duplicates. When that happens, the import fails
 // and a list of VM information (ID, guest OS, network, IP) is returned
-func resourceVcdVappVmImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdVappVmImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	vcdClient := meta.(*VCDClient)
 
 	var vapp *govcd.VApp
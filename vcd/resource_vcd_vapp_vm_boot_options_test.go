@@ -3,6 +3,7 @@
 package vcd
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -664,3 +665,166 @@ resource "vcd_vm" "vm" {
   }
 }
 `
+
+func TestAccVcdVmVtpm(t *testing.T) {
+	preTestChecks(t)
+
+	if checkVersion(testConfig.Provider.ApiVersion, "< 37.2") {
+		t.Skip("vTPM is only available since 37.2")
+	}
+
+	var params = StringMap{
+		"Org":                   testConfig.VCD.Org,
+		"Vdc":                   testConfig.Nsxt.Vdc,
+		"CatalogName":           testConfig.VCD.Catalog.NsxtBackedCatalogName,
+		"VappTemplateName":      testConfig.VCD.Catalog.CatalogItemWithEfiSupport,
+		"TestName":              t.Name(),
+		"PowerOn":               "false",
+		"Vtpm":                  "true",
+		"PreventUpdatePowerOff": "false",
+		"Description":           "vTPM VM",
+
+		"Tags": "vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name()
+	configText1 := templateFill(testAccVcdVmVtpm, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText1)
+
+	// The VM is powered off, so vTPM can be removed with prevent_update_power_off. The description changes at the same
+	// time, to check that the vTPM reconfiguration does not send back the previous one
+	params["FuncName"] = t.Name() + "-step-remove-powered-off"
+	params["Vtpm"] = "false"
+	params["PreventUpdatePowerOff"] = "true"
+	params["Description"] = "vTPM VM without vTPM"
+	configTextRemovePoweredOff := templateFill(testAccVcdVmVtpm, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configTextRemovePoweredOff)
+
+	params["FuncName"] = t.Name() + "-step-add-again"
+	params["Vtpm"] = "true"
+	params["PreventUpdatePowerOff"] = "false"
+	params["Description"] = "vTPM VM"
+	configTextAddAgain := templateFill(testAccVcdVmVtpm, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configTextAddAgain)
+
+	params["FuncName"] = t.Name() + "-step1"
+	params["PowerOn"] = "true"
+	params["PreventUpdatePowerOff"] = "true"
+	configText2 := templateFill(testAccVcdVmVtpm, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText2)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["Vtpm"] = "false"
+	configText3 := templateFill(testAccVcdVmVtpm, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText3)
+
+	params["FuncName"] = t.Name() + "-step3"
+	params["PreventUpdatePowerOff"] = "false"
+	configText4 := templateFill(testAccVcdVmVtpm, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText4)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdStandaloneVmDestroy(t.Name(), testConfig.VCD.Org, testConfig.Nsxt.Vdc),
+		Steps: []resource.TestStep{
+			// Step 0 - create a powered off VM with vTPM
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdNsxtStandaloneVmExists(t.Name(), "vcd_vm.vm"),
+					resource.TestCheckResourceAttr("vcd_vm.vm", "vtpm", "true"),
+					resource.TestCheckResourceAttr("data.vcd_vm.vm", "vtpm", "true"),
+				),
+			},
+			// Step 1 - remove vTPM from the powered off VM with prevent_update_power_off, changing its description
+			{
+				Config: configTextRemovePoweredOff,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vm.vm", "vtpm", "false"),
+					resource.TestCheckResourceAttr("vcd_vm.vm", "power_on", "false"),
+					resource.TestCheckResourceAttr("vcd_vm.vm", "description", "vTPM VM without vTPM"),
+					resource.TestCheckResourceAttr("data.vcd_vm.vm", "vtpm", "false"),
+					resource.TestCheckResourceAttr("data.vcd_vm.vm", "description", "vTPM VM without vTPM"),
+				),
+			},
+			// Step 2 - add vTPM again, restoring the description
+			{
+				Config: configTextAddAgain,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vm.vm", "vtpm", "true"),
+					resource.TestCheckResourceAttr("vcd_vm.vm", "description", "vTPM VM"),
+					resource.TestCheckResourceAttr("data.vcd_vm.vm", "description", "vTPM VM"),
+				),
+			},
+			// Step 3 - power on the VM, keeping vTPM
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vm.vm", "vtpm", "true"),
+					resource.TestCheckResourceAttr("vcd_vm.vm", "power_on", "true"),
+				),
+			},
+			// Step 4 - removing vTPM from the powered on VM needs a power off, which is prevented
+			{
+				Config:      configText3,
+				ExpectError: regexp.MustCompile("`prevent_update_power_off` is `true`"),
+			},
+			// Step 5 - remove vTPM, allowing the power off
+			{
+				Config: configText4,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_vm.vm", "vtpm", "false"),
+					resource.TestCheckResourceAttr("vcd_vm.vm", "power_on", "true"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVmVtpm = `
+data "vcd_catalog" "{{.CatalogName}}" {
+  org  = "{{.Org}}"
+  name = "{{.CatalogName}}"
+}
+
+data "vcd_catalog_vapp_template" "{{.VappTemplateName}}" {
+  org        = "{{.Org}}"
+  catalog_id = data.vcd_catalog.{{.CatalogName}}.id
+  name       = "{{.VappTemplateName}}"
+}
+
+resource "vcd_vm" "vm" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  name          = "{{.TestName}}"
+  computer_name = "vtpm-vm"
+  description   = "{{.Description}}"
+
+  vapp_template_id = data.vcd_catalog_vapp_template.{{.VappTemplateName}}.id
+  power_on         = {{.PowerOn}}
+  memory           = 1024
+  cpus             = 2
+  cpu_cores        = 1
+
+  firmware                 = "efi"
+  vtpm                     = {{.Vtpm}}
+  prevent_update_power_off = {{.PreventUpdatePowerOff}}
+
+  boot_options {
+    efi_secure_boot = true
+  }
+}
+
+data "vcd_vm" "vm" {
+  org  = vcd_vm.vm.org
+  vdc  = vcd_vm.vm.vdc
+  name = vcd_vm.vm.name
+}
+`
@@ -9,18 +9,21 @@ package vcd
 // More information in https://github.com/hashicorp/terraform-plugin-sdk/issues/817
 import (
 	"bytes"
+	"context"
+	"encoding/xml"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 // getVmSourceImage retrieves non-empty VM source image reference. It can be one of:
@@ -288,7 +291,7 @@ func updateAdvancedComputeSettings(d *schema.ResourceData, vm *govcd.VM) error {
 }
 
 // isItVappNetwork checks if it is a vApp network (not vApp Org Network)
-func isItVappNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
+func isItVappNetwork(ctx context.Context, vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
 	if err != nil {
 		return false, fmt.Errorf("error getting vApp networks: %s", err)
@@ -297,7 +300,7 @@ func isItVappNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 	for _, networkConfig := range vAppNetworkConfig.NetworkConfig {
 		if networkConfig.NetworkName == vAppNetworkName &&
 			govcd.IsVappNetwork(networkConfig.Configuration) {
-			log.Printf("[TRACE] vApp network found: %s", vAppNetworkName)
+			tflog.Trace(ctx, "vApp network found", map[string]interface{}{"vapp_network_name": vAppNetworkName})
 			return true, nil
 		}
 	}
@@ -368,7 +371,7 @@ func getVmIndependentDisks(vm govcd.VM) []string {
 	return disks
 }
 
-func addRemoveExtraConfiguration(d *schema.ResourceData, vm *govcd.VM) error {
+func addRemoveExtraConfiguration(ctx context.Context, d *schema.ResourceData, vm *govcd.VM) error {
 	if d.HasChange("set_extra_config") {
 		rawExtraConfig := d.Get("set_extra_config")
 		var inputExtraConfig []*types.ExtraConfigMarshal
@@ -380,7 +383,7 @@ func addRemoveExtraConfiguration(d *schema.ResourceData, vm *govcd.VM) error {
 			})
 		}
 
-		log.Printf("[TRACE] Updating VM extra configuration")
+		tflog.Trace(ctx, "Updating VM extra configuration")
 		_, err := vm.UpdateExtraConfig(inputExtraConfig)
 		if err != nil {
 			return err
@@ -390,14 +393,14 @@ func addRemoveExtraConfiguration(d *schema.ResourceData, vm *govcd.VM) error {
 	return nil
 }
 
-func addRemoveGuestProperties(d *schema.ResourceData, vm *govcd.VM) error {
+func addRemoveGuestProperties(ctx context.Context, d *schema.ResourceData, vm *govcd.VM) error {
 	if d.HasChange("guest_properties") {
-		vmProperties, err := getGuestProperties(d)
+		vmProperties, err := getGuestProperties(ctx, d)
 		if err != nil {
 			return fmt.Errorf("unable to convert guest properties to data structure")
 		}
 
-		log.Printf("[TRACE] Updating VM guest properties")
+		tflog.Trace(ctx, "Updating VM guest properties")
 		_, err = vm.SetProductSectionList(vmProperties)
 		if err != nil {
 			return fmt.Errorf("error setting guest properties: %s", err)
@@ -735,7 +738,7 @@ func getMatchedDisk(internalDiskProvidedConfig map[string]interface{}, diskSetti
 }
 
 // getGuestProperties returns a struct for setting guest properties
-func getGuestProperties(d *schema.ResourceData) (*types.ProductSectionList, error) {
+func getGuestProperties(ctx context.Context, d *schema.ResourceData) (*types.ProductSectionList, error) {
 	guestProperties := d.Get("guest_properties")
 	guestProp := convertToStringMap(guestProperties.(map[string]interface{}))
 	vmProperties := &types.ProductSectionList{
@@ -745,7 +748,7 @@ func getGuestProperties(d *schema.ResourceData) (*types.ProductSectionList, erro
 		},
 	}
 	for key, value := range guestProp {
-		log.Printf("[TRACE] Adding guest property: key=%s, value=%s to object", key, value)
+		tflog.Trace(ctx, "Adding guest property", map[string]interface{}{"key": key})
 		oneProp := &types.Property{
 			UserConfigurable: true,
 			Type:             "string",
@@ -760,11 +763,11 @@ func getGuestProperties(d *schema.ResourceData) (*types.ProductSectionList, erro
 }
 
 // setGuestProperties sets guest properties into state
-func setGuestProperties(d *schema.ResourceData, properties *types.ProductSectionList) error {
+func setGuestProperties(ctx context.Context, d *schema.ResourceData, properties *types.ProductSectionList) error {
 	data := make(map[string]string)
 
 	// if properties object does not have actual properties - do not set it at all (leave Terraform 'null')
-	log.Printf("[TRACE] Setting empty properties into statefile because no properties were specified")
+	tflog.Trace(ctx, "Setting empty properties into statefile because no properties were specified")
 	if properties == nil || properties.ProductSection == nil || len(properties.ProductSection.Property) == 0 {
 		return nil
 	}
@@ -776,7 +779,7 @@ func setGuestProperties(d *schema.ResourceData, properties *types.ProductSection
 		}
 	}
 
-	log.Printf("[TRACE] Setting properties into statefile")
+	tflog.Trace(ctx, "Setting properties into statefile")
 	return d.Set("guest_properties", data)
 }
 
@@ -810,7 +813,7 @@ func resourceVcdVmIndependentDiskHash(v interface{}) int {
 	// With the VM resource, we assume that disks have a unique name.
 	// In the event that this is not true, we return an error
 	if err != nil {
-		util.Logger.Printf("[ERROR] error writing to string: %s", err)
+		tflog.Error(providerLogContext(), "error writing to string", map[string]interface{}{"error": err})
 	}
 	return hashcodeString(buf.String())
 }
@@ -821,7 +824,7 @@ func resourceVcdVmIndependentDiskHash(v interface{}) int {
 // The `vapp` parameter does not play critical role in the code, but adds additional validations:
 // * `org` type of networks will be checked if they are already attached to the vApp
 // * `vapp` type networks will be checked for existence inside the vApp
-func networksToConfig(d *schema.ResourceData, vapp *govcd.VApp) (types.NetworkConnectionSection, error) {
+func networksToConfig(ctx context.Context, d *schema.ResourceData, vapp *govcd.VApp) (types.NetworkConnectionSection, error) {
 	networks := d.Get("network").([]interface{})
 
 	isStandaloneVm := vapp == nil || (vapp != nil && vapp.VApp.IsAutoNature)
@@ -860,7 +863,7 @@ func networksToConfig(d *schema.ResourceData, vapp *govcd.VApp) (types.NetworkCo
 
 		networkType := nic["type"].(string)
 		if networkType == "org" && !isStandaloneVm {
-			isVappOrgNetwork, err := isItVappOrgNetwork(networkName, *vapp)
+			isVappOrgNetwork, err := isItVappOrgNetwork(ctx, networkName, *vapp)
 			if err != nil {
 				return types.NetworkConnectionSection{}, err
 			}
@@ -869,7 +872,7 @@ func networksToConfig(d *schema.ResourceData, vapp *govcd.VApp) (types.NetworkCo
 			}
 		}
 		if networkType == "vapp" && !isStandaloneVm {
-			isVappNetwork, err := isItVappNetwork(networkName, *vapp)
+			isVappNetwork, err := isItVappNetwork(ctx, networkName, *vapp)
 			if err != nil {
 				return types.NetworkConnectionSection{}, fmt.Errorf("unable to find vApp network %s: %s", networkName, err)
 			}
@@ -914,7 +917,7 @@ func networksToConfig(d *schema.ResourceData, vapp *govcd.VApp) (types.NetworkCo
 }
 
 // isItVappOrgNetwork checks if it is a vApp Org network (not vApp Network)
-func isItVappOrgNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
+func isItVappOrgNetwork(ctx context.Context, vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
 	if err != nil {
 		return false, fmt.Errorf("error getting vApp networks: %s", err)
@@ -923,7 +926,7 @@ func isItVappOrgNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 	for _, networkConfig := range vAppNetworkConfig.NetworkConfig {
 		if networkConfig.NetworkName == vAppNetworkName &&
 			!govcd.IsVappNetwork(networkConfig.Configuration) {
-			log.Printf("[TRACE] vApp Org network found: %s", vAppNetworkName)
+			tflog.Trace(ctx, "vApp Org network found", map[string]interface{}{"vapp_network_name": vAppNetworkName})
 			return true, nil
 		}
 	}
@@ -933,7 +936,7 @@ func isItVappOrgNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 
 // getVmNicIndexesWithDhcpEnabled loops over VMs NICs and returns list of indexes for the ones using
 // DHCP
-func getVmNicIndexesWithDhcpEnabled(networkConnectionSection *types.NetworkConnectionSection) []int {
+func getVmNicIndexesWithDhcpEnabled(ctx context.Context, networkConnectionSection *types.NetworkConnectionSection) []int {
 
 	var nicIndexes []int
 
@@ -947,10 +950,14 @@ func getVmNicIndexesWithDhcpEnabled(networkConnectionSection *types.NetworkConne
 
 		// validate if the NIC is suitable for DHCP waiting (has DHCP interface)
 		if singleNic.IPAddressAllocationMode != types.IPAllocationModeDHCP {
-			log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] NIC '%d' is not using DHCP in 'ip_allocation_mode'. Skipping IP wait", nicIndex)
+			tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] NIC is not using DHCP in 'ip_allocation_mode'. Skipping IP wait", map[string]interface{}{
+				"nic_index": nicIndex,
+			})
 			continue
 		}
-		log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] NIC '%d' is using DHCP in 'ip_allocation_mode'.", nicIndex)
+		tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] NIC is using DHCP in 'ip_allocation_mode'", map[string]interface{}{
+			"nic_index": nicIndex,
+		})
 		nicIndexes = append(nicIndexes, singleNic.NetworkConnectionIndex)
 
 	}
@@ -1183,8 +1190,98 @@ func handleExposeHardwareVirtualization(d *schema.ResourceData, newVm *govcd.VM)
 	return nil
 }
 
+// vmTrustedPlatformModule is the part of the VM representation that holds the virtual TPM device.
+// The device is exposed by VCD from API version 37.2 (VCD 10.4.2), but it is not part of types.Vm
+type vmTrustedPlatformModule struct {
+	XMLName       xml.Name             `xml:"Vm"`
+	Xmlns         string               `xml:"xmlns,attr,omitempty"`
+	Name          string               `xml:"name,attr"`
+	Description   string               `xml:"Description,omitempty"`
+	ComputePolicy *types.ComputePolicy `xml:"ComputePolicy,omitempty"`
+	// TrustedPlatformModule is missing when the VM has never had a vTPM device
+	TrustedPlatformModule *trustedPlatformModule `xml:"TrustedPlatformModule,omitempty"`
+}
+
+type trustedPlatformModule struct {
+	TpmPresent bool `xml:"TpmPresent"`
+}
+
+// getVmVtpm returns true if the VM has a virtual TPM device. VCD versions older than 10.4.2 don't
+// support vTPM, so the device is always reported as absent
+func getVmVtpm(vcdClient *VCDClient, vm *govcd.VM) (bool, error) {
+	if vcdClient.Client.APIVCDMaxVersionIs("< 37.2") {
+		return false, nil
+	}
+	vmTpm := &vmTrustedPlatformModule{}
+	_, err := vcdClient.Client.ExecuteRequestWithApiVersion(vm.VM.HREF, http.MethodGet, "",
+		"error retrieving VM: %s", nil, vmTpm, vcdClient.Client.GetSpecificApiVersionOnCondition(">= 37.2", "37.2"))
+	if err != nil {
+		return false, fmt.Errorf("error retrieving vTPM of VM %s: %s", vm.VM.Name, err)
+	}
+	return vmTpm.TrustedPlatformModule != nil && vmTpm.TrustedPlatformModule.TpmPresent, nil
+}
+
+// updateVmVtpm adds (`present` is true) or removes the virtual TPM device of a VM.
+// The VM must be powered off. The reconfiguration sends back the name, description and compute policy of the VM, so
+// the VM is refreshed before it, to not revert changes made earlier in the same operation, and after it, so that the
+// following changes start from the reconfigured VM
+func updateVmVtpm(ctx context.Context, vcdClient *VCDClient, vm *govcd.VM, present bool) error {
+	if vcdClient.Client.APIVCDMaxVersionIs("< 37.2") {
+		return fmt.Errorf("'vtpm' is supported on VCD 10.4.2+")
+	}
+
+	err := retryOnBusyEntity(ctx, vcdClient, func() error {
+		err := vm.Refresh()
+		if err != nil {
+			return fmt.Errorf("error refreshing VM %s before updating its vTPM: %s", vm.VM.Name, err)
+		}
+		payload := &vmTrustedPlatformModule{
+			Xmlns:       types.XMLNamespaceVCloud,
+			Name:        vm.VM.Name,
+			Description: vm.VM.Description,
+			// Without the compute policy VCD would reset the VM to the default sizing policy of the VDC
+			ComputePolicy:         vm.VM.ComputePolicy,
+			TrustedPlatformModule: &trustedPlatformModule{TpmPresent: present},
+		}
+		task, err := vcdClient.Client.ExecuteTaskRequestWithApiVersion(vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+			types.MimeVM, "error updating VM vTPM: %s", payload, vcdClient.Client.GetSpecificApiVersionOnCondition(">= 37.2", "37.2"))
+		if err != nil {
+			return err
+		}
+		return waitTaskCompletionWithContext(ctx, task)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = vm.Refresh()
+	if err != nil {
+		return fmt.Errorf("error refreshing VM %s after updating its vTPM: %s", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// handleVtpm adds or removes the virtual TPM device of a VM according to the `vtpm` field value.
+// When `vtpm` is not set, the VM keeps the device as it is (e.g. as it was copied from the vApp template).
+func handleVtpm(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) error {
+	// The operation below assumes the VM is powered off and does not check for status because the
+	// VM is being powered on in the last stage of create/update cycle
+	vtpm, isSet := d.GetOkExists("vtpm")
+	if !isSet {
+		return nil
+	}
+	present, err := getVmVtpm(vcdClient, vm)
+	if err != nil {
+		return err
+	}
+	if present == vtpm.(bool) {
+		return nil
+	}
+	return updateVmVtpm(ctx, vcdClient, vm, vtpm.(bool))
+}
+
 // readNetworks returns network configuration for saving into statefile
-func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp, vdc *govcd.Vdc) ([]map[string]interface{}, error) {
+func readNetworks(ctx context.Context, d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp, vdc *govcd.Vdc) ([]map[string]interface{}, error) {
 	// Determine type for all networks in vApp
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
 	if err != nil {
@@ -1250,17 +1347,23 @@ func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp, vdc *gov
 		maxDhcpWaitSecondsInt := maxDhcpWaitSeconds.(int)
 
 		// look up NIC indexes which have DHCP enabled
-		dhcpNicIndexes := getVmNicIndexesWithDhcpEnabled(vm.VM.NetworkConnectionSection)
-		log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] '%s' DHCP is used on NICs %v with wait time '%d seconds'",
-			vm.VM.Name, dhcpNicIndexes, maxDhcpWaitSecondsInt)
+		dhcpNicIndexes := getVmNicIndexesWithDhcpEnabled(ctx, vm.VM.NetworkConnectionSection)
+		tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] DHCP is used on NICs", map[string]interface{}{
+			"vm_name":               vm.VM.Name,
+			"dhcp_nic_indexes":      dhcpNicIndexes,
+			"max_dhcp_wait_seconds": maxDhcpWaitSecondsInt,
+		})
 		if len(dhcpNicIndexes) == 0 {
 			logForScreen("vcd_vapp_vm", "INFO: Using 'network_dhcp_wait_seconds' only "+
 				"makes sense if at least one NIC is using 'ip_allocation_mode=DHCP'\n")
 		}
 
 		if len(dhcpNicIndexes) > 0 { // at least one NIC uses DHCP for IP allocation mode
-			log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] '%s' waiting for DHCP IPs up to '%d' seconds on NICs %v",
-				vm.VM.Name, maxDhcpWaitSeconds, dhcpNicIndexes)
+			tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] waiting for DHCP IPs", map[string]interface{}{
+				"vm_name":               vm.VM.Name,
+				"max_dhcp_wait_seconds": maxDhcpWaitSeconds,
+				"dhcp_nic_indexes":      dhcpNicIndexes,
+			})
 
 			start := time.Now()
 
@@ -1272,20 +1375,27 @@ func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp, vdc *gov
 			}
 
 			if timeout {
-				log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] VM %s timed out waiting %d seconds "+
-					"to report DHCP IPs. You may want to increase 'network_dhcp_wait_seconds' or ensure "+
-					"your DHCP settings are correct.\n", vm.VM.Name, maxDhcpWaitSeconds)
+				tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] VM timed out waiting to report DHCP IPs. You may want to increase 'network_dhcp_wait_seconds' or ensure your DHCP settings are correct", map[string]interface{}{
+					"vm_name":               vm.VM.Name,
+					"max_dhcp_wait_seconds": maxDhcpWaitSeconds,
+				})
 				logForScreen("vcd_vapp_vm", fmt.Sprintf("WARNING: VM %s timed out waiting %d seconds "+
 					"to report DHCP IPs. You may want to increase 'network_dhcp_wait_seconds' or ensure "+
 					"your DHCP settings are correct.", vm.VM.Name, maxDhcpWaitSeconds))
 			}
 
-			log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] VM '%s' waiting for DHCP IPs took '%s' (of '%ds')",
-				vm.VM.Name, time.Since(start), maxDhcpWaitSeconds)
+			tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] VM finished waiting for DHCP IPs", map[string]interface{}{
+				"vm_name":               vm.VM.Name,
+				"elapsed":               time.Since(start),
+				"max_dhcp_wait_seconds": maxDhcpWaitSeconds,
+			})
 
 			for sliceIndex, nicIndex := range dhcpNicIndexes {
-				log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] VM '%s' NIC %d reported IP %s",
-					vm.VM.Name, nicIndex, nicIps[sliceIndex])
+				tflog.Debug(ctx, "[VM read] [DHCP IP Lookup] VM NIC reported IP", map[string]interface{}{
+					"vm_name":   vm.VM.Name,
+					"nic_index": nicIndex,
+					"nic_ips":   nicIps[sliceIndex],
+				})
 				nets[nicIndex]["ip"] = nicIps[sliceIndex]
 			}
 		}
@@ -1329,14 +1439,14 @@ func updateHardwareVersionOsTypeAndFirmware(vcdClient *VCDClient, d *schema.Reso
 	return nil
 }
 
-func createOrUpdateVmSecurityTags(d *schema.ResourceData, vm *govcd.VM) error {
+func createOrUpdateVmSecurityTags(ctx context.Context, d *schema.ResourceData, vm *govcd.VM) error {
 	var err error
 	entitySecurityTags := &types.EntitySecurityTags{}
 
 	entitySecurityTagsFromSchema := d.Get("security_tags")
 	entitySecurityTagsSlice := convertSchemaSetToSliceOfStrings(entitySecurityTagsFromSchema.(*schema.Set))
 	entitySecurityTags.Tags = entitySecurityTagsSlice
-	log.Printf("[DEBUG] Setting security_tags %s", entitySecurityTags)
+	tflog.Debug(ctx, "Setting security_tags", map[string]interface{}{"entity_security_tags": entitySecurityTags})
 	_, err = vm.UpdateVMSecurityTags(entitySecurityTags)
 
 	if err != nil {
@@ -1345,3 +1455,117 @@ func createOrUpdateVmSecurityTags(d *schema.ResourceData, vm *govcd.VM) error {
 
 	return nil
 }
+
+// resourceVcdVmCustomizeDiff performs plan-time validations for `vcd_vapp_vm` and `vcd_vm`, so
+// that invalid configurations are reported before any VM is created or modified
+func resourceVcdVmCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return customdiff.All(
+		validateVmNetworksPlan,
+		validateVmComputePoliciesPlan,
+	)(ctx, d, meta)
+}
+
+// validateVmNetworksPlan checks that IP addresses are set for NICs using MANUAL IP allocation
+// and that only one NIC is marked as primary
+func validateVmNetworksPlan(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	networks, ok := rawConfigElements(d.GetRawConfig(), "network")
+	if !ok {
+		return nil
+	}
+
+	var primaryNics []string
+	for index, network := range networks {
+		for _, ipField := range [][2]string{{"ip_allocation_mode", "ip"}, {"secondary_ip_allocation_mode", "secondary_ip"}} {
+			allocationMode, ok := rawConfigString(network, ipField[0])
+			if !ok || allocationMode != "MANUAL" {
+				continue
+			}
+			ip, _ := rawConfigAttribute(network, ipField[1])
+			if ip.IsKnown() && (ip.IsNull() || ip.AsString() == "") {
+				return fmt.Errorf("network.%d.%s: an IP address must be set when '%s' is 'MANUAL'", index, ipField[1], ipField[0])
+			}
+		}
+
+		if isPrimary, ok := rawConfigBool(network, "is_primary"); ok && isPrimary {
+			primaryNics = append(primaryNics, fmt.Sprintf("network.%d.is_primary", index))
+		}
+	}
+
+	if len(primaryNics) > 1 {
+		return fmt.Errorf("%s: only one NIC can be primary", strings.Join(primaryNics, ", "))
+	}
+
+	return nil
+}
+
+// validateVmComputePoliciesPlan checks that `sizing_policy_id` and `placement_policy_id` are
+// assigned to the VDC and that `memory`, `cpus` and `cpu_cores` do not conflict with the values
+// defined in the sizing policy.
+// The check is skipped when policies cannot be retrieved (e.g. insufficient rights), as the
+// operation will then be validated by VCD during apply.
+func validateVmComputePoliciesPlan(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	sizingPolicyId, sizingPolicySet := rawConfigString(rawConfig, "sizing_policy_id")
+	placementPolicyId, placementPolicySet := rawConfigString(rawConfig, "placement_policy_id")
+	if !sizingPolicySet && !placementPolicySet {
+		return nil
+	}
+	if !d.HasChanges("sizing_policy_id", "placement_policy_id", "memory", "cpus", "cpu_cores") {
+		return nil
+	}
+
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil {
+		return nil
+	}
+	orgName, vdcName, ok := getOrgAndVdcNamesFromDiff(d, vcdClient)
+	if !ok {
+		return nil
+	}
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		tflog.Debug(ctx, "[VM plan] skipping compute policy validation", map[string]interface{}{"error": err})
+		return nil
+	}
+	assignedPolicies, err := vcdClient.GetAllAssignedVdcComputePoliciesV2(vdc.Vdc.ID, nil)
+	if err != nil {
+		tflog.Debug(ctx, "[VM plan] skipping compute policy validation: error retrieving policies assigned to VDC", map[string]interface{}{
+			"vdc_name": vdcName,
+			"error":    err,
+		})
+		return nil
+	}
+	policies := make(map[string]*types.VdcComputePolicyV2)
+	for _, policy := range assignedPolicies {
+		policies[policy.VdcComputePolicyV2.ID] = policy.VdcComputePolicyV2
+	}
+
+	if placementPolicySet && policies[placementPolicyId] == nil {
+		return fmt.Errorf("placement_policy_id: policy '%s' is not assigned to VDC '%s'", placementPolicyId, vdcName)
+	}
+	if !sizingPolicySet {
+		return nil
+	}
+	sizingPolicy := policies[sizingPolicyId]
+	if sizingPolicy == nil {
+		return fmt.Errorf("sizing_policy_id: policy '%s' is not assigned to VDC '%s'", sizingPolicyId, vdcName)
+	}
+
+	policyValues := []struct {
+		field       string
+		policyValue *int
+	}{
+		{"memory", sizingPolicy.Memory},
+		{"cpus", sizingPolicy.CPUCount},
+		{"cpu_cores", sizingPolicy.CoresPerSocket},
+	}
+	for _, pv := range policyValues {
+		value, ok := rawConfigInt(rawConfig, pv.field)
+		if ok && pv.policyValue != nil && value != *pv.policyValue {
+			return fmt.Errorf("%s: value %d does not match the value %d defined in sizing policy '%s'",
+				pv.field, value, *pv.policyValue, sizingPolicy.Name)
+		}
+	}
+
+	return nil
+}
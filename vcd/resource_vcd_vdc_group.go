@@ -3,7 +3,6 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
 
@@ -468,8 +467,8 @@ func resourceVcdVdcGroupDelete(_ context.Context, d *schema.ResourceData, meta i
 	return diag.FromErr(vdcGroupToDelete.ForceDelete(forceDelete))
 }
 
-func resourceVdcGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVdcGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name")
 	}
@@ -5,18 +5,29 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
-	"github.com/vmware/go-vcloud-director/v3/util"
 )
 
 //lint:file-ignore SA1019 ignore deprecated functions
+
+// vmAffinityRuleStateUpgrade moves the legacy `virtual_machine_ids` to `vm_ids` (schema version 0 to 1)
+var vmAffinityRuleStateUpgrade = stateUpgrade{
+	legacyAttributes: map[string]*schema.Schema{
+		"virtual_machine_ids": stringSetStateAttribute(),
+	},
+	upgradeFuncs: []stateUpgradeFunc{
+		renameStateAttribute("virtual_machine_ids", "vm_ids"),
+	},
+}
+
 func resourceVcdVmAffinityRule() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		CreateContext: resourceVcdVmAffinityRuleCreate,
 		ReadContext:   resourceVcdVmAffinityRuleRead,
 		UpdateContext: resourceVcdVmAffinityRuleUpdate,
@@ -75,7 +86,7 @@ func resourceVcdVmAffinityRule() *schema.Resource {
 				},
 			},
 		},
-	}
+	}, vmAffinityRuleStateUpgrade)
 }
 
 // resourceToAffinityRule prepares a VM affinity rule definition from the data in the resource
@@ -145,7 +156,7 @@ func resourceToAffinityRule(d *schema.ResourceData, meta interface{}) (*types.Vm
 
 // resourceVcdVmAffinityRuleCreate creates a VM affinity rule from the definition in the resource data
 func resourceVcdVmAffinityRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] VM affinity rule creation")
+	tflog.Trace(ctx, "VM affinity rule creation")
 
 	vcdClient := meta.(*VCDClient)
 
@@ -169,7 +180,7 @@ func resourceVcdVmAffinityRuleCreate(ctx context.Context, d *schema.ResourceData
 
 // getVmAffinityRule searches a VM affinity rule using the data passed in the resource
 // It can retrieve a rule either by ID or by name (if it was unique)
-func getVmAffinityRule(d *schema.ResourceData, meta interface{}) (*govcd.VmAffinityRule, error) {
+func getVmAffinityRule(ctx context.Context, d *schema.ResourceData, meta interface{}) (*govcd.VmAffinityRule, error) {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -208,22 +219,22 @@ func getVmAffinityRule(d *schema.ResourceData, meta interface{}) (*govcd.VmAffin
 		return nil, fmt.Errorf("error retrieving rule by %s (%s): %s", method, identifier, err)
 	}
 
-	util.Logger.Printf("[TRACE] [get vm affinity rule] Retrieved by %s\n", method)
+	tflog.Trace(ctx, "[get vm affinity rule] Retrieved", map[string]interface{}{"method": method})
 	return vmAffinityRule, nil
 }
 
 // resourceVcdVmAffinityRuleRead reads a resource VM affinity rule
-func resourceVcdVmAffinityRuleRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericVcdVmAffinityRuleRead(d, meta, "resource")
+func resourceVcdVmAffinityRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericVcdVmAffinityRuleRead(ctx, d, meta, "resource")
 }
 
 // genericVcdVmAffinityRuleRead retrieve an affinity rule using the resource or data source data
-func genericVcdVmAffinityRuleRead(d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] VM affinity rule Read")
+func genericVcdVmAffinityRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}, origin string) diag.Diagnostics {
+	tflog.Trace(ctx, "VM affinity rule Read")
 
 	name := d.Get("name").(string)
 
-	vmAffinityRule, err := getVmAffinityRule(d, meta)
+	vmAffinityRule, err := getVmAffinityRule(ctx, d, meta)
 	if err != nil {
 		if origin == "resource" {
 			d.SetId("")
@@ -258,13 +269,13 @@ func genericVcdVmAffinityRuleRead(d *schema.ResourceData, meta interface{}, orig
 
 // resourceVcdVmAffinityRuleUpdate updates a VM affinity rule, including changing its name
 func resourceVcdVmAffinityRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] VM affinity rule Update")
+	tflog.Trace(ctx, "VM affinity rule Update")
 	vmAffinityRuleDef, err := resourceToAffinityRule(d, meta)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	vmAffinityRule, err := getVmAffinityRule(d, meta)
+	vmAffinityRule, err := getVmAffinityRule(ctx, d, meta)
 	if err != nil {
 		d.SetId("")
 		return nil
@@ -286,9 +297,9 @@ func resourceVcdVmAffinityRuleUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 // resourceVcdVmAffinityRuleDelete removes a VM affinity rule
-func resourceVcdVmAffinityRuleDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	util.Logger.Printf("[TRACE] VM affinity rule Delete")
-	vmAffinityRule, err := getVmAffinityRule(d, meta)
+func resourceVcdVmAffinityRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Trace(ctx, "VM affinity rule Delete")
+	vmAffinityRule, err := getVmAffinityRule(ctx, d, meta)
 	if err != nil {
 		return diag.Errorf("[VM affinity rule delete] error retrieving VM affinity rule %s: %s", d.Get("name"), err)
 	}
@@ -324,8 +335,8 @@ func resourceVcdVmAffinityRuleDelete(_ context.Context, d *schema.ResourceData,
 //	terraform import vcd_vm_affinity_rule.unknown list@my-org.my-vdc.any_string
 //
 // Returns an error with all the VM affinity rules (name + ID for each)
-func resourceVcdVmAffinityRuleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdVmAffinityRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[VM affinity rule import] resource identifier must be specified as org.vdc.my-affinity-rule")
 	}
@@ -4,10 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -166,7 +166,7 @@ func resourceVmInternalDiskCreate(ctx context.Context, d *schema.ResourceData, m
 		OverrideVmDefault:   overrideVmDefault,
 	}
 
-	vmStatusBefore, err := powerOffIfNeeded(d, vm)
+	vmStatusBefore, err := powerOffIfNeeded(ctx, d, vm)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -178,7 +178,7 @@ func resourceVmInternalDiskCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(diskId)
 
-	err = powerOnIfNeeded(d, vm, vmStatusBefore)
+	err = powerOnIfNeeded(ctx, d, vm, vmStatusBefore)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -205,14 +205,14 @@ func getIopsValue(d *schema.ResourceData, vcdClient *VCDClient, storageProfilePr
 	return iops, nil
 }
 
-func powerOnIfNeeded(d *schema.ResourceData, vm *govcd.VM, vmStatusBefore string) error {
+func powerOnIfNeeded(ctx context.Context, d *schema.ResourceData, vm *govcd.VM, vmStatusBefore string) error {
 	vmStatus, err := vm.GetStatus()
 	if err != nil {
 		return fmt.Errorf("error getting VM status before ensuring it is powered on: %s", err)
 	}
 
 	if vmStatusBefore == "POWERED_ON" && vmStatus != "POWERED_ON" && d.Get("bus_type").(string) == "ide" && d.Get("allow_vm_reboot").(bool) {
-		log.Printf("[DEBUG] Powering on VM %s after adding internal disk.", vm.VM.Name)
+		tflog.Debug(ctx, "Powering on VM after adding internal disk", map[string]interface{}{"vm_name": vm.VM.Name})
 
 		task, err := vm.PowerOn()
 		if err != nil {
@@ -226,7 +226,7 @@ func powerOnIfNeeded(d *schema.ResourceData, vm *govcd.VM, vmStatusBefore string
 	return nil
 }
 
-func powerOffIfNeeded(d *schema.ResourceData, vm *govcd.VM) (string, error) {
+func powerOffIfNeeded(ctx context.Context, d *schema.ResourceData, vm *govcd.VM) (string, error) {
 	vmStatus, err := vm.GetStatus()
 	if err != nil {
 		return "", fmt.Errorf("error getting VM status before ensuring it is powered off: %s", err)
@@ -234,7 +234,7 @@ func powerOffIfNeeded(d *schema.ResourceData, vm *govcd.VM) (string, error) {
 	vmStatusBefore := vmStatus
 
 	if vmStatus != "POWERED_OFF" && d.Get("bus_type").(string) == "ide" && d.Get("allow_vm_reboot").(bool) {
-		log.Printf("[DEBUG] Powering off VM %s for adding/updating internal disk.", vm.VM.Name)
+		tflog.Debug(ctx, "Powering off VM for adding/updating internal disk", map[string]interface{}{"vm_name": vm.VM.Name})
 
 		task, err := vm.PowerOff()
 		if err != nil {
@@ -249,7 +249,7 @@ func powerOffIfNeeded(d *schema.ResourceData, vm *govcd.VM) (string, error) {
 }
 
 // resourceVmInternalDiskDelete deletes disk from VM
-func resourceVmInternalDiskDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceVmInternalDiskDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	vcdClient := m.(*VCDClient)
 
 	vcdClient.lockParentVapp(d)
@@ -260,7 +260,7 @@ func resourceVmInternalDiskDelete(_ context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
-	vmStatusBefore, err := powerOffIfNeeded(d, vm)
+	vmStatusBefore, err := powerOffIfNeeded(ctx, d, vm)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -270,12 +270,12 @@ func resourceVmInternalDiskDelete(_ context.Context, d *schema.ResourceData, m i
 		return diag.Errorf("[resourceVmInternalDiskDelete] failed to delete internal disk: %s", err)
 	}
 
-	err = powerOnIfNeeded(d, vm, vmStatusBefore)
+	err = powerOnIfNeeded(ctx, d, vm, vmStatusBefore)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[TRACE] VM internal disk %s deleted", d.Id())
+	tflog.Trace(ctx, "VM internal disk deleted", map[string]interface{}{"id": d.Id()})
 	d.SetId("")
 	return nil
 }
@@ -298,7 +298,7 @@ func getVm(vcdClient *VCDClient, d *schema.ResourceData) (*govcd.VM, *govcd.Vdc,
 
 // Update the resource
 func resourceVmInternalDiskUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	log.Printf("[TRACE] Update Internal Disk with ID: %s started.", d.Id())
+	tflog.Trace(ctx, "Update Internal Disk started", map[string]interface{}{"id": d.Id()})
 	vcdClient := meta.(*VCDClient)
 
 	vcdClient.lockParentVapp(d)
@@ -314,7 +314,7 @@ func resourceVmInternalDiskUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// has refresh inside
-	vmStatusBefore, err := powerOffIfNeeded(d, vm)
+	vmStatusBefore, err := powerOffIfNeeded(ctx, d, vm)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -323,7 +323,7 @@ func resourceVmInternalDiskUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[TRACE] Internal Disk with id %s found", d.Id())
+	tflog.Trace(ctx, "Internal Disk found", map[string]interface{}{"id": d.Id()})
 	diskSettingsToUpdate.SizeMb = int64(d.Get("size_in_mb").(int))
 	// Note can't change adapter type, bus number, unit number as vSphere changes diskId
 
@@ -357,23 +357,26 @@ func resourceVmInternalDiskUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	err = powerOnIfNeeded(d, vm, vmStatusBefore)
+	err = powerOnIfNeeded(ctx, d, vm, vmStatusBefore)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[TRACE] Inernal Disk %s updated", d.Id())
+	tflog.Trace(ctx, "Internal Disk updated", map[string]interface{}{"id": d.Id()})
 	return resourceVmInternalDiskRead(ctx, d, meta)
 }
 
 // Retrieves internal disk from VM and updates terraform state
-func resourceVmInternalDiskRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceVmInternalDiskRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	vcdClient := m.(*VCDClient)
 
 	vm, _, err := getVm(vcdClient, d)
 	if err != nil {
 		if govcd.ContainsNotFound(err) {
-			log.Printf("unable to find VM that owns the disk '%s'. Removing its disk from tfstate: %s", d.Id(), err)
+			tflog.Debug(ctx, "unable to find VM that owns the disk. Removing its disk from tfstate", map[string]interface{}{
+				"id":    d.Id(),
+				"error": err,
+			})
 			d.SetId("")
 			return nil
 		}
@@ -382,7 +385,7 @@ func resourceVmInternalDiskRead(_ context.Context, d *schema.ResourceData, m int
 
 	diskSettings, err := vm.GetInternalDiskById(d.Id(), true)
 	if err == govcd.ErrorEntityNotFound {
-		log.Printf("[DEBUG] Unable to find disk with Id: %s. Removing from tfstate", d.Id())
+		tflog.Debug(ctx, "Unable to find disk. Removing from tfstate", map[string]interface{}{"id": d.Id()})
 		d.SetId("")
 		return nil
 	}
@@ -425,12 +428,12 @@ var errHelpInternalDiskImport = fmt.Errorf(`resource id must be specified in one
 // Example resource name (_resource_name_): vcd_vm_internal_disk.my-disk
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.vm-name.my-internal-disk-id
 // Example list path (_the_id_string_): list@org-name.vdc-name.vapp-name.vm-name
-func resourceVcdVmInternalDiskImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceVcdVmInternalDiskImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, vappName, vmName, diskId string
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI := importIdElements(ctx, d, -1)
 
-	log.Printf("[DEBUG] importing vcd_vm_internal_disk resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing vcd_vm_internal_disk resource", map[string]interface{}{"id": d.Id()})
 
 	if len(resourceURI) != 4 && len(resourceURI) != 5 {
 		return nil, errHelpInternalDiskImport
@@ -445,6 +448,9 @@ func resourceVcdVmInternalDiskImport(_ context.Context, d *schema.ResourceData,
 		orgName = commandOrgNameSplit[1]
 		return listInternalDisksForImport(meta, orgName, vdcName, vappName, vmName)
 	} else {
+		if len(resourceURI) != 5 {
+			return nil, errHelpInternalDiskImport
+		}
 		orgName, vdcName, vappName, vmName, diskId = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3], resourceURI[4]
 		return getInternalDiskForImport(d, meta, orgName, vdcName, vappName, vmName, diskId)
 	}
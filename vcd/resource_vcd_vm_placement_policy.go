@@ -3,13 +3,13 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/vmware/go-vcloud-director/v3/util"
-	"log"
 	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
 	"github.com/vmware/go-vcloud-director/v3/types/v56"
@@ -71,7 +71,10 @@ func resourceVmPlacementPolicyCreate(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("either `vm_group_ids` or `logical_vm_group_ids` must have a")
 	}
 
-	log.Printf("[TRACE] VM Placement Policy creation initiated: %s in pVDC %s", d.Get("name").(string), d.Get("provider_vdc_id").(string))
+	tflog.Trace(ctx, "VM Placement Policy creation initiated in pVDC", map[string]interface{}{
+		"name":            d.Get("name").(string),
+		"provider_vdc_id": d.Get("provider_vdc_id").(string),
+	})
 	vcdClient := meta.(*VCDClient)
 
 	if !vcdClient.Client.IsSysAdmin {
@@ -104,16 +107,18 @@ func resourceVmPlacementPolicyCreate(ctx context.Context, d *schema.ResourceData
 	}
 	computePolicy.PvdcLogicalVmGroupsMap = logicalVmGroups
 
-	log.Printf("[DEBUG] Creating VM Placement Policy: %#v", computePolicy)
+	tflog.Debug(ctx, "Creating VM Placement Policy", map[string]interface{}{"compute_policy_name": computePolicy.Name})
 
 	createdVmSizingPolicy, err := vcdClient.CreateVdcComputePolicyV2(computePolicy)
 	if err != nil {
-		log.Printf("[DEBUG] Error creating VM Placement Policy: %s", err)
+		tflog.Debug(ctx, "Error creating VM Placement Policy", map[string]interface{}{"error": err})
 		return diag.Errorf("error creating VM Placement Policy: %s", err)
 	}
 
 	d.SetId(createdVmSizingPolicy.VdcComputePolicyV2.ID)
-	log.Printf("[TRACE] VM Placement Policy created: %#v", createdVmSizingPolicy.VdcComputePolicyV2)
+	tflog.Trace(ctx, "VM Placement Policy created", map[string]interface{}{
+		"compute_policy_id": createdVmSizingPolicy.VdcComputePolicyV2.ID,
+	})
 
 	return sharedVcdVmPlacementPolicyRead(ctx, d, meta, true)
 }
@@ -130,7 +135,7 @@ func sharedVcdVmPlacementPolicyRead(ctx context.Context, d *schema.ResourceData,
 	if !isResource {
 		vdcId = d.Get("vdc_id").(string)
 	}
-	log.Printf("[TRACE] VM Placement Policy '%s' read initiated", policyName)
+	tflog.Trace(ctx, "VM Placement Policy read initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 	// The method variable stores the information about how we found the rule, for logging purposes
@@ -182,19 +187,19 @@ func sharedVcdVmPlacementPolicyRead(ctx context.Context, d *schema.ResourceData,
 	if policy == nil {
 		return diag.Errorf("error fetching VM Placement Policy with name %s", policyName)
 	}
-	util.Logger.Printf("[TRACE] [get VM Placement Policy] Retrieved by %s\n", method)
+	tflog.Trace(ctx, "[get VM Placement Policy] Retrieved", map[string]interface{}{"method": method})
 	return setVmPlacementPolicy(ctx, d, vcdClient, *policy.VdcComputePolicyV2)
 }
 
 func resourceVmPlacementPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM sizing policy update initiated: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy update initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
 	policy, err := vcdClient.GetVdcComputePolicyV2ById(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM Placement Policy %s", policyName)
+		tflog.Debug(ctx, "Unable to find VM Placement Policy", map[string]interface{}{"policy_name": policyName})
 		return diag.Errorf("unable to find VM Placement Policy %s, error:  %s", policyName, err)
 	}
 
@@ -223,17 +228,20 @@ func resourceVmPlacementPolicyUpdate(ctx context.Context, d *schema.ResourceData
 
 	_, err = policy.Update()
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VM Placement Policy %s with error %s", policyName, err)
+		tflog.Debug(ctx, "Error updating VM Placement Policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error updating VM Placement Policy %s, err: %s", policyName, err)
 	}
 
-	log.Printf("[TRACE] VM Placement Policy update completed: %s", policyName)
+	tflog.Trace(ctx, "VM Placement Policy update completed", map[string]interface{}{"policy_name": policyName})
 	return resourceVmPlacementPolicyRead(ctx, d, meta)
 }
 
-func resourceVmPlacementPolicyDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVmPlacementPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM Placement Policy delete started: %s", policyName)
+	tflog.Trace(ctx, "VM Placement Policy delete started", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -243,18 +251,21 @@ func resourceVmPlacementPolicyDelete(_ context.Context, d *schema.ResourceData,
 
 	policy, err := vcdClient.GetVdcComputePolicyV2ById(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM Placement Policy %s. Removing from tfstate", policyName)
+		tflog.Debug(ctx, "Unable to find VM Placement Policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 		d.SetId("")
 		return nil
 	}
 
 	err = policy.Delete()
 	if err != nil {
-		log.Printf("[DEBUG] Error deleting VM Placement Policy %s, err: %s", policyName, err)
+		tflog.Debug(ctx, "Error deleting VM Placement Policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error deleting VM Placement Policy %s, err: %s", policyName, err)
 	}
 
-	log.Printf("[TRACE] VM Placement Policy delete completed: %s", policyName)
+	tflog.Trace(ctx, "VM Placement Policy delete completed", map[string]interface{}{"policy_name": policyName})
 	return nil
 }
 
@@ -275,10 +286,10 @@ var errHelpVmPlacementPolicyImport = fmt.Errorf(`resource id must be specified i
 // Example import path (_the_id_string_): my_existing_vm_placement_policy_id
 // Example list path (_the_id_string_): list@
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVmPlacementPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVmPlacementPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 
-	log.Printf("[DEBUG] importing VM Placement Policy resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing VM Placement Policy resource", map[string]interface{}{"id": d.Id()})
 
 	if len(resourceURI) != 1 {
 		return nil, errHelpVmPlacementPolicyImport
@@ -288,7 +299,7 @@ func resourceVmPlacementPolicyImport(_ context.Context, d *schema.ResourceData,
 		return listComputePoliciesForImport(meta, "vcd_vm_placement_policy", "placement")
 	} else {
 		policyId := resourceURI[0]
-		return getVmPlacementPolicy(d, meta, policyId)
+		return getVmPlacementPolicy(ctx, d, meta, policyId)
 	}
 }
 
@@ -365,7 +376,7 @@ func getPvdcLogicalVmGroupsMap(d *schema.ResourceData, vcdClient *VCDClient, pVd
 }
 
 // getVmPlacementPolicy reads the corresponding VM Placement Policy from the resource.
-func getVmPlacementPolicy(d *schema.ResourceData, meta interface{}, policyId string) ([]*schema.ResourceData, error) {
+func getVmPlacementPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}, policyId string) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
 	var computePolicy *govcd.VdcComputePolicyV2
@@ -376,11 +387,11 @@ func getVmPlacementPolicy(d *schema.ResourceData, meta interface{}, policyId str
 		queryParams.Add("filter", fmt.Sprintf("%sname==%s;isSizingOnly==false;policyType==VdcVmPolicy", getVgpuFilterToPrepend(vcdClient, false), policyId))
 		computePolicies, err := vcdClient.GetAllVdcComputePoliciesV2(queryParams)
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM Placement Policy %s", policyId)
+			tflog.Debug(ctx, "Unable to find VM Placement Policy", map[string]interface{}{"policy_id": policyId})
 			return nil, fmt.Errorf("unable to find VM Placement Policy %s, err: %s", policyId, err)
 		}
 		if len(computePolicies) != 1 {
-			log.Printf("[DEBUG] Unable to find unique VM Placement Policy %s", policyId)
+			tflog.Debug(ctx, "Unable to find unique VM Placement Policy", map[string]interface{}{"policy_id": policyId})
 			return nil, fmt.Errorf("unable to find unique VM Placement Policy %s, err: %s", policyId, err)
 		}
 		computePolicy = computePolicies[0]
@@ -416,7 +427,7 @@ func getVmPlacementPolicy(d *schema.ResourceData, meta interface{}, policyId str
 }
 
 // setVmPlacementPolicy sets the Terraform state from the Compute Policy input parameter
-func setVmPlacementPolicy(_ context.Context, d *schema.ResourceData, vcdClient *VCDClient, policy types.VdcComputePolicyV2) diag.Diagnostics {
+func setVmPlacementPolicy(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, policy types.VdcComputePolicyV2) diag.Diagnostics {
 	dSet(d, "name", policy.Name)
 	dSet(d, "description", policy.Description)
 
@@ -450,7 +461,7 @@ func setVmPlacementPolicy(_ context.Context, d *schema.ResourceData, vcdClient *
 
 	d.SetId(policy.ID)
 
-	log.Printf("[TRACE] VM Placement Policy read completed: %s", policy.Name)
+	tflog.Trace(ctx, "VM Placement Policy read completed", map[string]interface{}{"policy_name": policy.Name})
 	return nil
 }
 
@@ -4,14 +4,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"strconv"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/vmware/go-vcloud-director/v3/util"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v3/govcd"
@@ -143,7 +142,7 @@ var sizingPolicyMemory = &schema.Resource{
 
 func resourceVmSizingPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM sizing policy creation initiated: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy creation initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -155,16 +154,16 @@ func resourceVmSizingPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] Creating VM sizing policy: %#v", params)
+	tflog.Debug(ctx, "Creating VM sizing policy", map[string]interface{}{"compute_policy_name": params.Name})
 
 	createdVmSizingPolicy, err := vcdClient.Client.CreateVdcComputePolicy(params)
 	if err != nil {
-		log.Printf("[DEBUG] Error VM sizing policy: %s", err)
+		tflog.Debug(ctx, "Error VM sizing policy", map[string]interface{}{"error": err})
 		return diag.Errorf("error VM sizing policy: %s", err)
 	}
 
 	d.SetId(createdVmSizingPolicy.VdcComputePolicy.ID)
-	log.Printf("[TRACE] VM sizing policy created: %#v", createdVmSizingPolicy.VdcComputePolicy)
+	tflog.Trace(ctx, "VM sizing policy created", map[string]interface{}{"compute_policy_id": createdVmSizingPolicy.VdcComputePolicy.ID})
 
 	return resourceVmSizingPolicyRead(ctx, d, meta)
 }
@@ -177,7 +176,7 @@ func resourceVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, met
 // Fetches information about an existing VM sizing policy for a data definition
 func genericVcdVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM sizing policy read initiated: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy read initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -189,7 +188,7 @@ func genericVcdVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, m
 	if d.Id() != "" {
 		policy, err = vcdClient.Client.GetVdcComputePolicyById(d.Id())
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM sizing policy %s. Removing from tfstate.", policyName)
+			tflog.Debug(ctx, "Unable to find VM sizing policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 			d.SetId("")
 			return diag.Errorf("unable to find VM sizing policy %s, err: %s. Removing from tfstate", policyName, err)
 		}
@@ -205,12 +204,15 @@ func genericVcdVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, m
 		queryParams.Add("filter", fmt.Sprintf("name==%s;isSizingOnly==true", policyName))
 		filteredPoliciesByName, err := vcdClient.Client.GetAllVdcComputePolicies(queryParams)
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM sizing policy %s. Removing from tfstate.", policyName)
+			tflog.Debug(ctx, "Unable to find VM sizing policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 			d.SetId("")
 			return diag.Errorf("unable to find VM sizing policy %s, err: %s. Removing from tfstate", policyName, err)
 		}
 		if len(filteredPoliciesByName) != 1 {
-			log.Printf("[DEBUG] Unable to find VM sizing policy %s . Found Policies by name: %d. Removing from tfstate.", policyName, len(filteredPoliciesByName))
+			tflog.Debug(ctx, "Unable to find VM sizing policy. Found Policies by name. Removing from tfstate", map[string]interface{}{
+				"policy_name":                     policyName,
+				"filtered_policies_by_name_count": len(filteredPoliciesByName),
+			})
 			d.SetId("")
 			return diag.Errorf("[DEBUG] Unable to find VM sizing policy %s, err: %s. Found Policies by name: %d. Removing from tfstate", policyName, govcd.ErrorEntityNotFound, len(filteredPoliciesByName))
 		}
@@ -222,12 +224,12 @@ func genericVcdVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, m
 	if policy == nil {
 		return diag.Errorf("[genericVcdVmSizingPolicyRead] error defining sizing policy")
 	}
-	util.Logger.Printf("[TRACE] [get VM sizing policy] Retrieved by %s\n", method)
+	tflog.Trace(ctx, "[get VM sizing policy] Retrieved", map[string]interface{}{"method": method})
 	return setVmSizingPolicy(ctx, d, *policy.VdcComputePolicy)
 }
 
 // setVmSizingPolicy sets object state from *govcd.VdcComputePolicy
-func setVmSizingPolicy(_ context.Context, d *schema.ResourceData, policy types.VdcComputePolicy) diag.Diagnostics {
+func setVmSizingPolicy(ctx context.Context, d *schema.ResourceData, policy types.VdcComputePolicy) diag.Diagnostics {
 
 	dSet(d, "name", policy.Name)
 	dSet(d, "description", policy.Description)
@@ -298,43 +300,49 @@ func setVmSizingPolicy(_ context.Context, d *schema.ResourceData, policy types.V
 		}
 	}
 
-	log.Printf("[TRACE] VM sizing policy read completed: %s", policy.Name)
+	tflog.Trace(ctx, "VM sizing policy read completed", map[string]interface{}{"policy_name": policy.Name})
 	return nil
 }
 
 // resourceVmSizingPolicyUpdate function updates resource with found configurations changes
 func resourceVmSizingPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM sizing policy update initiated: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy update initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
 	policy, err := vcdClient.Client.GetVdcComputePolicyById(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM sizing policy %s", policyName)
+		tflog.Debug(ctx, "Unable to find VM sizing policy", map[string]interface{}{"policy_name": policyName})
 		return diag.Errorf("unable to find VM sizing policy %s, error:  %s", policyName, err)
 	}
 
 	changedPolicy, err := getUpdatedVmSizingPolicyInput(d, policy)
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VM sizing policy %s with error %s", policyName, err)
+		tflog.Debug(ctx, "Error updating VM sizing policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error updating VM sizing policy %s, err: %s", policyName, err)
 	}
 
 	_, err = changedPolicy.Update()
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VM sizing policy %s with error %s", policyName, err)
+		tflog.Debug(ctx, "Error updating VM sizing policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error updating VM sizing policy %s, err: %s", policyName, err)
 	}
 
-	log.Printf("[TRACE] VM sizing policy update completed: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy update completed", map[string]interface{}{"policy_name": policyName})
 	return resourceVmSizingPolicyRead(ctx, d, meta)
 }
 
 // Deletes a VM sizing policy
-func resourceVmSizingPolicyDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVmSizingPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM sizing policy delete started: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy delete started", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -344,18 +352,21 @@ func resourceVmSizingPolicyDelete(_ context.Context, d *schema.ResourceData, met
 
 	policy, err := vcdClient.Client.GetVdcComputePolicyById(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM sizing policy %s. Removing from tfstate", policyName)
+		tflog.Debug(ctx, "Unable to find VM sizing policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 		d.SetId("")
 		return nil
 	}
 
 	err = policy.Delete()
 	if err != nil {
-		log.Printf("[DEBUG] Error removing VM sizing policy %s, err: %s", policyName, err)
+		tflog.Debug(ctx, "Error removing VM sizing policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error removing VM sizing policy %s, err: %s", policyName, err)
 	}
 
-	log.Printf("[TRACE] VM sizing policy delete completed: %s", policyName)
+	tflog.Trace(ctx, "VM sizing policy delete completed", map[string]interface{}{"policy_name": policyName})
 	return nil
 }
 
@@ -516,10 +527,10 @@ var errHelpVmSizingPolicyImport = fmt.Errorf(`resource id must be specified in o
 // Example import path (_the_id_string_): my_existing_vm_sizing_policy_id
 // Example list path (_the_id_string_): list@
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
-func resourceVmSizingPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVmSizingPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 
-	log.Printf("[DEBUG] importing VM sizing policy resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing VM sizing policy resource", map[string]interface{}{"id": d.Id()})
 
 	if len(resourceURI) != 1 {
 		return nil, errHelpVmSizingPolicyImport
@@ -529,11 +540,11 @@ func resourceVmSizingPolicyImport(_ context.Context, d *schema.ResourceData, met
 		return listComputePoliciesForImport(meta, "vcd_vm_sizing_policy", "sizing")
 	} else {
 		policyId := resourceURI[0]
-		return getVmSizingPolicy(d, meta, policyId)
+		return getVmSizingPolicy(ctx, d, meta, policyId)
 	}
 }
 
-func getVmSizingPolicy(d *schema.ResourceData, meta interface{}, policyId string) ([]*schema.ResourceData, error) {
+func getVmSizingPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}, policyId string) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
 	var computePolicy *govcd.VdcComputePolicy
@@ -544,11 +555,11 @@ func getVmSizingPolicy(d *schema.ResourceData, meta interface{}, policyId string
 		queryParams.Add("filter", fmt.Sprintf("name==%s;isSizingOnly==true", policyId))
 		computePolicies, err := vcdClient.Client.GetAllVdcComputePolicies(queryParams)
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM Sizing Policy %s", policyId)
+			tflog.Debug(ctx, "Unable to find VM Sizing Policy", map[string]interface{}{"policy_id": policyId})
 			return nil, fmt.Errorf("unable to find VM Sizing Policy %s, err: %s", policyId, err)
 		}
 		if len(computePolicies) != 1 {
-			log.Printf("[DEBUG] Unable to find unique VM Sizing Policy %s", policyId)
+			tflog.Debug(ctx, "Unable to find unique VM Sizing Policy", map[string]interface{}{"policy_id": policyId})
 			return nil, fmt.Errorf("unable to find unique VM Sizing Policy %s, err: %s", policyId, err)
 		}
 		computePolicy = computePolicies[0]
@@ -0,0 +1,407 @@
+package vcd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// Default values for the 'timeouts' block of `vcd_vm_snapshot`
+const (
+	vmSnapshotDefaultCreateTimeout = 30 * time.Minute
+	vmSnapshotDefaultUpdateTimeout = 30 * time.Minute
+	vmSnapshotDefaultDeleteTimeout = 30 * time.Minute
+)
+
+// VCD keeps at most one snapshot for each VM: creating a new snapshot replaces the existing one. For this reason,
+// the snapshot is identified by the ID of its VM, and its creation time tells it apart from a snapshot that replaced
+// it, e.g. one taken outside Terraform or by another vcd_vm_snapshot of the same VM. Such a snapshot is not managed
+// by the resource: it is neither adopted at read nor removed at delete.
+//
+// go-vcloud-director does not handle VM snapshots, so the actions are run directly on the VM links
+// (see vmSnapshotAction).
+
+// createSnapshotParams is the payload of the "createSnapshot" action of a VM
+type createSnapshotParams struct {
+	XMLName     xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Name        string   `xml:"name,attr,omitempty"`
+	Memory      bool     `xml:"memory,attr"`
+	Quiesce     bool     `xml:"quiesce,attr"`
+	Description string   `xml:"Description,omitempty"`
+}
+
+const (
+	vmSnapshotActionCreate = "createSnapshot"
+	vmSnapshotActionRevert = "revertToCurrentSnapshot"
+	vmSnapshotActionRemove = "removeAllSnapshots"
+
+	mimeCreateSnapshotParams = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+)
+
+func resourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdVmSnapshotCreate,
+		ReadContext:   resourceVcdVmSnapshotRead,
+		UpdateContext: resourceVcdVmSnapshotUpdate,
+		DeleteContext: resourceVcdVmSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdVmSnapshotImport,
+		},
+		Timeouts: resourceTimeouts(vmSnapshotDefaultCreateTimeout, vmSnapshotDefaultUpdateTimeout, vmSnapshotDefaultDeleteTimeout),
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vm_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the VM (from either 'vcd_vapp_vm' or 'vcd_vm') to take the snapshot of",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressTextAfterImport(),
+				Description:      "The name of the snapshot",
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressTextAfterImport(),
+				Description:      "The description of the snapshot",
+			},
+			"memory": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          false,
+				DiffSuppressFunc: suppressFieldAfterImport("memory"),
+				Description:      "Whether to include the memory of a powered on VM in the snapshot",
+			},
+			"quiesce": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          false,
+				DiffSuppressFunc: suppressFieldAfterImport("quiesce"),
+				Description:      "Whether to quiesce the file system of a powered on VM before taking the snapshot. Requires VMware Tools",
+			},
+			"revert_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Any change to this value reverts the VM to the snapshot. An empty value, " +
+					"or a value given at creation, does not revert",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation time of the snapshot",
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the VM was powered on when the snapshot was taken",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the snapshot, in bytes",
+			},
+			"imported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Tells whether this resource has been imported",
+			},
+		},
+	}
+}
+
+func resourceVcdVmSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vm, err := getVmFromVmId(d, vcdClient)
+	if err != nil {
+		return diag.Errorf("[VM snapshot create] %s", err)
+	}
+	unlock, err := lockVmParentVapp(d, vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot create] %s", err)
+	}
+	defer unlock()
+
+	params := &createSnapshotParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        d.Get("name").(string),
+		Memory:      d.Get("memory").(bool),
+		Quiesce:     d.Get("quiesce").(bool),
+		Description: d.Get("description").(string),
+	}
+	err = vmSnapshotAction(ctx, vcdClient, vm, vmSnapshotActionCreate, mimeCreateSnapshotParams, params)
+	if err != nil {
+		return errorDiagnostics(d, err, "[VM snapshot create] error creating snapshot of VM '%s': %s", vm.VM.Name, err)
+	}
+
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot create] %s", err)
+	}
+	if snapshot == nil {
+		return diag.Errorf("[VM snapshot create] VM %s has no snapshot after its creation", vm.VM.Name)
+	}
+	dSet(d, "created_at", snapshot.Created)
+	d.SetId(vm.VM.ID)
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vm, err := getVmFromVmId(d, vcdClient)
+	if govcd.ContainsNotFound(err) {
+		tflog.Debug(ctx, "VM not found. Removing snapshot from state", map[string]interface{}{"vm_id": d.Get("vm_id").(string)})
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("[VM snapshot read] %s", err)
+	}
+
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot read] %s", err)
+	}
+	if snapshot == nil {
+		tflog.Debug(ctx, "VM has no snapshot. Removing it from state", map[string]interface{}{"vm_name": vm.VM.Name})
+		d.SetId("")
+		return nil
+	}
+	if isReplacedVmSnapshot(d, snapshot) {
+		tflog.Warn(ctx, "VM snapshot was replaced by another one. Removing it from state", map[string]interface{}{
+			"vm_name":            vm.VM.Name,
+			"created_at":         d.Get("created_at").(string),
+			"current_created_at": snapshot.Created,
+		})
+		d.SetId("")
+		return nil
+	}
+
+	dSet(d, "created_at", snapshot.Created)
+	dSet(d, "powered_on", snapshot.PoweredOn)
+	dSet(d, "size", snapshot.Size)
+	d.SetId(vm.VM.ID)
+	return nil
+}
+
+// resourceVcdVmSnapshotUpdate reverts the VM to its snapshot when 'revert_trigger' changes. All the other fields
+// require a new snapshot
+func resourceVcdVmSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("revert_trigger") || d.Get("revert_trigger").(string) == "" {
+		return resourceVcdVmSnapshotRead(ctx, d, meta)
+	}
+
+	vcdClient := meta.(*VCDClient)
+
+	vm, err := getVmFromVmId(d, vcdClient)
+	if err != nil {
+		return diag.Errorf("[VM snapshot update] %s", err)
+	}
+	unlock, err := lockVmParentVapp(d, vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot update] %s", err)
+	}
+	defer unlock()
+	err = vmSnapshotAction(ctx, vcdClient, vm, vmSnapshotActionRevert, "", nil)
+	if err != nil {
+		return errorDiagnostics(d, err, "[VM snapshot update] error reverting VM '%s' to its snapshot: %s", vm.VM.Name, err)
+	}
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vm, err := getVmFromVmId(d, vcdClient)
+	if govcd.ContainsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("[VM snapshot delete] %s", err)
+	}
+	unlock, err := lockVmParentVapp(d, vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot delete] %s", err)
+	}
+	defer unlock()
+
+	// The removal would also delete a snapshot that replaced the one of this resource
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot delete] %s", err)
+	}
+	if snapshot == nil || isReplacedVmSnapshot(d, snapshot) {
+		tflog.Debug(ctx, "VM snapshot was already removed or replaced. Nothing to delete", map[string]interface{}{"vm_name": vm.VM.Name})
+		return nil
+	}
+	err = vmSnapshotAction(ctx, vcdClient, vm, vmSnapshotActionRemove, "", nil)
+	if err != nil {
+		return errorDiagnostics(d, err, "[VM snapshot delete] error removing snapshot of VM '%s': %s", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// resourceVcdVmSnapshotImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains a dot formatted path to the VM, as org-name.vdc-name.vapp-name.vm-name-or-ID
+// for VMs within a vApp, or org-name.vdc-name.vm-name-or-ID for standalone VMs
+// 3. The function read is called to check that the VM has a snapshot
+func resourceVcdVmSnapshotImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
+	var orgName, vdcName, vappName, vmIdentifier string
+	switch len(resourceURI) {
+	case 3:
+		orgName, vdcName, vmIdentifier = resourceURI[0], resourceURI[1], resourceURI[2]
+	case 4:
+		orgName, vdcName, vappName, vmIdentifier = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+	default:
+		return nil, fmt.Errorf("[VM snapshot import] resource name must be specified as " +
+			"org-name.vdc-name.vapp-name.vm-name-or-ID or org-name.vdc-name.vm-name-or-ID")
+	}
+
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf("[VM snapshot import] unable to find VDC %s: %s ", vdcName, err)
+	}
+
+	var vm *govcd.VM
+	if vappName == "" {
+		vm, err = vdc.QueryVmById(vmIdentifier)
+		if govcd.IsNotFound(err) {
+			vmByName, listStr, errByName := getVmByName(vcdClient, vdc, vmIdentifier)
+			if errByName != nil && listStr != "" {
+				return nil, fmt.Errorf("[VM snapshot import] error retrieving VM %s by name: %s\n%s", vmIdentifier, errByName, listStr)
+			}
+			vm, err = vmByName, errByName
+		}
+	} else {
+		var vapp *govcd.VApp
+		vapp, err = vdc.GetVAppByName(vappName, false)
+		if err != nil {
+			return nil, fmt.Errorf("[VM snapshot import] error retrieving vApp %s: %s", vappName, err)
+		}
+		vm, err = vapp.GetVMByNameOrId(vmIdentifier, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[VM snapshot import] error retrieving VM %s: %s", vmIdentifier, err)
+	}
+
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return nil, fmt.Errorf("[VM snapshot import] %s", err)
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("[VM snapshot import] VM %s has no snapshot", vm.VM.Name)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "vm_id", vm.VM.ID)
+	// The name and description of the snapshot are not returned by VCD
+	dSet(d, "name", defaultImportedValue)
+	dSet(d, "description", defaultImportedValue)
+	dSet(d, "memory", false)
+	dSet(d, "quiesce", false)
+	dSet(d, "imported", true)
+	d.SetId(vm.VM.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getVmFromVmId retrieves the VM given in 'vm_id'
+func getVmFromVmId(d *schema.ResourceData, vcdClient *VCDClient) (*govcd.VM, error) {
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	vmId := d.Get("vm_id").(string)
+	vm, err := vdc.QueryVmById(vmId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VM %s: %s", vmId, err)
+	}
+	return vm, nil
+}
+
+// lockVmParentVapp locks the vApp that contains the given VM, which is the same lock that the VM resources take
+// with lockParentVapp (standalone VMs are locked by their hidden vApp). This prevents a snapshot operation from
+// running at the same time as a VM update in the same vApp. It returns the function that releases the lock
+func lockVmParentVapp(d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) (func(), error) {
+	vapp, err := vm.GetParentVApp()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving parent vApp of VM %s: %s", vm.VM.Name, err)
+	}
+	vappName := vapp.VApp.Name
+	vcdClient.lockParentVappWithName(d, vappName)
+
+	// The VM may have changed while waiting for the lock
+	err = vm.Refresh()
+	if err != nil {
+		vcdClient.unLockParentVappWithName(d, vappName)
+		return nil, fmt.Errorf("error refreshing VM %s: %s", vm.VM.Name, err)
+	}
+	return func() { vcdClient.unLockParentVappWithName(d, vappName) }, nil
+}
+
+// getVmSnapshot returns the snapshot of a VM, or nil when the VM has no snapshot
+func getVmSnapshot(vcdClient *VCDClient, vm *govcd.VM) (*types.SnapshotItem, error) {
+	section := &types.SnapshotSection{}
+	_, err := vcdClient.Client.ExecuteRequest(vm.VM.HREF+"/snapshotSection", http.MethodGet, "",
+		"error retrieving snapshot section: %s", nil, section)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving snapshot of VM %s: %s", vm.VM.Name, err)
+	}
+	if len(section.Snapshot) == 0 {
+		return nil, nil
+	}
+	return section.Snapshot[0], nil
+}
+
+// isReplacedVmSnapshot returns true when the snapshot of the VM is not the one recorded in the resource. The
+// creation time is unknown after an import, until the first read
+func isReplacedVmSnapshot(d *schema.ResourceData, snapshot *types.SnapshotItem) bool {
+	createdAt := d.Get("created_at").(string)
+	return createdAt != "" && createdAt != snapshot.Created
+}
+
+// vmSnapshotAction runs one of the snapshot actions of a VM and waits for its task
+func vmSnapshotAction(ctx context.Context, vcdClient *VCDClient, vm *govcd.VM, action, contentType string, payload interface{}) error {
+	return retryOnBusyEntity(ctx, vcdClient, func() error {
+		task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/"+action, http.MethodPost, contentType,
+			"error running action "+action+": %s", payload)
+		if err != nil {
+			return err
+		}
+		return waitTaskCompletionWithContext(ctx, task)
+	})
+}
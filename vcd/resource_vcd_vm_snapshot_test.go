@@ -0,0 +1,112 @@
+//go:build (standaloneVm || vm || ALL || functional) && !skipStandaloneVm
+
+package vcd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdVmSnapshot(t *testing.T) {
+	preTestChecks(t)
+	var standaloneVmName = fmt.Sprintf("%s-%d", t.Name(), os.Getpid())
+
+	var params = StringMap{
+		"Org":           testConfig.VCD.Org,
+		"Vdc":           testConfig.VCD.Vdc,
+		"Catalog":       testSuiteCatalogName,
+		"CatalogItem":   testSuiteCatalogOVAItem,
+		"VmName":        standaloneVmName,
+		"RevertTrigger": "",
+		"Tags":          "standaloneVm vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configTextStep0 := templateFill(testAccCheckVcdVmSnapshot, params)
+
+	params["RevertTrigger"] = "revert-1"
+	params["FuncName"] = t.Name() + "-step1"
+	configTextStep1 := templateFill(testAccCheckVcdVmSnapshot, params)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configTextStep2 := templateFill(testAccCheckVcdVmSnapshot+testAccCheckVcdVmSnapshotDS, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configTextStep0)
+	resourceName := "vcd_vm_snapshot.snapshot"
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdStandaloneVmDestroy(standaloneVmName, "", ""),
+		Steps: []resource.TestStep{
+			{
+				Config: configTextStep0,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "vcd_vm."+standaloneVmName, "id"),
+					resource.TestMatchResourceAttr(resourceName, "created_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+					resource.TestCheckResourceAttr(resourceName, "powered_on", "false"),
+				),
+			},
+			{
+				Config: configTextStep1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "revert_trigger", "revert-1"),
+					resource.TestCheckResourceAttrPair(resourceName, "id", "vcd_vm."+standaloneVmName, "id"),
+				),
+			},
+			{
+				Config: configTextStep2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vcd_vm.ds", "has_snapshot", "true"),
+					resource.TestCheckResourceAttrPair("data.vcd_vm.ds", "snapshot_created_at", resourceName, "created_at"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdOrgVdcObject(standaloneVmName),
+				ImportStateVerifyIgnore: []string{"name", "description", "revert_trigger", "imported"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccCheckVcdVmSnapshot = `
+resource "vcd_vm" "{{.VmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 384
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = false
+}
+
+resource "vcd_vm_snapshot" "snapshot" {
+  org            = "{{.Org}}"
+  vdc            = "{{.Vdc}}"
+  vm_id          = vcd_vm.{{.VmName}}.id
+  name           = "{{.VmName}}-snapshot"
+  description    = "snapshot of {{.VmName}}"
+  revert_trigger = "{{.RevertTrigger}}"
+}
+`
+
+const testAccCheckVcdVmSnapshotDS = `
+data "vcd_vm" "ds" {
+  org        = "{{.Org}}"
+  vdc        = "{{.Vdc}}"
+  name       = vcd_vm.{{.VmName}}.name
+  depends_on = [vcd_vm_snapshot.snapshot]
+}
+`
@@ -0,0 +1,31 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_isReplacedVmSnapshot(t *testing.T) {
+	snapshot := &types.SnapshotItem{Created: "2024-05-02T10:15:00.000Z"}
+	tests := []struct {
+		name      string
+		createdAt string
+		expected  bool
+	}{
+		{name: "same-snapshot", createdAt: "2024-05-02T10:15:00.000Z", expected: false},
+		{name: "replaced", createdAt: "2024-05-01T08:00:00.000Z", expected: true},
+		{name: "imported", createdAt: "", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := resourceVcdVmSnapshot().TestResourceData()
+			dSet(d, "created_at", tt.createdAt)
+			if got := isReplacedVmSnapshot(d, snapshot); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
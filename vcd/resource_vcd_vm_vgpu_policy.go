@@ -3,12 +3,11 @@ package vcd
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/vmware/go-vcloud-director/v3/util"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -113,7 +112,7 @@ var providerVdcScope = &schema.Resource{
 
 func resourceVcdVmVgpuPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM vGPU policy creation initiated: %s", policyName)
+	tflog.Trace(ctx, "VM vGPU policy creation initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -126,16 +125,16 @@ func resourceVcdVmVgpuPolicyCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] Creating VM vGPU policy: %#v", params)
+	tflog.Debug(ctx, "Creating VM vGPU policy", map[string]interface{}{"compute_policy_name": params.Name})
 
 	createdVmVgpuPolicy, err := vcdClient.CreateVdcComputePolicyV2(params)
 	if err != nil {
-		log.Printf("[DEBUG] Error VM vGPU policy: %s", err)
+		tflog.Debug(ctx, "Error VM vGPU policy", map[string]interface{}{"error": err})
 		return diag.Errorf("error VM vGPU policy: %s", err)
 	}
 
 	d.SetId(createdVmVgpuPolicy.VdcComputePolicyV2.ID)
-	log.Printf("[TRACE] VM vGPU policy created: %#v", createdVmVgpuPolicy.VdcComputePolicyV2)
+	tflog.Trace(ctx, "VM vGPU policy created", map[string]interface{}{"compute_policy_id": createdVmVgpuPolicy.VdcComputePolicyV2.ID})
 
 	return resourceVcdVmVgpuPolicyRead(ctx, d, meta)
 }
@@ -148,7 +147,7 @@ func resourceVcdVmVgpuPolicyRead(ctx context.Context, d *schema.ResourceData, me
 // Fetches information about an existing VM vGPU policy for a data definition
 func genericVcdVgpuPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM vGPU policy read initiated: %s", policyName)
+	tflog.Trace(ctx, "VM vGPU policy read initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -160,7 +159,7 @@ func genericVcdVgpuPolicyRead(ctx context.Context, d *schema.ResourceData, meta
 	if d.Id() != "" {
 		policy, err = vcdClient.GetVdcComputePolicyV2ById(d.Id())
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM vGPU policy %s. Removing from tfstate.", policyName)
+			tflog.Debug(ctx, "Unable to find VM vGPU policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 			d.SetId("")
 			return diag.Errorf("unable to find VM vGPU policy %s, err: %s. Removing from tfstate", policyName, err)
 		}
@@ -176,12 +175,15 @@ func genericVcdVgpuPolicyRead(ctx context.Context, d *schema.ResourceData, meta
 		queryParams.Add("filter", fmt.Sprintf("name==%s;isVgpuPolicy==true", policyName))
 		filteredPoliciesByName, err := vcdClient.GetAllVdcComputePoliciesV2(queryParams)
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM vGPU policy %s. Removing from tfstate.", policyName)
+			tflog.Debug(ctx, "Unable to find VM vGPU policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 			d.SetId("")
 			return diag.Errorf("unable to find VM vGPU policy %s, err: %s. Removing from tfstate", policyName, err)
 		}
 		if len(filteredPoliciesByName) != 1 {
-			log.Printf("[DEBUG] Unable to find VM vGPU policy %s . Found Policies by name: %d. Removing from tfstate.", policyName, len(filteredPoliciesByName))
+			tflog.Debug(ctx, "Unable to find VM vGPU policy. Found Policies by name. Removing from tfstate", map[string]interface{}{
+				"policy_name":                     policyName,
+				"filtered_policies_by_name_count": len(filteredPoliciesByName),
+			})
 			d.SetId("")
 			return diag.Errorf("[DEBUG] Unable to find VM vGPU policy %s, err: %s. Found Policies by name: %d. Removing from tfstate", policyName, govcd.ErrorEntityNotFound, len(filteredPoliciesByName))
 		}
@@ -193,43 +195,49 @@ func genericVcdVgpuPolicyRead(ctx context.Context, d *schema.ResourceData, meta
 	if policy == nil {
 		return diag.Errorf("[genericVcdVgpuPolicyRead] error defining vGPU policy")
 	}
-	util.Logger.Printf("[TRACE] [get VM vGPU policy] Retrieved by %s\n", method)
+	tflog.Trace(ctx, "[get VM vGPU policy] Retrieved", map[string]interface{}{"method": method})
 	return setVgpuPolicy(ctx, d, policy.VdcComputePolicyV2)
 }
 
 // resourceVmVgpuPolicyUpdate function updates resource with found configurations changes
 func resourceVcdVmVgpuPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM vGPU policy update initiated: %s", policyName)
+	tflog.Trace(ctx, "VM vGPU policy update initiated", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
 	policy, err := vcdClient.GetVdcComputePolicyV2ById(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM vGPU policy %s", policyName)
+		tflog.Debug(ctx, "Unable to find VM vGPU policy", map[string]interface{}{"policy_name": policyName})
 		return diag.Errorf("unable to find VM vGPU policy %s, error:  %s", policyName, err)
 	}
 
 	changedPolicy, err := getUpdatedVgpuPolicyInput(d, vcdClient, policy)
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VM vGPU policy %s with error %s", policyName, err)
+		tflog.Debug(ctx, "Error updating VM vGPU policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error updating VM vGPU policy %s, err: %s", policyName, err)
 	}
 
 	_, err = changedPolicy.Update()
 	if err != nil {
-		log.Printf("[DEBUG] Error updating VM vGPU policy %s with error %s", policyName, err)
+		tflog.Debug(ctx, "Error updating VM vGPU policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error updating VM vGPU policy %s, err: %s", policyName, err)
 	}
 
-	log.Printf("[TRACE] VM vGPU policy update completed: %s", policyName)
+	tflog.Trace(ctx, "VM vGPU policy update completed", map[string]interface{}{"policy_name": policyName})
 	return resourceVcdVmVgpuPolicyRead(ctx, d, meta)
 }
 
 // Deletes a VM vGPU policy
-func resourceVcdVmVgpuPolicyDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVmVgpuPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policyName := d.Get("name").(string)
-	log.Printf("[TRACE] VM vGPU policy delete started: %s", policyName)
+	tflog.Trace(ctx, "VM vGPU policy delete started", map[string]interface{}{"policy_name": policyName})
 
 	vcdClient := meta.(*VCDClient)
 
@@ -239,18 +247,21 @@ func resourceVcdVmVgpuPolicyDelete(_ context.Context, d *schema.ResourceData, me
 
 	policy, err := vcdClient.GetVdcComputePolicyV2ById(d.Id())
 	if err != nil {
-		log.Printf("[DEBUG] Unable to find VM vGPU policy %s. Removing from tfstate", policyName)
+		tflog.Debug(ctx, "Unable to find VM vGPU policy. Removing from tfstate", map[string]interface{}{"policy_name": policyName})
 		d.SetId("")
 		return nil
 	}
 
 	err = policy.Delete()
 	if err != nil {
-		log.Printf("[DEBUG] Error removing VM vGPU policy %s, err: %s", policyName, err)
+		tflog.Debug(ctx, "Error removing VM vGPU policy", map[string]interface{}{
+			"policy_name": policyName,
+			"error":       err,
+		})
 		return diag.Errorf("error removing VM vGPU policy %s, err: %s", policyName, err)
 	}
 
-	log.Printf("[TRACE] VM vGPU policy delete completed: %s", policyName)
+	tflog.Trace(ctx, "VM vGPU policy delete completed", map[string]interface{}{"policy_name": policyName})
 	return nil
 }
 
@@ -440,10 +451,10 @@ func getVgpuClustersAndVmGroups(d *schema.ResourceData, vcdClient *VCDClient) ([
 var errHelpVmVgpuPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
 'vm-vgpu-policy-name', 'vm-vgpu-policy-id' or 'list@' to get a list of VM vgpu policies with their IDs`)
 
-func resourceVcdVmVgpuPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+func resourceVcdVmVgpuPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := importIdElements(ctx, d, -1)
 
-	log.Printf("[DEBUG] importing VM vGPU policy resource with provided id %s", d.Id())
+	tflog.Debug(ctx, "importing VM vGPU policy resource", map[string]interface{}{"id": d.Id()})
 
 	if len(resourceURI) != 1 {
 		return nil, errHelpVmVgpuPolicyImport
@@ -452,11 +463,11 @@ func resourceVcdVmVgpuPolicyImport(_ context.Context, d *schema.ResourceData, me
 		return listComputePoliciesForImport(meta, "vcd_vm_vgpu_policy", "vgpu")
 	} else {
 		policyId := resourceURI[0]
-		return getVmVgpuPolicy(d, meta, policyId)
+		return getVmVgpuPolicy(ctx, d, meta, policyId)
 	}
 }
 
-func getVmVgpuPolicy(d *schema.ResourceData, meta interface{}, policyId string) ([]*schema.ResourceData, error) {
+func getVmVgpuPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}, policyId string) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
 	var computePolicy *govcd.VdcComputePolicyV2
@@ -467,11 +478,11 @@ func getVmVgpuPolicy(d *schema.ResourceData, meta interface{}, policyId string)
 		queryParams.Add("filter", fmt.Sprintf("name==%s;isVgpuPolicy==true", policyId))
 		computePolicies, err := vcdClient.GetAllVdcComputePoliciesV2(queryParams)
 		if err != nil {
-			log.Printf("[DEBUG] Unable to find VM vGPU Policy %s", policyId)
+			tflog.Debug(ctx, "Unable to find VM vGPU Policy", map[string]interface{}{"policy_id": policyId})
 			return nil, fmt.Errorf("unable to find VM vGPU Policy %s, err: %s", policyId, err)
 		}
 		if len(computePolicies) != 1 {
-			log.Printf("[DEBUG] Unable to find unique VM vGPU Policy %s", policyId)
+			tflog.Debug(ctx, "Unable to find unique VM vGPU Policy", map[string]interface{}{"policy_id": policyId})
 			return nil, fmt.Errorf("unable to find unique VM vGPU Policy with the name %s", policyId)
 		}
 		computePolicy = computePolicies[0]
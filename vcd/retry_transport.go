@@ -0,0 +1,301 @@
+package vcd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Classes of errors that can be retried, as used in the provider `retry.retryable_errors` argument
+const (
+	retryErrorThrottled   = "throttled"    // HTTP 429
+	retryErrorServer      = "server_error" // HTTP 500, 502, 503, 504
+	retryErrorBusyEntity  = "busy_entity"  // VCD rejects the operation because another task is running on the entity
+	retryErrorConnection  = "connection"   // Network errors, when no response was received
+	maxRetryErrorBodySize = 64 * 1024      // Size of the response body inspected to detect busy entities
+)
+
+var allRetryErrorClasses = []string{retryErrorThrottled, retryErrorServer, retryErrorBusyEntity, retryErrorConnection}
+
+// reBusyEntity matches the error messages returned by VCD when an entity is busy with another task
+var reBusyEntity = regexp.MustCompile(`(?i)BUSY_ENTITY|is busy|another task is (already )?running`)
+
+// retryPolicy defines how API requests that failed with a transient error are retried
+type retryPolicy struct {
+	MaxAttempts     int             // Maximum number of attempts, including the first one
+	MinBackoff      time.Duration   // Delay before the first retry
+	MaxBackoff      time.Duration   // Maximum delay between two attempts
+	Jitter          bool            // Randomizes delays, so that parallel requests don't retry together
+	RetryableErrors map[string]bool // Classes of errors that are retried
+}
+
+// retrySchema returns the schema of the provider `retry` block
+func retrySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Retry policy for API requests that fail with transient errors",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Maximum number of attempts for each API request, including the first one",
+				},
+				"min_backoff_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Delay before the first retry. It doubles with each following retry",
+				},
+				"max_backoff_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Maximum delay between two attempts",
+				},
+				"jitter": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Randomizes the delay between attempts, so that parallel requests are not retried at the same time",
+				},
+				"retryable_errors": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Description: fmt.Sprintf("Classes of errors that are retried. One or more of %v. All of them are retried when not set. "+
+						"'%s' and '%s' are retried only for idempotent requests", allRetryErrorClasses, retryErrorServer, retryErrorConnection),
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(allRetryErrorClasses, false),
+					},
+				},
+			},
+		},
+	}
+}
+
+// getRetryPolicy returns the retry policy defined in the provider `retry` block, or nil if it is
+// not defined
+func getRetryPolicy(d *schema.ResourceData) (*retryPolicy, error) {
+	retryBlocks := d.Get("retry").([]interface{})
+	if len(retryBlocks) == 0 || retryBlocks[0] == nil {
+		return nil, nil
+	}
+	retryBlock := retryBlocks[0].(map[string]interface{})
+
+	policy := &retryPolicy{
+		MaxAttempts:     retryBlock["max_attempts"].(int),
+		MinBackoff:      time.Duration(retryBlock["min_backoff_seconds"].(int)) * time.Second,
+		MaxBackoff:      time.Duration(retryBlock["max_backoff_seconds"].(int)) * time.Second,
+		Jitter:          retryBlock["jitter"].(bool),
+		RetryableErrors: make(map[string]bool),
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		return nil, fmt.Errorf("'max_backoff_seconds' (%d) must not be lower than 'min_backoff_seconds' (%d)",
+			retryBlock["max_backoff_seconds"].(int), retryBlock["min_backoff_seconds"].(int))
+	}
+
+	errorClasses := convertSchemaSetToSliceOfStrings(retryBlock["retryable_errors"].(*schema.Set))
+	if len(errorClasses) == 0 {
+		errorClasses = allRetryErrorClasses
+	}
+	for _, errorClass := range errorClasses {
+		policy.RetryableErrors[errorClass] = true
+	}
+	return policy, nil
+}
+
+// backoff returns the delay before the given retry (starting from 1). When the response contains a
+// Retry-After header, the delay is at least the requested one, up to MaxBackoff
+func (p *retryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter && delay > 0 {
+		// Equal jitter: the delay is between half and the full value
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)) // #nosec G404 -- no need for a secure random number here
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retryAfter := time.Duration(seconds) * time.Second
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+			if delay > p.MaxBackoff {
+				delay = p.MaxBackoff
+			}
+		}
+	}
+	return delay
+}
+
+// retryTransport is an http.RoundTripper that retries requests failing with transient errors,
+// according to a retryPolicy. It is set in the HTTP client used by govcd, so that it applies to
+// all API calls made through VCDClient.
+type retryTransport struct {
+	transport http.RoundTripper
+	policy    retryPolicy
+}
+
+// newRetryTransport wraps the given transport with the retry policy
+func newRetryTransport(transport http.RoundTripper, policy retryPolicy) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &retryTransport{transport: transport, policy: policy}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+
+		errorClass, reason := classifyRetryableError(req, resp, err)
+		if errorClass == "" || !t.policy.RetryableErrors[errorClass] || attempt >= t.policy.MaxAttempts || !isRequestReplayable(req) {
+			return resp, err
+		}
+		if (errorClass == retryErrorServer || errorClass == retryErrorConnection) && !isIdempotentMethod(req.Method) {
+			return resp, err
+		}
+
+		delay := t.policy.backoff(attempt, resp)
+		tflog.Warn(providerLogContext(), "[retry] request failed. Retrying", map[string]interface{}{
+			"method":       req.Method,
+			"url":          req.URL.Redacted(),
+			"reason":       reason,
+			"attempt":      attempt,
+			"max_attempts": t.policy.MaxAttempts,
+			"delay":        delay.String(),
+		})
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error preparing request body for retry: %s", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// classifyRetryableError returns the retry error class of a request result, together with a
+// description of the error. An empty class is returned when the result must not be retried.
+// When the response body is inspected, it is restored so that it can still be read by the caller
+func classifyRetryableError(req *http.Request, resp *http.Response, err error) (string, string) {
+	if err != nil {
+		if errors.Is(err, req.Context().Err()) {
+			return "", ""
+		}
+		return retryErrorConnection, fmt.Sprintf("connection error: %s", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return retryErrorThrottled, resp.Status
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// A busy entity may also be reported as a server error
+		if isBusyEntityResponse(resp) {
+			return retryErrorBusyEntity, fmt.Sprintf("busy entity (%s)", resp.Status)
+		}
+		return retryErrorServer, resp.Status
+	case http.StatusBadRequest, http.StatusConflict:
+		if isBusyEntityResponse(resp) {
+			return retryErrorBusyEntity, fmt.Sprintf("busy entity (%s)", resp.Status)
+		}
+	}
+	return "", ""
+}
+
+// isBusyEntityResponse checks whether an error response reports that the entity is busy with
+// another task. The response body is read and replaced with an identical one
+func isBusyEntityResponse(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRetryErrorBodySize))
+	// The remaining part of the body (if any) is still available to the caller
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+	return reBusyEntity.Match(body)
+}
+
+// retryOnBusyEntity runs an operation that starts VCD tasks and waits for them. VCD can accept a
+// request and then fail its task because the entity is busy with another task, which retryTransport
+// can't detect. In that case, the whole operation is run again according to the provider retry
+// policy, when 'busy_entity' errors are retryable.
+// The generic task wait can't do the same, as it only knows the task and not the request that
+// started it, so each operation that should be retried must be wrapped explicitly. The list of such
+// operations is in the `retryable_errors` documentation of the provider.
+func retryOnBusyEntity(ctx context.Context, vcdClient *VCDClient, operation func() error) error {
+	policy := vcdClient.retry
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil || policy == nil || !policy.RetryableErrors[retryErrorBusyEntity] ||
+			attempt >= policy.MaxAttempts || !reBusyEntity.MatchString(err.Error()) {
+			return err
+		}
+
+		delay := policy.backoff(attempt, nil)
+		tflog.Warn(ctx, "[retry] task failed on a busy entity. Retrying", map[string]interface{}{
+			"attempt":      attempt,
+			"max_attempts": policy.MaxAttempts,
+			"error":        err,
+			"delay":        delay,
+		})
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isRequestReplayable returns true when the request body can be sent again
+func isRequestReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// isIdempotentMethod returns true for HTTP methods that can be repeated without side effects
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
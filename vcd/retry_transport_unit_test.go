@@ -0,0 +1,165 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_retryTransport(t *testing.T) {
+	policy := retryPolicy{
+		MaxAttempts:     3,
+		MinBackoff:      time.Millisecond,
+		MaxBackoff:      5 * time.Millisecond,
+		Jitter:          true,
+		RetryableErrors: map[string]bool{retryErrorThrottled: true, retryErrorServer: true, retryErrorBusyEntity: true},
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		failures         int
+		failureStatus    int
+		failureBody      string
+		wantStatus       int
+		wantAttempts     int32
+		wantResponseBody string
+	}{
+		{name: "success", method: http.MethodGet, wantStatus: http.StatusOK, wantAttempts: 1},
+		{name: "server-error-get", method: http.MethodGet, failures: 2, failureStatus: http.StatusServiceUnavailable, wantStatus: http.StatusOK, wantAttempts: 3},
+		{name: "server-error-post", method: http.MethodPost, failures: 1, failureStatus: http.StatusServiceUnavailable, wantStatus: http.StatusServiceUnavailable, wantAttempts: 1},
+		{name: "throttled-post", method: http.MethodPost, failures: 1, failureStatus: http.StatusTooManyRequests, wantStatus: http.StatusOK, wantAttempts: 2},
+		{name: "too-many-failures", method: http.MethodGet, failures: 5, failureStatus: http.StatusBadGateway, wantStatus: http.StatusBadGateway, wantAttempts: 3},
+		{name: "busy-entity-post", method: http.MethodPost, failures: 1, failureStatus: http.StatusBadRequest,
+			failureBody: `<Error minorErrorCode="BUSY_ENTITY" message="The entity vm1 is busy completing an operation."/>`,
+			wantStatus:  http.StatusOK, wantAttempts: 2},
+		{name: "bad-request-not-retried", method: http.MethodPost, failures: 1, failureStatus: http.StatusBadRequest,
+			failureBody: `<Error minorErrorCode="BAD_REQUEST" message="invalid name"/>`,
+			wantStatus:  http.StatusBadRequest, wantAttempts: 1, wantResponseBody: `<Error minorErrorCode="BAD_REQUEST" message="invalid name"/>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != "payload" {
+					t.Errorf("attempt %d: unexpected request body '%s'", attempt, body)
+				}
+				if int(attempt) <= tt.failures {
+					w.WriteHeader(tt.failureStatus)
+					_, _ = w.Write([]byte(tt.failureBody))
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := http.Client{Transport: newRetryTransport(http.DefaultTransport, policy)}
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer resp.Body.Close()
+			responseBody, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+			if tt.wantResponseBody != "" && string(responseBody) != tt.wantResponseBody {
+				t.Errorf("expected response body '%s', got '%s'", tt.wantResponseBody, responseBody)
+			}
+		})
+	}
+}
+
+func Test_retryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i+1, nil); got != want {
+			t.Errorf("retry %d: expected backoff %s, got %s", i+1, want, got)
+		}
+	}
+
+	policy.Jitter = true
+	for retry := 1; retry < 6; retry++ {
+		got := policy.backoff(retry, nil)
+		if got < expected[retry-1]/2 || got > expected[retry-1] {
+			t.Errorf("retry %d: backoff with jitter %s out of range", retry, got)
+		}
+	}
+
+	policy.Jitter = false
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	if got := policy.backoff(1, resp); got != 5*time.Second {
+		t.Errorf("expected Retry-After backoff of 5s, got %s", got)
+	}
+	resp.Header.Set("Retry-After", "60")
+	if got := policy.backoff(1, resp); got != 10*time.Second {
+		t.Errorf("expected Retry-After backoff capped to 10s, got %s", got)
+	}
+}
+
+func Test_retryOnBusyEntity(t *testing.T) {
+	policy := &retryPolicy{
+		MaxAttempts:     3,
+		MinBackoff:      time.Millisecond,
+		MaxBackoff:      5 * time.Millisecond,
+		RetryableErrors: map[string]bool{retryErrorBusyEntity: true},
+	}
+	busyTaskError := &taskError{task: &types.Task{ID: "urn:vcloud:task:1", Status: "error",
+		Error: &types.Error{MajorErrorCode: 400, MinorErrorCode: "BUSY_ENTITY", Message: "The entity vm-1 is busy completing an operation"}}}
+
+	tests := []struct {
+		name         string
+		policy       *retryPolicy
+		failures     int
+		failure      error
+		wantAttempts int
+		wantError    bool
+	}{
+		{name: "success", policy: policy, failures: 0, failure: busyTaskError, wantAttempts: 1},
+		{name: "busy-then-success", policy: policy, failures: 2, failure: busyTaskError, wantAttempts: 3},
+		{name: "busy-exhausted", policy: policy, failures: 5, failure: busyTaskError, wantAttempts: 3, wantError: true},
+		{name: "other-error", policy: policy, failures: 5, failure: fmt.Errorf("invalid configuration"), wantAttempts: 1, wantError: true},
+		{name: "no-policy", policy: nil, failures: 5, failure: busyTaskError, wantAttempts: 1, wantError: true},
+		{name: "busy-not-retryable", policy: &retryPolicy{MaxAttempts: 3, RetryableErrors: map[string]bool{retryErrorThrottled: true}},
+			failures: 5, failure: busyTaskError, wantAttempts: 1, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := retryOnBusyEntity(context.Background(), &VCDClient{retry: tt.policy}, func() error {
+				attempts++
+				if attempts <= tt.failures {
+					return tt.failure
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantError {
+				t.Errorf("unexpected error result: %v", err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.wantAttempts, attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,510 @@
+package vcd
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+// The session cache stores authenticated sessions on disk, so that a bearer token obtained by one
+// Terraform run can be reused by the following ones, instead of performing a full login each time.
+//
+// Each entry is identified by an HMAC of the connection data computed in Config.Client(), and its contents
+// are encrypted with AES-GCM, using another key derived with HMAC from the same data. The HMAC secret is
+// `session_cache_key` or, when it is not set, a random key generated in the user configuration directory
+// (see sessionCacheKeyFile). A session is reused only if VCD still accepts its token; otherwise a normal
+// authentication is performed and the entry is replaced. If VCD rejects the token of a restored session
+// later in the run, the entry is removed and the client authenticates again (see sessionCacheTransport).
+//
+// The cache is opt-in: it is used only when `session_cache_file` is set.
+
+// sessionCacheMutex serializes access to the session cache file within the provider process
+var sessionCacheMutex sync.Mutex
+
+// sessionCacheContents is the structure of the session cache file
+type sessionCacheContents struct {
+	Entries map[string]sessionCacheEntry `json:"entries"`
+}
+
+// sessionCacheEntry contains an encrypted cachedSession
+type sessionCacheEntry struct {
+	Created time.Time `json:"created"`
+	Nonce   []byte    `json:"nonce"`
+	Data    []byte    `json:"data"`
+}
+
+// cachedSession contains the data needed to restore an authenticated session
+type cachedSession struct {
+	Token            string `json:"token"`
+	AuthHeader       string `json:"auth_header"`
+	UsingAccessToken bool   `json:"using_access_token"`
+}
+
+// sessionCacheEnabled returns true when the cached session can be used for the given configuration.
+// Sessions are not cached when the user provides a token directly, as there is no login to save
+func (c *Config) sessionCacheEnabled() bool {
+	return c.SessionCacheFile != "" && c.Token == ""
+}
+
+// sessionCacheKeys returns the ID of the session cache entry for the given connection data and the key
+// that encrypts it. Both are computed with HMAC, so that neither the ID nor the encrypted contents can be
+// used to guess the credentials without knowing the secret
+func (c *Config) sessionCacheKeys(connectionData string) (string, []byte, error) {
+	secret := []byte(c.SessionCacheKey)
+	if c.SessionCacheKey == "" {
+		keyFile, err := sessionCacheKeyFile()
+		if err != nil {
+			return "", nil, err
+		}
+		secret, err = readOrCreateSessionCacheSecret(keyFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("error reading session cache key file %s: %s", keyFile, err)
+		}
+	}
+	return fmt.Sprintf("%x", sessionCacheHmac(secret, "id#"+connectionData)), sessionCacheHmac(secret, "key#"+connectionData), nil
+}
+
+func sessionCacheHmac(secret []byte, data string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sessionCacheKeyFile returns the file that stores the random secret of the session cache, used when
+// `session_cache_key` is not set
+func sessionCacheKeyFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving the user configuration directory for the session cache key: %s", err)
+	}
+	return filepath.Join(configDir, "terraform-provider-vcd", "session_cache.key"), nil
+}
+
+// readOrCreateSessionCacheSecret reads the secret stored in the given file, generating it when the file
+// does not exist. The file must be accessible only by its owner
+func readOrCreateSessionCacheSecret(fileName string) ([]byte, error) {
+	fileName = filepath.Clean(fileName)
+	info, err := os.Stat(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		err = createSessionCacheSecret(fileName)
+		if err != nil && !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		info, err = os.Stat(fileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("permissions %s are too open: the file must be accessible only by its owner", info.Mode().Perm())
+	}
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := hex.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil || len(secret) < sessionCacheSecretSize {
+		return nil, fmt.Errorf("invalid contents: expected %d hex encoded bytes", sessionCacheSecretSize)
+	}
+	return secret, nil
+}
+
+const sessionCacheSecretSize = 32
+
+// createSessionCacheSecret writes a random secret to a new file with permissions 0600. It fails with
+// os.ErrExist if another process created the file in the meantime
+func createSessionCacheSecret(fileName string) error {
+	secret := make([]byte, sessionCacheSecretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(fileName), 0700)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(hex.EncodeToString(secret))
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(fileName)
+		return err
+	}
+	return file.Close()
+}
+
+// restoreCachedSession tries to authenticate the client with the session stored for the given
+// checksum. It returns true only if the session was found and VCD accepted its token. Any failure
+// is logged and the cached entry is discarded, as the caller will perform a normal authentication.
+func (c *Config) restoreCachedSession(ctx context.Context, vcdClient *VCDClient, checksum string, key []byte) bool {
+	sessionCacheMutex.Lock()
+	defer sessionCacheMutex.Unlock()
+
+	cache, err := readSessionCache(c.SessionCacheFile)
+	if err != nil {
+		tflog.Warn(ctx, "[session cache] error reading", map[string]interface{}{
+			"session_cache_file": c.SessionCacheFile,
+			"error":              err,
+		})
+		return false
+	}
+	entry, found := cache.Entries[checksum]
+	if !found {
+		tflog.Debug(ctx, "[session cache] no session found")
+		return false
+	}
+
+	session, err := decryptCachedSession(entry, key)
+	if err == nil && time.Since(entry.Created) > c.SessionCacheMaxAge {
+		err = fmt.Errorf("session is older than %s", c.SessionCacheMaxAge)
+	}
+	if err == nil {
+		vcdClient.Client.UsingAccessToken = session.UsingAccessToken
+		err = vcdClient.SetToken(c.SysOrg, session.AuthHeader, session.Token)
+	}
+	if err != nil {
+		tflog.Debug(ctx, "[session cache] discarding cached session", map[string]interface{}{"error": err})
+		vcdClient.Client.UsingAccessToken = false
+		vcdClient.Client.UsingBearerToken = false
+		delete(cache.Entries, checksum)
+		err = writeSessionCache(c.SessionCacheFile, cache, c.SessionCacheMaxAge)
+		if err != nil {
+			tflog.Warn(ctx, "[session cache] error writing", map[string]interface{}{
+				"session_cache_file": c.SessionCacheFile,
+				"error":              err,
+			})
+		}
+		return false
+	}
+
+	tflog.Debug(ctx, "[session cache] reusing session", map[string]interface{}{"created": entry.Created.Format(time.RFC3339)})
+	return true
+}
+
+// removeCachedSession removes the session stored for the given checksum
+func (c *Config) removeCachedSession(ctx context.Context, checksum string) {
+	sessionCacheMutex.Lock()
+	defer sessionCacheMutex.Unlock()
+
+	cache, err := readSessionCache(c.SessionCacheFile)
+	if err == nil {
+		delete(cache.Entries, checksum)
+		err = writeSessionCache(c.SessionCacheFile, cache, c.SessionCacheMaxAge)
+	}
+	if err != nil {
+		tflog.Warn(ctx, "[session cache] error removing session", map[string]interface{}{
+			"session_cache_file": c.SessionCacheFile,
+			"error":              err,
+		})
+	}
+}
+
+// storeCachedSession saves the session of an authenticated client for the given checksum. Errors
+// are only logged, as the cache must not prevent the provider from working
+func (c *Config) storeCachedSession(ctx context.Context, vcdClient *VCDClient, checksum string, key []byte) {
+	if vcdClient.Client.VCDToken == "" || vcdClient.Client.VCDAuthHeader == govcd.ApiTokenHeader {
+		return
+	}
+
+	sessionCacheMutex.Lock()
+	defer sessionCacheMutex.Unlock()
+
+	entry, err := encryptCachedSession(cachedSession{
+		Token:            vcdClient.Client.VCDToken,
+		AuthHeader:       vcdClient.Client.VCDAuthHeader,
+		UsingAccessToken: vcdClient.Client.UsingAccessToken,
+	}, key)
+	if err != nil {
+		tflog.Warn(ctx, "[session cache] error encrypting session", map[string]interface{}{"error": err})
+		return
+	}
+
+	cache, err := readSessionCache(c.SessionCacheFile)
+	if err != nil {
+		// A corrupted file is replaced
+		tflog.Warn(ctx, "[session cache] error reading", map[string]interface{}{
+			"session_cache_file": c.SessionCacheFile,
+			"error":              err,
+		})
+		cache = &sessionCacheContents{Entries: make(map[string]sessionCacheEntry)}
+	}
+	cache.Entries[checksum] = entry
+	err = writeSessionCache(c.SessionCacheFile, cache, c.SessionCacheMaxAge)
+	if err != nil {
+		tflog.Warn(ctx, "[session cache] error writing", map[string]interface{}{
+			"session_cache_file": c.SessionCacheFile,
+			"error":              err,
+		})
+		return
+	}
+	tflog.Debug(ctx, "[session cache] session stored", map[string]interface{}{"session_cache_file": c.SessionCacheFile})
+}
+
+// readSessionCache reads the session cache file. A missing file is returned as an empty cache
+func readSessionCache(fileName string) (*sessionCacheContents, error) {
+	cache := &sessionCacheContents{}
+	contents, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if len(contents) > 0 {
+		err = json.Unmarshal(contents, cache)
+		if err != nil {
+			return nil, fmt.Errorf("invalid session cache contents: %s", err)
+		}
+	}
+	if cache.Entries == nil {
+		cache.Entries = make(map[string]sessionCacheEntry)
+	}
+	return cache, nil
+}
+
+// writeSessionCache writes the session cache file, readable only by the current user, after
+// removing the entries older than 'maxAge'. The file is replaced atomically, so that concurrent
+// Terraform runs never read a partially written file
+func writeSessionCache(fileName string, cache *sessionCacheContents, maxAge time.Duration) error {
+	for checksum, entry := range cache.Entries {
+		if time.Since(entry.Created) > maxAge {
+			delete(cache.Entries, checksum)
+		}
+	}
+	contents, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Removes the temporary file if it was not renamed
+		_ = os.Remove(tempFile.Name())
+	}()
+	_, err = tempFile.Write(contents)
+	if err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	err = tempFile.Close()
+	if err != nil {
+		return err
+	}
+	// os.CreateTemp already uses 0600 permissions, but the file may be placed in a shared directory
+	err = os.Chmod(tempFile.Name(), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), fileName)
+}
+
+// encryptCachedSession encrypts a session with AES-GCM using the given key
+func encryptCachedSession(session cachedSession, key []byte) (sessionCacheEntry, error) {
+	plainText, err := json.Marshal(session)
+	if err != nil {
+		return sessionCacheEntry{}, err
+	}
+	gcm, err := newSessionCacheCipher(key)
+	if err != nil {
+		return sessionCacheEntry{}, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return sessionCacheEntry{}, err
+	}
+	return sessionCacheEntry{
+		Created: time.Now(),
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, plainText, nil),
+	}, nil
+}
+
+// decryptCachedSession decrypts a session encrypted by encryptCachedSession
+func decryptCachedSession(entry sessionCacheEntry, key []byte) (*cachedSession, error) {
+	gcm, err := newSessionCacheCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(entry.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size %d", len(entry.Nonce))
+	}
+	plainText, err := gcm.Open(nil, entry.Nonce, entry.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting session: %s", err)
+	}
+	var session cachedSession
+	err = json.Unmarshal(plainText, &session)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session contents: %s", err)
+	}
+	if session.Token == "" || session.AuthHeader == "" {
+		return nil, fmt.Errorf("empty session token")
+	}
+	return &session, nil
+}
+
+// sessionCacheTransport authenticates the client again when VCD rejects the token of a session restored
+// from the cache with "401 Unauthorized", e.g. because the session was closed or expired after it was
+// stored. The cached entry is replaced with the new session and the rejected request is sent again.
+type sessionCacheTransport struct {
+	transport http.RoundTripper
+	config    *Config
+	vcdClient *VCDClient
+	checksum  string
+	key       []byte
+	// authenticate performs a new login with the credentials of the configuration
+	authenticate func() error
+
+	mutex sync.Mutex
+	// restoredHeader and restoredToken identify the session restored from the cache
+	restoredHeader string
+	restoredToken  string
+	// newHeader and newToken identify the session that replaced the restored one
+	newHeader string
+	newToken  string
+}
+
+func newSessionCacheTransport(transport http.RoundTripper, config *Config, vcdClient *VCDClient, checksum string, key []byte) *sessionCacheTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &sessionCacheTransport{
+		transport: transport,
+		config:    config,
+		vcdClient: vcdClient,
+		checksum:  checksum,
+		key:       key,
+		authenticate: func() error {
+			return ProviderAuthenticate(vcdClient.VCDClient, config.User, config.Password, config.Token, config.SysOrg, config.ApiToken,
+				config.ApiTokenFile, config.ServiceAccountTokenFile, config.Jwt, config.JwtFile)
+		},
+	}
+}
+
+// restore authenticates the client with the cached session, if possible
+func (t *sessionCacheTransport) restore(ctx context.Context) bool {
+	if !t.config.restoreCachedSession(ctx, t.vcdClient, t.checksum, t.key) {
+		return false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.restoredHeader = t.vcdClient.Client.VCDAuthHeader
+	t.restoredToken = t.vcdClient.Client.VCDToken
+	return true
+}
+
+// store saves the session of the authenticated client
+func (t *sessionCacheTransport) store(ctx context.Context) {
+	t.config.storeCachedSession(ctx, t.vcdClient, t.checksum, t.key)
+}
+
+func (t *sessionCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !isRequestReplayable(req) {
+		return resp, err
+	}
+
+	t.mutex.Lock()
+	restoredHeader := t.restoredHeader
+	t.mutex.Unlock()
+	// Requests without the restored session token, such as the logins, are not handled
+	token := ""
+	if restoredHeader != "" {
+		token = req.Header.Get(restoredHeader)
+	}
+	if token == "" {
+		return resp, err
+	}
+	authHeader, newToken, ok := t.reauthenticate(token)
+	if !ok {
+		return resp, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("error preparing request body after authenticating again: %s", err)
+		}
+	}
+	_ = resp.Body.Close()
+	retry.Header.Del(restoredHeader)
+	retry.Header.Del("Authorization")
+	retry.Header.Del("X-Vmware-Vcloud-Token-Type")
+	retry.Header.Set(authHeader, newToken)
+	// Same headers as go-vcloud-director sets for bearer tokens
+	if len(newToken) > 32 {
+		retry.Header.Set("X-Vmware-Vcloud-Token-Type", "Bearer")
+		retry.Header.Set("Authorization", "bearer "+newToken)
+	}
+	return t.transport.RoundTrip(retry)
+}
+
+// reauthenticate replaces the restored session, rejected by VCD, with a new login. It returns the header
+// and token of the new session, which are also returned to the requests that used the rejected token
+// while the login was performed
+func (t *sessionCacheTransport) reauthenticate(rejectedToken string) (string, string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.newToken != "" && rejectedToken == t.restoredToken {
+		return t.newHeader, t.newToken, true
+	}
+	if t.restoredToken == "" || rejectedToken != t.restoredToken {
+		return "", "", false
+	}
+
+	ctx := providerLogContext()
+	tflog.Info(ctx, "[session cache] VCD rejected the cached session. Authenticating again")
+	t.config.removeCachedSession(ctx, t.checksum)
+
+	client := &t.vcdClient.Client
+	previousClient := *client
+	client.VCDAuthHeader = ""
+	client.VCDToken = ""
+	client.UsingBearerToken = false
+	client.UsingAccessToken = false
+	err := t.authenticate()
+	if err != nil {
+		tflog.Error(ctx, "[session cache] error authenticating again", map[string]interface{}{"error": err})
+		client.VCDAuthHeader = previousClient.VCDAuthHeader
+		client.VCDToken = previousClient.VCDToken
+		client.UsingBearerToken = previousClient.UsingBearerToken
+		client.UsingAccessToken = previousClient.UsingAccessToken
+		return "", "", false
+	}
+	t.newHeader = client.VCDAuthHeader
+	t.newToken = client.VCDToken
+	t.vcdClient.syncTenantContextSessions()
+	t.store(ctx)
+	return t.newHeader, t.newToken, true
+}
+
+func newSessionCacheCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
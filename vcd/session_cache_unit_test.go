@@ -0,0 +1,234 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+func Test_sessionCacheEncryption(t *testing.T) {
+	config := Config{SessionCacheKey: "secret"}
+	checksum, key, err := config.sessionCacheKeys("user#password#")
+	if err != nil {
+		t.Fatalf("error computing session cache keys: %s", err)
+	}
+	session := cachedSession{Token: "token-value", AuthHeader: "X-Vmware-Vcloud-Access-Token"}
+
+	entry, err := encryptCachedSession(session, key)
+	if err != nil {
+		t.Fatalf("error encrypting session: %s", err)
+	}
+	if string(entry.Data) == session.Token {
+		t.Fatalf("session data was not encrypted")
+	}
+
+	decrypted, err := decryptCachedSession(entry, key)
+	if err != nil {
+		t.Fatalf("error decrypting session: %s", err)
+	}
+	if *decrypted != session {
+		t.Errorf("expected session %v, got %v", session, *decrypted)
+	}
+
+	// Different credentials or cache key must produce different entries, which can't decrypt the session
+	for _, other := range []struct {
+		config         Config
+		connectionData string
+	}{
+		{config: config, connectionData: "user#other-password#"},
+		{config: Config{SessionCacheKey: "other"}, connectionData: "user#password#"},
+	} {
+		otherChecksum, otherKey, err := other.config.sessionCacheKeys(other.connectionData)
+		if err != nil {
+			t.Fatalf("error computing session cache keys: %s", err)
+		}
+		if otherChecksum == checksum {
+			t.Errorf("different connection data or cache key produced the same entry %s", checksum)
+		}
+		_, err = decryptCachedSession(entry, otherKey)
+		if err == nil {
+			t.Errorf("session was decrypted with a different key")
+		}
+	}
+}
+
+func Test_sessionCacheKeyFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	config := Config{}
+	checksum, key, err := config.sessionCacheKeys("user#password#")
+	if err != nil {
+		t.Fatalf("error computing session cache keys with a generated secret: %s", err)
+	}
+
+	keyFile, err := sessionCacheKeyFile()
+	if err != nil {
+		t.Fatalf("error retrieving key file name: %s", err)
+	}
+	fileInfo, err := os.Stat(keyFile)
+	if err != nil {
+		t.Fatalf("key file was not created: %s", err)
+	}
+	if runtime.GOOS != "windows" && fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("expected key file permissions 0600, got %o", fileInfo.Mode().Perm())
+	}
+
+	// The generated secret is reused
+	sameChecksum, sameKey, err := config.sessionCacheKeys("user#password#")
+	if err != nil {
+		t.Fatalf("error computing session cache keys with an existing secret: %s", err)
+	}
+	if sameChecksum != checksum || !bytes.Equal(sameKey, key) {
+		t.Errorf("the secret in the key file was not reused")
+	}
+
+	if runtime.GOOS != "windows" {
+		err = os.Chmod(keyFile, 0644)
+		if err != nil {
+			t.Fatalf("error changing key file permissions: %s", err)
+		}
+		_, _, err = config.sessionCacheKeys("user#password#")
+		if err == nil {
+			t.Errorf("expected error with a key file readable by other users")
+		}
+	}
+}
+
+func Test_sessionCacheFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "sessions.json")
+
+	cache, err := readSessionCache(fileName)
+	if err != nil {
+		t.Fatalf("missing file should be read as empty cache: %s", err)
+	}
+	if len(cache.Entries) != 0 {
+		t.Fatalf("expected empty cache, got %d entries", len(cache.Entries))
+	}
+
+	cache.Entries["recent"] = sessionCacheEntry{Created: time.Now(), Nonce: []byte("nonce"), Data: []byte("data")}
+	cache.Entries["expired"] = sessionCacheEntry{Created: time.Now().Add(-2 * time.Hour)}
+	err = writeSessionCache(fileName, cache, time.Hour)
+	if err != nil {
+		t.Fatalf("error writing cache: %s", err)
+	}
+
+	fileInfo, err := os.Stat(fileName)
+	if err != nil {
+		t.Fatalf("error reading cache file information: %s", err)
+	}
+	if fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("expected file permissions 0600, got %o", fileInfo.Mode().Perm())
+	}
+
+	cache, err = readSessionCache(fileName)
+	if err != nil {
+		t.Fatalf("error reading cache: %s", err)
+	}
+	if _, found := cache.Entries["expired"]; found {
+		t.Errorf("expired entry was not removed")
+	}
+	if entry, found := cache.Entries["recent"]; !found || string(entry.Data) != "data" {
+		t.Errorf("recent entry was not stored correctly: %v", cache.Entries)
+	}
+
+	err = os.WriteFile(fileName, []byte("not JSON"), 0600)
+	if err != nil {
+		t.Fatalf("error writing file: %s", err)
+	}
+	_, err = readSessionCache(fileName)
+	if err == nil {
+		t.Errorf("expected error reading invalid cache file")
+	}
+}
+
+func Test_sessionCacheTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(govcd.BearerTokenHeader) != "new-token" || string(body) != "payload" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		SessionCacheFile:   filepath.Join(t.TempDir(), "sessions.json"),
+		SessionCacheKey:    "secret",
+		SessionCacheMaxAge: time.Hour,
+	}
+	vcdClient := &VCDClient{VCDClient: &govcd.VCDClient{}}
+	vcdClient.Client.VCDAuthHeader = govcd.BearerTokenHeader
+	vcdClient.Client.VCDToken = "restored-token"
+
+	transport := newSessionCacheTransport(http.DefaultTransport, config, vcdClient, "checksum", make([]byte, 32))
+	var logins int32
+	transport.authenticate = func() error {
+		atomic.AddInt32(&logins, 1)
+		vcdClient.Client.VCDToken = "new-token"
+		vcdClient.Client.VCDAuthHeader = govcd.BearerTokenHeader
+		return nil
+	}
+	transport.restoredHeader = govcd.BearerTokenHeader
+	transport.restoredToken = "restored-token"
+	httpClient := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+		if err != nil {
+			t.Fatalf("error creating request: %s", err)
+		}
+		req.Header.Set(govcd.BearerTokenHeader, "restored-token")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("expected the request to be sent again with the new session, got status %d", resp.StatusCode)
+		}
+	}
+	if logins != 1 {
+		t.Errorf("expected one login, got %d", logins)
+	}
+	if requests != 4 {
+		t.Errorf("expected 4 requests, got %d", requests)
+	}
+
+	cache, err := readSessionCache(config.SessionCacheFile)
+	if err != nil {
+		t.Fatalf("error reading cache: %s", err)
+	}
+	if _, found := cache.Entries["checksum"]; !found {
+		t.Errorf("the new session was not stored in the cache")
+	}
+
+	// Requests with other tokens are not handled
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	req.Header.Set(govcd.BearerTokenHeader, "other-token")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized || logins != 1 {
+		t.Errorf("expected status 401 without login, got status %d and %d logins", resp.StatusCode, logins)
+	}
+}
@@ -0,0 +1,228 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// This file contains the framework used to upgrade resource states between schema versions.
+//
+// Each resource that changes its schema in a way that is not compatible with existing states (e.g.
+// renaming or converting attributes) must wrap its definition with withStateUpgrades, adding a new
+// stateUpgrade at the end of the list. The resource SchemaVersion is the number of upgrades, so
+// existing upgrades must never be removed or reordered.
+
+// stateUpgradeFunc modifies in place the raw state of a resource, as stored in Terraform state
+// JSON. Nested blocks are stored as []interface{} of map[string]interface{}
+type stateUpgradeFunc func(ctx context.Context, rawState map[string]interface{}) error
+
+// stateUpgrade contains the changes needed to upgrade a resource state from one schema version to
+// the next one
+type stateUpgrade struct {
+	// legacyAttributes contains the attributes that existed in the previous schema version, but are
+	// not present in the current schema anymore. They are needed to decode states stored in legacy
+	// (flatmap) format. Attributes of nested blocks use the path of the blocks, separated by dots
+	// (e.g. "source.virtual_machine_ids")
+	legacyAttributes map[string]*schema.Schema
+	// upgradeFuncs are applied to the raw state in the given order
+	upgradeFuncs []stateUpgradeFunc
+}
+
+// metadataStateUpgrade converts the legacy `metadata` map into `metadata_entry` blocks, for
+// resources using metadataEntryResourceSchemaDeprecated
+var metadataStateUpgrade = stateUpgrade{
+	upgradeFuncs: []stateUpgradeFunc{upgradeMetadataToMetadataEntry},
+}
+
+// withStateUpgrades sets SchemaVersion and StateUpgraders of the given resource. Upgrades must be
+// given in version order, starting from version 0, so that the resulting SchemaVersion is the
+// number of upgrades
+func withStateUpgrades(resource *schema.Resource, upgrades ...stateUpgrade) *schema.Resource {
+	resource.SchemaVersion = len(upgrades)
+	resource.StateUpgraders = make([]schema.StateUpgrader, len(upgrades))
+
+	for version := range upgrades {
+		// A state of this version contains all current attributes plus the ones removed by this
+		// and the following upgrades
+		versionSchema := make(map[string]*schema.Schema, len(resource.Schema))
+		for name, attribute := range resource.Schema {
+			versionSchema[name] = attribute
+		}
+		for _, upgrade := range upgrades[version:] {
+			for name, attribute := range upgrade.legacyAttributes {
+				addLegacyStateAttribute(versionSchema, strings.Split(name, "."), attribute)
+			}
+		}
+
+		upgradeFuncs := upgrades[version].upgradeFuncs
+		resource.StateUpgraders[version] = schema.StateUpgrader{
+			Version: version,
+			Type:    (&schema.Resource{Schema: versionSchema}).CoreConfigSchema().ImpliedType(),
+			Upgrade: func(ctx context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+				if rawState == nil {
+					return rawState, nil
+				}
+				for _, upgradeFunc := range upgradeFuncs {
+					err := upgradeFunc(ctx, rawState)
+					if err != nil {
+						return nil, fmt.Errorf("error upgrading state from schema version %d: %s", version, err)
+					}
+				}
+				return rawState, nil
+			},
+		}
+	}
+	return resource
+}
+
+// addLegacyStateAttribute adds the attribute at the given path to the schema map. The nested blocks
+// along the path are copied, so that the schema of the resource is not modified
+func addLegacyStateAttribute(schemaMap map[string]*schema.Schema, path []string, attribute *schema.Schema) {
+	if len(path) == 1 {
+		schemaMap[path[0]] = attribute
+		return
+	}
+	block, found := schemaMap[path[0]]
+	if !found {
+		panic(fmt.Sprintf("legacy attribute parent block '%s' not found", path[0]))
+	}
+	blockResource, ok := block.Elem.(*schema.Resource)
+	if !ok {
+		panic(fmt.Sprintf("legacy attribute parent '%s' is not a block", path[0]))
+	}
+	blockSchema := make(map[string]*schema.Schema, len(blockResource.Schema)+1)
+	for name, nestedAttribute := range blockResource.Schema {
+		blockSchema[name] = nestedAttribute
+	}
+	addLegacyStateAttribute(blockSchema, path[1:], attribute)
+
+	blockCopy := *block
+	blockCopy.Elem = &schema.Resource{Schema: blockSchema}
+	schemaMap[path[0]] = &blockCopy
+}
+
+// upgradeMetadataToMetadataEntry fills `metadata_entry` with the contents of the legacy `metadata`
+// map, when the former is empty. Legacy metadata is always a string entry in the GENERAL domain with
+// read-write access
+func upgradeMetadataToMetadataEntry(ctx context.Context, rawState map[string]interface{}) error {
+	metadata, ok := rawState["metadata"].(map[string]interface{})
+	if !ok || len(metadata) == 0 {
+		return nil
+	}
+	if metadataEntries, ok := rawState["metadata_entry"].([]interface{}); ok && len(metadataEntries) > 0 {
+		return nil
+	}
+
+	metadataEntries := make([]interface{}, 0, len(metadata))
+	for key, value := range metadata {
+		stringValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("metadata value for key '%s' is not a string: %v", key, value)
+		}
+		metadataEntries = append(metadataEntries, map[string]interface{}{
+			"key":         key,
+			"value":       stringValue,
+			"type":        types.MetadataStringValue,
+			"user_access": types.MetadataReadWriteVisibility,
+			"is_system":   false,
+		})
+	}
+	rawState["metadata_entry"] = metadataEntries
+	tflog.Debug(ctx, "[state upgrade] converted 'metadata' items into 'metadata_entry'", map[string]interface{}{
+		"metadata_entries_count": len(metadataEntries),
+	})
+	return nil
+}
+
+// renameStateAttribute returns a stateUpgradeFunc that moves the value of attribute 'oldName' to
+// 'newName'. When 'parentBlocks' are given, the attribute is renamed inside all the occurrences of
+// such nested blocks (e.g. "source" for "source.0.oldName").
+// The value is not moved if 'newName' is already set.
+func renameStateAttribute(oldName, newName string, parentBlocks ...string) stateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}) error {
+		for _, object := range nestedStateObjects(rawState, parentBlocks) {
+			moveStateValue(ctx, object, oldName, newName, true)
+		}
+		return nil
+	}
+}
+
+// copyStateAttribute returns a stateUpgradeFunc that copies the value of attribute 'source' to
+// 'target', when the latter is not set. It is meant for deprecated attributes that are still present
+// in the schema, together with their replacement.
+func copyStateAttribute(source, target string) stateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}) error {
+		moveStateValue(ctx, rawState, source, target, false)
+		return nil
+	}
+}
+
+// moveStateValue sets the value of 'source' into 'target' when the latter is empty. The 'source'
+// attribute is removed from the object if 'removeSource' is true
+func moveStateValue(ctx context.Context, object map[string]interface{}, source, target string, removeSource bool) {
+	value, found := object[source]
+	if !found {
+		return
+	}
+	if removeSource {
+		delete(object, source)
+	}
+	if isEmptyStateValue(value) || !isEmptyStateValue(object[target]) {
+		return
+	}
+	object[target] = value
+	tflog.Debug(ctx, "[state upgrade] moved attribute value", map[string]interface{}{
+		"source": source,
+		"target": target,
+	})
+}
+
+// nestedStateObjects returns all the objects found following the path of nested 'blocks' in the raw state
+func nestedStateObjects(rawState map[string]interface{}, blocks []string) []map[string]interface{} {
+	objects := []map[string]interface{}{rawState}
+	for _, block := range blocks {
+		var children []map[string]interface{}
+		for _, object := range objects {
+			items, ok := object[block].([]interface{})
+			if !ok {
+				continue
+			}
+			for _, item := range items {
+				if child, ok := item.(map[string]interface{}); ok {
+					children = append(children, child)
+				}
+			}
+		}
+		objects = children
+	}
+	return objects
+}
+
+// isEmptyStateValue returns true if a raw state value is null or an empty string, list or map
+func isEmptyStateValue(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typedValue == ""
+	case []interface{}:
+		return len(typedValue) == 0
+	case map[string]interface{}:
+		return len(typedValue) == 0
+	}
+	return false
+}
+
+// stringSetStateAttribute returns a schema for a legacy set of strings, used in stateUpgrade.legacyAttributes
+func stringSetStateAttribute() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
@@ -0,0 +1,200 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_upgradeMetadataToMetadataEntry(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name":     "test",
+		"metadata": map[string]interface{}{"key1": "value1"},
+	}
+	err := upgradeMetadataToMetadataEntry(context.Background(), rawState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			"key":         "key1",
+			"value":       "value1",
+			"type":        types.MetadataStringValue,
+			"user_access": types.MetadataReadWriteVisibility,
+			"is_system":   false,
+		},
+	}
+	if !reflect.DeepEqual(rawState["metadata_entry"], expected) {
+		t.Errorf("expected metadata_entry %v, got %v", expected, rawState["metadata_entry"])
+	}
+
+	// Existing metadata_entry must not be overwritten
+	existingEntries := []interface{}{map[string]interface{}{"key": "key2", "value": "value2"}}
+	rawState = map[string]interface{}{
+		"metadata":       map[string]interface{}{"key1": "value1"},
+		"metadata_entry": existingEntries,
+	}
+	err = upgradeMetadataToMetadataEntry(context.Background(), rawState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(rawState["metadata_entry"], existingEntries) {
+		t.Errorf("existing metadata_entry was modified: %v", rawState["metadata_entry"])
+	}
+
+	// No metadata at all
+	rawState = map[string]interface{}{"metadata": nil}
+	err = upgradeMetadataToMetadataEntry(context.Background(), rawState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, found := rawState["metadata_entry"]; found {
+		t.Errorf("metadata_entry should not be added when there is no metadata")
+	}
+}
+
+func Test_renameAndCopyStateAttribute(t *testing.T) {
+	rawState := map[string]interface{}{
+		"source": []interface{}{
+			map[string]interface{}{"virtual_machine_ids": []interface{}{"vm1"}},
+			map[string]interface{}{"virtual_machine_ids": []interface{}{"vm2"}, "vm_ids": []interface{}{"vm3"}},
+		},
+		"deprecated_id": "id1",
+	}
+	err := renameStateAttribute("virtual_machine_ids", "vm_ids", "source")(context.Background(), rawState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err = copyStateAttribute("deprecated_id", "new_id")(context.Background(), rawState)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"source": []interface{}{
+			map[string]interface{}{"vm_ids": []interface{}{"vm1"}},
+			map[string]interface{}{"vm_ids": []interface{}{"vm3"}},
+		},
+		"deprecated_id": "id1",
+		"new_id":        "id1",
+	}
+	if !reflect.DeepEqual(rawState, expected) {
+		t.Errorf("expected state %v, got %v", expected, rawState)
+	}
+}
+
+func Test_withStateUpgrades(t *testing.T) {
+	resource := resourceVcdVmAffinityRule()
+	if resource.SchemaVersion != 1 || len(resource.StateUpgraders) != 1 {
+		t.Fatalf("expected schema version 1 with 1 upgrader, got %d with %d", resource.SchemaVersion, len(resource.StateUpgraders))
+	}
+	err := resource.InternalValidate(nil, true)
+	if err != nil {
+		t.Fatalf("resource is not valid: %s", err)
+	}
+	if !resource.StateUpgraders[0].Type.HasAttribute("virtual_machine_ids") {
+		t.Errorf("schema version 0 type is missing legacy attribute 'virtual_machine_ids'")
+	}
+
+	upgradedState, err := resource.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{
+		"name":                "rule",
+		"virtual_machine_ids": []interface{}{"vm1", "vm2"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, found := upgradedState["virtual_machine_ids"]; found {
+		t.Errorf("legacy attribute was not removed: %v", upgradedState)
+	}
+	if !reflect.DeepEqual(upgradedState["vm_ids"], []interface{}{"vm1", "vm2"}) {
+		t.Errorf("unexpected value for vm_ids: %v", upgradedState["vm_ids"])
+	}
+}
+
+// Test_nsxvFirewallRuleFlatmapUpgrade upgrades a schema version 0 state stored in legacy (flatmap) format, decoding it
+// with the type of the upgrader as Terraform does
+func Test_nsxvFirewallRuleFlatmapUpgrade(t *testing.T) {
+	resource := resourceVcdNsxvFirewallRule()
+	if resource.SchemaVersion != 1 || len(resource.StateUpgraders) != 1 {
+		t.Fatalf("expected schema version 1 with 1 upgrader, got %d with %d", resource.SchemaVersion, len(resource.StateUpgraders))
+	}
+	upgrader := resource.StateUpgraders[0]
+
+	flatmapState := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":                             "1",
+			"name":                           "rule",
+			"edge_gateway":                   "edge",
+			"action":                         "accept",
+			"source.#":                       "1",
+			"source.0.exclude":               "false",
+			"source.0.virtual_machine_ids.#": "2",
+			"source.0.virtual_machine_ids.1234567890":     "vm1",
+			"source.0.virtual_machine_ids.987654321":      "vm2",
+			"destination.#":                               "1",
+			"destination.0.exclude":                       "false",
+			"destination.0.virtual_machine_ids.#":         "1",
+			"destination.0.virtual_machine_ids.111111111": "vm3",
+			"service.#":          "1",
+			"service.0.protocol": "any",
+		},
+	}
+	value, err := flatmapState.AttrsAsObjectValue(upgrader.Type)
+	if err != nil {
+		t.Fatalf("error decoding flatmap state: %s", err)
+	}
+	rawState, err := schema.StateValueToJSONMap(value, upgrader.Type)
+	if err != nil {
+		t.Fatalf("error converting flatmap state: %s", err)
+	}
+	upgradedState, err := upgrader.Upgrade(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedIds := map[string][]interface{}{
+		"source":      {"vm1", "vm2"},
+		"destination": {"vm3"},
+	}
+	for block, expected := range expectedIds {
+		items, ok := upgradedState[block].([]interface{})
+		if !ok || len(items) != 1 {
+			t.Fatalf("unexpected block %s: %v", block, upgradedState[block])
+		}
+		item := items[0].(map[string]interface{})
+		if _, found := item["virtual_machine_ids"]; found {
+			t.Errorf("legacy attribute was not removed from %s: %v", block, item)
+		}
+		vmIds, _ := item["vm_ids"].([]interface{})
+		if len(vmIds) != len(expected) {
+			t.Errorf("unexpected value for %s vm_ids: %v", block, item["vm_ids"])
+			continue
+		}
+		for _, id := range expected {
+			if !containsStateValue(vmIds, id) {
+				t.Errorf("expected %s vm_ids to contain %v, got %v", block, id, vmIds)
+			}
+		}
+	}
+
+	// The legacy attribute is only added to the upgrader schema
+	if _, found := resource.Schema["source"].Elem.(*schema.Resource).Schema["virtual_machine_ids"]; found {
+		t.Errorf("the resource schema was modified")
+	}
+}
+
+func containsStateValue(values []interface{}, value interface{}) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
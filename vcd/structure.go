@@ -308,11 +308,11 @@ func removeItemsFromMapWithKeyPrefixes(input map[string]interface{}, prefixes []
 // createOrUpdateMetadata creates or updates metadata entries for the given resource and attribute name
 // TODO: This function implementation should be replaced with the implementation of `createOrUpdateMetadataEntryInVcd`
 // once "metadata" field is removed.
-func createOrUpdateMetadata(d *schema.ResourceData, resource metadataCompatible, attributeName string) error {
+func createOrUpdateMetadata(d *schema.ResourceData, vcdClient *VCDClient, resource metadataCompatible, attributeName string) error {
 	// We invoke the new "metadata_entry" metadata creation here to have it centralized and reduce duplication.
 	// Ideally, once "metadata" is removed in a new major version, the implementation of `createOrUpdateMetadataEntryInVcd` should
 	// just go here in the `createOrUpdateMetadata` body.
-	err := createOrUpdateMetadataEntryInVcd(d, resource)
+	err := createOrUpdateMetadataEntryInVcd(d, vcdClient, resource)
 	if err != nil {
 		return err
 	}
@@ -324,8 +324,10 @@ func createOrUpdateMetadata(d *schema.ResourceData, resource metadataCompatible,
 		var toBeRemovedMetadata []string
 		// Check if any key in old metadata was removed in new metadata.
 		// Creates a list of keys to be removed.
+		// Default metadata keys are not removed, as they are restored by createOrUpdateMetadataEntryInVcd.
 		for k := range oldMetadata {
-			if _, ok := newMetadata[k]; !ok {
+			_, isDefaultKey := vcdClient.defaultMetadata[k]
+			if _, ok := newMetadata[k]; !ok && !isDefaultKey {
 				toBeRemovedMetadata = append(toBeRemovedMetadata, k)
 			}
 		}
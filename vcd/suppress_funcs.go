@@ -58,6 +58,22 @@ func suppressFieldAfterImport(ignoreField string) schema.SchemaDiffSuppressFunc
 	}
 }
 
+// suppressUnreadableSetAfterImport ignores the changes of a set that can't be read from VCD, as long as the resource
+// was imported and the set is still empty in the state, so that importing does not cause the re-creation of a
+// resource whose set is ForceNew
+// Note: don't use this function unless the resource has a Boolean field named "imported" that is set during import
+func suppressUnreadableSetAfterImport(setName string) schema.SchemaDiffSuppressFunc {
+	return func(k string, old string, new string, d *schema.ResourceData) bool {
+		imported, _ := d.Get("imported").(bool)
+		if !imported {
+			return false
+		}
+		oldValue, _ := d.GetChange(setName)
+		oldSet, ok := oldValue.(*schema.Set)
+		return ok && oldSet.Len() == 0
+	}
+}
+
 // falseBoolSuppress suppresses change if value is set to false or is empty
 func falseBoolSuppress() schema.SchemaDiffSuppressFunc {
 	return func(k string, old string, new string, d *schema.ResourceData) bool {
@@ -0,0 +1,281 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// A System administrator can operate "as a tenant" by sending the tenant context headers with each request:
+// * X-VMWARE-VCLOUD-TENANT-CONTEXT, with the Org UUID, used by OpenAPI
+// * X-VMWARE-VCLOUD-AUTH-CONTEXT, with the Org name, used by the legacy API
+//
+// VCD then records the tenant as the owner and audit context of the objects. The provider `tenant_context` argument
+// sets the headers for all the requests, and the same argument in a resource sets them for the operations of that
+// resource only. As the HTTP client is shared by all the resources, each tenant context gets its own copy of the
+// client, with a transport that adds the headers (see VCDClient.withTenantContext).
+
+// tenantContextSystem disables the tenant context, which is useful to override the provider setting in a resource
+const tenantContextSystem = "System"
+
+// tenantContextSchema is the `tenant_context` argument added to the resources with an `org` argument
+var tenantContextSchema = &schema.Schema{
+	Type:     schema.TypeString,
+	Optional: true,
+	Description: "Name or ID of the Organization used as tenant context for the operations of this resource. " +
+		"Only for System administrators. Overrides the provider `tenant_context`. 'System' disables it",
+}
+
+// tenantContextClients contains the clients created for each tenant context. It is shared by a VCDClient and all the
+// clients derived from it
+type tenantContextClients struct {
+	// baseTransport is the transport without tenant context headers
+	baseTransport http.RoundTripper
+
+	lock    sync.Mutex
+	clients map[string]*VCDClient
+}
+
+// tenantContextTransport is an http.RoundTripper that adds the tenant context headers to the requests. The requests
+// where govcd sets its own tenant context and the login requests are not changed
+type tenantContextTransport struct {
+	transport http.RoundTripper
+	orgId     string
+	orgName   string
+}
+
+// newTenantContextTransport wraps the given transport, adding the context of the given Org to the requests
+func newTenantContextTransport(transport http.RoundTripper, orgId, orgName string) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &tenantContextTransport{transport: transport, orgId: extractUuid(orgId), orgName: orgName}
+}
+
+func (t *tenantContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isLoginRequest(req) || req.Header.Get(types.HeaderTenantContext) != "" || req.Header.Get(types.HeaderAuthContext) != "" {
+		return t.transport.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set(types.HeaderTenantContext, t.orgId)
+	req.Header.Set(types.HeaderAuthContext, t.orgName)
+	return t.transport.RoundTrip(req)
+}
+
+// withTenantContext returns a copy of the client that sends the context of the given Org, identified by name or ID.
+// An empty value returns the client itself, and 'System' or the Org of the provider user a client without tenant
+// context, as VCD already uses the Org of the session
+func (cli *VCDClient) withTenantContext(tenantContext string) (*VCDClient, error) {
+	if tenantContext == "" || cli.tenantContexts == nil {
+		return cli, nil
+	}
+	key := strings.ToLower(tenantContext)
+
+	cli.tenantContexts.lock.Lock()
+	client, found := cli.tenantContexts.clients[key]
+	cli.tenantContexts.lock.Unlock()
+	if found {
+		return client, nil
+	}
+
+	// The Org is retrieved without tenant context, as the one of the provider could hide it. The lock is not held
+	// during the request, as a new login replaces the session of all the clients while holding it (see
+	// syncTenantContextSessions)
+	transport := cli.tenantContexts.baseTransport
+	if !strings.EqualFold(tenantContext, tenantContextSystem) {
+		org, err := cli.copyWithTransport(transport).GetOrgByNameOrId(tenantContext)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Org '%s' for tenant context: %s", tenantContext, err)
+		}
+		if !strings.EqualFold(org.Org.Name, cli.SysOrg) {
+			transport = newTenantContextTransport(transport, org.Org.ID, org.Org.Name)
+		}
+	}
+
+	cli.tenantContexts.lock.Lock()
+	defer cli.tenantContexts.lock.Unlock()
+	if client, found := cli.tenantContexts.clients[key]; found {
+		return client, nil
+	}
+	client = cli.copyWithTransport(transport)
+	cli.tenantContexts.clients[key] = client
+	return client, nil
+}
+
+// copyWithTransport returns a copy of the client, with its own HTTP client using the given transport. The limiter
+// and the caches are shared with the original client, while the govcd client, including the session token, is
+// copied by value. The copies stored in the tenant context clients get the new session when the original client
+// logs in again (see syncTenantContextSessions)
+func (cli *VCDClient) copyWithTransport(transport http.RoundTripper) *VCDClient {
+	govcdClient := *cli.VCDClient
+	govcdClient.Client.Http.Transport = transport
+	client := *cli
+	client.VCDClient = &govcdClient
+	return &client
+}
+
+// syncTenantContextSessions copies the session of the client to the clients of all the tenant contexts. It must be
+// called when the client logs in again, otherwise the copies keep sending the previous session token
+func (cli *VCDClient) syncTenantContextSessions() {
+	if cli.tenantContexts == nil {
+		return
+	}
+	cli.tenantContexts.lock.Lock()
+	defer cli.tenantContexts.lock.Unlock()
+	for _, client := range cli.tenantContexts.clients {
+		client.Client.VCDAuthHeader = cli.Client.VCDAuthHeader
+		client.Client.VCDToken = cli.Client.VCDToken
+		client.Client.UsingBearerToken = cli.Client.UsingBearerToken
+		client.Client.UsingAccessToken = cli.Client.UsingAccessToken
+	}
+}
+
+// setTenantContext sets the context of the given Org in all the requests of the client. It must be called once,
+// after authentication
+func (cli *VCDClient) setTenantContext(tenantContext string) error {
+	if strings.EqualFold(tenantContext, tenantContextSystem) {
+		return nil
+	}
+	client, err := cli.withTenantContext(tenantContext)
+	if err != nil {
+		return err
+	}
+	cli.Client.Http.Transport = client.Client.Http.Transport
+	return nil
+}
+
+// newTenantContextClients creates the tenant context storage for a client using the given transport
+func newTenantContextClients(baseTransport http.RoundTripper) *tenantContextClients {
+	return &tenantContextClients{
+		baseTransport: baseTransport,
+		clients:       make(map[string]*VCDClient),
+	}
+}
+
+// withTenantContexts returns a copy of the given resource map, where the resources with an `org` argument also have
+// a `tenant_context` argument. Their functions receive a client with the tenant context, when it is set
+func withTenantContexts(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		_, hasOrg := resource.Schema["org"]
+		_, hasTenantContext := resource.Schema["tenant_context"]
+		if !hasOrg || hasTenantContext {
+			result[name] = resource
+			continue
+		}
+		withContext := *resource
+		withContext.Schema = make(map[string]*schema.Schema, len(resource.Schema)+1)
+		for attribute, attributeSchema := range resource.Schema {
+			withContext.Schema[attribute] = attributeSchema
+		}
+		withContext.Schema["tenant_context"] = tenantContextSchema
+		if resource.Update == nil && resource.UpdateContext == nil {
+			// In resources without update, all the other arguments force a replacement. tenant_context only
+			// changes the headers of the following operations, so it is updated in place
+			withContext.UpdateContext = updateTenantContextOnly
+		}
+
+		if resource.CreateContext != nil {
+			withContext.CreateContext = withResourceTenantContext(resource.CreateContext)
+		}
+		if resource.ReadContext != nil {
+			withContext.ReadContext = withResourceTenantContext(resource.ReadContext)
+		}
+		if resource.UpdateContext != nil {
+			withContext.UpdateContext = withResourceTenantContext(resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			withContext.DeleteContext = withResourceTenantContext(resource.DeleteContext)
+		}
+		if resource.Create != nil {
+			withContext.Create = withResourceTenantContextNoContext(resource.Create)
+		}
+		if resource.Read != nil {
+			withContext.Read = withResourceTenantContextNoContext(resource.Read)
+		}
+		if resource.Update != nil {
+			withContext.Update = withResourceTenantContextNoContext(resource.Update)
+		}
+		if resource.Delete != nil {
+			withContext.Delete = withResourceTenantContextNoContext(resource.Delete)
+		}
+		if resource.Importer != nil {
+			withContext.Importer = withImporterTenantContext(resource.Importer)
+		}
+		result[name] = &withContext
+	}
+	return result
+}
+
+// updateTenantContextOnly is the update function of the resources that can only change their tenant_context. There
+// is nothing to change in VCD, as Terraform saves the new value in the state
+func updateTenantContextOnly(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return nil
+}
+
+// withResourceTenantContext wraps a resource function so that it receives a client with the tenant context of the
+// resource
+func withResourceTenantContext(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		meta, err := resourceTenantContextMeta(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+// withResourceTenantContextNoContext is the same as withResourceTenantContext, for functions without context
+func withResourceTenantContextNoContext(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		meta, err := resourceTenantContextMeta(d, meta)
+		if err != nil {
+			return err
+		}
+		return f(d, meta)
+	}
+}
+
+// withImporterTenantContext returns a copy of the importer whose functions receive a client with the tenant context
+// of the resource. As the import ID doesn't contain `tenant_context`, imports use the tenant context of the provider
+func withImporterTenantContext(importer *schema.ResourceImporter) *schema.ResourceImporter {
+	withContext := *importer
+	if importer.StateContext != nil {
+		stateContext := importer.StateContext
+		withContext.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			meta, err := resourceTenantContextMeta(d, meta)
+			if err != nil {
+				return nil, err
+			}
+			return stateContext(ctx, d, meta)
+		}
+	}
+	if importer.State != nil {
+		state := importer.State
+		withContext.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			meta, err := resourceTenantContextMeta(d, meta)
+			if err != nil {
+				return nil, err
+			}
+			return state(d, meta)
+		}
+	}
+	return &withContext
+}
+
+// resourceTenantContextMeta returns the client to use for the given resource data
+func resourceTenantContextMeta(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok {
+		return meta, nil
+	}
+	tenantContext, _ := d.Get("tenant_context").(string)
+	return vcdClient.withTenantContext(tenantContext)
+}
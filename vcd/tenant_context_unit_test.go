@@ -0,0 +1,188 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_tenantContextTransport(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	orgId := "urn:vcloud:org:6127c856-7315-46b8-b774-f2b8f1686c80"
+	client := &http.Client{Transport: newTenantContextTransport(nil, orgId, "tenant1")}
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		header          map[string]string
+		expectedTenant  string
+		expectedAuthCtx string
+	}{
+		{name: "openapi", method: http.MethodPost, path: "/cloudapi/1.0.0/edgeGateways",
+			expectedTenant: "6127c856-7315-46b8-b774-f2b8f1686c80", expectedAuthCtx: "tenant1"},
+		{name: "legacy", method: http.MethodGet, path: "/api/org/6127c856-7315-46b8-b774-f2b8f1686c80",
+			expectedTenant: "6127c856-7315-46b8-b774-f2b8f1686c80", expectedAuthCtx: "tenant1"},
+		{name: "login", method: http.MethodPost, path: "/cloudapi/1.0.0/sessions/provider"},
+		{name: "explicit-context", method: http.MethodGet, path: "/cloudapi/1.0.0/certificateLibrary",
+			header:         map[string]string{types.HeaderTenantContext: "other-id", types.HeaderAuthContext: "other"},
+			expectedTenant: "other-id", expectedAuthCtx: "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_ = resp.Body.Close()
+			if received.Get(types.HeaderTenantContext) != tt.expectedTenant {
+				t.Errorf("expected tenant context '%s', got '%s'", tt.expectedTenant, received.Get(types.HeaderTenantContext))
+			}
+			if received.Get(types.HeaderAuthContext) != tt.expectedAuthCtx {
+				t.Errorf("expected auth context '%s', got '%s'", tt.expectedAuthCtx, received.Get(types.HeaderAuthContext))
+			}
+			if len(tt.header) == 0 && req.Header.Get(types.HeaderTenantContext) != "" {
+				t.Errorf("the original request was modified")
+			}
+		})
+	}
+}
+
+func Test_withTenantContexts(t *testing.T) {
+	var receivedMeta interface{}
+	resources := map[string]*schema.Resource{
+		"vcd_with_org": {
+			Schema: map[string]*schema.Schema{
+				"org":  {Type: schema.TypeString, Optional: true},
+				"name": {Type: schema.TypeString, Required: true},
+			},
+			ReadContext: func(_ context.Context, _ *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				receivedMeta = meta
+				return nil
+			},
+			UpdateContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil },
+			Importer: &schema.ResourceImporter{
+				StateContext: func(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+					receivedMeta = meta
+					return []*schema.ResourceData{d}, nil
+				},
+			},
+		},
+		"vcd_without_update": {
+			Schema: map[string]*schema.Schema{
+				"org":  {Type: schema.TypeString, Optional: true, ForceNew: true},
+				"name": {Type: schema.TypeString, Required: true, ForceNew: true},
+			},
+			ReadContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil },
+		},
+		"vcd_without_org": {
+			Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+			},
+		},
+	}
+	result := withTenantContexts(resources)
+
+	if _, found := result["vcd_without_org"].Schema["tenant_context"]; found {
+		t.Errorf("expected no tenant_context in resources without org")
+	}
+	if _, found := result["vcd_with_org"].Schema["tenant_context"]; !found {
+		t.Fatalf("expected tenant_context in resources with org")
+	}
+	if _, found := resources["vcd_with_org"].Schema["tenant_context"]; found {
+		t.Errorf("the original schema was modified")
+	}
+	withoutUpdate := result["vcd_without_update"]
+	if withoutUpdate.Schema["tenant_context"].ForceNew || withoutUpdate.UpdateContext == nil {
+		t.Errorf("expected tenant_context to be updated in place in resources without update")
+	}
+	if resources["vcd_without_update"].UpdateContext != nil {
+		t.Errorf("the original resource was modified")
+	}
+
+	vcdUrl, err := url.ParseRequestURI("https://vcd.example.com/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vcdClient := &VCDClient{VCDClient: govcd.NewVCDClient(*vcdUrl, true)}
+	vcdClient.tenantContexts = newTenantContextClients(vcdClient.Client.Http.Transport)
+	tenantClient := vcdClient.copyWithTransport(newTenantContextTransport(nil, "urn:vcloud:org:6127c856-7315-46b8-b774-f2b8f1686c80", "tenant1"))
+	vcdClient.tenantContexts.clients["tenant1"] = tenantClient
+
+	d := schema.TestResourceDataRaw(t, result["vcd_with_org"].Schema, map[string]interface{}{"name": "x"})
+	result["vcd_with_org"].ReadContext(context.Background(), d, vcdClient)
+	if receivedMeta != vcdClient {
+		t.Errorf("expected the provider client without tenant_context")
+	}
+
+	d = schema.TestResourceDataRaw(t, result["vcd_with_org"].Schema, map[string]interface{}{"name": "x", "tenant_context": "Tenant1"})
+	result["vcd_with_org"].ReadContext(context.Background(), d, vcdClient)
+	if receivedMeta != tenantClient {
+		t.Errorf("expected the tenant client")
+	}
+	receivedMeta = nil
+	_, err = result["vcd_with_org"].Importer.StateContext(context.Background(), d, vcdClient)
+	if err != nil {
+		t.Fatalf("unexpected import error: %s", err)
+	}
+	if receivedMeta != tenantClient {
+		t.Errorf("expected the tenant client in the importer")
+	}
+
+	d = schema.TestResourceDataRaw(t, result["vcd_with_org"].Schema, map[string]interface{}{"name": "x", "tenant_context": "system"})
+	result["vcd_with_org"].ReadContext(context.Background(), d, vcdClient)
+	systemClient, ok := receivedMeta.(*VCDClient)
+	if !ok || systemClient == vcdClient || systemClient.Client.Http.Transport != vcdClient.Client.Http.Transport {
+		t.Errorf("expected a copy of the client without tenant context")
+	}
+}
+
+func Test_syncTenantContextSessions(t *testing.T) {
+	vcdUrl, err := url.ParseRequestURI("https://vcd.example.com/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vcdClient := &VCDClient{VCDClient: govcd.NewVCDClient(*vcdUrl, true)}
+	vcdClient.tenantContexts = newTenantContextClients(vcdClient.Client.Http.Transport)
+	vcdClient.Client.VCDAuthHeader = "X-Vcloud-Authorization"
+	vcdClient.Client.VCDToken = "old-token"
+	tenantClient := vcdClient.copyWithTransport(newTenantContextTransport(nil, "urn:vcloud:org:6127c856-7315-46b8-b774-f2b8f1686c80", "tenant1"))
+	vcdClient.tenantContexts.clients["tenant1"] = tenantClient
+
+	vcdClient.Client.VCDAuthHeader = "Authorization"
+	vcdClient.Client.VCDToken = "new-token"
+	vcdClient.Client.UsingBearerToken = true
+	if tenantClient.Client.VCDToken != "old-token" {
+		t.Fatalf("expected the copy to keep its own session until it is synchronized")
+	}
+	vcdClient.syncTenantContextSessions()
+	if tenantClient.Client.VCDToken != "new-token" || tenantClient.Client.VCDAuthHeader != "Authorization" ||
+		!tenantClient.Client.UsingBearerToken {
+		t.Errorf("expected the new session in the tenant context client, got %s %s",
+			tenantClient.Client.VCDAuthHeader, tenantClient.Client.VCDToken)
+	}
+	if tenantClient.Client.Http.Transport == vcdClient.Client.Http.Transport {
+		t.Errorf("expected the tenant context client to keep its transport")
+	}
+}
@@ -0,0 +1,133 @@
+package vcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+// taskPollingInterval is the time to wait between two consecutive task refreshes. It matches the
+// interval used by govcd.Task.WaitTaskCompletion
+var taskPollingInterval = 3 * time.Second
+
+// resourceTimeouts returns a schema.ResourceTimeout with the given defaults for create, update and
+// delete operations. The values can be overridden by users with a `timeouts` block in the resource
+// configuration.
+//
+// Note. The Terraform SDK wraps CreateContext, UpdateContext and DeleteContext functions with a
+// context that expires after the configured timeout. It is up to the resource code to honor the
+// context, which is done by starting the VCD tasks with the asynchronous govcd functions and
+// waiting for them with waitTaskCompletionWithContext. The operations that govcd only offers as
+// synchronous calls are not interrupted.
+func resourceTimeouts(create, update, delete time.Duration) *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(create),
+		Update: schema.DefaultTimeout(update),
+		Delete: schema.DefaultTimeout(delete),
+	}
+}
+
+// waitTaskCompletionWithContext works like govcd.Task.WaitTaskCompletion, but it stops waiting as
+// soon as the given context is done. The returned error contains the task ID and HREF so that
+// users can track down a task that is still running in VCD after the timeout has passed.
+func waitTaskCompletionWithContext(ctx context.Context, task govcd.Task) error {
+	return waitTask(ctx, &task)
+}
+
+// waitTaskOwnerWithContext works like waitTaskCompletionWithContext and returns the HREF of the
+// entity that owns the task. For creation tasks, it is the created entity.
+func waitTaskOwnerWithContext(ctx context.Context, task govcd.Task) (string, error) {
+	err := waitTask(ctx, &task)
+	if err != nil {
+		return "", err
+	}
+	if task.Task.Owner == nil || task.Task.Owner.HREF == "" {
+		return "", fmt.Errorf("task '%s' does not have an owner", task.Task.ID)
+	}
+	return task.Task.Owner.HREF, nil
+}
+
+// waitTask polls the given task until it finishes or the context is done. The task is refreshed
+// in place
+func waitTask(ctx context.Context, task *govcd.Task) error {
+	if task.Task == nil {
+		return fmt.Errorf("cannot wait for an empty task")
+	}
+	ctx = taskLogContext(ctx, *task)
+	tflog.Debug(ctx, "waiting for task to complete", map[string]interface{}{"operation": task.Task.Operation})
+
+	for {
+		err := task.Refresh()
+		if err != nil {
+			return fmt.Errorf("error retrieving task '%s': %s", task.Task.ID, err)
+		}
+
+		switch task.Task.Status {
+		case "success":
+			tflog.Debug(ctx, "task completed successfully")
+			return nil
+		case "error", "aborted":
+			tflog.Warn(ctx, "task finished with an error", map[string]interface{}{"task_status": task.Task.Status})
+			return &taskError{task: task.Task}
+		}
+
+		select {
+		case <-ctx.Done():
+			tflog.Warn(ctx, "stopped waiting for task", map[string]interface{}{"reason": contextErrorReason(ctx.Err())})
+			return taskContextError(ctx, task.Task)
+		case <-time.After(taskPollingInterval):
+		}
+	}
+}
+
+// remainingTimeout returns the time left before the context deadline. When 'limit' is greater than
+// zero and shorter than the remaining time, 'limit' is returned instead. It is meant for govcd
+// functions that accept a timeout argument.
+// A zero value is returned when there is neither a deadline nor a limit, which govcd functions
+// usually interpret as "wait indefinitely".
+func remainingTimeout(ctx context.Context, limit time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return limit
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		// A negative or zero value would mean "no timeout" for govcd
+		remaining = time.Nanosecond
+	}
+	if limit > 0 && limit < remaining {
+		return limit
+	}
+	return remaining
+}
+
+// taskContextError builds the error returned when a context expires while waiting for a task
+func taskContextError(ctx context.Context, task *types.Task) error {
+	return fmt.Errorf("%s while waiting for task '%s' (%s) with status '%s'. The task may still be running in VCD",
+		contextErrorReason(ctx.Err()), task.ID, task.HREF, task.Status)
+}
+
+// contextErrorReason returns a user-friendly text for context errors
+func contextErrorReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout reached"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "operation canceled"
+	}
+	return err.Error()
+}
+
+// taskErrorMessage returns the error details contained in a failed task
+func taskErrorMessage(task *types.Task) string {
+	if task.Error == nil {
+		return fmt.Sprintf("task status is '%s'", task.Status)
+	}
+	return fmt.Sprintf("[%d:%s] - %s", task.Error.MajorErrorCode, task.Error.MinorErrorCode, task.Error.Message)
+}
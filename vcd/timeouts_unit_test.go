@@ -0,0 +1,91 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+	"github.com/vmware/go-vcloud-director/v3/types/v56"
+)
+
+func Test_remainingTimeout(t *testing.T) {
+	noDeadline := context.Background()
+	withDeadline, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		limit   time.Duration
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{name: "no-deadline-no-limit", ctx: noDeadline, limit: 0, wantMin: 0, wantMax: 0},
+		{name: "no-deadline-limit", ctx: noDeadline, limit: time.Minute, wantMin: time.Minute, wantMax: time.Minute},
+		{name: "deadline-no-limit", ctx: withDeadline, limit: 0, wantMin: 59 * time.Minute, wantMax: time.Hour},
+		{name: "deadline-shorter-limit", ctx: withDeadline, limit: time.Minute, wantMin: time.Minute, wantMax: time.Minute},
+		{name: "deadline-longer-limit", ctx: withDeadline, limit: 2 * time.Hour, wantMin: 59 * time.Minute, wantMax: time.Hour},
+		{name: "expired-deadline", ctx: expired, limit: time.Minute, wantMin: time.Nanosecond, wantMax: time.Nanosecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := remainingTimeout(tt.ctx, tt.limit)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("remainingTimeout() = %s, want between %s and %s", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func Test_waitTaskOwnerWithContext(t *testing.T) {
+	defaultInterval := taskPollingInterval
+	taskPollingInterval = time.Millisecond
+	defer func() { taskPollingInterval = defaultInterval }()
+
+	var refreshes int32
+	status := "running"
+	serverUrl := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&refreshes, 1) > 2 {
+			status = "success"
+		}
+		_, _ = fmt.Fprintf(w, `<Task xmlns="http://www.vmware.com/vcloud/v1.5" id="urn:vcloud:task:1" href="%s/api/task/1" status="%s">`+
+			`<Owner href="https://vcd.example.com/api/vApp/vapp-1" type="application/vnd.vmware.vcloud.vApp+xml"/></Task>`, serverUrl, status)
+	}))
+	defer server.Close()
+	serverUrl = server.URL
+
+	client := &govcd.Client{Http: *server.Client()}
+	newTask := func() govcd.Task {
+		task := govcd.NewTask(client)
+		task.Task = &types.Task{ID: "urn:vcloud:task:1", HREF: server.URL + "/api/task/1", Status: "queued"}
+		return *task
+	}
+
+	owner, err := waitTaskOwnerWithContext(context.Background(), newTask())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if owner != "https://vcd.example.com/api/vApp/vapp-1" {
+		t.Errorf("unexpected task owner %s", owner)
+	}
+
+	status = "running"
+	atomic.StoreInt32(&refreshes, -1000)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = waitTaskOwnerWithContext(ctx, newTask())
+	if err == nil || !strings.Contains(err.Error(), "timeout reached while waiting for task 'urn:vcloud:task:1'") {
+		t.Errorf("expected timeout error with the task ID, got: %v", err)
+	}
+}
@@ -0,0 +1,527 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	semver "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Each resource and data source requires a minimum VCD API version, and works with classic VCD, with VMware Cloud
+// Foundation Tenant Manager (TM) or with both. The requirements are checked against the VCD the provider is
+// connected to, so that an entity that is not supported fails at plan time with a single clear message, instead of
+// an HTTP error in the middle of an apply:
+// * resources are checked in CustomizeDiff, during the plan, and when they are imported
+// * data sources are checked before they are read
+//
+// Every resource and data source must be listed in resourceVersionRequirements or dataSourceVersionRequirements, with
+// its minimum API version and platform. The ones with the vcd_tm_ prefix are only available in TM.
+
+// vcdPlatform is the flavor of VCD where a resource or data source can be used
+type vcdPlatform int
+
+const (
+	vcdPlatformAny vcdPlatform = iota
+	vcdPlatformClassic
+	vcdPlatformTm
+)
+
+const (
+	// minimumVcdApiVersion is the lowest API version supported by the provider (VCD 10.4.0)
+	minimumVcdApiVersion = "37.0"
+	// tmApiVersion is the first API version of Tenant Manager, required by the vcd_tm_ entities. Whether the connected
+	// VCD is Tenant Manager is not derived from it, but reported by VCD itself (see getConnectedVersion)
+	tmApiVersion = "40.0"
+)
+
+// vcdVersionRequirement describes where a resource or data source can be used
+type vcdVersionRequirement struct {
+	// minApiVersion is the lowest VCD API version that supports the entity
+	minApiVersion string
+	platform      vcdPlatform
+	// tmReplacement is the entity to use in TM instead of one that is only available in classic VCD
+	tmReplacement string
+}
+
+// vcdVersionsByApiVersion maps the API versions used in the requirements to the VCD versions, for the messages
+var vcdVersionsByApiVersion = map[string]string{
+	"37.0": "10.4",
+	"37.1": "10.4.1",
+	"37.2": "10.4.2",
+	"37.3": "10.4.3",
+	"38.0": "10.5",
+	"38.1": "10.5.1",
+	"39.0": "10.6",
+}
+
+// resourceVersionRequirements contains the requirements of all the resources
+var resourceVersionRequirements = map[string]vcdVersionRequirement{
+	"vcd_api_filter":                                   {minApiVersion: "38.1", platform: vcdPlatformAny},
+	"vcd_api_token":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_content_library"},
+	"vcd_catalog_access_control":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog_item":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog_media":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog_vapp_template":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_content_library_item"},
+	"vcd_cloned_vapp":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_cse_kubernetes_cluster":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_dse_registry_configuration":                   {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_dse_solution_publish":                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_edgegateway":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_edgegateway_settings":                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_edgegateway_vpn":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_external_endpoint":                            {minApiVersion: "38.1", platform: vcdPlatformAny},
+	"vcd_external_network":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_external_network_v2":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_provider_gateway"},
+	"vcd_global_role":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_independent_disk":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_inserted_media":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_ip_space":                                     {minApiVersion: "37.1", platform: vcdPlatformClassic, tmReplacement: "vcd_tm_ip_space"},
+	"vcd_ip_space_custom_quota":                        {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_ip_space_ip_allocation":                       {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_ip_space_uplink":                              {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_lb_app_profile":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_app_rule":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_server_pool":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_service_monitor":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_virtual_server":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_library_certificate":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_org_association":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_site_association":                   {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_direct":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_isolated":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_isolated_v2":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_pool":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_routed":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_routed_v2":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_cloud":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_controller":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_edgegateway_service_engine_group":    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_pool":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_service_engine_group":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_settings":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service":                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service_http_req_rules":      {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":     {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":      {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxt_app_port_profile":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_distributed_firewall":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_distributed_firewall_rule":               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_dynamic_security_group":                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_bgp_configuration":           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_bgp_ip_prefix_list":          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_bgp_neighbor":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_dhcp_forwarding":             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_dhcpv6":                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_dns":                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_l2_vpn_tunnel":               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_rate_limiting":               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_static_route":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_firewall":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_global_default_segment_profile_template": {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_ip_set":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_ipsec_vpn_tunnel":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_nat_rule":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_dhcp":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_dhcp_binding":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_imported":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_segment_profile":                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_route_advertisement":                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_security_group":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_profile_template":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_dhcp_relay":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_distributed_firewall":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_dnat":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_firewall_rule":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_ip_set":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_snat":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org":                                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_org"},
+	"vcd_org_group":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_ldap":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_oidc":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_saml":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_user":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_vdc":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_org_vdc"},
+	"vcd_org_vdc_access_control":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_vdc_nsxt_network_profile":                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_vdc_template":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_vdc_template_instance":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_provider_vdc":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde":                                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_interface":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_interface_behavior":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_type":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_type_behavior":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_type_behavior_acl":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rights_bundle":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_role":                                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_security_tag":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_service_account":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_solution_add_on":                              {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_solution_add_on_instance":                     {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_solution_add_on_instance_publish":             {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_solution_landing_zone":                        {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_subscribed_catalog":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_tm_content_library":                           {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_content_library_item":                      {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_edge_cluster_qos":                          {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_ip_space":                                  {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_nsxt_manager":                              {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_org":                                       {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_org_vdc":                                   {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_provider_gateway":                          {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_region":                                    {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_vcenter":                                   {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_ui_plugin":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp":                                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_access_control":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_firewall_rules":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_nat_rules":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_network":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_org_network":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_static_routing":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_vm":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vdc_group":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm":                                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_affinity_rule":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_internal_disk":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_placement_policy":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_sizing_policy":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_snapshot":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_vgpu_policy":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+}
+
+// dataSourceVersionRequirements contains the requirements of all the data sources
+var dataSourceVersionRequirements = map[string]vcdVersionRequirement{
+	"vcd_api_filter":                                   {minApiVersion: "37.3", platform: vcdPlatformAny},
+	"vcd_catalog":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_content_library"},
+	"vcd_catalog_access_control":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog_item":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog_media":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_catalog_vapp_template":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_content_library_item"},
+	"vcd_cse_kubernetes_cluster":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_dse_registry_configuration":                   {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_dse_solution_publish":                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_edgegateway":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_external_endpoint":                            {minApiVersion: "37.3", platform: vcdPlatformAny},
+	"vcd_external_network":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_external_network_v2":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_provider_gateway"},
+	"vcd_global_role":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_independent_disk":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_ip_space":                                     {minApiVersion: "37.1", platform: vcdPlatformClassic, tmReplacement: "vcd_tm_ip_space"},
+	"vcd_ip_space_custom_quota":                        {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_ip_space_ip_allocation":                       {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_ip_space_uplink":                              {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_lb_app_profile":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_app_rule":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_server_pool":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_service_monitor":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_lb_virtual_server":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_library_certificate":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_org_association":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_org_data":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_site":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_site_association":                   {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_multisite_site_data":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_direct":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_isolated":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_isolated_v2":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_pool":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_routed":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_network_routed_v2":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_cloud":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_controller":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_edgegateway_service_engine_group":    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_importable_cloud":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_pool":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_service_engine_group":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_settings":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service":                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service_http_req_rules":      {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service_http_resp_rules":     {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxt_alb_virtual_service_http_sec_rules":      {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxt_app_port_profile":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_distributed_firewall":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_distributed_firewall_rule":               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_dynamic_security_group":                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edge_cluster":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_edge_cluster"},
+	"vcd_nsxt_edgegateway":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_bgp_configuration":           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_bgp_ip_prefix_list":          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_bgp_neighbor":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_dhcp_forwarding":             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_dhcpv6":                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_dns":                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_l2_vpn_tunnel":               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_qos_profile":                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_rate_limiting":               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_edgegateway_static_route":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_firewall":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_global_default_segment_profile_template": {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_ip_set":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_ipsec_vpn_tunnel":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_manager":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_nsxt_manager"},
+	"vcd_nsxt_nat_rule":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_context_profile":                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_dhcp":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_dhcp_binding":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_imported":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_network_segment_profile":                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_route_advertisement":                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_security_group":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_ip_discovery_profile":            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_mac_discovery_profile":           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_profile_template":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_qos_profile":                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_security_profile":                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_segment_spoof_guard_profile":             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxt_tier0_router":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_tier0_gateway"},
+	"vcd_nsxt_tier0_router_interface":                  {minApiVersion: "38.0", platform: vcdPlatformAny},
+	"vcd_nsxv_application":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_application_finder":                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_application_group":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_dhcp_relay":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_distributed_firewall":                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_dnat":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_firewall_rule":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_ip_set":                                  {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_nsxv_snat":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org":                                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_org"},
+	"vcd_org_group":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_ldap":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_oidc":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_saml":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_saml_metadata":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_user":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_vdc":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_org_vdc"},
+	"vcd_org_vdc_nsxt_network_profile":                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_org_vdc_template":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_portgroup":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_provider_vdc":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde":                                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_behavior_invocation":                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_interface":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_interface_behavior":                       {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_type":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_type_behavior":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rde_type_behavior_acl":                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_resource_list":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_resource_pool":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_resource_schema":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_right":                                        {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_rights_bundle":                                {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_role":                                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_service_account":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_solution_add_on":                              {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_solution_add_on_instance":                     {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_solution_add_on_instance_publish":             {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_solution_landing_zone":                        {minApiVersion: "37.1", platform: vcdPlatformAny},
+	"vcd_storage_profile":                              {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_subscribed_catalog":                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_task":                                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_tm_content_library":                           {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_content_library_item":                      {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_edge_cluster":                              {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_edge_cluster_qos":                          {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_ip_space":                                  {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_nsxt_manager":                              {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_org":                                       {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_org_vdc":                                   {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_provider_gateway":                          {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_region":                                    {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_region_storage_policy":                     {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_region_zone":                               {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_supervisor":                                {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_supervisor_zone":                           {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_tier0_gateway":                             {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_tm_vcenter":                                   {minApiVersion: tmApiVersion, platform: vcdPlatformTm},
+	"vcd_ui_plugin":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp":                                         {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_network":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_org_network":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vapp_vm":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vcenter":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformClassic, tmReplacement: "vcd_tm_vcenter"},
+	"vcd_vdc_group":                                    {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_version":                                      {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vgpu_profile":                                 {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm":                                           {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_affinity_rule":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_console_ticket":                            {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_group":                                     {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_placement_policy":                          {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_sizing_policy":                             {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+	"vcd_vm_vgpu_policy":                               {minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny},
+}
+
+// getVersionRequirement returns the requirement of the given resource or data source. Every entity has an explicit
+// requirement (see Test_versionRequirementEntities); the minimum version supported by the provider is only a fallback
+func getVersionRequirement(name string, requirements map[string]vcdVersionRequirement) vcdVersionRequirement {
+	requirement, found := requirements[name]
+	if !found {
+		return vcdVersionRequirement{minApiVersion: minimumVcdApiVersion, platform: vcdPlatformAny}
+	}
+	return requirement
+}
+
+// vcdVersionLabel returns a readable name for the VCD that supports the given API version, e.g. "VCD 10.5.1+"
+func vcdVersionLabel(apiVersion string) string {
+	if vcdVersion, found := vcdVersionsByApiVersion[apiVersion]; found {
+		return fmt.Sprintf("VCD %s+", vcdVersion)
+	}
+	return fmt.Sprintf("VCD API version %s+", apiVersion)
+}
+
+// check returns an error when the given entity can't be used with a VCD with the given maximum API version, which
+// is Tenant Manager when isTm is true
+func (requirement vcdVersionRequirement) check(name string, apiVersion *semver.Version, isTm bool) error {
+	switch {
+	case requirement.platform == vcdPlatformTm && !isTm:
+		return fmt.Errorf("%s requires VMware Cloud Foundation Tenant Manager, but the provider is connected to "+
+			"VCD with API version %s", name, apiVersion.Original())
+	case requirement.platform == vcdPlatformClassic && isTm:
+		if requirement.tmReplacement != "" {
+			return fmt.Errorf("%s is not supported in VMware Cloud Foundation Tenant Manager, use %s instead",
+				name, requirement.tmReplacement)
+		}
+		return fmt.Errorf("%s is not supported in VMware Cloud Foundation Tenant Manager", name)
+	}
+
+	minVersion, err := semver.NewVersion(requirement.minApiVersion)
+	if err != nil {
+		return fmt.Errorf("error parsing the minimum API version of %s: %s", name, err)
+	}
+	if apiVersion.LessThan(minVersion) {
+		return fmt.Errorf("%s requires %s (API version %s), but the provider is connected to VCD with API version %s",
+			name, vcdVersionLabel(requirement.minApiVersion), requirement.minApiVersion, apiVersion.Original())
+	}
+	return nil
+}
+
+// connectedVcdVersion keeps the maximum API version of the VCD the client is connected to, and whether it is
+// Tenant Manager, which are retrieved only once
+type connectedVcdVersion struct {
+	once       sync.Once
+	apiVersion *semver.Version
+	isTm       bool
+	err        error
+}
+
+// getConnectedVersion returns the maximum API version supported by the VCD the client is connected to, and whether
+// it is Tenant Manager
+func (cli *VCDClient) getConnectedVersion() (*semver.Version, bool, error) {
+	// Clients not created by Config.Client don't share the version, which is then retrieved each time
+	version := cli.connectedVersion
+	if version == nil {
+		version = &connectedVcdVersion{}
+	}
+	version.once.Do(func() {
+		// IsTm also retrieves the supported versions from VCD, when the login did not do it already
+		version.isTm = cli.Client.IsTm()
+		maxVersion, err := cli.Client.MaxSupportedVersion()
+		if err != nil {
+			version.err = fmt.Errorf("could not get VCD API version: %s", err)
+			return
+		}
+		version.apiVersion, version.err = semver.NewVersion(maxVersion)
+	})
+	return version.apiVersion, version.isTm, version.err
+}
+
+// checkVersionRequirement returns an error when the given entity can't be used with the VCD the provider
+// is connected to. When the version can't be retrieved, the check is skipped and the API calls report the problem
+func checkVersionRequirement(ctx context.Context, meta interface{}, name string, requirement vcdVersionRequirement) error {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil || vcdClient.VCDClient == nil {
+		return nil
+	}
+	apiVersion, isTm, err := vcdClient.getConnectedVersion()
+	if err != nil {
+		tflog.Warn(ctx, "skipping the version check", map[string]interface{}{
+			"name":  name,
+			"error": err,
+		})
+		return nil
+	}
+	return requirement.check(name, apiVersion, isTm)
+}
+
+// withVersionRequirements returns a copy of the given resource map, where each resource checks its version
+// requirement in CustomizeDiff and before importing
+func withVersionRequirements(resources map[string]*schema.Resource, requirements map[string]vcdVersionRequirement) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		requirement := getVersionRequirement(name, requirements)
+		withRequirement := *resource
+
+		customizeDiff := resource.CustomizeDiff
+		withRequirement.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if err := checkVersionRequirement(ctx, meta, name, requirement); err != nil {
+				return err
+			}
+			if customizeDiff != nil {
+				return customizeDiff(ctx, diff, meta)
+			}
+			return nil
+		}
+
+		if resource.Importer != nil {
+			importer := *resource.Importer
+			if resource.Importer.StateContext != nil {
+				stateContext := resource.Importer.StateContext
+				importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+					if err := checkVersionRequirement(ctx, meta, name, requirement); err != nil {
+						return nil, err
+					}
+					return stateContext(ctx, d, meta)
+				}
+			}
+			if resource.Importer.State != nil {
+				state := resource.Importer.State
+				importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+					if err := checkVersionRequirement(providerLogContext(), meta, name, requirement); err != nil {
+						return nil, err
+					}
+					return state(d, meta)
+				}
+			}
+			withRequirement.Importer = &importer
+		}
+		result[name] = &withRequirement
+	}
+	return result
+}
+
+// withDataSourceVersionRequirements returns a copy of the given data source map, where each data source checks its
+// version requirement before reading
+func withDataSourceVersionRequirements(dataSources map[string]*schema.Resource, requirements map[string]vcdVersionRequirement) map[string]*schema.Resource {
+	result := make(map[string]*schema.Resource, len(dataSources))
+	for name, dataSource := range dataSources {
+		requirement := getVersionRequirement(name, requirements)
+		withRequirement := *dataSource
+		if dataSource.ReadContext != nil {
+			readContext := dataSource.ReadContext
+			withRequirement.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				if err := checkVersionRequirement(ctx, meta, name, requirement); err != nil {
+					return diag.FromErr(err)
+				}
+				return readContext(ctx, d, meta)
+			}
+		}
+		if dataSource.Read != nil {
+			read := dataSource.Read
+			withRequirement.Read = func(d *schema.ResourceData, meta interface{}) error {
+				if err := checkVersionRequirement(providerLogContext(), meta, name, requirement); err != nil {
+					return err
+				}
+				return read(d, meta)
+			}
+		}
+		result[name] = &withRequirement
+	}
+	return result
+}
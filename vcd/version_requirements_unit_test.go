@@ -0,0 +1,120 @@
+//go:build unit || ALL
+
+package vcd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	semver "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v3/govcd"
+)
+
+func Test_versionRequirementEntities(t *testing.T) {
+	checkTable := func(label string, requirements map[string]vcdVersionRequirement, entities map[string]*schema.Resource) {
+		for name, requirement := range requirements {
+			if _, found := entities[name]; !found {
+				t.Errorf("%s requirement for unknown entity %s", label, name)
+			}
+			if requirement.tmReplacement != "" {
+				if _, found := entities[requirement.tmReplacement]; !found {
+					t.Errorf("%s requirement of %s refers to unknown replacement %s", label, name, requirement.tmReplacement)
+				}
+			}
+			if _, err := semver.NewVersion(requirement.minApiVersion); err != nil {
+				t.Errorf("%s requirement of %s has an invalid API version: %s", label, name, err)
+			}
+		}
+		for name := range entities {
+			requirement, found := requirements[name]
+			if !found {
+				t.Errorf("%s %s has no version requirement", label, name)
+				continue
+			}
+			if strings.HasPrefix(name, "vcd_tm_") && requirement.platform != vcdPlatformTm {
+				t.Errorf("%s %s must only be available in Tenant Manager", label, name)
+			}
+		}
+	}
+	checkTable("resource", resourceVersionRequirements, globalResourceMap)
+	checkTable("data source", dataSourceVersionRequirements, globalDataSourceMap)
+}
+
+func Test_vcdVersionRequirementCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		entity        string
+		requirements  map[string]vcdVersionRequirement
+		apiVersion    string
+		isTm          bool
+		expectedError string
+	}{
+		{name: "default", entity: "vcd_nsxt_edgegateway_l2_vpn_tunnel", requirements: resourceVersionRequirements, apiVersion: "37.0"},
+		{name: "minimum-version-met", entity: "vcd_api_filter", requirements: resourceVersionRequirements, apiVersion: "38.1"},
+		{name: "minimum-version-not-met", entity: "vcd_api_filter", requirements: resourceVersionRequirements, apiVersion: "38.0",
+			expectedError: "vcd_api_filter requires VCD 10.5.1+ (API version 38.1), but the provider is connected to VCD with API version 38.0"},
+		{name: "data-source-version", entity: "vcd_api_filter", requirements: dataSourceVersionRequirements, apiVersion: "37.3"},
+		{name: "tm-on-classic", entity: "vcd_tm_org", requirements: resourceVersionRequirements, apiVersion: "39.0",
+			expectedError: "vcd_tm_org requires VMware Cloud Foundation Tenant Manager, but the provider is connected to VCD with API version 39.0"},
+		{name: "tm-on-tm", entity: "vcd_tm_org", requirements: resourceVersionRequirements, apiVersion: "40.0", isTm: true},
+		{name: "tm-on-classic-with-tm-api-version", entity: "vcd_tm_org", requirements: resourceVersionRequirements, apiVersion: "40.0",
+			expectedError: "vcd_tm_org requires VMware Cloud Foundation Tenant Manager, but the provider is connected to VCD with API version 40.0"},
+		{name: "classic-on-tm", entity: "vcd_org", requirements: resourceVersionRequirements, apiVersion: "40.0", isTm: true,
+			expectedError: "vcd_org is not supported in VMware Cloud Foundation Tenant Manager, use vcd_tm_org instead"},
+		{name: "classic-with-tm-api-version", entity: "vcd_org", requirements: resourceVersionRequirements, apiVersion: "40.0"},
+		{name: "any-on-tm", entity: "vcd_version", requirements: dataSourceVersionRequirements, apiVersion: "40.0", isTm: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiVersion := semver.Must(semver.NewVersion(tt.apiVersion))
+			err := getVersionRequirement(tt.entity, tt.requirements).check(tt.entity, apiVersion, tt.isTm)
+			switch {
+			case tt.expectedError == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.expectedError != "" && (err == nil || err.Error() != tt.expectedError):
+				t.Errorf("expected error '%s', got: %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func Test_withVersionRequirements(t *testing.T) {
+	var customizeDiffCalls, readCalls int
+	resources := withVersionRequirements(map[string]*schema.Resource{
+		"vcd_test": {
+			CustomizeDiff: func(context.Context, *schema.ResourceDiff, interface{}) error {
+				customizeDiffCalls++
+				return nil
+			},
+		},
+	}, map[string]vcdVersionRequirement{"vcd_test": {minApiVersion: "38.0"}})
+	dataSources := withDataSourceVersionRequirements(map[string]*schema.Resource{
+		"vcd_test": {
+			ReadContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+				readCalls++
+				return nil
+			},
+		},
+	}, map[string]vcdVersionRequirement{"vcd_test": {minApiVersion: "38.0"}})
+
+	// Without a connected client the check is skipped
+	if err := resources["vcd_test"].CustomizeDiff(context.Background(), nil, nil); err != nil || customizeDiffCalls != 1 {
+		t.Errorf("expected the original CustomizeDiff to be called, got error %v and %d calls", err, customizeDiffCalls)
+	}
+
+	client := &VCDClient{VCDClient: &govcd.VCDClient{}, connectedVersion: &connectedVcdVersion{}}
+	client.connectedVersion.once.Do(func() {
+		client.connectedVersion.apiVersion = semver.Must(semver.NewVersion("37.2"))
+	})
+	err := resources["vcd_test"].CustomizeDiff(context.Background(), nil, client)
+	if err == nil || !strings.Contains(err.Error(), "vcd_test requires VCD 10.5+") || customizeDiffCalls != 1 {
+		t.Errorf("expected the version requirement to fail the plan, got error %v and %d calls", err, customizeDiffCalls)
+	}
+	diags := dataSources["vcd_test"].ReadContext(context.Background(), nil, client)
+	if !diags.HasError() || readCalls != 0 {
+		t.Errorf("expected the version requirement to fail the read, got %v and %d calls", diags, readCalls)
+	}
+}